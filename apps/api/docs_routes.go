@@ -13,8 +13,10 @@ import (
 // docSpecs maps public documentation names to their contract files.
 var docSpecs = map[string]string{
 	// Expose only mounted domains in docs
+	"access-control":    "contracts/access-control.yaml",
 	"schema-categories": "contracts/schema-categories.yaml",
 	"schema-repository": "contracts/schema-repository.yaml",
+	"service-accounts":  "contracts/service-accounts.yaml",
 	"users":             "contracts/users.yaml",
 }
 
@@ -3,104 +3,257 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/caarlos0/env/v11"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
 	"go.uber.org/zap"
 
+	"github.com/zenGate-Global/palmyra-pro-saas/database"
+	accesscontrolhandler "github.com/zenGate-Global/palmyra-pro-saas/domains/access-control/be/handler"
+	accesscontrolrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/access-control/be/repo"
+	accesscontrolservice "github.com/zenGate-Global/palmyra-pro-saas/domains/access-control/be/service"
+	authhandler "github.com/zenGate-Global/palmyra-pro-saas/domains/auth/be/handler"
+	authrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/auth/be/repo"
+	authservice "github.com/zenGate-Global/palmyra-pro-saas/domains/auth/be/service"
 	entitieshandler "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/handler"
 	entitiesrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/repo"
 	entitiesservice "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/service"
+	jobshandler "github.com/zenGate-Global/palmyra-pro-saas/domains/jobs/be/handler"
+	jobsrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/jobs/be/repo"
+	jobsservice "github.com/zenGate-Global/palmyra-pro-saas/domains/jobs/be/service"
 	schemacategorieshandler "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-categories/be/handler"
 	schemacategoriesrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-categories/be/repo"
 	schemacategoriesservice "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-categories/be/service"
 	schemarepositoryhandler "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-repository/be/handler"
 	schemarepositoryrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-repository/be/repo"
 	schemarepositoryservice "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-repository/be/service"
+	serviceaccountshandler "github.com/zenGate-Global/palmyra-pro-saas/domains/service-accounts/be/handler"
+	serviceaccountsrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/service-accounts/be/repo"
+	serviceaccountsservice "github.com/zenGate-Global/palmyra-pro-saas/domains/service-accounts/be/service"
 	usershandler "github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/handler"
+	usersmiddleware "github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/middleware"
 	usersrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/repo"
 	usersservice "github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/service"
+	accesscontrol "github.com/zenGate-Global/palmyra-pro-saas/generated/go/access-control"
 	authapi "github.com/zenGate-Global/palmyra-pro-saas/generated/go/auth"
 	entitiesapi "github.com/zenGate-Global/palmyra-pro-saas/generated/go/entities"
+	jobsapi "github.com/zenGate-Global/palmyra-pro-saas/generated/go/jobs"
 	schemacategories "github.com/zenGate-Global/palmyra-pro-saas/generated/go/schema-categories"
 	schemarepository "github.com/zenGate-Global/palmyra-pro-saas/generated/go/schema-repository"
+	serviceaccounts "github.com/zenGate-Global/palmyra-pro-saas/generated/go/service-accounts"
 	users "github.com/zenGate-Global/palmyra-pro-saas/generated/go/users"
 	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformconfig "github.com/zenGate-Global/palmyra-pro-saas/platform/go/config"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/gcp"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/health"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/idempotency"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/jobs"
 	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/metrics"
 	platformmiddleware "github.com/zenGate-Global/palmyra-pro-saas/platform/go/middleware"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/migrate"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/ratelimit"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
 )
 
 var swaggerLoaders = map[string]func() (*openapi3.T, error){
+	"contracts/access-control.yaml":    accesscontrol.GetSwagger,
 	"contracts/entities.yaml":          entitiesapi.GetSwagger,
 	"contracts/auth.yaml":              authapi.GetSwagger,
+	"contracts/jobs.yaml":              jobsapi.GetSwagger,
 	"contracts/schema-categories.yaml": schemacategories.GetSwagger,
 	"contracts/schema-repository.yaml": schemarepository.GetSwagger,
+	"contracts/service-accounts.yaml":  serviceaccounts.GetSwagger,
 	"contracts/users.yaml":             users.GetSwagger,
 }
 
 type config struct {
-	Port            string        `env:"PORT" envDefault:"3000"`
-	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
-	RequestTimeout  time.Duration `env:"REQUEST_TIMEOUT" envDefault:"15s"`
-	LogLevel        string        `env:"LOG_LEVEL" envDefault:"info"`
-	DatabaseURL     string        `env:"DATABASE_URL,required"`
-	AuthProvider    string        `env:"AUTH_PROVIDER" envDefault:"firebase"`
+	Server   platformconfig.Server   `yaml:"server"`
+	Database platformconfig.Database `yaml:"database"`
+	Log      platformconfig.Log      `yaml:"log"`
+	CORS     platformconfig.CORS     `yaml:"cors"`
+	Auth     platformconfig.Auth     `yaml:"auth"`
+	Jobs     platformconfig.Jobs     `yaml:"jobs"`
+
+	MigrationsRequired bool     `env:"MIGRATIONS_REQUIRED" envDefault:"false" yaml:"migrations_required"`           // refuse to start when the database is behind
+	DefaultTenantID    string   `env:"DEFAULT_TENANT_ID" yaml:"default_tenant_id"`                                  // used when tokens carry no tenant claim; empty requires the claim
+	AutoApproveDomains []string `env:"USER_AUTO_APPROVE_DOMAINS" envSeparator:"," yaml:"user_auto_approve_domains"` // verified sign-ups from these email domains skip the approval queue
+
+	// Per-caller rate limits, "<requests>/<duration>[:<burst>]" or "off".
+	RateLimitBackend string           `env:"RATE_LIMIT_BACKEND" envDefault:"memory" yaml:"rate_limit_backend"` // memory | postgres | off
+	RateLimitRead    ratelimit.Policy `env:"RATE_LIMIT_READ" envDefault:"600/1m" yaml:"rate_limit_read"`
+	RateLimitWrite   ratelimit.Policy `env:"RATE_LIMIT_WRITE" envDefault:"120/1m" yaml:"rate_limit_write"`
+	RateLimitAdmin   ratelimit.Policy `env:"RATE_LIMIT_ADMIN" envDefault:"60/1m" yaml:"rate_limit_admin"`
+
+	IdempotencyKeyTTL time.Duration `env:"IDEMPOTENCY_KEY_TTL" envDefault:"24h" yaml:"idempotency_key_ttl"` // how long POST responses are replayed per Idempotency-Key; 0 disables
+
+	MetricsEnabled bool   `env:"METRICS_ENABLED" envDefault:"true" yaml:"metrics_enabled"` // serves Prometheus metrics on /metrics of MetricsAddr
+	MetricsAddr    string `env:"METRICS_ADDR" envDefault:":9464" yaml:"metrics_addr"`      // separate listener kept off the public port; it has no auth
+
+	TracingExporter    string  `env:"TRACING_EXPORTER" envDefault:"none" yaml:"tracing_exporter"` // none | otlp | stdout; otlp reads OTEL_EXPORTER_OTLP_* variables
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1" yaml:"tracing_sample_ratio"`
+}
+
+// Validate checks the API settings not covered by the shared sections.
+func (c config) Validate() error {
+	var errs []error
+	if c.DefaultTenantID != "" {
+		if _, err := tenant.Normalize(c.DefaultTenantID); err != nil {
+			errs = append(errs, fmt.Errorf("default_tenant_id: %w", err))
+		}
+	}
+	if !slices.Contains([]string{"memory", "postgres", "off"}, c.RateLimitBackend) {
+		errs = append(errs, fmt.Errorf("rate_limit_backend %q must be one of memory, postgres, off", c.RateLimitBackend))
+	}
+	if c.IdempotencyKeyTTL < 0 {
+		errs = append(errs, errors.New("idempotency_key_ttl must not be negative"))
+	}
+	if c.MetricsEnabled && c.MetricsAddr == "" {
+		errs = append(errs, errors.New("metrics_addr is required when metrics_enabled is set"))
+	}
+	if !slices.Contains([]string{"none", "otlp", "stdout"}, c.TracingExporter) {
+		errs = append(errs, fmt.Errorf("tracing_exporter %q must be one of none, otlp, stdout", c.TracingExporter))
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		errs = append(errs, errors.New("tracing_sample_ratio must be between 0 and 1"))
+	}
+	return errors.Join(errs...)
 }
 
 func main() {
+	configFile := flag.String("config", os.Getenv(platformconfig.FileEnv), "YAML configuration file; environment variables override it")
+	flag.Parse()
+
 	ctx := context.Background()
 
 	var cfg config
-	if err := env.Parse(&cfg); err != nil {
-		log.Fatalf("load config: %v", err)
+	if err := platformconfig.Load(&cfg, *configFile); err != nil {
+		log.Fatal(err)
 	}
 
-	logger, err := platformlogging.NewLogger(platformlogging.Config{
-		Component: "api-server",
-		Level:     cfg.LogLevel,
-	})
+	logger, err := cfg.Log.Logger("api-server")
 	if err != nil {
 		log.Fatalf("init zap logger: %v", err)
 	}
 	defer func() {
 		_ = logger.Sync()
 	}()
+	logger.Info("configuration loaded", zap.String("file", *configFile), zap.Any("config", platformconfig.Redacted(&cfg)))
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName: "palmyra-api",
+		Exporter:    cfg.TracingExporter,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		logger.Fatal("init tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Warn("flush traces", zap.Error(err))
+		}
+	}()
 
-	var authMiddleware func(http.Handler) http.Handler
-	switch cfg.AuthProvider {
+	poolConfig := cfg.Database.PoolConfig()
+	poolConfig.Tracer = tracing.NewQueryTracer()
+	pool, err := persistence.NewPool(ctx, poolConfig)
+	if err != nil {
+		logger.Fatal("init postgres pool", zap.Error(err))
+	}
+	defer persistence.ClosePool(pool)
+
+	metricsRegistry := metrics.NewRegistry()
+	metricsRegistry.MustRegister(metrics.NewPoolCollector(pool, "api"))
+
+	migrator := newMigrator(pool, logger)
+	if err := migrator.Verify(ctx); err != nil {
+		if cfg.MigrationsRequired {
+			logger.Fatal("database schema is not up to date; run migrate up", zap.Error(err))
+		}
+		logger.Warn("database schema is not up to date; run migrate up", zap.Error(err))
+	}
+
+	healthChecker := health.New(logger)
+	healthChecker.Register(
+		health.Check{Name: "postgres", Critical: true, Run: health.Ping(pool)},
+		health.Check{Name: "postgres_pool", Run: health.PoolSaturation(pool, 0.9)},
+		health.Check{Name: "migrations", Critical: cfg.MigrationsRequired, Run: migrator.Verify},
+	)
+
+	authStore, err := persistence.NewAuthStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init auth store", zap.Error(err))
+	}
+
+	var (
+		authMiddleware func(http.Handler) http.Handler
+		localSigner    *platformauth.LocalTokenSigner
+	)
+	switch cfg.Auth.Provider {
 	case "firebase":
 		_, fbAuth, err := gcp.InitFirebaseAuth(ctx)
 		if err != nil {
 			logger.Fatal("init firebase auth", zap.Error(err))
 		}
 		authMiddleware = platformauth.JWT(platformauth.FirebaseTokenVerifier(fbAuth), nil)
+	case "local":
+		localSigner, err = platformauth.NewLocalTokenSigner(platformauth.LocalTokenConfig{
+			SigningKey: []byte(cfg.Auth.LocalSigningKey),
+			Issuer:     cfg.Auth.Issuer,
+			AccessTTL:  cfg.Auth.AccessTokenTTL,
+		})
+		if err != nil {
+			logger.Fatal("init local token signer", zap.Error(err))
+		}
+		authMiddleware = platformauth.JWT(platformauth.LocalTokenVerifier(localSigner, localSessionChecker(authStore)), nil)
+	case "jwks":
+		mapping, err := platformauth.ParseClaimMapping(cfg.Auth.JWKSClaims)
+		if err != nil {
+			logger.Fatal("parse jwks claim mapping", zap.Error(err))
+		}
+		keys, err := platformauth.NewJWKS(ctx, platformauth.JWKSConfig{
+			URL:             cfg.Auth.JWKSURL,
+			File:            cfg.Auth.JWKSFile,
+			RefreshInterval: cfg.Auth.JWKSRefreshInterval,
+		})
+		if err != nil {
+			logger.Fatal("load jwks", zap.Error(err))
+		}
+		healthChecker.Register(health.Check{Name: "auth_keys", Timeout: 5 * time.Second, Run: keys.Check})
+		authMiddleware = platformauth.JWT(
+			platformauth.JWKSTokenVerifier(keys, platformauth.JWKSVerifierConfig{
+				Issuer:   cfg.Auth.JWKSIssuer,
+				Audience: cfg.Auth.JWKSAudience,
+			}),
+			platformauth.ClaimMappingExtractor(mapping),
+		)
 	case "dev":
 		logger.Warn("using dev auth middleware; do not use in production")
 		authMiddleware = platformauth.JWT(platformauth.UnsignedTokenVerifier(), nil)
 	default:
-		logger.Fatal("unsupported auth provider", zap.String("provider", cfg.AuthProvider))
+		logger.Fatal("unsupported auth provider", zap.String("provider", cfg.Auth.Provider))
 	}
 
-	pool, err := persistence.NewPool(ctx, persistence.PoolConfig{ConnString: cfg.DatabaseURL})
-	if err != nil {
-		logger.Fatal("init postgres pool", zap.Error(err))
-	}
-	defer persistence.ClosePool(pool)
-
 	categoryStore, err := persistence.NewSchemaCategoryStore(ctx, pool)
 	if err != nil {
 		logger.Fatal("init schema category store", zap.Error(err))
@@ -114,12 +267,33 @@ func main() {
 	if err != nil {
 		logger.Fatal("init schema repository store", zap.Error(err))
 	}
+	healthChecker.Register(health.Check{Name: "schema_repository", Critical: true, Run: schemaStore.CheckReadable})
+
+	accessStore, err := persistence.NewAccessControlStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init access control store", zap.Error(err))
+	}
+
+	accessRepo := accesscontrolrepo.NewPostgresRepository(accessStore, schemaStore)
+	accessService := accesscontrolservice.New(accessRepo)
+	accessHTTPHandler := accesscontrolhandler.New(accessService, logger)
+
+	serviceAccountStore, err := persistence.NewServiceAccountStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init service account store", zap.Error(err))
+	}
 
-	schemaRepo := schemarepositoryrepo.NewPostgresRepository(schemaStore)
+	serviceAccountRepo := serviceaccountsrepo.NewPostgresRepository(serviceAccountStore, schemaStore)
+	serviceAccountService := serviceaccountsservice.New(serviceAccountRepo)
+	serviceAccountHTTPHandler := serviceaccountshandler.New(serviceAccountService, logger)
+
+	schemaRepo := schemarepositoryrepo.NewPostgresRepository(schemaStore, accessStore)
 	schemaService := schemarepositoryservice.New(schemaRepo)
 	schemaHTTPHandler := schemarepositoryhandler.New(schemaService, logger)
 
-	schemaValidator := persistence.NewSchemaValidator()
+	schemaValidator := persistence.NewSchemaValidator(
+		persistence.WithSchemaValidatorObserver(metrics.NewSchemaValidator(metricsRegistry)),
+	)
 
 	userStore, err := persistence.NewUserStore(ctx, pool)
 	if err != nil {
@@ -127,21 +301,76 @@ func main() {
 	}
 
 	userRepo := usersrepo.NewPostgresRepository(userStore)
-	userService := usersservice.New(userRepo)
+	userOptions := []usersservice.Option{usersservice.WithAutoApproveDomains(cfg.AutoApproveDomains...)}
+	if localSigner != nil {
+		userOptions = append(userOptions, usersservice.WithLocalIssuer(cfg.Auth.Issuer))
+	}
+	userService := usersservice.New(userRepo, userOptions...)
 	userHTTPHandler := usershandler.New(userService, logger)
 
-	entitiesRepo := entitiesrepo.New(pool, schemaStore, schemaValidator)
-	entitiesService := entitiesservice.New(entitiesRepo)
+	entityImportStore, err := persistence.NewEntityImportStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init entity import store", zap.Error(err))
+	}
+
+	jobStore, err := persistence.NewJobStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init job store", zap.Error(err))
+	}
+
+	entitiesRepo := entitiesrepo.New(pool, schemaStore, schemaValidator, accessStore, entityImportStore,
+		entitiesrepo.WithWriteObserver(metrics.NewEntityWrites(metricsRegistry)),
+	)
+	entitiesService := entitiesservice.New(entitiesRepo,
+		entitiesservice.WithLogger(logger),
+		entitiesservice.WithJobs(jobStore),
+		entitiesservice.WithMaxImportSize(cfg.Server.MaxImportSize),
+	)
 	entitiesHTTPHandler := entitieshandler.New(entitiesService, logger)
 
+	jobService := jobsservice.New(jobsrepo.NewPostgresRepository(jobStore))
+	jobHTTPHandler := jobshandler.New(jobService, logger)
+
+	// Jobs queued here run on these workers or on apps/worker, which registers the same handlers.
+	jobRegistry := jobs.NewRegistry()
+	entitiesservice.RegisterJobs(jobRegistry, entitiesRepo, logger)
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	defer stopWorker()
+	workerDone := make(chan struct{})
+	if cfg.Jobs.Workers > 0 {
+		worker := jobs.NewWorker(jobStore, jobRegistry, cfg.Jobs.WorkerConfig(), logger)
+		go func() {
+			defer close(workerDone)
+			if err := worker.Run(workerCtx); err != nil {
+				logger.Error("job worker stopped", zap.Error(err))
+			}
+		}()
+	} else {
+		close(workerDone)
+	}
+
 	rootRouter := chi.NewRouter()
 
 	rootRouter.Use(
 		chimw.RequestID,
 		chimw.RealIP,
+		tracing.Middleware("/healthz", "/readyz"),
+		metrics.NewHTTP(metricsRegistry).Middleware(),
 		chimw.Recoverer,
-		chimw.Timeout(cfg.RequestTimeout),
-		platformmiddleware.DefaultCORS(),
+		platformmiddleware.Timeout(cfg.Server.RequestTimeout, platformmiddleware.LongRequest{
+			Match: func(r *http.Request) bool {
+				switch {
+				case strings.HasPrefix(r.URL.Path, "/api/v1/entities/"):
+					return strings.HasSuffix(r.URL.Path, "/export") || isImportUpload(r)
+				case strings.HasPrefix(r.URL.Path, "/api/v1/jobs/"):
+					return strings.HasSuffix(r.URL.Path, "/events")
+				default:
+					return false
+				}
+			},
+			Timeout: cfg.Server.BulkTimeout,
+		}),
+		platformmiddleware.CORS(cfg.CORS.AllowedOrigins),
 	)
 
 	rootRouter.Use(platformlogging.RequestLogger(logger))
@@ -149,21 +378,42 @@ func main() {
 	rootRouter.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
-	rootRouter.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
+	rootRouter.Handle("/readyz", healthChecker.Handler())
 
 	// ---- Swagger UI + OpenAPI JSON (public) ----
 	registerDocsRoutes(rootRouter, logger)
 
 	apiRouter := chi.NewRouter()
+	// API keys (plm_...) authenticate service accounts; every other bearer token goes to the JWT middleware.
+	apiRouter.Use(platformauth.APIKey(serviceAccountService.Authenticate))
 	apiRouter.Use(authMiddleware)
+	apiRouter.Use(platformmiddleware.ResolveTenant(cfg.DefaultTenantID))
+	// Resolves the caller's identity to a user record (UserCredentials.UserID). Pending, rejected and
+	// disabled users may only read their own profile and log out.
+	apiRouter.Use(usersmiddleware.RequireActiveUser(userService, logger, "/api/v1/users/me", "/api/v1/auth/logout"))
+	apiRouter.Use(platformauth.ResolveRoles(userRoleLookup(userStore)))
+	if limiter := newRateLimiter(ctx, cfg, pool, logger); limiter != nil {
+		apiRouter.Use(limiter.Middleware())
+	}
+	if cfg.IdempotencyKeyTTL > 0 {
+		idempotencyStore, err := persistence.NewIdempotencyStore(ctx, pool)
+		if err != nil {
+			logger.Fatal("init idempotency store", zap.Error(err))
+		}
+		// Keys outlive the request timeout so a retry never takes over a request that is still running.
+		// Import uploads are streamed to storage under MaxImportSize rather than buffered for a key.
+		apiRouter.Use(idempotency.New(idempotencyStore, idempotency.Config{
+			TTL:         cfg.IdempotencyKeyTTL,
+			LockTimeout: 2 * cfg.Server.RequestTimeout,
+			Skip:        isImportUpload,
+		}, logger).Middleware())
+	}
 
 	schemaCategoriesValidator := mustNewSpecValidator(logger, "contracts/schema-categories.yaml")
 	apiRouter.Group(func(r chi.Router) {
 		r.Use(schemaCategoriesValidator)
 		_ = schemacategories.HandlerWithOptions(
-			schemacategories.NewStrictHandler(categoryHTTPHandler, nil),
+			schemacategories.NewStrictHandler(categoryHTTPHandler, []schemacategories.StrictMiddlewareFunc{tracing.StrictMiddleware("schema-categories")}),
 			schemacategories.ChiServerOptions{BaseRouter: r},
 		)
 	})
@@ -172,7 +422,7 @@ func main() {
 	apiRouter.Group(func(r chi.Router) {
 		r.Use(schemaRepositoryValidator)
 		_ = schemarepository.HandlerWithOptions(
-			schemarepository.NewStrictHandler(schemaHTTPHandler, nil),
+			schemarepository.NewStrictHandler(schemaHTTPHandler, []schemarepository.StrictMiddlewareFunc{tracing.StrictMiddleware("schema-repository")}),
 			schemarepository.ChiServerOptions{BaseRouter: r},
 		)
 	})
@@ -181,47 +431,218 @@ func main() {
 	apiRouter.Group(func(r chi.Router) {
 		r.Use(entitiesValidator)
 		_ = entitiesapi.HandlerWithOptions(
-			entitiesapi.NewStrictHandler(entitiesHTTPHandler, nil),
+			entitiesapi.NewStrictHandler(entitiesHTTPHandler, []entitiesapi.StrictMiddlewareFunc{tracing.StrictMiddleware("entities")}),
 			entitiesapi.ChiServerOptions{BaseRouter: r},
 		)
 	})
 
+	jobsValidator := mustNewSpecValidator(logger, "contracts/jobs.yaml")
+	apiRouter.Group(func(r chi.Router) {
+		r.Use(jobsValidator)
+		_ = jobsapi.HandlerWithOptions(
+			jobsapi.NewStrictHandler(jobHTTPHandler, []jobsapi.StrictMiddlewareFunc{tracing.StrictMiddleware("jobs")}),
+			jobsapi.ChiServerOptions{BaseRouter: r},
+		)
+	})
+
 	usersValidator := mustNewSpecValidator(logger, "contracts/users.yaml")
 	apiRouter.Group(func(r chi.Router) {
 		r.Use(usersValidator)
 		_ = users.HandlerWithOptions(
-			users.NewStrictHandler(userHTTPHandler, nil),
+			users.NewStrictHandler(userHTTPHandler, []users.StrictMiddlewareFunc{tracing.StrictMiddleware("users")}),
 			users.ChiServerOptions{BaseRouter: r},
 		)
 	})
 
+	accessControlValidator := mustNewSpecValidator(logger, "contracts/access-control.yaml")
+	apiRouter.Group(func(r chi.Router) {
+		r.Use(accessControlValidator)
+		_ = accesscontrol.HandlerWithOptions(
+			accesscontrol.NewStrictHandler(accessHTTPHandler, []accesscontrol.StrictMiddlewareFunc{tracing.StrictMiddleware("access-control")}),
+			accesscontrol.ChiServerOptions{BaseRouter: r},
+		)
+	})
+
+	serviceAccountsValidator := mustNewSpecValidator(logger, "contracts/service-accounts.yaml")
+	apiRouter.Group(func(r chi.Router) {
+		r.Use(serviceAccountsValidator)
+		_ = serviceaccounts.HandlerWithOptions(
+			serviceaccounts.NewStrictHandler(serviceAccountHTTPHandler, []serviceaccounts.StrictMiddlewareFunc{tracing.StrictMiddleware("service-accounts")}),
+			serviceaccounts.ChiServerOptions{BaseRouter: r},
+		)
+	})
+
+	if localSigner != nil {
+		authRepo := authrepo.NewPostgresRepository(authStore, userStore)
+		authService := authservice.New(authRepo, localSigner, authservice.Config{RefreshTTL: cfg.Auth.RefreshTokenTTL})
+		authHTTPHandler := authhandler.New(authService, logger)
+
+		authValidator := mustNewSpecValidator(logger, "contracts/auth.yaml")
+		apiRouter.Group(func(r chi.Router) {
+			r.Use(authValidator)
+			_ = authapi.HandlerWithOptions(
+				authapi.NewStrictHandler(authHTTPHandler, []authapi.StrictMiddlewareFunc{tracing.StrictMiddleware("auth")}),
+				authapi.ChiServerOptions{BaseRouter: r},
+			)
+		})
+	}
+
 	rootRouter.Mount("/api/v1", apiRouter)
 
 	server := &http.Server{
-		Addr:         ":" + cfg.Port,
+		Addr:         ":" + cfg.Server.Port,
 		Handler:      rootRouter,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 60 * time.Second,
-		IdleTimeout:  2 * time.Minute,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+
+	// Metrics have no auth, so they get their own listener rather than a route on the public port.
+	var metricsServer *http.Server
+	if cfg.MetricsEnabled {
+		metricsRouter := chi.NewRouter()
+		metricsRouter.Handle("/metrics", metrics.Handler(metricsRegistry))
+		metricsServer = &http.Server{
+			Addr:              cfg.MetricsAddr,
+			Handler:           metricsRouter,
+			ReadHeaderTimeout: cfg.Server.ReadTimeout,
+			IdleTimeout:       cfg.Server.IdleTimeout,
+		}
+		metricsListener, err := net.Listen("tcp", cfg.MetricsAddr)
+		if err != nil {
+			logger.Fatal("metrics listen failed", zap.Error(err))
+		}
+		go func() {
+			logger.Info("serving metrics", zap.String("addr", metricsListener.Addr().String()))
+			if err := metricsServer.Serve(metricsListener); !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("metrics server stopped", zap.Error(err))
+			}
+		}()
 	}
 
 	go func() {
-		logger.Info("starting api server", zap.String("port", cfg.Port))
+		logger.Info("starting api server", zap.String("port", cfg.Server.Port))
 		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatal("server listen failed", zap.Error(err))
 		}
 	}()
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 	<-stop
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	healthChecker.Drain()
+	logger.Info("draining before shutdown", zap.Duration("delay", cfg.Server.ShutdownDrainDelay))
+	time.Sleep(cfg.Server.ShutdownDrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		logger.Error("graceful shutdown failed", zap.Error(err))
 	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("metrics server shutdown failed", zap.Error(err))
+		}
+	}
+
+	// Running jobs are cancelled and released back to the queue for another worker.
+	stopWorker()
+	select {
+	case <-workerDone:
+	case <-shutdownCtx.Done():
+		logger.Warn("job worker did not stop before the shutdown timeout")
+	}
+}
+
+// isImportUpload matches POST /api/v1/entities/{tableName}/imports, whose body is a streamed upload.
+func isImportUpload(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/v1/entities/") &&
+		strings.HasSuffix(r.URL.Path, "/imports")
+}
+
+// newMigrator returns a migrator for the migrations embedded from database/migrations.
+func newMigrator(pool *pgxpool.Pool, logger *zap.Logger) *migrate.Migrator {
+	migrations, err := migrate.Load(database.Migrations())
+	if err != nil {
+		logger.Fatal("load migrations", zap.Error(err))
+	}
+	migrator, err := migrate.New(pool, migrate.Config{Migrations: migrations, Schema: database.Schema()})
+	if err != nil {
+		logger.Fatal("init migrator", zap.Error(err))
+	}
+	return migrator
+}
+
+// newRateLimiter builds the API rate limiter for cfg.RateLimitBackend; it returns nil when limiting is off.
+func newRateLimiter(ctx context.Context, cfg config, pool *pgxpool.Pool, logger *zap.Logger) *ratelimit.Limiter {
+	var backend ratelimit.Backend
+	switch cfg.RateLimitBackend {
+	case "off":
+		return nil
+	case "memory":
+		backend = ratelimit.NewMemoryBackend()
+	case "postgres":
+		store, err := persistence.NewRateLimitStore(ctx, pool)
+		if err != nil {
+			logger.Fatal("init rate limit store", zap.Error(err))
+		}
+		backend = ratelimit.NewPostgresBackend(store)
+	default:
+		logger.Fatal("unsupported rate limit backend", zap.String("backend", cfg.RateLimitBackend))
+	}
+
+	logger.Info("rate limiting enabled",
+		zap.String("backend", cfg.RateLimitBackend),
+		zap.Stringer("read", cfg.RateLimitRead),
+		zap.Stringer("write", cfg.RateLimitWrite),
+		zap.Stringer("admin", cfg.RateLimitAdmin),
+	)
+
+	return ratelimit.New(backend, ratelimit.Policies{
+		Read:  cfg.RateLimitRead,
+		Write: cfg.RateLimitWrite,
+		Admin: cfg.RateLimitAdmin,
+	}, logger, "/api/v1/admin/")
+}
+
+// localSessionChecker reports whether the login session behind a locally issued token is still active.
+func localSessionChecker(store *persistence.AuthStore) platformauth.SessionChecker {
+	return func(ctx context.Context, sessionID string) (bool, error) {
+		id, err := uuid.Parse(sessionID)
+		if err != nil {
+			return false, nil
+		}
+		return store.IsSessionActive(ctx, id)
+	}
+}
+
+// userRoleLookup resolves the roles stored on the users table for the authenticated caller, using the
+// user ID that RequireActiveUser resolved from their identity; callers without a user record get no stored roles.
+func userRoleLookup(store *persistence.UserStore) platformauth.RoleLookup {
+	return func(ctx context.Context, creds *platformauth.UserCredentials) ([]platformauth.Role, error) {
+		id, err := uuid.Parse(creds.UserID)
+		if err != nil {
+			return nil, nil
+		}
+
+		user, err := store.GetUser(ctx, id)
+		if errors.Is(err, persistence.ErrUserNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		roles := make([]platformauth.Role, 0, len(user.Roles))
+		for _, raw := range user.Roles {
+			if role, ok := platformauth.ParseRole(raw); ok {
+				roles = append(roles, role)
+			}
+		}
+		return roles, nil
+	}
 }
 
 // mustNewSpecValidator loads the OpenAPI document and builds oapi-codegen validator middleware.
@@ -237,6 +658,7 @@ func mustNewSpecValidator(logger *zap.Logger, path string) func(http.Handler) ht
 			Options: openapi3filter.Options{
 				AuthenticationFunc: platformmiddleware.ValidateAuthenticationViaSwagger,
 			},
+			ErrorHandlerWithOpts: platformmiddleware.SpecValidationErrorHandler,
 		})
 	}
 
@@ -291,6 +713,7 @@ func mustNewSpecValidator(logger *zap.Logger, path string) func(http.Handler) ht
 		Options: openapi3filter.Options{
 			AuthenticationFunc: platformmiddleware.ValidateAuthenticationViaSwagger,
 		},
+		ErrorHandlerWithOpts: platformmiddleware.SpecValidationErrorHandler,
 	})
 }
 
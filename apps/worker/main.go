@@ -0,0 +1,111 @@
+// Command worker runs background jobs queued by the API without serving HTTP, so long-running jobs can
+// be scaled and deployed apart from the API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	entitiesrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/repo"
+	entitiesservice "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/service"
+	platformconfig "github.com/zenGate-Global/palmyra-pro-saas/platform/go/config"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/jobs"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+type config struct {
+	Database platformconfig.Database `yaml:"database"`
+	Log      platformconfig.Log      `yaml:"log"`
+	Jobs     platformconfig.Jobs     `yaml:"jobs"`
+
+	TracingExporter    string  `env:"TRACING_EXPORTER" envDefault:"none" yaml:"tracing_exporter"` // none | otlp | stdout
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1" yaml:"tracing_sample_ratio"`
+}
+
+// Validate checks the worker settings not covered by the shared sections.
+func (c config) Validate() error {
+	if c.Jobs.Workers == 0 {
+		return errors.New("jobs.workers must be at least 1 for the worker")
+	}
+	return nil
+}
+
+func main() {
+	configFile := flag.String("config", os.Getenv(platformconfig.FileEnv), "YAML configuration file; environment variables override it")
+	flag.Parse()
+
+	var cfg config
+	if err := platformconfig.Load(&cfg, *configFile); err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := cfg.Log.Logger("worker")
+	if err != nil {
+		log.Fatalf("init zap logger: %v", err)
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+	logger.Info("configuration loaded", zap.String("file", *configFile), zap.Any("config", platformconfig.Redacted(&cfg)))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName: "palmyra-worker",
+		Exporter:    cfg.TracingExporter,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		logger.Fatal("init tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Warn("flush traces", zap.Error(err))
+		}
+	}()
+
+	poolConfig := cfg.Database.PoolConfig()
+	poolConfig.Tracer = tracing.NewQueryTracer()
+	pool, err := persistence.NewPool(ctx, poolConfig)
+	if err != nil {
+		logger.Fatal("init postgres pool", zap.Error(err))
+	}
+	defer persistence.ClosePool(pool)
+
+	schemaStore, err := persistence.NewSchemaRepositoryStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init schema repository store", zap.Error(err))
+	}
+	accessStore, err := persistence.NewAccessControlStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init access control store", zap.Error(err))
+	}
+	entityImportStore, err := persistence.NewEntityImportStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init entity import store", zap.Error(err))
+	}
+	jobStore, err := persistence.NewJobStore(ctx, pool)
+	if err != nil {
+		logger.Fatal("init job store", zap.Error(err))
+	}
+
+	entitiesRepo := entitiesrepo.New(pool, schemaStore, persistence.NewSchemaValidator(), accessStore, entityImportStore)
+
+	// The same handlers as apps/api: table revalidations and imports.
+	registry := jobs.NewRegistry()
+	entitiesservice.RegisterJobs(registry, entitiesRepo, logger)
+
+	// Run returns once ctx is cancelled and the running jobs have been released back to the queue.
+	if err := jobs.NewWorker(jobStore, registry, cfg.Jobs.WorkerConfig(), logger).Run(ctx); err != nil {
+		logger.Fatal("run job worker", zap.Error(err))
+	}
+}
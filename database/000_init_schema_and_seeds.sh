@@ -4,6 +4,7 @@ set -euo pipefail
 PSQL=(psql -v ON_ERROR_STOP=1 -U "${POSTGRES_USER:-postgres}" -d "${POSTGRES_DB:-postgres}")
 
 declare -r SCHEMA_DIR="/docker-entrypoint-initdb.d/schema"
+declare -r MIGRATIONS_DIR="/docker-entrypoint-initdb.d/migrations"
 declare -r DEV_SEED_DIR="/docker-entrypoint-initdb.d/seeds/dev"
 
 run_sql_dir() {
@@ -27,7 +28,24 @@ run_sql_dir() {
   done
 }
 
+# The schema snapshot already contains every migration, so record them as applied (same checksum as
+# platform/go/migrate: sha256 of the file) instead of running them.
+record_migration_baseline() {
+  local file base
+  mapfile -t files < <(find "$MIGRATIONS_DIR" -maxdepth 1 -type f -name '*.sql' ! -name '*.down.sql' | sort)
+  echo "[db-init] Recording ${#files[@]} migrations as baseline"
+  for file in "${files[@]}"; do
+    base="$(basename "$file" .sql)"
+    "${PSQL[@]}" -q \
+      -v version="${base%%_*}" \
+      -v name="${base#*_}" \
+      -v checksum="$(sha256sum "$file" | cut -d' ' -f1)" \
+      <<<"INSERT INTO schema_migrations (version, name, checksum, baseline) VALUES (:'version', :'name', :'checksum', TRUE) ON CONFLICT (version) DO NOTHING;"
+  done
+}
+
 run_sql_dir "$SCHEMA_DIR" "schema"
+record_migration_baseline
 
 seed_mode="${PALMYRA_DB_SEED_MODE:-dev}"
 if [[ "$seed_mode" == "dev" ]]; then
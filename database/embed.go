@@ -0,0 +1,29 @@
+// Package database embeds the SQL assets so binaries can provision and migrate databases without a
+// checkout of this directory.
+package database
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed schema/*.sql migrations/*.sql
+var files embed.FS
+
+// Schema returns the canonical DDL snapshot (database/schema), applied as-is to empty databases.
+func Schema() fs.FS {
+	sub, err := fs.Sub(files, "schema")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Migrations returns the versioned change sets (database/migrations).
+func Migrations() fs.FS {
+	sub, err := fs.Sub(files, "migrations")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
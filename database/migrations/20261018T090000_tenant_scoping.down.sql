@@ -0,0 +1,79 @@
+-- Revert tenant scoping: drop the isolation policies and the tenant_id columns, restoring the
+-- single-tenant keys and indexes. Only a database holding nothing but the "default" tenant can go back;
+-- rows of other tenants would collide once the tenant prefix is gone, so the rollback refuses them.
+
+-- Row-level security is lifted first so the checks and the loop below see every tenant's rows.
+DROP POLICY IF EXISTS schema_repository_tenant_isolation ON schema_repository;
+ALTER TABLE schema_repository NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE schema_repository DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS schema_categories_tenant_isolation ON schema_categories;
+ALTER TABLE schema_categories NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE schema_categories DISABLE ROW LEVEL SECURITY;
+
+DO $$
+DECLARE
+    tbl TEXT;
+    foreign_rows BOOLEAN;
+BEGIN
+    IF EXISTS (SELECT 1 FROM schema_categories WHERE tenant_id <> 'default')
+        OR EXISTS (SELECT 1 FROM schema_repository WHERE tenant_id <> 'default') THEN
+        RAISE EXCEPTION 'tenant scoping cannot be reverted: schemas of tenants other than "default" exist';
+    END IF;
+
+    FOR tbl IN
+        SELECT DISTINCT sr.table_name
+        FROM schema_repository sr
+        JOIN information_schema.tables t
+          ON t.table_schema = current_schema() AND t.table_name = sr.table_name
+    LOOP
+        EXECUTE format('DROP POLICY IF EXISTS %I ON %I', tbl || '_tenant_isolation', tbl);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', tbl);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', tbl);
+        EXECUTE format('SELECT EXISTS (SELECT 1 FROM %I WHERE tenant_id <> %L)', tbl, 'default') INTO foreign_rows;
+        IF foreign_rows THEN
+            RAISE EXCEPTION 'tenant scoping cannot be reverted: % holds entities of tenants other than "default"', tbl;
+        END IF;
+
+        -- Dropping the column drops the tenant-prefixed primary key, foreign key and indexes with it.
+        EXECUTE format('ALTER TABLE %I DROP COLUMN tenant_id', tbl);
+        EXECUTE format('ALTER TABLE %I ADD PRIMARY KEY (entity_id, entity_version)', tbl);
+        EXECUTE format(
+            'ALTER TABLE %I ADD FOREIGN KEY (schema_id, schema_version) REFERENCES schema_repository(schema_id, schema_version)',
+            tbl
+        );
+        EXECUTE format('CREATE UNIQUE INDEX %I ON %I (entity_id) WHERE is_active AND NOT is_soft_deleted', tbl || '_active_idx', tbl);
+        EXECUTE format('CREATE UNIQUE INDEX %I ON %I (slug) WHERE is_active AND NOT is_soft_deleted', tbl || '_slug_active_idx', tbl);
+        EXECUTE format('CREATE INDEX %I ON %I (schema_id, schema_version)', tbl || '_schema_idx', tbl);
+    END LOOP;
+END
+$$;
+
+-- As above, the column takes its check, unique key, foreign key and tenant-prefixed indexes with it.
+ALTER TABLE schema_repository DROP COLUMN tenant_id;
+ALTER TABLE schema_repository ADD CONSTRAINT schema_repository_category_id_fkey
+    FOREIGN KEY (category_id) REFERENCES schema_categories(category_id);
+
+CREATE UNIQUE INDEX schema_repository_table_name_idx
+    ON schema_repository(table_name)
+    WHERE NOT is_soft_deleted AND is_active;
+
+CREATE UNIQUE INDEX schema_repository_slug_idx
+    ON schema_repository(slug)
+    WHERE NOT is_soft_deleted AND is_active;
+
+CREATE INDEX schema_repository_category_idx
+    ON schema_repository(category_id)
+    WHERE NOT is_soft_deleted;
+
+ALTER TABLE schema_categories DROP COLUMN tenant_id;
+ALTER TABLE schema_categories ADD CONSTRAINT schema_categories_parent_category_id_fkey
+    FOREIGN KEY (parent_category_id) REFERENCES schema_categories(category_id);
+
+CREATE UNIQUE INDEX schema_categories_name_idx
+    ON schema_categories(name)
+    WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX schema_categories_slug_idx
+    ON schema_categories(slug)
+    WHERE deleted_at IS NULL;
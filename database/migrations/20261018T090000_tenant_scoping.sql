@@ -0,0 +1,97 @@
+-- Scope schema categories, schema repository rows and entity tables by tenant.
+-- Existing rows are assigned to the "default" tenant (DEFAULT_TENANT_ID in single-tenant deployments).
+
+ALTER TABLE schema_categories ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE schema_categories ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE schema_categories ADD CONSTRAINT schema_categories_tenant_id_check
+    CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$');
+ALTER TABLE schema_categories ADD CONSTRAINT schema_categories_tenant_id_category_id_key
+    UNIQUE (tenant_id, category_id);
+ALTER TABLE schema_categories DROP CONSTRAINT IF EXISTS schema_categories_parent_category_id_fkey;
+ALTER TABLE schema_categories ADD CONSTRAINT schema_categories_tenant_id_parent_category_id_fkey
+    FOREIGN KEY (tenant_id, parent_category_id) REFERENCES schema_categories(tenant_id, category_id);
+
+DROP INDEX IF EXISTS schema_categories_name_idx;
+CREATE UNIQUE INDEX schema_categories_name_idx
+    ON schema_categories(tenant_id, name)
+    WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS schema_categories_slug_idx;
+CREATE UNIQUE INDEX schema_categories_slug_idx
+    ON schema_categories(tenant_id, slug)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE schema_repository ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE schema_repository ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE schema_repository ADD CONSTRAINT schema_repository_tenant_id_check
+    CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$');
+ALTER TABLE schema_repository ADD CONSTRAINT schema_repository_tenant_id_schema_id_schema_version_key
+    UNIQUE (tenant_id, schema_id, schema_version);
+ALTER TABLE schema_repository DROP CONSTRAINT IF EXISTS schema_repository_category_id_fkey;
+ALTER TABLE schema_repository ADD CONSTRAINT schema_repository_tenant_id_category_id_fkey
+    FOREIGN KEY (tenant_id, category_id) REFERENCES schema_categories(tenant_id, category_id);
+
+DROP INDEX IF EXISTS schema_repository_table_name_idx;
+CREATE UNIQUE INDEX schema_repository_table_name_idx
+    ON schema_repository(tenant_id, table_name)
+    WHERE NOT is_soft_deleted AND is_active;
+
+DROP INDEX IF EXISTS schema_repository_slug_idx;
+CREATE UNIQUE INDEX schema_repository_slug_idx
+    ON schema_repository(tenant_id, slug)
+    WHERE NOT is_soft_deleted AND is_active;
+
+DROP INDEX IF EXISTS schema_repository_category_idx;
+CREATE INDEX schema_repository_category_idx
+    ON schema_repository(tenant_id, category_id)
+    WHERE NOT is_soft_deleted;
+
+-- Entity tables are created on demand by the entity repository; bring existing ones in line
+-- with the tenant-aware layout (tenant_id column, tenant-prefixed keys and the isolation policy).
+DO $$
+DECLARE
+    tbl TEXT;
+BEGIN
+    FOR tbl IN
+        SELECT DISTINCT sr.table_name
+        FROM schema_repository sr
+        JOIN information_schema.tables t
+          ON t.table_schema = current_schema() AND t.table_name = sr.table_name
+    LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT %L', tbl, 'default');
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id DROP DEFAULT', tbl);
+        EXECUTE format('ALTER TABLE %I DROP CONSTRAINT IF EXISTS %I', tbl, tbl || '_schema_id_schema_version_fkey');
+        EXECUTE format('ALTER TABLE %I DROP CONSTRAINT IF EXISTS %I', tbl, tbl || '_pkey');
+        EXECUTE format('ALTER TABLE %I ADD PRIMARY KEY (tenant_id, entity_id, entity_version)', tbl);
+        EXECUTE format(
+            'ALTER TABLE %I ADD FOREIGN KEY (tenant_id, schema_id, schema_version) REFERENCES schema_repository(tenant_id, schema_id, schema_version)',
+            tbl
+        );
+        EXECUTE format('DROP INDEX IF EXISTS %I', tbl || '_active_idx');
+        EXECUTE format('CREATE UNIQUE INDEX %I ON %I (tenant_id, entity_id) WHERE is_active AND NOT is_soft_deleted', tbl || '_active_idx', tbl);
+        EXECUTE format('DROP INDEX IF EXISTS %I', tbl || '_slug_active_idx');
+        EXECUTE format('CREATE UNIQUE INDEX %I ON %I (tenant_id, slug) WHERE is_active AND NOT is_soft_deleted', tbl || '_slug_active_idx', tbl);
+        EXECUTE format('DROP INDEX IF EXISTS %I', tbl || '_schema_idx');
+        EXECUTE format('CREATE INDEX %I ON %I (tenant_id, schema_id, schema_version)', tbl || '_schema_idx', tbl);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', tbl);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', tbl);
+        EXECUTE format(
+            'CREATE POLICY %I ON %I USING (tenant_id = current_setting(%L, true)) WITH CHECK (tenant_id = current_setting(%L, true))',
+            tbl || '_tenant_isolation', tbl, 'app.tenant_id', 'app.tenant_id'
+        );
+    END LOOP;
+END
+$$;
+
+-- Enable row-level security last so the loop above still sees every schema row.
+ALTER TABLE schema_categories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE schema_categories FORCE ROW LEVEL SECURITY;
+CREATE POLICY schema_categories_tenant_isolation ON schema_categories
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE schema_repository ENABLE ROW LEVEL SECURITY;
+ALTER TABLE schema_repository FORCE ROW LEVEL SECURITY;
+CREATE POLICY schema_repository_tenant_isolation ON schema_repository
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
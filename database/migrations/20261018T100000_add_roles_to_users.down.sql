@@ -0,0 +1,4 @@
+-- Roles return to coming only from tokens.
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_roles_check;
+ALTER TABLE users DROP COLUMN IF EXISTS roles;
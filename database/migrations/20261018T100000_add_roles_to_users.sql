@@ -0,0 +1,6 @@
+-- Persist application roles (contracts/common/iam.yaml#/components/schemas/UserRole) per user.
+-- Roles granted here are merged with any roles carried by the caller's token.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS roles TEXT[] NOT NULL DEFAULT ARRAY['user']::TEXT[];
+ALTER TABLE users ADD CONSTRAINT users_roles_check
+    CHECK (cardinality(roles) > 0 AND roles <@ ARRAY['admin', 'user_manager', 'user']::TEXT[]);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS access_control_entries;
+DROP TABLE IF EXISTS access_group_members;
+DROP TABLE IF EXISTS access_groups;
@@ -0,0 +1,73 @@
+-- Access control lists: groups of users and read/write/admin grants on schema categories or entity tables.
+-- Resources without any applicable entry stay open to every authenticated caller.
+
+-- Access groups bundle users so ACL entries can target many principals at once.
+CREATE TABLE access_groups (
+    group_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 128),
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, group_id)
+);
+
+CREATE UNIQUE INDEX access_groups_name_idx
+    ON access_groups(tenant_id, LOWER(name));
+
+CREATE TABLE access_group_members (
+    tenant_id TEXT NOT NULL,
+    group_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, group_id, user_id),
+    FOREIGN KEY (tenant_id, group_id) REFERENCES access_groups(tenant_id, group_id) ON DELETE CASCADE
+);
+
+CREATE INDEX access_group_members_user_idx
+    ON access_group_members(tenant_id, user_id);
+
+-- ACL entries grant read/write/admin on a schema category (inherited down the tree) or an entity table.
+-- Each entry targets exactly one principal (user or group) and exactly one resource.
+CREATE TABLE access_control_entries (
+    entry_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    user_id UUID REFERENCES users(user_id) ON DELETE CASCADE,
+    group_id UUID,
+    category_id UUID,
+    table_name TEXT CHECK (table_name ~ '^[a-z][a-z0-9_]*$'),
+    permission TEXT NOT NULL CHECK (permission IN ('read', 'write', 'admin')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (num_nonnulls(user_id, group_id) = 1),
+    CHECK (num_nonnulls(category_id, table_name) = 1),
+    FOREIGN KEY (tenant_id, group_id) REFERENCES access_groups(tenant_id, group_id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id, category_id) REFERENCES schema_categories(tenant_id, category_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX access_control_entries_grant_idx
+    ON access_control_entries(tenant_id, COALESCE(user_id, group_id), COALESCE(category_id::TEXT, table_name));
+
+CREATE INDEX access_control_entries_category_idx
+    ON access_control_entries(tenant_id, category_id)
+    WHERE category_id IS NOT NULL;
+
+CREATE INDEX access_control_entries_table_idx
+    ON access_control_entries(tenant_id, table_name)
+    WHERE table_name IS NOT NULL;
+
+ALTER TABLE access_groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE access_groups FORCE ROW LEVEL SECURITY;
+CREATE POLICY access_groups_tenant_isolation ON access_groups
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE access_group_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE access_group_members FORCE ROW LEVEL SECURITY;
+CREATE POLICY access_group_members_tenant_isolation ON access_group_members
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE access_control_entries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE access_control_entries FORCE ROW LEVEL SECURITY;
+CREATE POLICY access_control_entries_tenant_isolation ON access_control_entries
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
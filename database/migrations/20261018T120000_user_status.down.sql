@@ -0,0 +1,6 @@
+-- Pending, rejected and disabled users regain access once the status column is gone.
+
+DROP INDEX IF EXISTS users_status_idx;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS status;
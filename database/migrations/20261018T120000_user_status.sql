@@ -0,0 +1,10 @@
+-- Approval lifecycle for users: pending -> approved | rejected; approved/enabled <-> disabled.
+-- Existing users keep their access, so they are backfilled as approved before the default flips to pending.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'approved';
+ALTER TABLE users ALTER COLUMN status SET DEFAULT 'pending';
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('pending', 'approved', 'rejected', 'enabled', 'disabled'));
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS users_status_idx ON users(status, created_at DESC);
@@ -0,0 +1,43 @@
+-- Service accounts for machine clients and the hashed API keys they authenticate with.
+
+-- Service accounts authenticate machine clients (seeders, integrations) with API keys instead of user tokens.
+-- table_scope restricts the account to the listed entity tables; NULL allows every table its roles allow.
+CREATE TABLE service_accounts (
+    service_account_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 128),
+    description TEXT,
+    roles TEXT[] NOT NULL CHECK (cardinality(roles) > 0),
+    table_scope TEXT[],
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, service_account_id)
+);
+
+CREATE UNIQUE INDEX service_accounts_name_idx
+    ON service_accounts(tenant_id, LOWER(name));
+
+-- API keys are looked up by their public prefix before the tenant is known, so the table is not covered by
+-- row-level security; management queries still filter on tenant_id. Only SHA-256 hashes of the secrets are stored.
+CREATE TABLE api_keys (
+    key_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    service_account_id UUID NOT NULL,
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 128),
+    key_prefix TEXT NOT NULL UNIQUE,
+    secret_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    FOREIGN KEY (tenant_id, service_account_id) REFERENCES service_accounts(tenant_id, service_account_id) ON DELETE CASCADE
+);
+
+CREATE INDEX api_keys_service_account_idx
+    ON api_keys(tenant_id, service_account_id);
+
+ALTER TABLE service_accounts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE service_accounts FORCE ROW LEVEL SECURITY;
+CREATE POLICY service_accounts_tenant_isolation ON service_accounts
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
@@ -0,0 +1,10 @@
+-- Token buckets shared by API replicas (RATE_LIMIT_BACKEND=postgres). Keyed by caller identity, not
+-- tenant-scoped, and UNLOGGED because losing them on a crash only resets the limits.
+
+CREATE UNLOGGED TABLE rate_limit_buckets (
+    bucket_key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    refilled_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_refilled_idx ON rate_limit_buckets(refilled_at);
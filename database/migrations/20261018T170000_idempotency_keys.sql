@@ -0,0 +1,26 @@
+-- Idempotency-Key records: the fingerprint of the first request per (caller, key) and, once it finished,
+-- the response replayed to retries. In-progress rows hold a lock until locked_until so crashed requests
+-- do not block the key forever.
+
+CREATE TABLE idempotency_keys (
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    scope TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL CHECK (length(idempotency_key) BETWEEN 1 AND 255),
+    fingerprint TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('in_progress', 'completed')),
+    response_status INTEGER,
+    response_headers JSONB,
+    response_body BYTEA,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, scope, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_idx ON idempotency_keys(tenant_id, expires_at);
+
+ALTER TABLE idempotency_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE idempotency_keys FORCE ROW LEVEL SECURITY;
+CREATE POLICY idempotency_keys_tenant_isolation ON idempotency_keys
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
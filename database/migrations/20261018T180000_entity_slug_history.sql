@@ -0,0 +1,19 @@
+-- Slugs an entity used before it was renamed, so old public URLs keep resolving (with a redirect) to the
+-- entity's current slug. Entity tables are created on demand, so the history is kept here keyed by table.
+
+CREATE TABLE entity_slug_history (
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    table_name TEXT NOT NULL,
+    slug TEXT NOT NULL CHECK (slug ~ '^[a-z0-9]+(?:-[a-z0-9]+)*$'),
+    entity_id TEXT NOT NULL,
+    retired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, table_name, slug)
+);
+
+CREATE INDEX IF NOT EXISTS entity_slug_history_entity_idx ON entity_slug_history(tenant_id, table_name, entity_id);
+
+ALTER TABLE entity_slug_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_slug_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY entity_slug_history_tenant_isolation ON entity_slug_history
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS entity_import_results;
+DROP TABLE IF EXISTS entity_imports;
@@ -0,0 +1,44 @@
+-- Uploads imported into entity tables through the API. entity_imports tracks each import's options,
+-- status and outcome counts; entity_import_results keeps the outcome of every input line so rejections
+-- can be downloaded afterwards.
+
+CREATE TABLE entity_imports (
+    import_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    table_name TEXT NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('ndjson', 'csv')),
+    options JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    accepted_count BIGINT NOT NULL DEFAULT 0,
+    updated_count BIGINT NOT NULL DEFAULT 0,
+    unchanged_count BIGINT NOT NULL DEFAULT 0,
+    rejected_count BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS entity_imports_table_idx ON entity_imports(tenant_id, table_name, created_at DESC);
+
+CREATE TABLE entity_import_results (
+    import_id UUID NOT NULL REFERENCES entity_imports(import_id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    line INTEGER NOT NULL,
+    entity_id TEXT,
+    outcome TEXT NOT NULL CHECK (outcome IN ('accepted', 'updated', 'unchanged', 'rejected')),
+    error TEXT,
+    PRIMARY KEY (import_id, line)
+);
+
+ALTER TABLE entity_imports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_imports FORCE ROW LEVEL SECURITY;
+CREATE POLICY entity_imports_tenant_isolation ON entity_imports
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE entity_import_results ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_import_results FORCE ROW LEVEL SECURITY;
+CREATE POLICY entity_import_results_tenant_isolation ON entity_import_results
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
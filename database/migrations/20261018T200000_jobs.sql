@@ -0,0 +1,31 @@
+-- Background jobs (see platform/go/jobs). Workers claim queued jobs of every tenant with
+-- FOR UPDATE SKIP LOCKED, so the table is not covered by row-level security; API queries still filter on
+-- tenant_id. Running jobs hold their claim by heartbeating; jobs whose heartbeat stops are requeued.
+-- created_by is the principal key of the caller who enqueued the job (user:<id>, sa:<id>).
+
+CREATE TABLE jobs (
+    job_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL CHECK (status IN ('queued', 'running', 'succeeded', 'failed', 'cancelled')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 1 CHECK (max_attempts > 0),
+    run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    worker_id TEXT,
+    heartbeat_at TIMESTAMPTZ,
+    progress REAL NOT NULL DEFAULT 0 CHECK (progress BETWEEN 0 AND 100),
+    progress_message TEXT,
+    result JSONB,
+    error TEXT,
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS jobs_queued_idx ON jobs(run_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS jobs_running_idx ON jobs(heartbeat_at) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS jobs_tenant_idx ON jobs(tenant_id, created_at DESC);
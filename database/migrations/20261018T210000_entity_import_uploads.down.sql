@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS entity_import_uploads;
+ALTER TABLE entity_imports DROP COLUMN IF EXISTS job_id;
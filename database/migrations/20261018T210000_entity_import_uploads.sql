@@ -0,0 +1,26 @@
+-- Imports run as entities.import jobs on any API or worker process, so each upload is kept in the
+-- database, in chunks, until its import finishes. entity_imports.job_id links the import to its job.
+
+ALTER TABLE entity_imports ADD COLUMN IF NOT EXISTS job_id UUID;
+
+CREATE TABLE entity_import_uploads (
+    import_id UUID NOT NULL REFERENCES entity_imports(import_id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (import_id, seq)
+);
+
+ALTER TABLE entity_import_uploads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_import_uploads FORCE ROW LEVEL SECURITY;
+CREATE POLICY entity_import_uploads_tenant_isolation ON entity_import_uploads
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Imports used to run in the API process that accepted them, reading a temporary file. Unfinished ones
+-- died with that process and have nothing left to resume from.
+ALTER TABLE entity_imports NO FORCE ROW LEVEL SECURITY;
+UPDATE entity_imports
+SET status = 'failed', error = 'interrupted by a restart; upload it again', finished_at = NOW()
+WHERE status IN ('queued', 'running');
+ALTER TABLE entity_imports FORCE ROW LEVEL SECURITY;
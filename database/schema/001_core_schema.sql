@@ -2,37 +2,45 @@
 -- This file is executed automatically by the Postgres container on first startup.
 
 -- Schema Categories capture the taxonomy for schemas.
+-- Every row belongs to a tenant; parents must live in the same tenant.
 CREATE TABLE IF NOT EXISTS schema_categories (
     category_id UUID PRIMARY KEY,
-    parent_category_id UUID REFERENCES schema_categories(category_id),
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    parent_category_id UUID,
     name TEXT NOT NULL,
     slug TEXT NOT NULL CHECK (slug ~ '^[a-z0-9]+(?:-[a-z0-9]+)*$'),
     description TEXT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    deleted_at TIMESTAMPTZ
+    deleted_at TIMESTAMPTZ,
+    UNIQUE (tenant_id, category_id),
+    FOREIGN KEY (tenant_id, parent_category_id) REFERENCES schema_categories(tenant_id, category_id)
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS schema_categories_name_idx
-    ON schema_categories(name)
+    ON schema_categories(tenant_id, name)
     WHERE deleted_at IS NULL;
 
 CREATE UNIQUE INDEX IF NOT EXISTS schema_categories_slug_idx
-    ON schema_categories(slug)
+    ON schema_categories(tenant_id, slug)
     WHERE deleted_at IS NULL;
 
 -- Schema Repository stores every JSON schema definition and lifecycle flags.
+-- Table names and slugs are unique per tenant; entity tables carry the same tenant_id column.
 CREATE TABLE IF NOT EXISTS schema_repository (
     schema_id UUID NOT NULL,
     schema_version TEXT NOT NULL CHECK (schema_version ~ '^[0-9]+\.[0-9]+\.[0-9]+$'),
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
     schema_definition JSONB NOT NULL,
     table_name TEXT NOT NULL CHECK (table_name ~ '^[a-z][a-z0-9_]*$'),
     slug TEXT NOT NULL CHECK (slug ~ '^[a-z0-9]+(?:-[a-z0-9]+)*$'),
-    category_id UUID NOT NULL REFERENCES schema_categories(category_id),
+    category_id UUID NOT NULL,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     is_soft_deleted BOOLEAN NOT NULL DEFAULT FALSE,
     is_active BOOLEAN NOT NULL DEFAULT FALSE,
-    PRIMARY KEY (schema_id, schema_version)
+    PRIMARY KEY (schema_id, schema_version),
+    UNIQUE (tenant_id, schema_id, schema_version),
+    FOREIGN KEY (tenant_id, category_id) REFERENCES schema_categories(tenant_id, category_id)
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS schema_repository_active_schema_idx
@@ -40,24 +48,367 @@ CREATE UNIQUE INDEX IF NOT EXISTS schema_repository_active_schema_idx
     WHERE is_active AND NOT is_soft_deleted;
 
 CREATE UNIQUE INDEX IF NOT EXISTS schema_repository_table_name_idx
-    ON schema_repository(table_name)
+    ON schema_repository(tenant_id, table_name)
     WHERE NOT is_soft_deleted AND is_active;
 
 CREATE UNIQUE INDEX IF NOT EXISTS schema_repository_slug_idx
-    ON schema_repository(slug)
+    ON schema_repository(tenant_id, slug)
     WHERE NOT is_soft_deleted AND is_active;
 
 CREATE INDEX IF NOT EXISTS schema_repository_category_idx
-    ON schema_repository(category_id)
+    ON schema_repository(tenant_id, category_id)
     WHERE NOT is_soft_deleted;
 
+-- Row-level security is defense in depth on top of the tenant_id predicates every store applies.
+-- The API pool sets app.tenant_id on each acquired connection (see platform/go/persistence/pool.go).
+-- FORCE keeps the policy active for the table owner as well; superusers still bypass it.
+ALTER TABLE schema_categories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE schema_categories FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS schema_categories_tenant_isolation ON schema_categories;
+CREATE POLICY schema_categories_tenant_isolation ON schema_categories
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE schema_repository ENABLE ROW LEVEL SECURITY;
+ALTER TABLE schema_repository FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS schema_repository_tenant_isolation ON schema_repository;
+CREATE POLICY schema_repository_tenant_isolation ON schema_repository
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
 -- Users table for admin/approval workflows.
 CREATE TABLE IF NOT EXISTS users (
     user_id UUID PRIMARY KEY,
     email TEXT NOT NULL UNIQUE,
     full_name TEXT NOT NULL,
+    roles TEXT[] NOT NULL DEFAULT ARRAY['user']::TEXT[]
+        CHECK (cardinality(roles) > 0 AND roles <@ ARRAY['admin', 'user_manager', 'user']::TEXT[]),
+    -- Approval lifecycle: pending -> approved | rejected; approved/enabled <-> disabled.
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'enabled', 'disabled')),
+    status_reason TEXT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
 CREATE INDEX IF NOT EXISTS users_created_at_idx ON users(created_at DESC);
+CREATE INDEX IF NOT EXISTS users_status_idx ON users(status, created_at DESC);
+
+-- External identities (token issuer + subject) linked to the user record they resolve to.
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS user_identities_user_idx ON user_identities(user_id);
+
+-- Local identity provider (AUTH_PROVIDER=local): password credentials, login sessions and rotating refresh tokens.
+CREATE TABLE IF NOT EXISTS auth_credentials (
+    user_id UUID PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    session_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS auth_sessions_user_idx ON auth_sessions(user_id);
+
+-- Refresh tokens are stored as SHA-256 hashes; used_at marks rotated tokens for reuse detection.
+CREATE TABLE IF NOT EXISTS auth_refresh_tokens (
+    token_hash TEXT PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES auth_sessions(session_id) ON DELETE CASCADE,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS auth_refresh_tokens_session_idx ON auth_refresh_tokens(session_id);
+
+-- Access groups bundle users so ACL entries can target many principals at once.
+CREATE TABLE IF NOT EXISTS access_groups (
+    group_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 128),
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, group_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS access_groups_name_idx
+    ON access_groups(tenant_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS access_group_members (
+    tenant_id TEXT NOT NULL,
+    group_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, group_id, user_id),
+    FOREIGN KEY (tenant_id, group_id) REFERENCES access_groups(tenant_id, group_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS access_group_members_user_idx
+    ON access_group_members(tenant_id, user_id);
+
+-- ACL entries grant read/write/admin on a schema category (inherited down the tree) or an entity table.
+-- Each entry targets exactly one principal (user or group) and exactly one resource.
+CREATE TABLE IF NOT EXISTS access_control_entries (
+    entry_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    user_id UUID REFERENCES users(user_id) ON DELETE CASCADE,
+    group_id UUID,
+    category_id UUID,
+    table_name TEXT CHECK (table_name ~ '^[a-z][a-z0-9_]*$'),
+    permission TEXT NOT NULL CHECK (permission IN ('read', 'write', 'admin')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (num_nonnulls(user_id, group_id) = 1),
+    CHECK (num_nonnulls(category_id, table_name) = 1),
+    FOREIGN KEY (tenant_id, group_id) REFERENCES access_groups(tenant_id, group_id) ON DELETE CASCADE,
+    FOREIGN KEY (tenant_id, category_id) REFERENCES schema_categories(tenant_id, category_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS access_control_entries_grant_idx
+    ON access_control_entries(tenant_id, COALESCE(user_id, group_id), COALESCE(category_id::TEXT, table_name));
+
+CREATE INDEX IF NOT EXISTS access_control_entries_category_idx
+    ON access_control_entries(tenant_id, category_id)
+    WHERE category_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS access_control_entries_table_idx
+    ON access_control_entries(tenant_id, table_name)
+    WHERE table_name IS NOT NULL;
+
+ALTER TABLE access_groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE access_groups FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS access_groups_tenant_isolation ON access_groups;
+CREATE POLICY access_groups_tenant_isolation ON access_groups
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE access_group_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE access_group_members FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS access_group_members_tenant_isolation ON access_group_members;
+CREATE POLICY access_group_members_tenant_isolation ON access_group_members
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE access_control_entries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE access_control_entries FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS access_control_entries_tenant_isolation ON access_control_entries;
+CREATE POLICY access_control_entries_tenant_isolation ON access_control_entries
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Service accounts authenticate machine clients (seeders, integrations) with API keys instead of user tokens.
+-- table_scope restricts the account to the listed entity tables; NULL allows every table its roles allow.
+CREATE TABLE IF NOT EXISTS service_accounts (
+    service_account_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 128),
+    description TEXT,
+    roles TEXT[] NOT NULL CHECK (cardinality(roles) > 0),
+    table_scope TEXT[],
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, service_account_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS service_accounts_name_idx
+    ON service_accounts(tenant_id, LOWER(name));
+
+-- API keys are looked up by their public prefix before the tenant is known, so the table is not covered by
+-- row-level security; management queries still filter on tenant_id. Only SHA-256 hashes of the secrets are stored.
+CREATE TABLE IF NOT EXISTS api_keys (
+    key_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    service_account_id UUID NOT NULL,
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 128),
+    key_prefix TEXT NOT NULL UNIQUE,
+    secret_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    FOREIGN KEY (tenant_id, service_account_id) REFERENCES service_accounts(tenant_id, service_account_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_service_account_idx
+    ON api_keys(tenant_id, service_account_id);
+
+ALTER TABLE service_accounts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE service_accounts FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS service_accounts_tenant_isolation ON service_accounts;
+CREATE POLICY service_accounts_tenant_isolation ON service_accounts
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Token buckets shared by API replicas (RATE_LIMIT_BACKEND=postgres). Keyed by caller identity, not
+-- tenant-scoped, and UNLOGGED because losing them on a crash only resets the limits.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket_key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    refilled_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_refilled_idx ON rate_limit_buckets(refilled_at);
+
+-- Idempotency-Key records: the fingerprint of the first request per (caller, key) and, once it finished,
+-- the response replayed to retries. In-progress rows hold a lock until locked_until so crashed requests
+-- do not block the key forever.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    scope TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL CHECK (length(idempotency_key) BETWEEN 1 AND 255),
+    fingerprint TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('in_progress', 'completed')),
+    response_status INTEGER,
+    response_headers JSONB,
+    response_body BYTEA,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, scope, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_idx ON idempotency_keys(tenant_id, expires_at);
+
+ALTER TABLE idempotency_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE idempotency_keys FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS idempotency_keys_tenant_isolation ON idempotency_keys;
+CREATE POLICY idempotency_keys_tenant_isolation ON idempotency_keys
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Slugs an entity used before it was renamed, so old public URLs keep resolving (with a redirect) to the
+-- entity's current slug. Entity tables are created on demand, so the history is kept here keyed by table.
+CREATE TABLE IF NOT EXISTS entity_slug_history (
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    table_name TEXT NOT NULL,
+    slug TEXT NOT NULL CHECK (slug ~ '^[a-z0-9]+(?:-[a-z0-9]+)*$'),
+    entity_id TEXT NOT NULL,
+    retired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, table_name, slug)
+);
+
+CREATE INDEX IF NOT EXISTS entity_slug_history_entity_idx ON entity_slug_history(tenant_id, table_name, entity_id);
+
+ALTER TABLE entity_slug_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_slug_history FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS entity_slug_history_tenant_isolation ON entity_slug_history;
+CREATE POLICY entity_slug_history_tenant_isolation ON entity_slug_history
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Uploads imported into entity tables through the API. entity_imports tracks each import's options,
+-- status and outcome counts; entity_import_results keeps the outcome of every input line so rejections
+-- can be downloaded afterwards. Imports run as entities.import jobs (job_id), reading their upload from
+-- entity_import_uploads, where it stays in chunks until the import finishes.
+CREATE TABLE IF NOT EXISTS entity_imports (
+    import_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    table_name TEXT NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('ndjson', 'csv')),
+    options JSONB NOT NULL DEFAULT '{}'::jsonb,
+    job_id UUID,
+    status TEXT NOT NULL CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    accepted_count BIGINT NOT NULL DEFAULT 0,
+    updated_count BIGINT NOT NULL DEFAULT 0,
+    unchanged_count BIGINT NOT NULL DEFAULT 0,
+    rejected_count BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS entity_imports_table_idx ON entity_imports(tenant_id, table_name, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS entity_import_results (
+    import_id UUID NOT NULL REFERENCES entity_imports(import_id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    line INTEGER NOT NULL,
+    entity_id TEXT,
+    outcome TEXT NOT NULL CHECK (outcome IN ('accepted', 'updated', 'unchanged', 'rejected')),
+    error TEXT,
+    PRIMARY KEY (import_id, line)
+);
+
+CREATE TABLE IF NOT EXISTS entity_import_uploads (
+    import_id UUID NOT NULL REFERENCES entity_imports(import_id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (import_id, seq)
+);
+
+ALTER TABLE entity_imports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_imports FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS entity_imports_tenant_isolation ON entity_imports;
+CREATE POLICY entity_imports_tenant_isolation ON entity_imports
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE entity_import_results ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_import_results FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS entity_import_results_tenant_isolation ON entity_import_results;
+CREATE POLICY entity_import_results_tenant_isolation ON entity_import_results
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE entity_import_uploads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE entity_import_uploads FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS entity_import_uploads_tenant_isolation ON entity_import_uploads;
+CREATE POLICY entity_import_uploads_tenant_isolation ON entity_import_uploads
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Background jobs (see platform/go/jobs). Workers claim queued jobs of every tenant with
+-- FOR UPDATE SKIP LOCKED, so the table is not covered by row-level security; API queries still filter on
+-- tenant_id. Running jobs hold their claim by heartbeating; jobs whose heartbeat stops are requeued.
+-- created_by is the principal key of the caller who enqueued the job (user:<id>, sa:<id>).
+CREATE TABLE IF NOT EXISTS jobs (
+    job_id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL CHECK (tenant_id ~ '^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$'),
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL CHECK (status IN ('queued', 'running', 'succeeded', 'failed', 'cancelled')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 1 CHECK (max_attempts > 0),
+    run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    worker_id TEXT,
+    heartbeat_at TIMESTAMPTZ,
+    progress REAL NOT NULL DEFAULT 0 CHECK (progress BETWEEN 0 AND 100),
+    progress_message TEXT,
+    result JSONB,
+    error TEXT,
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS jobs_queued_idx ON jobs(run_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS jobs_running_idx ON jobs(heartbeat_at) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS jobs_tenant_idx ON jobs(tenant_id, created_at DESC);
+
+-- Migrations applied to this database (see platform/go/migrate). A database provisioned from this snapshot
+-- records every file in database/migrations as a baseline entry, since the snapshot already contains them.
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    checksum TEXT NOT NULL,
+    baseline BOOLEAN NOT NULL DEFAULT FALSE,
+    execution_ms BIGINT NOT NULL DEFAULT 0,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -1,16 +1,18 @@
 -- Development seed data for Palmyra Pro.
 -- These inserts are idempotent to keep `docker compose up` repeatable.
 
-INSERT INTO schema_categories (category_id, name, slug, description, created_at, updated_at)
+-- Everything below belongs to the "default" tenant used by DEFAULT_TENANT_ID in docker compose.
+INSERT INTO schema_categories (category_id, tenant_id, name, slug, description, created_at, updated_at)
 VALUES
-    ('aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaa1', 'Digital Collectibles', 'digital-collectibles', 'Schemas for NFT-style items', NOW(), NOW()),
-    ('bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb2', 'Supply Chain', 'supply-chain', 'Schemas for logistics docs', NOW(), NOW())
+    ('aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaa1', 'default', 'Digital Collectibles', 'digital-collectibles', 'Schemas for NFT-style items', NOW(), NOW()),
+    ('bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbb2', 'default', 'Supply Chain', 'supply-chain', 'Schemas for logistics docs', NOW(), NOW())
 ON CONFLICT (category_id) DO NOTHING;
 
 -- Activate a base schema for collectibles.
 INSERT INTO schema_repository (
     schema_id,
     schema_version,
+    tenant_id,
     schema_definition,
     table_name,
     slug,
@@ -21,6 +23,7 @@ INSERT INTO schema_repository (
 ) VALUES (
     'cccccccc-cccc-cccc-cccc-ccccccccccc3',
     '1.0.0',
+    'default',
     '{
       "$schema": "https://json-schema.org/draft/2020-12/schema",
       "type": "object",
@@ -48,8 +51,8 @@ SET schema_definition = EXCLUDED.schema_definition,
     is_soft_deleted = FALSE;
 
 -- Sample admin users for local testing.
-INSERT INTO users (user_id, email, full_name, created_at, updated_at)
+INSERT INTO users (user_id, email, full_name, roles, status, created_at, updated_at)
 VALUES
-    ('dddddddd-dddd-dddd-dddd-ddddddddddd4', 'admin@palmyra.dev', 'Palmyra Admin', NOW(), NOW()),
-    ('eeeeeeee-eeee-eeee-eeee-eeeeeeeeeee5', 'manager@palmyra.dev', 'Schema Manager', NOW(), NOW())
+    ('dddddddd-dddd-dddd-dddd-ddddddddddd4', 'admin@palmyra.dev', 'Palmyra Admin', ARRAY['admin'], 'approved', NOW(), NOW()),
+    ('eeeeeeee-eeee-eeee-eeee-eeeeeeeeeee5', 'manager@palmyra.dev', 'Schema Manager', ARRAY['user_manager'], 'approved', NOW(), NOW())
 ON CONFLICT (user_id) DO NOTHING;
@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/access-control/be/service"
+	accesscontrol "github.com/zenGate-Global/palmyra-pro-saas/generated/go/access-control"
+	externalRef2 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	externalRef3 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+)
+
+const (
+	problemTypeValidation = "https://palmyra.pro/problems/validation-error"
+	problemTypeForbidden  = "https://palmyra.pro/problems/forbidden"
+	problemTypeNotFound   = "https://palmyra.pro/problems/not-found"
+	problemTypeConflict   = "https://palmyra.pro/problems/conflict"
+	problemTypeInternal   = "https://palmyra.pro/problems/internal-error"
+	accessControlBasePath = "/api/v1/access-control"
+)
+
+type operation string
+
+const (
+	listEntriesOperation  operation = "listAccessControlEntries"
+	createEntryOperation  operation = "createAccessControlEntry"
+	deleteEntryOperation  operation = "deleteAccessControlEntry"
+	listGroupsOperation   operation = "listAccessGroups"
+	createGroupOperation  operation = "createAccessGroup"
+	deleteGroupOperation  operation = "deleteAccessGroup"
+	listMembersOperation  operation = "listAccessGroupMembers"
+	addMemberOperation    operation = "addAccessGroupMember"
+	removeMemberOperation operation = "removeAccessGroupMember"
+)
+
+// Handler wires the access control service to the generated HTTP contract.
+type Handler struct {
+	svc    service.Service
+	logger *zap.Logger
+}
+
+// New constructs a Handler instance.
+func New(svc service.Service, logger *zap.Logger) *Handler {
+	if svc == nil {
+		panic("access control service is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+
+	return &Handler{svc: svc, logger: logger}
+}
+
+func (h *Handler) ListAccessControlEntries(ctx context.Context, request accesscontrol.ListAccessControlEntriesRequestObject) (accesscontrol.ListAccessControlEntriesResponseObject, error) {
+	filter := service.EntryFilter{}
+	if request.Params.CategoryId != nil {
+		categoryID := uuidFromExternal(*request.Params.CategoryId)
+		filter.CategoryID = &categoryID
+	}
+	if request.Params.TableName != nil {
+		tableName := string(*request.Params.TableName)
+		filter.TableName = &tableName
+	}
+
+	entries, err := h.svc.ListEntries(ctx, filter)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, listEntriesOperation)
+		return accesscontrol.ListAccessControlEntriesdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	items := make([]accesscontrol.AccessControlEntry, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, toAPIEntry(entry))
+	}
+
+	return accesscontrol.ListAccessControlEntries200JSONResponse(accesscontrol.AccessControlEntryList{Items: items}), nil
+}
+
+func (h *Handler) CreateAccessControlEntry(ctx context.Context, request accesscontrol.CreateAccessControlEntryRequestObject) (accesscontrol.CreateAccessControlEntryResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return accesscontrol.CreateAccessControlEntrydefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: http.StatusBadRequest,
+		}, nil
+	}
+
+	input := service.CreateEntryInput{
+		PrincipalType: string(request.Body.PrincipalType),
+		PrincipalID:   uuidFromExternal(request.Body.PrincipalId),
+		Permission:    string(request.Body.Permission),
+	}
+	if request.Body.CategoryId != nil {
+		categoryID := uuidFromExternal(*request.Body.CategoryId)
+		input.CategoryID = &categoryID
+	}
+	if request.Body.TableName != nil {
+		tableName := string(*request.Body.TableName)
+		input.TableName = &tableName
+	}
+
+	entry, err := h.svc.CreateEntry(ctx, input)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, createEntryOperation)
+		return accesscontrol.CreateAccessControlEntrydefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	location := fmt.Sprintf("%s/entries/%s", accessControlBasePath, entry.ID)
+	return accesscontrol.CreateAccessControlEntry201JSONResponse{
+		Body:    toAPIEntry(entry),
+		Headers: accesscontrol.CreateAccessControlEntry201ResponseHeaders{Location: location},
+	}, nil
+}
+
+func (h *Handler) DeleteAccessControlEntry(ctx context.Context, request accesscontrol.DeleteAccessControlEntryRequestObject) (accesscontrol.DeleteAccessControlEntryResponseObject, error) {
+	if err := h.svc.DeleteEntry(ctx, uuidFromExternal(request.EntryId)); err != nil {
+		status, problem := h.problemForError(ctx, err, deleteEntryOperation)
+		return accesscontrol.DeleteAccessControlEntrydefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return accesscontrol.DeleteAccessControlEntry204Response{}, nil
+}
+
+func (h *Handler) ListAccessGroups(ctx context.Context, _ accesscontrol.ListAccessGroupsRequestObject) (accesscontrol.ListAccessGroupsResponseObject, error) {
+	groups, err := h.svc.ListGroups(ctx)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, listGroupsOperation)
+		return accesscontrol.ListAccessGroupsdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	items := make([]accesscontrol.AccessGroup, 0, len(groups))
+	for _, group := range groups {
+		items = append(items, toAPIGroup(group))
+	}
+
+	return accesscontrol.ListAccessGroups200JSONResponse(accesscontrol.AccessGroupList{Items: items}), nil
+}
+
+func (h *Handler) CreateAccessGroup(ctx context.Context, request accesscontrol.CreateAccessGroupRequestObject) (accesscontrol.CreateAccessGroupResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return accesscontrol.CreateAccessGroupdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: http.StatusBadRequest,
+		}, nil
+	}
+
+	group, err := h.svc.CreateGroup(ctx, service.CreateGroupInput{
+		Name:        request.Body.Name,
+		Description: request.Body.Description,
+	})
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, createGroupOperation)
+		return accesscontrol.CreateAccessGroupdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	location := fmt.Sprintf("%s/groups/%s", accessControlBasePath, group.ID)
+	return accesscontrol.CreateAccessGroup201JSONResponse{
+		Body:    toAPIGroup(group),
+		Headers: accesscontrol.CreateAccessGroup201ResponseHeaders{Location: location},
+	}, nil
+}
+
+func (h *Handler) DeleteAccessGroup(ctx context.Context, request accesscontrol.DeleteAccessGroupRequestObject) (accesscontrol.DeleteAccessGroupResponseObject, error) {
+	if err := h.svc.DeleteGroup(ctx, uuidFromExternal(request.GroupId)); err != nil {
+		status, problem := h.problemForError(ctx, err, deleteGroupOperation)
+		return accesscontrol.DeleteAccessGroupdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return accesscontrol.DeleteAccessGroup204Response{}, nil
+}
+
+func (h *Handler) ListAccessGroupMembers(ctx context.Context, request accesscontrol.ListAccessGroupMembersRequestObject) (accesscontrol.ListAccessGroupMembersResponseObject, error) {
+	members, err := h.svc.ListMembers(ctx, uuidFromExternal(request.GroupId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, listMembersOperation)
+		return accesscontrol.ListAccessGroupMembersdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	items := make([]accesscontrol.AccessGroupMember, 0, len(members))
+	for _, member := range members {
+		items = append(items, accesscontrol.AccessGroupMember{
+			UserId:  externalRef2.UUID(member.UserID),
+			AddedAt: externalRef2.Timestamp(member.AddedAt),
+		})
+	}
+
+	return accesscontrol.ListAccessGroupMembers200JSONResponse(accesscontrol.AccessGroupMemberList{Items: items}), nil
+}
+
+func (h *Handler) AddAccessGroupMember(ctx context.Context, request accesscontrol.AddAccessGroupMemberRequestObject) (accesscontrol.AddAccessGroupMemberResponseObject, error) {
+	if err := h.svc.AddMember(ctx, uuidFromExternal(request.GroupId), uuidFromExternal(request.UserId)); err != nil {
+		status, problem := h.problemForError(ctx, err, addMemberOperation)
+		return accesscontrol.AddAccessGroupMemberdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return accesscontrol.AddAccessGroupMember204Response{}, nil
+}
+
+func (h *Handler) RemoveAccessGroupMember(ctx context.Context, request accesscontrol.RemoveAccessGroupMemberRequestObject) (accesscontrol.RemoveAccessGroupMemberResponseObject, error) {
+	if err := h.svc.RemoveMember(ctx, uuidFromExternal(request.GroupId), uuidFromExternal(request.UserId)); err != nil {
+		status, problem := h.problemForError(ctx, err, removeMemberOperation)
+		return accesscontrol.RemoveAccessGroupMemberdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return accesscontrol.RemoveAccessGroupMember204Response{}, nil
+}
+
+func toAPIEntry(entry service.Entry) accesscontrol.AccessControlEntry {
+	apiEntry := accesscontrol.AccessControlEntry{
+		EntryId:       externalRef2.UUID(entry.ID),
+		PrincipalType: accesscontrol.PrincipalType(entry.PrincipalType),
+		PrincipalId:   externalRef2.UUID(entry.PrincipalID),
+		Permission:    accesscontrol.AccessPermission(entry.Permission),
+		CreatedAt:     externalRef2.Timestamp(entry.CreatedAt),
+	}
+
+	if entry.CategoryID != nil {
+		categoryID := externalRef2.UUID(*entry.CategoryID)
+		apiEntry.CategoryId = &categoryID
+	}
+
+	if entry.TableName != nil {
+		tableName := externalRef2.TableName(*entry.TableName)
+		apiEntry.TableName = &tableName
+	}
+
+	return apiEntry
+}
+
+func toAPIGroup(group service.Group) accesscontrol.AccessGroup {
+	return accesscontrol.AccessGroup{
+		GroupId:     externalRef2.UUID(group.ID),
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedAt:   externalRef2.Timestamp(group.CreatedAt),
+	}
+}
+
+func uuidFromExternal(id externalRef2.UUID) uuid.UUID {
+	return uuid.UUID(id)
+}
+
+func (h *Handler) problemForError(ctx context.Context, err error, op operation) (int, externalRef3.ProblemDetails) {
+	status, title, detail, problemType, fieldErrors := h.classifyError(err)
+
+	logger := h.loggerFrom(ctx)
+	fields := []zap.Field{
+		zap.String("operation", string(op)),
+		zap.Int("status", status),
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		logger.Error("access control operation failed", append(fields, zap.Error(err))...)
+	case status == http.StatusNotFound:
+		logger.Info("access control resource not found", append(fields, zap.Error(err))...)
+	default:
+		logger.Warn("access control request rejected", append(fields, zap.Error(err))...)
+	}
+
+	return status, h.buildProblem(title, detail, problemType, status, fieldErrors)
+}
+
+func (h *Handler) classifyError(err error) (status int, title, detail, problemType string, fieldErrors service.FieldErrors) {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest,
+			"Validation failed",
+			"one or more fields are invalid",
+			problemTypeValidation,
+			validationErr.Fields
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden,
+			"Forbidden",
+			"an admin grant on the resource is required",
+			problemTypeForbidden,
+			nil
+	case errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound,
+			"Resource not found",
+			"access control resource not found",
+			problemTypeNotFound,
+			nil
+	case errors.Is(err, service.ErrConflict):
+		return http.StatusConflict,
+			"Conflict",
+			"access control resource already exists",
+			problemTypeConflict,
+			nil
+	default:
+		return http.StatusInternalServerError,
+			"Internal server error",
+			"an unexpected error occurred",
+			problemTypeInternal,
+			nil
+	}
+}
+
+func (h *Handler) buildProblem(title, detail, problemType string, status int, fieldErrors service.FieldErrors) externalRef3.ProblemDetails {
+	problem := externalRef3.ProblemDetails{
+		Title:  title,
+		Status: status,
+	}
+
+	if detail != "" {
+		problem.Detail = &detail
+	}
+	if problemType != "" {
+		problem.Type = &problemType
+	}
+
+	if len(fieldErrors) > 0 {
+		copied := make(map[string][]string, len(fieldErrors))
+		for field, messages := range fieldErrors {
+			copied[field] = append([]string(nil), messages...)
+		}
+		problem.Errors = &copied
+	}
+
+	return problem
+}
+
+func (h *Handler) loggerFrom(ctx context.Context) *zap.Logger {
+	if logger, ok := platformlogging.FromContext(ctx); ok {
+		return logger
+	}
+	return h.logger
+}
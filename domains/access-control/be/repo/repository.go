@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// Repository exposes persistence operations required by the access control service.
+type Repository interface {
+	ListEntries(ctx context.Context, params persistence.ListAccessControlEntriesParams) ([]persistence.AccessControlEntry, error)
+	GetEntry(ctx context.Context, id uuid.UUID) (persistence.AccessControlEntry, error)
+	CreateEntry(ctx context.Context, params persistence.CreateAccessControlEntryParams) (persistence.AccessControlEntry, error)
+	DeleteEntry(ctx context.Context, id uuid.UUID) error
+	ListGroups(ctx context.Context) ([]persistence.AccessGroup, error)
+	GetGroup(ctx context.Context, id uuid.UUID) (persistence.AccessGroup, error)
+	CreateGroup(ctx context.Context, params persistence.CreateAccessGroupParams) (persistence.AccessGroup, error)
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+	ListMembers(ctx context.Context, groupID uuid.UUID) ([]persistence.AccessGroupMember, error)
+	AddMember(ctx context.Context, groupID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+	ResolveTableAccess(ctx context.Context, subject persistence.AccessSubject, tableName string) (persistence.AccessDecision, error)
+	ResolveCategoryAccess(ctx context.Context, subject persistence.AccessSubject, categoryID uuid.UUID) (persistence.AccessDecision, error)
+	TableExists(ctx context.Context, tableName string) (bool, error)
+}
+
+type postgresRepository struct {
+	store       *persistence.AccessControlStore
+	schemaStore *persistence.SchemaRepositoryStore
+}
+
+// NewPostgresRepository builds a Repository backed by the shared persistence layer.
+func NewPostgresRepository(store *persistence.AccessControlStore, schemaStore *persistence.SchemaRepositoryStore) Repository {
+	if store == nil {
+		panic("access control store is required")
+	}
+	if schemaStore == nil {
+		panic("schema repository store is required")
+	}
+	return &postgresRepository{store: store, schemaStore: schemaStore}
+}
+
+func (r *postgresRepository) ListEntries(ctx context.Context, params persistence.ListAccessControlEntriesParams) ([]persistence.AccessControlEntry, error) {
+	return r.store.ListAccessControlEntries(ctx, params)
+}
+
+func (r *postgresRepository) GetEntry(ctx context.Context, id uuid.UUID) (persistence.AccessControlEntry, error) {
+	return r.store.GetAccessControlEntry(ctx, id)
+}
+
+func (r *postgresRepository) CreateEntry(ctx context.Context, params persistence.CreateAccessControlEntryParams) (persistence.AccessControlEntry, error) {
+	return r.store.CreateAccessControlEntry(ctx, params)
+}
+
+func (r *postgresRepository) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteAccessControlEntry(ctx, id)
+}
+
+func (r *postgresRepository) ListGroups(ctx context.Context) ([]persistence.AccessGroup, error) {
+	return r.store.ListAccessGroups(ctx)
+}
+
+func (r *postgresRepository) GetGroup(ctx context.Context, id uuid.UUID) (persistence.AccessGroup, error) {
+	return r.store.GetAccessGroup(ctx, id)
+}
+
+func (r *postgresRepository) CreateGroup(ctx context.Context, params persistence.CreateAccessGroupParams) (persistence.AccessGroup, error) {
+	return r.store.CreateAccessGroup(ctx, params)
+}
+
+func (r *postgresRepository) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteAccessGroup(ctx, id)
+}
+
+func (r *postgresRepository) ListMembers(ctx context.Context, groupID uuid.UUID) ([]persistence.AccessGroupMember, error) {
+	return r.store.ListAccessGroupMembers(ctx, groupID)
+}
+
+func (r *postgresRepository) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	return r.store.AddAccessGroupMember(ctx, groupID, userID)
+}
+
+func (r *postgresRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	return r.store.RemoveAccessGroupMember(ctx, groupID, userID)
+}
+
+func (r *postgresRepository) ResolveTableAccess(ctx context.Context, subject persistence.AccessSubject, tableName string) (persistence.AccessDecision, error) {
+	decisions, err := r.store.ResolveTableAccess(ctx, subject, []string{tableName})
+	if err != nil {
+		return persistence.AccessDecision{}, err
+	}
+	return decisions[tableName], nil
+}
+
+func (r *postgresRepository) ResolveCategoryAccess(ctx context.Context, subject persistence.AccessSubject, categoryID uuid.UUID) (persistence.AccessDecision, error) {
+	return r.store.ResolveCategoryAccess(ctx, subject, categoryID)
+}
+
+func (r *postgresRepository) TableExists(ctx context.Context, tableName string) (bool, error) {
+	if _, err := r.schemaStore.GetActiveSchemaByTableName(ctx, tableName); err != nil {
+		if errors.Is(err, persistence.ErrSchemaNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,464 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/access-control/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+// FieldErrors maps request fields to validation issues.
+type FieldErrors map[string][]string
+
+// ValidationError captures input validation problems surfaced by the service.
+type ValidationError struct {
+	Fields FieldErrors
+}
+
+func (v *ValidationError) Error() string {
+	return "validation error"
+}
+
+// Domain-level error sentinel values.
+var (
+	ErrNotFound  = errors.New("access control resource not found")
+	ErrConflict  = errors.New("access control conflict")
+	ErrForbidden = errors.New("access control forbidden")
+)
+
+// Entry represents an ACL entry managed by the domain service.
+type Entry struct {
+	ID            uuid.UUID
+	PrincipalType persistence.AccessPrincipalType
+	PrincipalID   uuid.UUID
+	CategoryID    *uuid.UUID
+	TableName     *string
+	Permission    persistence.AccessPermission
+	CreatedAt     time.Time
+}
+
+// EntryFilter narrows the entries returned by ListEntries to a single resource.
+type EntryFilter struct {
+	CategoryID *uuid.UUID
+	TableName  *string
+}
+
+// CreateEntryInput defines the payload required to grant a permission.
+type CreateEntryInput struct {
+	PrincipalType string
+	PrincipalID   uuid.UUID
+	CategoryID    *uuid.UUID
+	TableName     *string
+	Permission    string
+}
+
+// Group represents an access group.
+type Group struct {
+	ID          uuid.UUID
+	Name        string
+	Description *string
+	CreatedAt   time.Time
+}
+
+// CreateGroupInput defines the payload required to create an access group.
+type CreateGroupInput struct {
+	Name        string
+	Description *string
+}
+
+// Member represents a user belonging to an access group.
+type Member struct {
+	UserID  uuid.UUID
+	AddedAt time.Time
+}
+
+// Service exposes the access control domain operations.
+type Service interface {
+	ListEntries(ctx context.Context, filter EntryFilter) ([]Entry, error)
+	CreateEntry(ctx context.Context, input CreateEntryInput) (Entry, error)
+	DeleteEntry(ctx context.Context, id uuid.UUID) error
+	ListGroups(ctx context.Context) ([]Group, error)
+	CreateGroup(ctx context.Context, input CreateGroupInput) (Group, error)
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+	ListMembers(ctx context.Context, groupID uuid.UUID) ([]Member, error)
+	AddMember(ctx context.Context, groupID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+}
+
+type service struct {
+	repo domainrepo.Repository
+}
+
+// New builds an access control Service backed by the provided repository.
+func New(repo domainrepo.Repository) Service {
+	if repo == nil {
+		panic("access control repository is required")
+	}
+	return &service{repo: repo}
+}
+
+func (s *service) ListEntries(ctx context.Context, filter EntryFilter) ([]Entry, error) {
+	ctx, span := tracing.Start(ctx, "access-control.ListEntries")
+	defer span.End()
+
+	if filter.CategoryID != nil && filter.TableName != nil {
+		return nil, &ValidationError{Fields: FieldErrors{"categoryId": []string{"filter by either categoryId or tableName, not both"}}}
+	}
+
+	params := persistence.ListAccessControlEntriesParams{CategoryID: filter.CategoryID}
+	if filter.TableName != nil {
+		tableName := normalizeTableName(*filter.TableName)
+		params.TableName = &tableName
+	}
+
+	if filter.CategoryID == nil && filter.TableName == nil {
+		// Listing every entry reveals the whole ACL layout, so it is reserved to administrators.
+		if _, isAdmin := caller(ctx); !isAdmin {
+			return nil, ErrForbidden
+		}
+	} else if err := s.authorizeResource(ctx, params.CategoryID, params.TableName); err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.ListEntries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, mapEntry(record))
+	}
+	return entries, nil
+}
+
+func (s *service) CreateEntry(ctx context.Context, input CreateEntryInput) (Entry, error) {
+	ctx, span := tracing.Start(ctx, "access-control.CreateEntry")
+	defer span.End()
+
+	params, err := s.validateCreateEntryInput(ctx, input)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if err = s.authorizeResource(ctx, params.CategoryID, params.TableName); err != nil {
+		return Entry{}, err
+	}
+
+	record, err := s.repo.CreateEntry(ctx, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, persistence.ErrAccessControlConflict):
+			return Entry{}, ErrConflict
+		case errors.Is(err, persistence.ErrAccessReferenceNotFound):
+			return Entry{}, &ValidationError{Fields: FieldErrors{"principalId": []string{"principal or category does not exist"}}}
+		default:
+			return Entry{}, err
+		}
+	}
+
+	return mapEntry(record), nil
+}
+
+func (s *service) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "access-control.DeleteEntry")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return ErrNotFound
+	}
+
+	record, err := s.repo.GetEntry(ctx, id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrAccessControlEntryNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	if err = s.authorizeResource(ctx, record.CategoryID, record.TableName); err != nil {
+		return err
+	}
+
+	if err = s.repo.DeleteEntry(ctx, id); err != nil {
+		if errors.Is(err, persistence.ErrAccessControlEntryNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) ListGroups(ctx context.Context) ([]Group, error) {
+	ctx, span := tracing.Start(ctx, "access-control.ListGroups")
+	defer span.End()
+
+	records, err := s.repo.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]Group, 0, len(records))
+	for _, record := range records {
+		groups = append(groups, mapGroup(record))
+	}
+	return groups, nil
+}
+
+func (s *service) CreateGroup(ctx context.Context, input CreateGroupInput) (Group, error) {
+	ctx, span := tracing.Start(ctx, "access-control.CreateGroup")
+	defer span.End()
+
+	if err := requireAdmin(ctx); err != nil {
+		return Group{}, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return Group{}, &ValidationError{Fields: FieldErrors{"name": []string{"name is required"}}}
+	}
+
+	record, err := s.repo.CreateGroup(ctx, persistence.CreateAccessGroupParams{
+		GroupID:     uuid.New(),
+		Name:        name,
+		Description: input.Description,
+	})
+	if err != nil {
+		if errors.Is(err, persistence.ErrAccessControlConflict) {
+			return Group{}, ErrConflict
+		}
+		return Group{}, err
+	}
+
+	return mapGroup(record), nil
+}
+
+func (s *service) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "access-control.DeleteGroup")
+	defer span.End()
+
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if id == uuid.Nil {
+		return ErrNotFound
+	}
+
+	if err := s.repo.DeleteGroup(ctx, id); err != nil {
+		if errors.Is(err, persistence.ErrAccessGroupNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) ListMembers(ctx context.Context, groupID uuid.UUID) ([]Member, error) {
+	ctx, span := tracing.Start(ctx, "access-control.ListMembers")
+	defer span.End()
+
+	if _, err := s.repo.GetGroup(ctx, groupID); err != nil {
+		if errors.Is(err, persistence.ErrAccessGroupNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	records, err := s.repo.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]Member, 0, len(records))
+	for _, record := range records {
+		members = append(members, Member{UserID: record.UserID, AddedAt: record.CreatedAt})
+	}
+	return members, nil
+}
+
+func (s *service) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "access-control.AddMember")
+	defer span.End()
+
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if groupID == uuid.Nil || userID == uuid.Nil {
+		return ErrNotFound
+	}
+
+	if err := s.repo.AddMember(ctx, groupID, userID); err != nil {
+		if errors.Is(err, persistence.ErrAccessReferenceNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "access-control.RemoveMember")
+	defer span.End()
+
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if groupID == uuid.Nil || userID == uuid.Nil {
+		return ErrNotFound
+	}
+
+	if err := s.repo.RemoveMember(ctx, groupID, userID); err != nil {
+		if errors.Is(err, persistence.ErrAccessReferenceNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) validateCreateEntryInput(ctx context.Context, input CreateEntryInput) (persistence.CreateAccessControlEntryParams, error) {
+	errs := FieldErrors{}
+	params := persistence.CreateAccessControlEntryParams{
+		EntryID:     uuid.New(),
+		PrincipalID: input.PrincipalID,
+		CategoryID:  input.CategoryID,
+	}
+
+	switch principalType := persistence.AccessPrincipalType(strings.TrimSpace(input.PrincipalType)); principalType {
+	case persistence.AccessPrincipalUser, persistence.AccessPrincipalGroup:
+		params.PrincipalType = principalType
+	default:
+		errs.add("principalType", fmt.Sprintf("unsupported principal type %q", input.PrincipalType))
+	}
+
+	if input.PrincipalID == uuid.Nil {
+		errs.add("principalId", "principalId is required")
+	}
+
+	permission, ok := persistence.ParseAccessPermission(input.Permission)
+	if !ok {
+		errs.add("permission", fmt.Sprintf("unsupported permission %q", input.Permission))
+	}
+	params.Permission = permission
+
+	switch {
+	case input.CategoryID == nil && input.TableName == nil:
+		errs.add("categoryId", "either categoryId or tableName is required")
+	case input.CategoryID != nil && input.TableName != nil:
+		errs.add("categoryId", "provide either categoryId or tableName, not both")
+	case input.CategoryID != nil && *input.CategoryID == uuid.Nil:
+		errs.add("categoryId", "categoryId must be a valid UUID")
+	case input.TableName != nil:
+		tableName := normalizeTableName(*input.TableName)
+		exists, err := s.repo.TableExists(ctx, tableName)
+		if err != nil {
+			return persistence.CreateAccessControlEntryParams{}, err
+		}
+		if !exists {
+			errs.add("tableName", "table not found")
+		}
+		params.TableName = &tableName
+	}
+
+	if len(errs) > 0 {
+		return persistence.CreateAccessControlEntryParams{}, &ValidationError{Fields: errs}
+	}
+
+	return params, nil
+}
+
+// authorizeResource lets administrators through and otherwise requires an explicit admin grant on the
+// category or table (directly, through a group or inherited from a parent category).
+func (s *service) authorizeResource(ctx context.Context, categoryID *uuid.UUID, tableName *string) error {
+	subject, isAdmin := caller(ctx)
+	if isAdmin {
+		return nil
+	}
+
+	var (
+		decision persistence.AccessDecision
+		err      error
+	)
+	switch {
+	case categoryID != nil:
+		decision, err = s.repo.ResolveCategoryAccess(ctx, subject, *categoryID)
+	case tableName != nil:
+		decision, err = s.repo.ResolveTableAccess(ctx, subject, *tableName)
+	default:
+		return ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+
+	if !decision.Grants(persistence.AccessPermissionAdmin) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// requireAdmin reserves group changes to administrators: members gain every grant of the group, so
+// whoever edits groups could otherwise reach tables the ACLs hide from them.
+func requireAdmin(ctx context.Context) error {
+	if _, isAdmin := caller(ctx); !isAdmin {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// caller returns the ACL subject for the authenticated caller and whether they hold the admin role.
+func caller(ctx context.Context) (persistence.AccessSubject, bool) {
+	creds, ok := platformauth.UserFromContext(ctx)
+	if !ok || creds == nil {
+		return persistence.AccessSubject{}, false
+	}
+	return persistence.AccessSubject{UserID: creds.UserID}, creds.HasRole(platformauth.RoleAdmin)
+}
+
+func normalizeTableName(tableName string) string {
+	return strings.ToLower(strings.TrimSpace(tableName))
+}
+
+func mapEntry(record persistence.AccessControlEntry) Entry {
+	return Entry{
+		ID:            record.EntryID,
+		PrincipalType: record.PrincipalType,
+		PrincipalID:   record.PrincipalID,
+		CategoryID:    record.CategoryID,
+		TableName:     record.TableName,
+		Permission:    record.Permission,
+		CreatedAt:     record.CreatedAt,
+	}
+}
+
+func mapGroup(record persistence.AccessGroup) Group {
+	return Group{
+		ID:          record.GroupID,
+		Name:        record.Name,
+		Description: record.Description,
+		CreatedAt:   record.CreatedAt,
+	}
+}
+
+func (f FieldErrors) add(field, message string) {
+	if _, ok := f[field]; !ok {
+		f[field] = []string{message}
+		return
+	}
+	f[field] = append(f[field], message)
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/access-control/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+func TestServiceCreateEntryValidation(t *testing.T) {
+	svc := New(&stubRepository{})
+
+	tableName := "cards_entities"
+	categoryID := uuid.New()
+	_, err := svc.CreateEntry(adminContext(), CreateEntryInput{
+		PrincipalType: "robot",
+		CategoryID:    &categoryID,
+		TableName:     &tableName,
+		Permission:    "owner",
+	})
+	require.Error(t, err)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	require.Contains(t, valErr.Fields, "principalType")
+	require.Contains(t, valErr.Fields, "principalId")
+	require.Contains(t, valErr.Fields, "permission")
+	require.Contains(t, valErr.Fields, "categoryId")
+}
+
+func TestServiceCreateEntryUnknownTable(t *testing.T) {
+	repo := &stubRepository{
+		tableExistsFn: func(_ context.Context, tableName string) (bool, error) {
+			require.Equal(t, "pricing_entities", tableName)
+			return false, nil
+		},
+	}
+	svc := New(repo)
+
+	tableName := " Pricing_Entities "
+	_, err := svc.CreateEntry(adminContext(), CreateEntryInput{
+		PrincipalType: "user",
+		PrincipalID:   uuid.New(),
+		TableName:     &tableName,
+		Permission:    "read",
+	})
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	require.Contains(t, valErr.Fields, "tableName")
+}
+
+func TestServiceCreateEntryRequiresAdminGrant(t *testing.T) {
+	categoryID := uuid.New()
+	decision := persistence.AccessDecision{Restricted: true, Permission: persistence.AccessPermissionWrite}
+	repo := &stubRepository{
+		categoryAccessFn: func(_ context.Context, subject persistence.AccessSubject, id uuid.UUID) (persistence.AccessDecision, error) {
+			require.Equal(t, "user-1", subject.UserID)
+			require.Equal(t, categoryID, id)
+			return decision, nil
+		},
+		createEntryFn: func(_ context.Context, params persistence.CreateAccessControlEntryParams) (persistence.AccessControlEntry, error) {
+			return persistence.AccessControlEntry{
+				EntryID:       params.EntryID,
+				PrincipalType: params.PrincipalType,
+				PrincipalID:   params.PrincipalID,
+				CategoryID:    params.CategoryID,
+				Permission:    params.Permission,
+			}, nil
+		},
+	}
+	svc := New(repo)
+
+	ctx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:     "firebase-uid",
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+	input := CreateEntryInput{
+		PrincipalType: "group",
+		PrincipalID:   uuid.New(),
+		CategoryID:    &categoryID,
+		Permission:    "read",
+	}
+
+	_, err := svc.CreateEntry(ctx, input)
+	require.ErrorIs(t, err, ErrForbidden)
+
+	decision.Permission = persistence.AccessPermissionAdmin
+	entry, err := svc.CreateEntry(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, persistence.AccessPrincipalGroup, entry.PrincipalType)
+	require.Equal(t, persistence.AccessPermissionRead, entry.Permission)
+}
+
+func TestServiceCreateEntryMapsConflict(t *testing.T) {
+	repo := &stubRepository{
+		createEntryFn: func(context.Context, persistence.CreateAccessControlEntryParams) (persistence.AccessControlEntry, error) {
+			return persistence.AccessControlEntry{}, persistence.ErrAccessControlConflict
+		},
+	}
+	svc := New(repo)
+
+	categoryID := uuid.New()
+	_, err := svc.CreateEntry(adminContext(), CreateEntryInput{
+		PrincipalType: "user",
+		PrincipalID:   uuid.New(),
+		CategoryID:    &categoryID,
+		Permission:    "write",
+	})
+	require.ErrorIs(t, err, ErrConflict)
+}
+
+func TestServiceListEntriesWithoutFilterIsAdminOnly(t *testing.T) {
+	svc := New(&stubRepository{})
+
+	ctx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:     "firebase-uid",
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUserManager},
+	})
+	_, err := svc.ListEntries(ctx, EntryFilter{})
+	require.ErrorIs(t, err, ErrForbidden)
+
+	entries, err := svc.ListEntries(adminContext(), EntryFilter{})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestServiceGroupChangesAreAdminOnly(t *testing.T) {
+	svc := New(&stubRepository{})
+
+	ctx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:     "firebase-uid",
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUserManager},
+	})
+	groupID, userID := uuid.New(), uuid.New()
+
+	_, err := svc.CreateGroup(ctx, CreateGroupInput{Name: "editors"})
+	require.ErrorIs(t, err, ErrForbidden)
+	require.ErrorIs(t, svc.DeleteGroup(ctx, groupID), ErrForbidden)
+	require.ErrorIs(t, svc.AddMember(ctx, groupID, userID), ErrForbidden, "a user manager cannot join a group to inherit its grants")
+	require.ErrorIs(t, svc.RemoveMember(ctx, groupID, userID), ErrForbidden)
+
+	require.NoError(t, svc.AddMember(adminContext(), groupID, userID))
+}
+
+func TestServiceDeleteGroupNotFound(t *testing.T) {
+	repo := &stubRepository{
+		deleteGroupFn: func(context.Context, uuid.UUID) error {
+			return persistence.ErrAccessGroupNotFound
+		},
+	}
+	svc := New(repo)
+
+	err := svc.DeleteGroup(adminContext(), uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func adminContext() context.Context {
+	return platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:    "admin-1",
+		Roles: []platformauth.Role{platformauth.RoleAdmin},
+	})
+}
+
+type stubRepository struct {
+	createEntryFn    func(context.Context, persistence.CreateAccessControlEntryParams) (persistence.AccessControlEntry, error)
+	deleteGroupFn    func(context.Context, uuid.UUID) error
+	categoryAccessFn func(context.Context, persistence.AccessSubject, uuid.UUID) (persistence.AccessDecision, error)
+	tableExistsFn    func(context.Context, string) (bool, error)
+}
+
+func (s *stubRepository) ListEntries(context.Context, persistence.ListAccessControlEntriesParams) ([]persistence.AccessControlEntry, error) {
+	return nil, nil
+}
+
+func (s *stubRepository) GetEntry(context.Context, uuid.UUID) (persistence.AccessControlEntry, error) {
+	return persistence.AccessControlEntry{}, persistence.ErrAccessControlEntryNotFound
+}
+
+func (s *stubRepository) CreateEntry(ctx context.Context, params persistence.CreateAccessControlEntryParams) (persistence.AccessControlEntry, error) {
+	if s.createEntryFn != nil {
+		return s.createEntryFn(ctx, params)
+	}
+	return persistence.AccessControlEntry{}, nil
+}
+
+func (s *stubRepository) DeleteEntry(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (s *stubRepository) ListGroups(context.Context) ([]persistence.AccessGroup, error) {
+	return nil, nil
+}
+
+func (s *stubRepository) GetGroup(context.Context, uuid.UUID) (persistence.AccessGroup, error) {
+	return persistence.AccessGroup{}, persistence.ErrAccessGroupNotFound
+}
+
+func (s *stubRepository) CreateGroup(context.Context, persistence.CreateAccessGroupParams) (persistence.AccessGroup, error) {
+	return persistence.AccessGroup{}, nil
+}
+
+func (s *stubRepository) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	if s.deleteGroupFn != nil {
+		return s.deleteGroupFn(ctx, id)
+	}
+	return nil
+}
+
+func (s *stubRepository) ListMembers(context.Context, uuid.UUID) ([]persistence.AccessGroupMember, error) {
+	return nil, nil
+}
+
+func (s *stubRepository) AddMember(context.Context, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+
+func (s *stubRepository) RemoveMember(context.Context, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+
+func (s *stubRepository) ResolveTableAccess(context.Context, persistence.AccessSubject, string) (persistence.AccessDecision, error) {
+	return persistence.AccessDecision{}, nil
+}
+
+func (s *stubRepository) ResolveCategoryAccess(ctx context.Context, subject persistence.AccessSubject, categoryID uuid.UUID) (persistence.AccessDecision, error) {
+	if s.categoryAccessFn != nil {
+		return s.categoryAccessFn(ctx, subject, categoryID)
+	}
+	return persistence.AccessDecision{}, nil
+}
+
+func (s *stubRepository) TableExists(ctx context.Context, tableName string) (bool, error) {
+	if s.tableExistsFn != nil {
+		return s.tableExistsFn(ctx, tableName)
+	}
+	return true, nil
+}
+
+var _ domainrepo.Repository = (*stubRepository)(nil)
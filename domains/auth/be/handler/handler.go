@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/auth/be/service"
+	auth "github.com/zenGate-Global/palmyra-pro-saas/generated/go/auth"
+	externalRef0 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/iam"
+	externalRef1 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	externalRef2 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
+	users "github.com/zenGate-Global/palmyra-pro-saas/generated/go/users"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+)
+
+const (
+	problemTypeValidation   = "https://palmyra.pro/problems/validation-error"
+	problemTypeUnauthorized = "https://palmyra.pro/problems/unauthorized"
+	problemTypeForbidden    = "https://palmyra.pro/problems/forbidden"
+	problemTypeConflict     = "https://palmyra.pro/problems/conflict"
+	problemTypeInternal     = "https://palmyra.pro/problems/internal-error"
+)
+
+type operation string
+
+const (
+	signupOperation  operation = "authSignup"
+	loginOperation   operation = "authLogin"
+	refreshOperation operation = "authRefresh"
+	logoutOperation  operation = "authLogout"
+)
+
+// Handler wires the auth service to the generated HTTP contract.
+type Handler struct {
+	svc    service.Service
+	logger *zap.Logger
+}
+
+// New constructs a Handler instance.
+func New(svc service.Service, logger *zap.Logger) *Handler {
+	if svc == nil {
+		panic("auth service is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+
+	return &Handler{svc: svc, logger: logger}
+}
+
+func (h *Handler) AuthSignup(ctx context.Context, request auth.AuthSignupRequestObject) (auth.AuthSignupResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return auth.AuthSignupdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: http.StatusBadRequest}, nil
+	}
+
+	user, err := h.svc.Signup(ctx, service.SignupInput{
+		Email:    string(request.Body.Email),
+		Password: request.Body.Password,
+		FullName: request.Body.FullName,
+	})
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, signupOperation)
+		return auth.AuthSignupdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return auth.AuthSignup201JSONResponse{User: toAPIUser(user)}, nil
+}
+
+func (h *Handler) AuthLogin(ctx context.Context, request auth.AuthLoginRequestObject) (auth.AuthLoginResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return auth.AuthLogindefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: http.StatusBadRequest}, nil
+	}
+
+	session, err := h.svc.Login(ctx, service.LoginInput{
+		Email:    string(request.Body.Email),
+		Password: request.Body.Password,
+	})
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, loginOperation)
+		return auth.AuthLogindefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return auth.AuthLogin200JSONResponse(toAccessTokenResponse(session)), nil
+}
+
+func (h *Handler) AuthRefresh(ctx context.Context, request auth.AuthRefreshRequestObject) (auth.AuthRefreshResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return auth.AuthRefreshdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: http.StatusBadRequest}, nil
+	}
+
+	session, err := h.svc.Refresh(ctx, request.Body.RefreshToken)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, refreshOperation)
+		return auth.AuthRefreshdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return auth.AuthRefresh200JSONResponse(toAccessTokenResponse(session)), nil
+}
+
+func (h *Handler) AuthLogout(ctx context.Context, _ auth.AuthLogoutRequestObject) (auth.AuthLogoutResponseObject, error) {
+	credentials, ok := platformauth.UserFromContext(ctx)
+	if !ok || credentials == nil {
+		problem := h.buildProblem("Unauthorized", "missing credentials", problemTypeUnauthorized, http.StatusUnauthorized, nil)
+		return auth.AuthLogoutdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: http.StatusUnauthorized}, nil
+	}
+
+	if err := h.svc.Logout(ctx, service.LogoutInput{UserID: credentials.Id, SessionID: credentials.SessionID}); err != nil {
+		status, problem := h.problemForError(ctx, err, logoutOperation)
+		return auth.AuthLogoutdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return auth.AuthLogout204Response{}, nil
+}
+
+func toAccessTokenResponse(session service.Session) auth.AccessTokenResponse {
+	refreshToken := session.RefreshToken
+	expiresIn := int(time.Until(session.ExpiresAt).Round(time.Second).Seconds())
+	user := toAPIUsersUser(session.User)
+
+	return auth.AccessTokenResponse{
+		AccessToken:  session.AccessToken,
+		RefreshToken: &refreshToken,
+		ExpiresIn:    &expiresIn,
+		User:         &user,
+	}
+}
+
+func toAPIUser(user service.User) auth.User {
+	status := users.UserStatus(user.Status)
+	return auth.User{
+		Id:        externalRef1.UUID(user.ID),
+		Email:     externalRef1.Email(user.Email),
+		FullName:  user.FullName,
+		Roles:     toAPIRoles(user.Roles),
+		Status:    &status,
+		CreatedAt: externalRef1.Timestamp(user.CreatedAt),
+		UpdatedAt: externalRef1.Timestamp(user.UpdatedAt),
+	}
+}
+
+func toAPIUsersUser(user service.User) users.User {
+	return users.User{
+		Id:           externalRef1.UUID(user.ID),
+		Email:        externalRef1.Email(user.Email),
+		FullName:     user.FullName,
+		Roles:        toAPIRoles(user.Roles),
+		Status:       users.UserStatus(user.Status),
+		StatusReason: user.StatusReason,
+		CreatedAt:    externalRef1.Timestamp(user.CreatedAt),
+		UpdatedAt:    externalRef1.Timestamp(user.UpdatedAt),
+	}
+}
+
+func toAPIRoles(roles []string) []externalRef0.UserRole {
+	result := make([]externalRef0.UserRole, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, externalRef0.UserRole(role))
+	}
+	return result
+}
+
+func (h *Handler) problemForError(ctx context.Context, err error, op operation) (int, externalRef2.ProblemDetails) {
+	status, title, detail, problemType, fields := h.classifyError(err)
+
+	logger := h.loggerFrom(ctx)
+	fieldsForLog := []zap.Field{
+		zap.String("operation", string(op)),
+		zap.Int("status", status),
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		logger.Error("auth operation failed", append(fieldsForLog, zap.Error(err))...)
+	default:
+		logger.Warn("auth request rejected", append(fieldsForLog, zap.Error(err))...)
+	}
+
+	return status, h.buildProblem(title, detail, problemType, status, fields)
+}
+
+func (h *Handler) classifyError(err error) (status int, title, detail, problemType string, fieldErrors service.FieldErrors) {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest,
+			"Validation failed",
+			"one or more fields are invalid",
+			problemTypeValidation,
+			validationErr.Fields
+	case errors.Is(err, service.ErrInvalidCredentials):
+		return http.StatusUnauthorized,
+			"Unauthorized",
+			"invalid email or password",
+			problemTypeUnauthorized,
+			nil
+	case errors.Is(err, service.ErrInvalidRefreshToken):
+		// The cause (unknown, reused, revoked) is logged but not disclosed to the caller.
+		return http.StatusUnauthorized,
+			"Unauthorized",
+			"invalid refresh token",
+			problemTypeUnauthorized,
+			nil
+	case errors.Is(err, service.ErrAccountInactive):
+		return http.StatusForbidden,
+			"Forbidden",
+			"account is not active",
+			problemTypeForbidden,
+			nil
+	case errors.Is(err, service.ErrConflict):
+		return http.StatusConflict,
+			"Conflict",
+			"a user with this email already exists",
+			problemTypeConflict,
+			nil
+	default:
+		return http.StatusInternalServerError,
+			"Internal server error",
+			"an unexpected error occurred",
+			problemTypeInternal,
+			nil
+	}
+}
+
+func (h *Handler) buildProblem(title, detail, problemType string, status int, fieldErrors service.FieldErrors) externalRef2.ProblemDetails {
+	problem := externalRef2.ProblemDetails{
+		Title:  title,
+		Status: status,
+	}
+
+	if detail != "" {
+		problem.Detail = &detail
+	}
+	if problemType != "" {
+		problem.Type = &problemType
+	}
+
+	if len(fieldErrors) > 0 {
+		copied := make(map[string][]string, len(fieldErrors))
+		for field, messages := range fieldErrors {
+			copied[field] = append([]string(nil), messages...)
+		}
+		problem.Errors = &copied
+	}
+
+	return problem
+}
+
+func (h *Handler) loggerFrom(ctx context.Context) *zap.Logger {
+	if logger, ok := platformlogging.FromContext(ctx); ok {
+		return logger
+	}
+	return h.logger
+}
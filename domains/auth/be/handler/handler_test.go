@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/auth/be/service"
+	auth "github.com/zenGate-Global/palmyra-pro-saas/generated/go/auth"
+	externalRef1 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+)
+
+type mockService struct {
+	signupFn  func(ctx context.Context, input service.SignupInput) (service.User, error)
+	loginFn   func(ctx context.Context, input service.LoginInput) (service.Session, error)
+	refreshFn func(ctx context.Context, refreshToken string) (service.Session, error)
+	logoutFn  func(ctx context.Context, input service.LogoutInput) error
+}
+
+func (m *mockService) Signup(ctx context.Context, input service.SignupInput) (service.User, error) {
+	if m.signupFn == nil {
+		panic("signupFn not configured")
+	}
+	return m.signupFn(ctx, input)
+}
+
+func (m *mockService) Login(ctx context.Context, input service.LoginInput) (service.Session, error) {
+	if m.loginFn == nil {
+		panic("loginFn not configured")
+	}
+	return m.loginFn(ctx, input)
+}
+
+func (m *mockService) Refresh(ctx context.Context, refreshToken string) (service.Session, error) {
+	if m.refreshFn == nil {
+		panic("refreshFn not configured")
+	}
+	return m.refreshFn(ctx, refreshToken)
+}
+
+func (m *mockService) Logout(ctx context.Context, input service.LogoutInput) error {
+	if m.logoutFn == nil {
+		panic("logoutFn not configured")
+	}
+	return m.logoutFn(ctx, input)
+}
+
+func TestAuthSignupSuccess(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	svc := &mockService{
+		signupFn: func(ctx context.Context, input service.SignupInput) (service.User, error) {
+			require.Equal(t, "new@example.com", input.Email)
+			return service.User{ID: userID, Email: input.Email, FullName: input.FullName, Roles: []string{"user"}, Status: "pending"}, nil
+		},
+	}
+
+	h := New(svc, zaptest.NewLogger(t))
+
+	resp, err := h.AuthSignup(context.Background(), auth.AuthSignupRequestObject{Body: &auth.SignupRequest{
+		Email:    externalRef1.Email("new@example.com"),
+		Password: "s3cret-pass",
+		FullName: "New",
+	}})
+	require.NoError(t, err)
+
+	success, ok := resp.(auth.AuthSignup201JSONResponse)
+	require.True(t, ok)
+	require.Equal(t, externalRef1.UUID(userID), success.User.Id)
+	require.NotNil(t, success.User.Status)
+	require.EqualValues(t, "pending", *success.User.Status)
+}
+
+func TestAuthSignupConflict(t *testing.T) {
+	t.Parallel()
+
+	svc := &mockService{
+		signupFn: func(ctx context.Context, input service.SignupInput) (service.User, error) {
+			return service.User{}, service.ErrConflict
+		},
+	}
+
+	h := New(svc, zaptest.NewLogger(t))
+
+	resp, err := h.AuthSignup(context.Background(), auth.AuthSignupRequestObject{Body: &auth.SignupRequest{Email: "taken@example.com", Password: "s3cret-pass", FullName: "Taken"}})
+	require.NoError(t, err)
+
+	problem, ok := resp.(auth.AuthSignupdefaultApplicationProblemPlusJSONResponse)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, problem.StatusCode)
+}
+
+func TestAuthLoginSuccess(t *testing.T) {
+	t.Parallel()
+
+	svc := &mockService{
+		loginFn: func(ctx context.Context, input service.LoginInput) (service.Session, error) {
+			return service.Session{
+				AccessToken:  "access",
+				RefreshToken: "refresh",
+				ExpiresAt:    time.Now().Add(15 * time.Minute),
+				User:         service.User{ID: uuid.New(), Email: input.Email, Status: "approved"},
+			}, nil
+		},
+	}
+
+	h := New(svc, zaptest.NewLogger(t))
+
+	resp, err := h.AuthLogin(context.Background(), auth.AuthLoginRequestObject{Body: &auth.LoginRequest{Email: "user@example.com", Password: "s3cret-pass"}})
+	require.NoError(t, err)
+
+	success, ok := resp.(auth.AuthLogin200JSONResponse)
+	require.True(t, ok)
+	require.Equal(t, "access", success.AccessToken)
+	require.Equal(t, "refresh", *success.RefreshToken)
+	require.InDelta(t, 900, *success.ExpiresIn, 2)
+	require.Equal(t, externalRef1.Email("user@example.com"), success.User.Email)
+}
+
+func TestAuthLoginErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[error]int{
+		service.ErrInvalidCredentials: http.StatusUnauthorized,
+		service.ErrAccountInactive:    http.StatusForbidden,
+		&service.ValidationError{Fields: service.FieldErrors{"email": {"email is required"}}}: http.StatusBadRequest,
+	}
+
+	for cause, want := range cases {
+		svc := &mockService{
+			loginFn: func(ctx context.Context, input service.LoginInput) (service.Session, error) {
+				return service.Session{}, cause
+			},
+		}
+
+		h := New(svc, zaptest.NewLogger(t))
+
+		resp, err := h.AuthLogin(context.Background(), auth.AuthLoginRequestObject{Body: &auth.LoginRequest{Email: "user@example.com", Password: "x"}})
+		require.NoError(t, err)
+
+		problem, ok := resp.(auth.AuthLogindefaultApplicationProblemPlusJSONResponse)
+		require.True(t, ok)
+		require.Equal(t, want, problem.StatusCode)
+	}
+}
+
+func TestAuthRefreshInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	svc := &mockService{
+		refreshFn: func(ctx context.Context, refreshToken string) (service.Session, error) {
+			return service.Session{}, service.ErrInvalidRefreshToken
+		},
+	}
+
+	h := New(svc, zaptest.NewLogger(t))
+
+	resp, err := h.AuthRefresh(context.Background(), auth.AuthRefreshRequestObject{Body: &auth.RefreshRequest{RefreshToken: "reused"}})
+	require.NoError(t, err)
+
+	problem, ok := resp.(auth.AuthRefreshdefaultApplicationProblemPlusJSONResponse)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, problem.StatusCode)
+}
+
+func TestAuthLogout(t *testing.T) {
+	t.Parallel()
+
+	var captured service.LogoutInput
+	svc := &mockService{
+		logoutFn: func(ctx context.Context, input service.LogoutInput) error {
+			captured = input
+			return nil
+		},
+	}
+
+	h := New(svc, zaptest.NewLogger(t))
+
+	resp, err := h.AuthLogout(context.Background(), auth.AuthLogoutRequestObject{})
+	require.NoError(t, err)
+	problem, ok := resp.(auth.AuthLogoutdefaultApplicationProblemPlusJSONResponse)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, problem.StatusCode)
+
+	ctx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{Id: "user-1", SessionID: "session-1"})
+	resp, err = h.AuthLogout(ctx, auth.AuthLogoutRequestObject{})
+	require.NoError(t, err)
+	require.IsType(t, auth.AuthLogout204Response{}, resp)
+	require.Equal(t, service.LogoutInput{UserID: "user-1", SessionID: "session-1"}, captured)
+}
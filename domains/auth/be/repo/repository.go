@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// Repository defines the persistence operations required by the auth service.
+type Repository interface {
+	CreateUser(ctx context.Context, params persistence.CreateUserParams, passwordHash string) (persistence.User, error)
+	GetCredential(ctx context.Context, email string) (persistence.User, string, error)
+	GetUser(ctx context.Context, id uuid.UUID) (persistence.User, error)
+	CreateSession(ctx context.Context, params persistence.CreateAuthSessionParams) (persistence.AuthSession, error)
+	RotateRefreshToken(ctx context.Context, tokenHash, newTokenHash string) (persistence.AuthSession, error)
+	RevokeSession(ctx context.Context, sessionID, userID uuid.UUID) error
+}
+
+type postgresRepository struct {
+	store     *persistence.AuthStore
+	userStore *persistence.UserStore
+}
+
+// NewPostgresRepository constructs a repository backed by the shared persistence layer.
+func NewPostgresRepository(store *persistence.AuthStore, userStore *persistence.UserStore) Repository {
+	if store == nil {
+		panic("auth store is required")
+	}
+	if userStore == nil {
+		panic("user store is required")
+	}
+	return &postgresRepository{store: store, userStore: userStore}
+}
+
+func (r *postgresRepository) CreateUser(ctx context.Context, params persistence.CreateUserParams, passwordHash string) (persistence.User, error) {
+	return r.store.CreateUserWithPassword(ctx, params, passwordHash)
+}
+
+func (r *postgresRepository) GetCredential(ctx context.Context, email string) (persistence.User, string, error) {
+	return r.store.GetPasswordCredential(ctx, email)
+}
+
+func (r *postgresRepository) GetUser(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+	return r.userStore.GetUser(ctx, id)
+}
+
+func (r *postgresRepository) CreateSession(ctx context.Context, params persistence.CreateAuthSessionParams) (persistence.AuthSession, error) {
+	return r.store.CreateSession(ctx, params)
+}
+
+func (r *postgresRepository) RotateRefreshToken(ctx context.Context, tokenHash, newTokenHash string) (persistence.AuthSession, error) {
+	return r.store.RotateRefreshToken(ctx, tokenHash, newTokenHash)
+}
+
+func (r *postgresRepository) RevokeSession(ctx context.Context, sessionID, userID uuid.UUID) error {
+	return r.store.RevokeSession(ctx, sessionID, userID)
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/auth/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+// FieldErrors maps request fields to validation issues.
+type FieldErrors map[string][]string
+
+// ValidationError is returned when the input payload is invalid.
+type ValidationError struct {
+	Fields FieldErrors
+}
+
+func (v *ValidationError) Error() string {
+	return "validation error"
+}
+
+// Domain sentinel errors.
+var (
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrConflict            = errors.New("user conflict")
+	ErrAccountInactive     = errors.New("account is not active")
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 128
+
+	// refreshTokenBytes is the entropy of opaque refresh tokens (256 bits).
+	refreshTokenBytes = 32
+
+	// DefaultRefreshTTL bounds the lifetime of a login session when Config.RefreshTTL is unset.
+	DefaultRefreshTTL = 30 * 24 * time.Hour
+)
+
+// Statuses that may no longer sign in (contracts/users.yaml#/components/schemas/UserStatus). Pending users
+// can sign in so they can see their own record while awaiting approval; the API gate still blocks them.
+var blockedStatuses = map[string]struct{}{
+	"rejected": {},
+	"disabled": {},
+}
+
+// TokenIssuer signs access tokens; *platformauth.LocalTokenSigner satisfies it.
+type TokenIssuer interface {
+	IssueAccessToken(subject platformauth.LocalAccessClaims) (string, time.Time, error)
+}
+
+// Config tunes the auth service.
+type Config struct {
+	// RefreshTTL is the absolute lifetime of a login session; rotating refresh tokens does not extend it.
+	RefreshTTL time.Duration
+	// PasswordParams overrides the Argon2id cost; zero value means platformauth.DefaultPasswordParams.
+	PasswordParams platformauth.PasswordParams
+}
+
+// User represents the domain view of an authenticated user.
+type User struct {
+	ID           uuid.UUID
+	Email        string
+	FullName     string
+	Roles        []string
+	Status       string
+	StatusReason *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Session carries the tokens issued by Login and Refresh.
+type Session struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	User         User
+}
+
+// SignupInput represents the payload required to register with a password.
+type SignupInput struct {
+	Email    string
+	Password string
+	FullName string
+}
+
+// LoginInput represents email/password credentials.
+type LoginInput struct {
+	Email    string
+	Password string
+}
+
+// LogoutInput identifies the session behind the current access token.
+type LogoutInput struct {
+	UserID    string
+	SessionID string
+}
+
+// Service defines the business operations for the auth domain.
+type Service interface {
+	Signup(ctx context.Context, input SignupInput) (User, error)
+	Login(ctx context.Context, input LoginInput) (Session, error)
+	Refresh(ctx context.Context, refreshToken string) (Session, error)
+	Logout(ctx context.Context, input LogoutInput) error
+}
+
+type service struct {
+	repo   repo.Repository
+	tokens TokenIssuer
+	cfg    Config
+	now    func() time.Time
+
+	dummyHashOnce sync.Once
+	dummyHash     string
+}
+
+// New constructs an auth Service instance backed by the provided repository and token issuer.
+func New(r repo.Repository, tokens TokenIssuer, cfg Config) Service {
+	if r == nil {
+		panic("auth repository is required")
+	}
+	if tokens == nil {
+		panic("token issuer is required")
+	}
+	if cfg.RefreshTTL <= 0 {
+		cfg.RefreshTTL = DefaultRefreshTTL
+	}
+	if cfg.PasswordParams == (platformauth.PasswordParams{}) {
+		cfg.PasswordParams = platformauth.DefaultPasswordParams
+	}
+	return &service{repo: r, tokens: tokens, cfg: cfg, now: time.Now}
+}
+
+func (s *service) Signup(ctx context.Context, input SignupInput) (User, error) {
+	ctx, span := tracing.Start(ctx, "auth.Signup")
+	defer span.End()
+
+	fieldErrors := FieldErrors{}
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if email == "" {
+		fieldErrors.add("email", "email is required")
+	} else if !strings.Contains(email, "@") {
+		fieldErrors.add("email", "email must contain '@'")
+	}
+
+	fullName := strings.TrimSpace(input.FullName)
+	if fullName == "" {
+		fieldErrors.add("fullName", "fullName is required")
+	}
+
+	switch length := utf8.RuneCountInString(input.Password); {
+	case length < minPasswordLength:
+		fieldErrors.add("password", fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+	case length > maxPasswordLength:
+		fieldErrors.add("password", fmt.Sprintf("password must be at most %d characters", maxPasswordLength))
+	}
+
+	if len(fieldErrors) > 0 {
+		return User{}, &ValidationError{Fields: fieldErrors}
+	}
+
+	hash, err := platformauth.HashPassword(input.Password, s.cfg.PasswordParams)
+	if err != nil {
+		return User{}, err
+	}
+
+	// Self-registered accounts wait in the approval queue (DefaultUserStatus).
+	record, err := s.repo.CreateUser(ctx, persistence.CreateUserParams{
+		UserID:   uuid.New(),
+		Email:    email,
+		FullName: fullName,
+		Status:   persistence.DefaultUserStatus,
+	}, hash)
+	if err != nil {
+		if errors.Is(err, persistence.ErrUserConflict) {
+			return User{}, ErrConflict
+		}
+		return User{}, err
+	}
+
+	return mapUser(record), nil
+}
+
+func (s *service) Login(ctx context.Context, input LoginInput) (Session, error) {
+	ctx, span := tracing.Start(ctx, "auth.Login")
+	defer span.End()
+
+	fieldErrors := FieldErrors{}
+	email := strings.TrimSpace(input.Email)
+	if email == "" {
+		fieldErrors.add("email", "email is required")
+	}
+	if input.Password == "" {
+		fieldErrors.add("password", "password is required")
+	}
+	if len(fieldErrors) > 0 {
+		return Session{}, &ValidationError{Fields: fieldErrors}
+	}
+
+	record, hash, err := s.repo.GetCredential(ctx, email)
+	if err != nil {
+		if errors.Is(err, persistence.ErrAuthCredentialNotFound) {
+			// Hash anyway so unknown emails take as long as wrong passwords.
+			_, _ = platformauth.VerifyPassword(input.Password, s.fallbackHash())
+			return Session{}, ErrInvalidCredentials
+		}
+		return Session{}, err
+	}
+
+	ok, err := platformauth.VerifyPassword(input.Password, hash)
+	if err != nil {
+		return Session{}, fmt.Errorf("verify password: %w", err)
+	}
+	if !ok {
+		return Session{}, ErrInvalidCredentials
+	}
+
+	if !canSignIn(record.Status) {
+		return Session{}, ErrAccountInactive
+	}
+
+	refreshToken, refreshHash, err := newRefreshToken()
+	if err != nil {
+		return Session{}, err
+	}
+
+	session, err := s.repo.CreateSession(ctx, persistence.CreateAuthSessionParams{
+		SessionID:        uuid.New(),
+		UserID:           record.UserID,
+		ExpiresAt:        s.now().Add(s.cfg.RefreshTTL),
+		RefreshTokenHash: refreshHash,
+	})
+	if err != nil {
+		return Session{}, err
+	}
+
+	return s.issue(record, session.SessionID, refreshToken)
+}
+
+func (s *service) Refresh(ctx context.Context, refreshToken string) (Session, error) {
+	ctx, span := tracing.Start(ctx, "auth.Refresh")
+	defer span.End()
+
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return Session{}, newValidationError(map[string]string{"refreshToken": "refreshToken is required"})
+	}
+
+	nextToken, nextHash, err := newRefreshToken()
+	if err != nil {
+		return Session{}, err
+	}
+
+	session, err := s.repo.RotateRefreshToken(ctx, hashRefreshToken(refreshToken), nextHash)
+	if err != nil {
+		switch {
+		case errors.Is(err, persistence.ErrRefreshTokenNotFound),
+			errors.Is(err, persistence.ErrRefreshTokenReused),
+			errors.Is(err, persistence.ErrAuthSessionInactive):
+			return Session{}, fmt.Errorf("%w: %v", ErrInvalidRefreshToken, err)
+		default:
+			return Session{}, err
+		}
+	}
+
+	// Reload the user so role and status changes apply from the next access token on.
+	record, err := s.repo.GetUser(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, persistence.ErrUserNotFound) {
+			return Session{}, ErrInvalidRefreshToken
+		}
+		return Session{}, err
+	}
+
+	if !canSignIn(record.Status) {
+		if revokeErr := s.repo.RevokeSession(ctx, session.SessionID, session.UserID); revokeErr != nil && !errors.Is(revokeErr, persistence.ErrAuthSessionInactive) {
+			return Session{}, revokeErr
+		}
+		return Session{}, ErrAccountInactive
+	}
+
+	return s.issue(record, session.SessionID, nextToken)
+}
+
+// Logout revokes the session behind the caller's access token. Tokens without a session (issued by an
+// external provider) and sessions that are already revoked are accepted as a no-op.
+func (s *service) Logout(ctx context.Context, input LogoutInput) error {
+	ctx, span := tracing.Start(ctx, "auth.Logout")
+	defer span.End()
+
+	if strings.TrimSpace(input.SessionID) == "" {
+		return nil
+	}
+
+	sessionID, err := uuid.Parse(input.SessionID)
+	if err != nil {
+		return newValidationError(map[string]string{"sid": "session id must be a UUID"})
+	}
+	userID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return newValidationError(map[string]string{"uid": "user id must be a UUID"})
+	}
+
+	if err := s.repo.RevokeSession(ctx, sessionID, userID); err != nil && !errors.Is(err, persistence.ErrAuthSessionInactive) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) issue(record persistence.User, sessionID uuid.UUID, refreshToken string) (Session, error) {
+	// Local accounts have no email verification flow, so EmailVerified stays false and
+	// auto-approval by domain never applies to them.
+	accessToken, expiresAt, err := s.tokens.IssueAccessToken(platformauth.LocalAccessClaims{
+		UserID:    record.UserID.String(),
+		Email:     record.Email,
+		Name:      record.FullName,
+		Roles:     record.Roles,
+		SessionID: sessionID.String(),
+	})
+	if err != nil {
+		return Session{}, err
+	}
+
+	return Session{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+		User:         mapUser(record),
+	}, nil
+}
+
+func (s *service) fallbackHash() string {
+	s.dummyHashOnce.Do(func() {
+		s.dummyHash, _ = platformauth.HashPassword(uuid.NewString(), s.cfg.PasswordParams)
+	})
+	return s.dummyHash
+}
+
+func canSignIn(status string) bool {
+	_, blocked := blockedStatuses[status]
+	return !blocked
+}
+
+// newRefreshToken returns an opaque refresh token and the hash stored in its place.
+func newRefreshToken() (string, string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashRefreshToken(token), nil
+}
+
+// hashRefreshToken derives the lookup key of a refresh token. Tokens carry 256 bits of entropy, so a
+// plain SHA-256 is sufficient and keeps lookups indexable.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func mapUser(record persistence.User) User {
+	return User{
+		ID:           record.UserID,
+		Email:        record.Email,
+		FullName:     record.FullName,
+		Roles:        record.Roles,
+		Status:       record.Status,
+		StatusReason: record.StatusReason,
+		CreatedAt:    record.CreatedAt,
+		UpdatedAt:    record.UpdatedAt,
+	}
+}
+
+func newValidationError(fields map[string]string) error {
+	fe := FieldErrors{}
+	for key, message := range fields {
+		fe.add(key, message)
+	}
+	return &ValidationError{Fields: fe}
+}
+
+func (f FieldErrors) add(field, message string) {
+	if f == nil {
+		return
+	}
+	f[field] = append(f[field], message)
+}
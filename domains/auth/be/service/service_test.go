@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+var testConfig = Config{
+	RefreshTTL:     time.Hour,
+	PasswordParams: platformauth.PasswordParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+}
+
+type mockRepository struct {
+	createUserFn    func(ctx context.Context, params persistence.CreateUserParams, passwordHash string) (persistence.User, error)
+	getCredentialFn func(ctx context.Context, email string) (persistence.User, string, error)
+	getUserFn       func(ctx context.Context, id uuid.UUID) (persistence.User, error)
+	createSessionFn func(ctx context.Context, params persistence.CreateAuthSessionParams) (persistence.AuthSession, error)
+	rotateFn        func(ctx context.Context, tokenHash, newTokenHash string) (persistence.AuthSession, error)
+	revokeFn        func(ctx context.Context, sessionID, userID uuid.UUID) error
+}
+
+func (m *mockRepository) CreateUser(ctx context.Context, params persistence.CreateUserParams, passwordHash string) (persistence.User, error) {
+	if m.createUserFn == nil {
+		panic("createUserFn not configured")
+	}
+	return m.createUserFn(ctx, params, passwordHash)
+}
+
+func (m *mockRepository) GetCredential(ctx context.Context, email string) (persistence.User, string, error) {
+	if m.getCredentialFn == nil {
+		panic("getCredentialFn not configured")
+	}
+	return m.getCredentialFn(ctx, email)
+}
+
+func (m *mockRepository) GetUser(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+	if m.getUserFn == nil {
+		panic("getUserFn not configured")
+	}
+	return m.getUserFn(ctx, id)
+}
+
+func (m *mockRepository) CreateSession(ctx context.Context, params persistence.CreateAuthSessionParams) (persistence.AuthSession, error) {
+	if m.createSessionFn == nil {
+		panic("createSessionFn not configured")
+	}
+	return m.createSessionFn(ctx, params)
+}
+
+func (m *mockRepository) RotateRefreshToken(ctx context.Context, tokenHash, newTokenHash string) (persistence.AuthSession, error) {
+	if m.rotateFn == nil {
+		panic("rotateFn not configured")
+	}
+	return m.rotateFn(ctx, tokenHash, newTokenHash)
+}
+
+func (m *mockRepository) RevokeSession(ctx context.Context, sessionID, userID uuid.UUID) error {
+	if m.revokeFn == nil {
+		panic("revokeFn not configured")
+	}
+	return m.revokeFn(ctx, sessionID, userID)
+}
+
+type stubIssuer struct {
+	issued []platformauth.LocalAccessClaims
+}
+
+func (s *stubIssuer) IssueAccessToken(subject platformauth.LocalAccessClaims) (string, time.Time, error) {
+	s.issued = append(s.issued, subject)
+	return "access-" + subject.SessionID, time.Now().Add(time.Minute), nil
+}
+
+func hashFor(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := platformauth.HashPassword(password, testConfig.PasswordParams)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestSignupCreatesPendingUser(t *testing.T) {
+	t.Parallel()
+
+	var captured persistence.CreateUserParams
+	var capturedHash string
+	repo := &mockRepository{
+		createUserFn: func(ctx context.Context, params persistence.CreateUserParams, passwordHash string) (persistence.User, error) {
+			captured = params
+			capturedHash = passwordHash
+			return persistence.User{UserID: params.UserID, Email: params.Email, FullName: params.FullName, Roles: []string{"user"}, Status: params.Status}, nil
+		},
+	}
+
+	svc := New(repo, &stubIssuer{}, testConfig)
+
+	user, err := svc.Signup(context.Background(), SignupInput{Email: " New@Example.com ", Password: "s3cret-pass", FullName: " New User "})
+	require.NoError(t, err)
+	require.Equal(t, "new@example.com", captured.Email)
+	require.Equal(t, "New User", captured.FullName)
+	require.Equal(t, persistence.DefaultUserStatus, captured.Status)
+	require.Equal(t, "pending", user.Status)
+
+	ok, err := platformauth.VerifyPassword("s3cret-pass", capturedHash)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestSignupValidation(t *testing.T) {
+	t.Parallel()
+
+	svc := New(&mockRepository{}, &stubIssuer{}, testConfig)
+
+	_, err := svc.Signup(context.Background(), SignupInput{Email: "invalid", Password: "short"})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Contains(t, validationErr.Fields, "email")
+	require.Contains(t, validationErr.Fields, "password")
+	require.Contains(t, validationErr.Fields, "fullName")
+}
+
+func TestSignupConflict(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepository{
+		createUserFn: func(ctx context.Context, params persistence.CreateUserParams, passwordHash string) (persistence.User, error) {
+			return persistence.User{}, persistence.ErrUserConflict
+		},
+	}
+
+	svc := New(repo, &stubIssuer{}, testConfig)
+
+	_, err := svc.Signup(context.Background(), SignupInput{Email: "taken@example.com", Password: "s3cret-pass", FullName: "Taken"})
+	require.ErrorIs(t, err, ErrConflict)
+}
+
+func TestLoginIssuesSession(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	hash := hashFor(t, "s3cret-pass")
+
+	var session persistence.CreateAuthSessionParams
+	repo := &mockRepository{
+		getCredentialFn: func(ctx context.Context, email string) (persistence.User, string, error) {
+			return persistence.User{UserID: userID, Email: email, FullName: "User", Roles: []string{"user"}, Status: "pending"}, hash, nil
+		},
+		createSessionFn: func(ctx context.Context, params persistence.CreateAuthSessionParams) (persistence.AuthSession, error) {
+			session = params
+			return persistence.AuthSession{SessionID: params.SessionID, UserID: params.UserID, ExpiresAt: params.ExpiresAt}, nil
+		},
+	}
+	issuer := &stubIssuer{}
+
+	svc := New(repo, issuer, testConfig)
+
+	result, err := svc.Login(context.Background(), LoginInput{Email: "user@example.com", Password: "s3cret-pass"})
+	require.NoError(t, err)
+	require.Equal(t, "access-"+session.SessionID.String(), result.AccessToken)
+	require.NotEmpty(t, result.RefreshToken)
+	require.Equal(t, hashRefreshToken(result.RefreshToken), session.RefreshTokenHash)
+	require.NotEqual(t, result.RefreshToken, session.RefreshTokenHash, "refresh tokens must not be stored in clear")
+	require.WithinDuration(t, time.Now().Add(time.Hour), session.ExpiresAt, 5*time.Second)
+
+	require.Len(t, issuer.issued, 1)
+	require.Equal(t, userID.String(), issuer.issued[0].UserID)
+	require.Equal(t, []string{"user"}, issuer.issued[0].Roles)
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	t.Parallel()
+
+	hash := hashFor(t, "s3cret-pass")
+	repo := &mockRepository{
+		getCredentialFn: func(ctx context.Context, email string) (persistence.User, string, error) {
+			if email == "unknown@example.com" {
+				return persistence.User{}, "", persistence.ErrAuthCredentialNotFound
+			}
+			return persistence.User{UserID: uuid.New(), Status: "approved"}, hash, nil
+		},
+	}
+
+	svc := New(repo, &stubIssuer{}, testConfig)
+
+	_, err := svc.Login(context.Background(), LoginInput{Email: "user@example.com", Password: "wrong-pass"})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = svc.Login(context.Background(), LoginInput{Email: "unknown@example.com", Password: "s3cret-pass"})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestLoginRejectsInactiveAccounts(t *testing.T) {
+	t.Parallel()
+
+	hash := hashFor(t, "s3cret-pass")
+	repo := &mockRepository{
+		getCredentialFn: func(ctx context.Context, email string) (persistence.User, string, error) {
+			return persistence.User{UserID: uuid.New(), Status: "disabled"}, hash, nil
+		},
+	}
+
+	svc := New(repo, &stubIssuer{}, testConfig)
+
+	_, err := svc.Login(context.Background(), LoginInput{Email: "user@example.com", Password: "s3cret-pass"})
+	require.ErrorIs(t, err, ErrAccountInactive)
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	var presented, stored string
+	repo := &mockRepository{
+		rotateFn: func(ctx context.Context, tokenHash, newTokenHash string) (persistence.AuthSession, error) {
+			presented, stored = tokenHash, newTokenHash
+			return persistence.AuthSession{SessionID: sessionID, UserID: userID}, nil
+		},
+		getUserFn: func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+			require.Equal(t, userID, id)
+			return persistence.User{UserID: id, Roles: []string{"admin"}, Status: "approved"}, nil
+		},
+	}
+	issuer := &stubIssuer{}
+
+	svc := New(repo, issuer, testConfig)
+
+	result, err := svc.Refresh(context.Background(), "old-token")
+	require.NoError(t, err)
+	require.Equal(t, hashRefreshToken("old-token"), presented)
+	require.Equal(t, hashRefreshToken(result.RefreshToken), stored)
+	require.NotEqual(t, "old-token", result.RefreshToken)
+	require.Equal(t, sessionID.String(), issuer.issued[0].SessionID)
+	require.Equal(t, []string{"admin"}, issuer.issued[0].Roles)
+}
+
+func TestRefreshInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	for _, cause := range []error{persistence.ErrRefreshTokenNotFound, persistence.ErrRefreshTokenReused, persistence.ErrAuthSessionInactive} {
+		repo := &mockRepository{
+			rotateFn: func(ctx context.Context, tokenHash, newTokenHash string) (persistence.AuthSession, error) {
+				return persistence.AuthSession{}, cause
+			},
+		}
+
+		svc := New(repo, &stubIssuer{}, testConfig)
+
+		_, err := svc.Refresh(context.Background(), "token")
+		require.ErrorIs(t, err, ErrInvalidRefreshToken)
+	}
+
+	_, err := New(&mockRepository{}, &stubIssuer{}, testConfig).Refresh(context.Background(), " ")
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func TestRefreshRevokesSessionOfDisabledUser(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	revoked := false
+	repo := &mockRepository{
+		rotateFn: func(ctx context.Context, tokenHash, newTokenHash string) (persistence.AuthSession, error) {
+			return persistence.AuthSession{SessionID: sessionID, UserID: userID}, nil
+		},
+		getUserFn: func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+			return persistence.User{UserID: id, Status: "rejected"}, nil
+		},
+		revokeFn: func(ctx context.Context, sid, uid uuid.UUID) error {
+			require.Equal(t, sessionID, sid)
+			require.Equal(t, userID, uid)
+			revoked = true
+			return nil
+		},
+	}
+
+	svc := New(repo, &stubIssuer{}, testConfig)
+
+	_, err := svc.Refresh(context.Background(), "token")
+	require.ErrorIs(t, err, ErrAccountInactive)
+	require.True(t, revoked)
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	calls := 0
+	repo := &mockRepository{
+		revokeFn: func(ctx context.Context, sid, uid uuid.UUID) error {
+			calls++
+			require.Equal(t, sessionID, sid)
+			require.Equal(t, userID, uid)
+			if calls > 1 {
+				return persistence.ErrAuthSessionInactive
+			}
+			return nil
+		},
+	}
+
+	svc := New(repo, &stubIssuer{}, testConfig)
+
+	input := LogoutInput{UserID: userID.String(), SessionID: sessionID.String()}
+	require.NoError(t, svc.Logout(context.Background(), input))
+	require.NoError(t, svc.Logout(context.Background(), input), "logout is idempotent")
+	require.NoError(t, svc.Logout(context.Background(), LogoutInput{UserID: userID.String()}), "tokens without a session")
+	require.Equal(t, 2, calls)
+
+	repo.revokeFn = func(ctx context.Context, sid, uid uuid.UUID) error {
+		return errors.New("boom")
+	}
+	require.Error(t, svc.Logout(context.Background(), input))
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/service"
+	entitiesapi "github.com/zenGate-Global/palmyra-pro-saas/generated/go/entities"
+)
+
+func (h *Handler) ExportDocuments(ctx context.Context, request entitiesapi.ExportDocumentsRequestObject) (entitiesapi.ExportDocumentsResponseObject, error) {
+	params := request.Params
+	opts := service.ExportOptions{}
+	if params.Format != nil {
+		opts.Format = service.ExportFormat(*params.Format)
+	}
+	if params.Fields != nil {
+		for _, field := range *params.Fields {
+			if field = strings.TrimSpace(field); field != "" {
+				opts.Fields = append(opts.Fields, field)
+			}
+		}
+	}
+	if params.Filter != nil {
+		opts.Filters = make(map[string]string, len(*params.Filter))
+		for _, filter := range *params.Filter {
+			path, value, ok := strings.Cut(filter, "=")
+			path = strings.TrimSpace(path)
+			if !ok || path == "" {
+				status, problem := h.validationProblem(fmt.Sprintf("filter %q must be path=value", filter))
+				return entitiesapi.ExportDocumentsdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+			}
+			if _, duplicate := opts.Filters[path]; duplicate {
+				status, problem := h.validationProblem(fmt.Sprintf("filter on %q given more than once", path))
+				return entitiesapi.ExportDocumentsdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+			}
+			opts.Filters[path] = value
+		}
+	}
+	if params.ChangedSince != nil {
+		changedSince := time.Time(*params.ChangedSince)
+		opts.ChangedSince = &changedSince
+	}
+
+	export, err := h.svc.Export(ctx, string(request.TableName), opts)
+	if err != nil {
+		status, problem := h.problemForError(err)
+		return entitiesapi.ExportDocumentsdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return exportResponse{
+		ctx:       ctx,
+		logger:    h.logger,
+		export:    export,
+		tableName: string(request.TableName),
+		gzip:      params.Compression != nil && *params.Compression == entitiesapi.Gzip,
+	}, nil
+}
+
+// exportResponse streams an export straight to the client. The generated 200 responses copy from an
+// io.Reader and report failures as a problem document, which cannot be done once the body has started;
+// a failed export is instead cut off so clients never mistake it for a complete one.
+type exportResponse struct {
+	ctx       context.Context
+	logger    *zap.Logger
+	export    *service.Export
+	tableName string
+	gzip      bool
+}
+
+func (response exportResponse) VisitExportDocumentsResponse(w http.ResponseWriter) error {
+	export := response.export
+	defer func() {
+		if err := export.Close(context.WithoutCancel(response.ctx)); err != nil {
+			response.logger.Warn("close entity export", zap.String("table", response.tableName), zap.Error(err))
+		}
+	}()
+
+	filename := fmt.Sprintf("%s-%s.%s", response.tableName, export.SnapshotAt.UTC().Format("20060102T150405Z"), export.Format)
+	contentType := export.Format.ContentType()
+	if response.gzip {
+		filename += ".gz"
+		contentType = "application/gzip"
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	header.Set("X-Schema-Id", export.SchemaID.String())
+	header.Set("X-Schema-Version", export.SchemaVersion.String())
+	header.Set("X-Export-Snapshot-At", export.SnapshotAt.UTC().Format(time.RFC3339Nano))
+	w.WriteHeader(http.StatusOK)
+
+	var out io.Writer = w
+	var compressor *gzip.Writer
+	if response.gzip {
+		compressor = gzip.NewWriter(w)
+		out = compressor
+	}
+
+	count, err := export.WriteTo(response.ctx, out)
+	if err == nil && compressor != nil {
+		err = compressor.Close()
+	}
+	if err != nil {
+		response.logger.Error("entity export failed",
+			zap.String("table", response.tableName),
+			zap.Int64("documents", count),
+			zap.Error(err),
+		)
+		panic(http.ErrAbortHandler)
+	}
+
+	response.logger.Info("entity export completed",
+		zap.String("table", response.tableName),
+		zap.String("format", string(export.Format)),
+		zap.Int64("documents", count),
+	)
+	return nil
+}
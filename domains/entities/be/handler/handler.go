@@ -16,8 +16,10 @@ import (
 
 const (
 	problemTypeValidation = "https://palmyra.pro/problems/validation-error"
+	problemTypeForbidden  = "https://palmyra.pro/problems/forbidden"
 	problemTypeNotFound   = "https://palmyra.pro/problems/not-found"
 	problemTypeConflict   = "https://palmyra.pro/problems/conflict"
+	problemTypeTooLarge   = "https://palmyra.pro/problems/payload-too-large"
 	problemTypeInternal   = "https://palmyra.pro/problems/internal-error"
 )
 
@@ -132,13 +134,47 @@ func (h *Handler) GetDocument(ctx context.Context, request entitiesapi.GetDocume
 	return entitiesapi.GetDocument200JSONResponse(apiDoc), nil
 }
 
+func (h *Handler) GetDocumentBySlug(ctx context.Context, request entitiesapi.GetDocumentBySlugRequestObject) (entitiesapi.GetDocumentBySlugResponseObject, error) {
+	doc, current, err := h.svc.GetBySlug(ctx, string(request.TableName), string(request.Slug))
+	if err != nil {
+		status, problem := h.problemForError(err)
+		return entitiesapi.GetDocumentBySlugdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	if !current {
+		return entitiesapi.GetDocumentBySlug301Response{
+			Headers: entitiesapi.GetDocumentBySlug301ResponseHeaders{
+				Location: fmt.Sprintf("/api/v1/entities/%s/by-slug/%s", request.TableName, doc.Slug),
+			},
+		}, nil
+	}
+
+	apiDoc, convErr := toAPIDocument(doc)
+	if convErr != nil {
+		status, problem := h.problemForInternal(convErr)
+		return entitiesapi.GetDocumentBySlugdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return entitiesapi.GetDocumentBySlug200JSONResponse(apiDoc), nil
+}
+
 func (h *Handler) UpdateDocument(ctx context.Context, request entitiesapi.UpdateDocumentRequestObject) (entitiesapi.UpdateDocumentResponseObject, error) {
-	if request.Body == nil || request.Body.Payload == nil {
-		status, problem := h.validationProblem("payload is required")
+	if request.Body == nil || (request.Body.Payload == nil && request.Body.Slug == nil) {
+		status, problem := h.validationProblem("payload or slug is required")
 		return entitiesapi.UpdateDocumentdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
 	}
 
-	doc, err := h.svc.Update(ctx, string(request.TableName), string(request.EntityId), *request.Body.Payload)
+	var payload map[string]interface{}
+	if request.Body.Payload != nil {
+		payload = *request.Body.Payload
+	}
+	var slug *string
+	if request.Body.Slug != nil {
+		value := string(*request.Body.Slug)
+		slug = &value
+	}
+
+	doc, err := h.svc.Update(ctx, string(request.TableName), string(request.EntityId), payload, slug)
 	if err != nil {
 		status, problem := h.problemForError(err)
 		return entitiesapi.UpdateDocumentdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
@@ -175,6 +211,7 @@ func toAPIDocument(doc service.Document) (entitiesapi.EntityDocument, error) {
 		EntityVersion: externalPrimitives.SemanticVersion(doc.EntityVersion.String()),
 		SchemaId:      externalPrimitives.UUID(doc.SchemaID),
 		SchemaVersion: externalPrimitives.SemanticVersion(doc.SchemaVersion.String()),
+		Slug:          externalPrimitives.Slug(doc.Slug),
 		Payload:       payload,
 		CreatedAt:     externalPrimitives.Timestamp(doc.CreatedAt),
 		IsActive:      doc.IsActive,
@@ -200,7 +237,7 @@ func (h *Handler) problemForError(err error) (int, externalProblems.ProblemDetai
 		return h.validationProblem(validationErr.Error())
 	}
 
-	if errors.Is(err, service.ErrTableNotFound) || errors.Is(err, service.ErrDocumentNotFound) {
+	if errors.Is(err, service.ErrTableNotFound) || errors.Is(err, service.ErrDocumentNotFound) || errors.Is(err, service.ErrImportNotFound) {
 		problem := externalProblems.ProblemDetails{
 			Type:   strPtr(problemTypeNotFound),
 			Title:  "Not found",
@@ -210,6 +247,36 @@ func (h *Handler) problemForError(err error) (int, externalProblems.ProblemDetai
 		return http.StatusNotFound, problem
 	}
 
+	if errors.Is(err, service.ErrForbidden) {
+		problem := externalProblems.ProblemDetails{
+			Type:   strPtr(problemTypeForbidden),
+			Title:  "Forbidden",
+			Detail: strPtr("write access to this table is not granted"),
+			Status: http.StatusForbidden,
+		}
+		return http.StatusForbidden, problem
+	}
+
+	if errors.Is(err, service.ErrImportTooLarge) {
+		problem := externalProblems.ProblemDetails{
+			Type:   strPtr(problemTypeTooLarge),
+			Title:  "Payload too large",
+			Detail: strPtr("the upload exceeds the largest import accepted"),
+			Status: http.StatusRequestEntityTooLarge,
+		}
+		return http.StatusRequestEntityTooLarge, problem
+	}
+
+	if errors.Is(err, service.ErrSlugConflict) {
+		problem := externalProblems.ProblemDetails{
+			Type:   strPtr(problemTypeConflict),
+			Title:  "Conflict",
+			Detail: strPtr("slug already in use"),
+			Status: http.StatusConflict,
+		}
+		return http.StatusConflict, problem
+	}
+
 	if errors.Is(err, service.ErrConflict) {
 		problem := externalProblems.ProblemDetails{
 			Type:   strPtr(problemTypeConflict),
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/service"
+	externalPrimitives "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	entitiesapi "github.com/zenGate-Global/palmyra-pro-saas/generated/go/entities"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/seed"
+)
+
+func (h *Handler) ImportDocuments(ctx context.Context, request entitiesapi.ImportDocumentsRequestObject) (entitiesapi.ImportDocumentsResponseObject, error) {
+	params := request.Params
+	opts := service.ImportOptions{KeyField: params.KeyField}
+	if params.Format != nil {
+		opts.Format = seed.Format(*params.Format)
+	}
+	if params.EntityId != nil {
+		opts.EntityID = seed.EntityIDStrategy(*params.EntityId)
+	}
+	if params.Namespace != nil {
+		opts.Namespace = uuid.UUID(*params.Namespace)
+	}
+	if params.SlugTemplate != nil {
+		opts.SlugTemplate = *params.SlugTemplate
+	}
+	if params.Fields != nil {
+		for _, field := range *params.Fields {
+			if field = strings.TrimSpace(field); field != "" {
+				opts.Fields = append(opts.Fields, field)
+			}
+		}
+	}
+
+	imp, err := h.svc.StartImport(ctx, string(request.TableName), request.Body, opts)
+	if err != nil {
+		status, problem := h.problemForError(err)
+		return entitiesapi.ImportDocumentsdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return entitiesapi.ImportDocuments202JSONResponse{
+		Body: toAPIImport(imp),
+		Headers: entitiesapi.ImportDocuments202ResponseHeaders{
+			Location: fmt.Sprintf("/api/v1/entities/%s/imports/%s", request.TableName, imp.ImportID),
+		},
+	}, nil
+}
+
+func (h *Handler) GetImport(ctx context.Context, request entitiesapi.GetImportRequestObject) (entitiesapi.GetImportResponseObject, error) {
+	imp, err := h.svc.GetImport(ctx, string(request.TableName), uuid.UUID(request.ImportId))
+	if err != nil {
+		status, problem := h.problemForError(err)
+		return entitiesapi.GetImportdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return entitiesapi.GetImport200JSONResponse(toAPIImport(imp)), nil
+}
+
+func (h *Handler) GetImportResults(ctx context.Context, request entitiesapi.GetImportResultsRequestObject) (entitiesapi.GetImportResultsResponseObject, error) {
+	var outcome string
+	if request.Params.Outcome != nil {
+		outcome = string(*request.Params.Outcome)
+	}
+
+	results, err := h.svc.ImportResults(ctx, string(request.TableName), uuid.UUID(request.ImportId), outcome)
+	if err != nil {
+		status, problem := h.problemForError(err)
+		return entitiesapi.GetImportResultsdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return importResultsResponse{ctx: ctx, logger: h.logger, results: results, importID: request.ImportId.String()}, nil
+}
+
+// importResultsResponse streams line results like exportResponse streams documents: a failure after the
+// body has started cuts the response off.
+type importResultsResponse struct {
+	ctx      context.Context
+	logger   *zap.Logger
+	results  *service.ImportResults
+	importID string
+}
+
+func (response importResultsResponse) VisitGetImportResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	count, err := response.results.WriteTo(response.ctx, w)
+	if err != nil {
+		response.logger.Error("entity import results failed",
+			zap.String("import", response.importID),
+			zap.Int64("results", count),
+			zap.Error(err),
+		)
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
+func toAPIImport(imp service.Import) entitiesapi.EntityImport {
+	apiImport := entitiesapi.EntityImport{
+		ImportId:  externalPrimitives.UUID(imp.ImportID),
+		TableName: externalPrimitives.TableName(imp.TableName),
+		Format:    entitiesapi.EntityImportFormat(imp.Options.Format),
+		Status:    entitiesapi.EntityImportStatus(imp.Status),
+		KeyField:  imp.Options.KeyField,
+		EntityId:  entitiesapi.EntityImportEntityId(imp.Options.EntityID),
+		Counts: entitiesapi.EntityImportCounts{
+			Accepted:  imp.Accepted,
+			Updated:   imp.Updated,
+			Unchanged: imp.Unchanged,
+			Rejected:  imp.Rejected,
+		},
+		Error:     imp.Error,
+		CreatedAt: externalPrimitives.Timestamp(imp.CreatedAt),
+	}
+	if imp.JobID != nil {
+		jobID := externalPrimitives.UUID(*imp.JobID)
+		apiImport.JobId = &jobID
+	}
+	if imp.Options.Namespace != uuid.Nil {
+		namespace := externalPrimitives.UUID(imp.Options.Namespace)
+		apiImport.Namespace = &namespace
+	}
+	if imp.Options.SlugTemplate != "" {
+		apiImport.SlugTemplate = &imp.Options.SlugTemplate
+	}
+	if len(imp.Options.Fields) > 0 {
+		apiImport.Fields = &imp.Options.Fields
+	}
+	if imp.StartedAt != nil {
+		startedAt := externalPrimitives.Timestamp(*imp.StartedAt)
+		apiImport.StartedAt = &startedAt
+	}
+	if imp.FinishedAt != nil {
+		finishedAt := externalPrimitives.Timestamp(*imp.FinishedAt)
+		apiImport.FinishedAt = &finishedAt
+	}
+	return apiImport
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	externalPrimitives "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	entitiesapi "github.com/zenGate-Global/palmyra-pro-saas/generated/go/entities"
+)
+
+func (h *Handler) RevalidateDocuments(ctx context.Context, request entitiesapi.RevalidateDocumentsRequestObject) (entitiesapi.RevalidateDocumentsResponseObject, error) {
+	job, err := h.svc.StartRevalidation(ctx, string(request.TableName))
+	if err != nil {
+		status, problem := h.problemForError(err)
+		return entitiesapi.RevalidateDocumentsdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return entitiesapi.RevalidateDocuments202JSONResponse{
+		Body: entitiesapi.JobReference{
+			JobId:     externalPrimitives.UUID(job.JobID),
+			Kind:      job.Kind,
+			Status:    entitiesapi.JobReferenceStatus(job.Status),
+			CreatedAt: externalPrimitives.Timestamp(job.CreatedAt),
+		},
+		Headers: entitiesapi.RevalidateDocuments202ResponseHeaders{
+			Location: fmt.Sprintf("/api/v1/jobs/%s", job.JobID),
+		},
+	}, nil
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/seed"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
 )
 
 // ListParams defines pagination and sorting inputs for listing entities.
@@ -32,18 +36,49 @@ type Repository interface {
 	List(ctx context.Context, tableName string, params ListParams) (ListResult, error)
 	Create(ctx context.Context, tableName string, entityID string, payload json.RawMessage) (persistence.EntityRecord, error)
 	Get(ctx context.Context, tableName string, entityID string) (persistence.EntityRecord, error)
-	Update(ctx context.Context, tableName string, entityID string, payload json.RawMessage) (persistence.EntityRecord, error)
+	GetBySlug(ctx context.Context, tableName string, slug string) (record persistence.EntityRecord, current bool, err error)
+	Update(ctx context.Context, tableName string, entityID string, payload json.RawMessage, slug *string) (persistence.EntityRecord, error)
 	Delete(ctx context.Context, tableName string, entityID string) error
+	Export(ctx context.Context, tableName string, params persistence.ExportEntitiesParams) (*persistence.EntityExport, error)
+	ResolveAccess(ctx context.Context, tableName string, subject persistence.AccessSubject) (persistence.AccessDecision, error)
+	// Validate checks payload against schema, e.g. the schema of an export.
+	Validate(ctx context.Context, schema persistence.SchemaRecord, payload []byte) error
+
+	// Import writes the records read from input into the table with the seed runner (see seed.Apply);
+	// slugTemplate overrides the schema's slug template when set.
+	Import(ctx context.Context, tableName string, input io.Reader, slugTemplate string, opts seed.Options) (seed.Summary, error)
+	CreateImport(ctx context.Context, params persistence.CreateEntityImportParams) (persistence.EntityImport, error)
+	GetImport(ctx context.Context, importID uuid.UUID) (persistence.EntityImport, error)
+	SetImportJob(ctx context.Context, importID, jobID uuid.UUID) error
+	OpenImportUpload(ctx context.Context, importID uuid.UUID) (io.Reader, error)
+	StartImport(ctx context.Context, importID uuid.UUID) error
+	ResetImport(ctx context.Context, importID uuid.UUID) error
+	FinishImport(ctx context.Context, importID uuid.UUID, status persistence.EntityImportStatus, cause error) error
+	RecordImportResults(ctx context.Context, importID uuid.UUID, results []persistence.EntityImportResult) error
+	EachImportResult(ctx context.Context, importID uuid.UUID, outcome string, fn func(persistence.EntityImportResult) error) error
 }
 
 type repository struct {
-	pool        *pgxpool.Pool
-	schemaStore *persistence.SchemaRepositoryStore
-	validator   *persistence.SchemaValidator
+	pool          *pgxpool.Pool
+	schemaStore   *persistence.SchemaRepositoryStore
+	validator     *persistence.SchemaValidator
+	accessStore   *persistence.AccessControlStore
+	importStore   *persistence.EntityImportStore
+	writeObserver persistence.EntityWriteObserver
+}
+
+// Option customizes the repository.
+type Option func(*repository)
+
+// WithWriteObserver reports every entity write to observer.
+func WithWriteObserver(observer persistence.EntityWriteObserver) Option {
+	return func(r *repository) {
+		r.writeObserver = observer
+	}
 }
 
 // New constructs a Repository backed by the shared persistence layer.
-func New(pool *pgxpool.Pool, schemaStore *persistence.SchemaRepositoryStore, validator *persistence.SchemaValidator) Repository {
+func New(pool *pgxpool.Pool, schemaStore *persistence.SchemaRepositoryStore, validator *persistence.SchemaValidator, accessStore *persistence.AccessControlStore, importStore *persistence.EntityImportStore, opts ...Option) Repository {
 	if pool == nil {
 		panic("postgres pool is required")
 	}
@@ -53,8 +88,18 @@ func New(pool *pgxpool.Pool, schemaStore *persistence.SchemaRepositoryStore, val
 	if validator == nil {
 		panic("schema validator is required")
 	}
+	if accessStore == nil {
+		panic("access control store is required")
+	}
+	if importStore == nil {
+		panic("entity import store is required")
+	}
 
-	return &repository{pool: pool, schemaStore: schemaStore, validator: validator}
+	r := &repository{pool: pool, schemaStore: schemaStore, validator: validator, accessStore: accessStore, importStore: importStore}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *repository) List(ctx context.Context, tableName string, params ListParams) (ListResult, error) {
@@ -100,9 +145,12 @@ func (r *repository) Create(ctx context.Context, tableName string, entityID stri
 		return persistence.EntityRecord{}, err
 	}
 
-	slug, err := persistence.NormalizeSlug(uuid.New().String())
-	if err != nil {
-		return persistence.EntityRecord{}, fmt.Errorf("generate slug: %w", err)
+	// Schemas declaring a slug template derive the slug from the payload; others get a random one.
+	var slug string
+	if _, ok := repo.SlugTemplate(); !ok {
+		if slug, err = persistence.NormalizeSlug(uuid.New().String()); err != nil {
+			return persistence.EntityRecord{}, fmt.Errorf("generate slug: %w", err)
+		}
 	}
 
 	return repo.CreateEntity(ctx, persistence.CreateEntityParams{
@@ -121,15 +169,35 @@ func (r *repository) Get(ctx context.Context, tableName string, entityID string)
 	return repo.GetEntityByID(ctx, entityID)
 }
 
-func (r *repository) Update(ctx context.Context, tableName string, entityID string, payload json.RawMessage) (persistence.EntityRecord, error) {
+func (r *repository) GetBySlug(ctx context.Context, tableName string, slug string) (persistence.EntityRecord, bool, error) {
+	repo, err := r.resolveEntityRepo(ctx, tableName)
+	if err != nil {
+		return persistence.EntityRecord{}, false, err
+	}
+
+	return repo.GetEntityBySlug(ctx, slug)
+}
+
+// Update writes a new version of the entity. An empty payload keeps the current one, so a slug can be
+// changed on its own.
+func (r *repository) Update(ctx context.Context, tableName string, entityID string, payload json.RawMessage, slug *string) (persistence.EntityRecord, error) {
 	repo, err := r.resolveEntityRepo(ctx, tableName)
 	if err != nil {
 		return persistence.EntityRecord{}, err
 	}
 
+	if len(payload) == 0 {
+		current, err := repo.GetEntityByID(ctx, entityID)
+		if err != nil {
+			return persistence.EntityRecord{}, err
+		}
+		payload = current.Payload
+	}
+
 	return repo.UpdateEntity(ctx, persistence.UpdateEntityParams{
 		EntityID: entityID,
-		Payload:  payload,
+		Slug:     slug,
+		Payload:  persistence.SchemaDefinition(payload),
 	})
 }
 
@@ -142,17 +210,92 @@ func (r *repository) Delete(ctx context.Context, tableName string, entityID stri
 	return repo.SoftDeleteEntity(ctx, entityID, time.Now().UTC())
 }
 
+func (r *repository) Export(ctx context.Context, tableName string, params persistence.ExportEntitiesParams) (*persistence.EntityExport, error) {
+	repo, err := r.resolveEntityRepo(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.BeginExport(ctx, params)
+}
+
+func (r *repository) ResolveAccess(ctx context.Context, tableName string, subject persistence.AccessSubject) (persistence.AccessDecision, error) {
+	decisions, err := r.accessStore.ResolveTableAccess(ctx, subject, []string{tableName})
+	if err != nil {
+		return persistence.AccessDecision{}, err
+	}
+	return decisions[tableName], nil
+}
+
+func (r *repository) Validate(ctx context.Context, schema persistence.SchemaRecord, payload []byte) error {
+	return r.validator.Validate(ctx, schema, payload)
+}
+
+func (r *repository) Import(ctx context.Context, tableName string, input io.Reader, slugTemplate string, opts seed.Options) (seed.Summary, error) {
+	repo, err := r.resolveEntityRepoWithSlugTemplate(ctx, tableName, slugTemplate)
+	if err != nil {
+		return seed.Summary{}, err
+	}
+
+	return seed.Apply(ctx, repo, input, opts)
+}
+
+func (r *repository) CreateImport(ctx context.Context, params persistence.CreateEntityImportParams) (persistence.EntityImport, error) {
+	return r.importStore.CreateEntityImport(ctx, params)
+}
+
+func (r *repository) GetImport(ctx context.Context, importID uuid.UUID) (persistence.EntityImport, error) {
+	return r.importStore.GetEntityImport(ctx, importID)
+}
+
+func (r *repository) SetImportJob(ctx context.Context, importID, jobID uuid.UUID) error {
+	return r.importStore.SetEntityImportJob(ctx, importID, jobID)
+}
+
+func (r *repository) OpenImportUpload(ctx context.Context, importID uuid.UUID) (io.Reader, error) {
+	return r.importStore.OpenEntityImportUpload(ctx, importID)
+}
+
+func (r *repository) StartImport(ctx context.Context, importID uuid.UUID) error {
+	return r.importStore.StartEntityImport(ctx, importID)
+}
+
+func (r *repository) ResetImport(ctx context.Context, importID uuid.UUID) error {
+	return r.importStore.ResetEntityImport(ctx, importID)
+}
+
+func (r *repository) FinishImport(ctx context.Context, importID uuid.UUID, status persistence.EntityImportStatus, cause error) error {
+	return r.importStore.FinishEntityImport(ctx, importID, status, cause)
+}
+
+func (r *repository) RecordImportResults(ctx context.Context, importID uuid.UUID, results []persistence.EntityImportResult) error {
+	return r.importStore.RecordEntityImportResults(ctx, importID, results)
+}
+
+func (r *repository) EachImportResult(ctx context.Context, importID uuid.UUID, outcome string, fn func(persistence.EntityImportResult) error) error {
+	return r.importStore.EachEntityImportResult(ctx, importID, outcome, fn)
+}
+
 func (r *repository) resolveEntityRepo(ctx context.Context, tableName string) (*persistence.EntityRepository, error) {
+	return r.resolveEntityRepoWithSlugTemplate(ctx, tableName, "")
+}
+
+func (r *repository) resolveEntityRepoWithSlugTemplate(ctx context.Context, tableName string, slugTemplate string) (_ *persistence.EntityRepository, err error) {
 	if tableName == "" {
 		return nil, errors.New("table name is required")
 	}
 
+	ctx, span := tracing.Start(ctx, "entities.resolveTable", attribute.String("palmyra.table", tableName))
+	defer func() { tracing.End(span, err) }()
+
 	schemaRecord, err := r.schemaStore.GetActiveSchemaByTableName(ctx, tableName)
 	if err != nil {
 		return nil, err
 	}
 
 	return persistence.NewEntityRepository(ctx, r.pool, r.schemaStore, r.validator, persistence.EntityRepositoryConfig{
-		SchemaID: schemaRecord.SchemaID,
+		SchemaID:      schemaRecord.SchemaID,
+		WriteObserver: r.writeObserver,
+		SlugTemplate:  slugTemplate,
 	})
 }
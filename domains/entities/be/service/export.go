@@ -0,0 +1,380 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+// ExportFormat selects how exported documents are encoded.
+type ExportFormat string
+
+// Supported export formats.
+const (
+	ExportFormatNDJSON ExportFormat = "ndjson" // one document per line
+	ExportFormatCSV    ExportFormat = "csv"    // one row per document, payload flattened into columns
+	ExportFormatJSON   ExportFormat = "json"   // a single JSON array
+)
+
+// ContentType returns the media type of the format.
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatJSON:
+		return "application/json"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// csvMetadataColumns precede the payload columns of CSV exports.
+var csvMetadataColumns = []string{"entityId", "entityVersion", "schemaVersion", "slug", "createdAt"}
+
+// ExportOptions selects and shapes the documents of an export.
+type ExportOptions struct {
+	Format ExportFormat // defaults to ndjson
+	// Fields lists the payload fields (dotted paths) to keep; every field when empty. For CSV they are
+	// the payload columns, which otherwise come from the schema's properties.
+	Fields []string
+	// Filters keeps documents whose payload field equals the value, compared as text.
+	Filters      map[string]string
+	ChangedSince *time.Time
+}
+
+// Export is an open export over a consistent snapshot of a table. WriteTo streams it; Close must be called
+// to release the snapshot.
+type Export struct {
+	Format        ExportFormat
+	SchemaID      uuid.UUID
+	SchemaVersion persistence.SemanticVersion
+	SnapshotAt    time.Time
+
+	source  *persistence.EntityExport
+	fields  [][]string // projected payload paths; nil keeps the whole payload
+	columns []string   // CSV payload columns
+}
+
+func (s *service) Export(ctx context.Context, tableName string, opts ExportOptions) (*Export, error) {
+	ctx, span := tracing.Start(ctx, "entities.Export")
+	defer span.End()
+
+	if strings.TrimSpace(tableName) == "" {
+		return nil, &ValidationError{Reason: "tableName is required"}
+	}
+	format := opts.Format
+	if format == "" {
+		format = ExportFormatNDJSON
+	}
+	if !slices.Contains([]ExportFormat{ExportFormatNDJSON, ExportFormatCSV, ExportFormatJSON}, format) {
+		return nil, &ValidationError{Reason: fmt.Sprintf("unsupported export format %q", format)}
+	}
+
+	var fields [][]string
+	for _, field := range opts.Fields {
+		if !persistence.IsPayloadPath(field) {
+			return nil, &ValidationError{Reason: fmt.Sprintf("invalid field %q", field)}
+		}
+		fields = append(fields, strings.Split(field, "."))
+	}
+	for field := range opts.Filters {
+		if !persistence.IsPayloadPath(field) {
+			return nil, &ValidationError{Reason: fmt.Sprintf("invalid filter field %q", field)}
+		}
+	}
+
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionRead); err != nil {
+		return nil, err
+	}
+
+	source, err := s.repo.Export(ctx, tableName, persistence.ExportEntitiesParams{
+		ChangedSince: opts.ChangedSince,
+		Filters:      opts.Filters,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	export := &Export{
+		Format:        format,
+		SchemaID:      source.Schema.SchemaID,
+		SchemaVersion: source.Schema.SchemaVersion,
+		SnapshotAt:    source.SnapshotAt,
+		source:        source,
+		fields:        fields,
+	}
+	if format == ExportFormatCSV {
+		export.columns = opts.Fields
+		if len(export.columns) == 0 {
+			export.columns = schemaColumns(source.Schema.SchemaDefinition)
+		}
+	}
+	return export, nil
+}
+
+// WriteTo streams every document of the export to w and returns how many were written.
+func (e *Export) WriteTo(ctx context.Context, w io.Writer) (int64, error) {
+	buffered := bufio.NewWriterSize(w, 64*1024)
+	encoder := e.newEncoder(buffered)
+
+	if err := encoder.begin(); err != nil {
+		return 0, err
+	}
+	var count int64
+	for {
+		records, err := e.source.Next(ctx)
+		if err != nil {
+			return count, err
+		}
+		if len(records) == 0 {
+			break
+		}
+		for _, record := range records {
+			if err := encoder.write(record); err != nil {
+				return count, fmt.Errorf("encode entity %s: %w", record.EntityID, err)
+			}
+			count++
+		}
+		// Hand each batch to the client instead of holding it until the buffer fills.
+		if err := buffered.Flush(); err != nil {
+			return count, err
+		}
+	}
+	if err := encoder.end(); err != nil {
+		return count, err
+	}
+	return count, buffered.Flush()
+}
+
+// Close releases the export snapshot.
+func (e *Export) Close(ctx context.Context) error {
+	return e.source.Close(ctx)
+}
+
+type exportEncoder interface {
+	begin() error
+	write(record persistence.EntityRecord) error
+	end() error
+}
+
+func (e *Export) newEncoder(w *bufio.Writer) exportEncoder {
+	switch e.Format {
+	case ExportFormatCSV:
+		return &csvEncoder{w: csv.NewWriter(w), columns: e.columns}
+	case ExportFormatJSON:
+		return &jsonEncoder{w: w, fields: e.fields, array: true}
+	default:
+		return &jsonEncoder{w: w, fields: e.fields}
+	}
+}
+
+// exportedDocument is the JSON shape of an exported document.
+type exportedDocument struct {
+	EntityID      string          `json:"entityId"`
+	EntityVersion string          `json:"entityVersion"`
+	SchemaID      uuid.UUID       `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion"`
+	Slug          string          `json:"slug"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+type jsonEncoder struct {
+	w      *bufio.Writer
+	fields [][]string
+	array  bool
+	wrote  bool
+}
+
+func (e *jsonEncoder) begin() error {
+	if e.array {
+		_, err := e.w.WriteString("[")
+		return err
+	}
+	return nil
+}
+
+func (e *jsonEncoder) write(record persistence.EntityRecord) error {
+	payload := json.RawMessage(record.Payload)
+	if e.fields != nil {
+		document, err := decodePayload(record.Payload)
+		if err != nil {
+			return err
+		}
+		if payload, err = json.Marshal(project(document, e.fields)); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(exportedDocument{
+		EntityID:      record.EntityID,
+		EntityVersion: record.EntityVersion.String(),
+		SchemaID:      record.SchemaID,
+		SchemaVersion: record.SchemaVersion.String(),
+		Slug:          record.Slug,
+		CreatedAt:     record.CreatedAt,
+		Payload:       payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	separator := "\n"
+	if e.array && e.wrote {
+		separator = ",\n"
+	}
+	if e.array || e.wrote {
+		if _, err := e.w.WriteString(separator); err != nil {
+			return err
+		}
+	}
+	e.wrote = true
+	_, err = e.w.Write(encoded)
+	return err
+}
+
+func (e *jsonEncoder) end() error {
+	closing := "\n"
+	if e.array {
+		closing = "\n]\n"
+	}
+	if !e.array && !e.wrote {
+		return nil
+	}
+	_, err := e.w.WriteString(closing)
+	return err
+}
+
+type csvEncoder struct {
+	w       *csv.Writer
+	columns []string
+	paths   [][]string
+}
+
+func (e *csvEncoder) begin() error {
+	header := slices.Concat(csvMetadataColumns, e.columns)
+	if len(e.columns) == 0 {
+		header = append(header, "payload")
+	}
+	for _, column := range e.columns {
+		e.paths = append(e.paths, strings.Split(column, "."))
+	}
+	return e.w.Write(header)
+}
+
+func (e *csvEncoder) write(record persistence.EntityRecord) error {
+	row := []string{
+		record.EntityID,
+		record.EntityVersion.String(),
+		record.SchemaVersion.String(),
+		record.Slug,
+		record.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if len(e.paths) == 0 {
+		row = append(row, string(record.Payload))
+		return e.w.Write(row)
+	}
+
+	document, err := decodePayload(record.Payload)
+	if err != nil {
+		return err
+	}
+	for _, path := range e.paths {
+		cell, err := csvCell(lookupPath(document, path))
+		if err != nil {
+			return err
+		}
+		row = append(row, cell)
+	}
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func csvCell(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		if v {
+			return "true", nil
+		}
+		return "false", nil
+	default:
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	}
+}
+
+// decodePayload keeps numbers as json.Number so large integers survive the round trip.
+func decodePayload(payload []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("decode entity payload: %w", err)
+	}
+	return document, nil
+}
+
+func lookupPath(document map[string]any, path []string) any {
+	var current any = document
+	for _, key := range path {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = object[key]
+	}
+	return current
+}
+
+// project copies the listed paths of document into a new document, keeping their nesting.
+func project(document map[string]any, paths [][]string) map[string]any {
+	projected := map[string]any{}
+	for _, path := range paths {
+		value := lookupPath(document, path)
+		if value == nil {
+			continue
+		}
+		target := projected
+		for _, key := range path[:len(path)-1] {
+			next, ok := target[key].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				target[key] = next
+			}
+			target = next
+		}
+		target[path[len(path)-1]] = value
+	}
+	return projected
+}
+
+// schemaColumns lists the schema's flattened payload fields (see persistence.SchemaFields).
+func schemaColumns(definition persistence.SchemaDefinition) []string {
+	var columns []string
+	for _, field := range persistence.SchemaFields(definition) {
+		columns = append(columns, field.Path)
+	}
+	return columns
+}
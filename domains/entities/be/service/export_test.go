@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+func exportRecords() []persistence.EntityRecord {
+	createdAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	version := persistence.SemanticVersion{Major: 1}
+	return []persistence.EntityRecord{
+		{
+			EntityID: "card-1", EntityVersion: version, SchemaID: uuid.Nil, SchemaVersion: version, Slug: "black-lotus",
+			Payload:   []byte(`{"name":"Black Lotus","set":{"code":"LEA","year":1993},"tcgPlayerIds":[1,2],"price":12345678901234567}`),
+			CreatedAt: createdAt,
+		},
+		{
+			EntityID: "card-2", EntityVersion: version, SchemaID: uuid.Nil, SchemaVersion: version, Slug: "mox-pearl",
+			Payload:   []byte(`{"name":"Mox, \"Pearl\"","set":{"code":"LEA"}}`),
+			CreatedAt: createdAt,
+		},
+	}
+}
+
+func encodeRecords(t *testing.T, export *Export) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	w := bufio.NewWriter(&out)
+	encoder := export.newEncoder(w)
+	require.NoError(t, encoder.begin())
+	for _, record := range exportRecords() {
+		require.NoError(t, encoder.write(record))
+	}
+	require.NoError(t, encoder.end())
+	require.NoError(t, w.Flush())
+	return out.String()
+}
+
+func TestExportNDJSONProjection(t *testing.T) {
+	out := encodeRecords(t, &Export{Format: ExportFormatNDJSON, fields: [][]string{{"name"}, {"set", "code"}}})
+
+	lines := bytes.Split(bytes.TrimSpace([]byte(out)), []byte("\n"))
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{
+		"entityId": "card-1", "entityVersion": "1.0.0", "schemaId": "00000000-0000-0000-0000-000000000000",
+		"schemaVersion": "1.0.0", "slug": "black-lotus", "createdAt": "2026-10-18T12:00:00Z",
+		"payload": {"name": "Black Lotus", "set": {"code": "LEA"}}
+	}`, string(lines[0]))
+}
+
+func TestExportJSONArray(t *testing.T) {
+	out := encodeRecords(t, &Export{Format: ExportFormatJSON})
+	require.True(t, bytes.HasPrefix([]byte(out), []byte("[\n{")))
+
+	var documents []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &documents))
+	require.Len(t, documents, 2)
+	require.Equal(t, "mox-pearl", documents[1]["slug"])
+
+	var empty bytes.Buffer
+	w := bufio.NewWriter(&empty)
+	encoder := (&Export{Format: ExportFormatJSON}).newEncoder(w)
+	require.NoError(t, encoder.begin())
+	require.NoError(t, encoder.end())
+	require.NoError(t, w.Flush())
+	require.NoError(t, json.Unmarshal(empty.Bytes(), &documents))
+	require.Empty(t, documents)
+}
+
+func TestExportCSVFlattensSchema(t *testing.T) {
+	columns := schemaColumns(persistence.SchemaDefinition(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"set": {"$ref": "#/$defs/set"},
+			"tcgPlayerIds": {"type": "array", "items": {"type": "integer"}},
+			"price": {"type": "integer"}
+		},
+		"$defs": {
+			"set": {"type": "object", "properties": {"year": {"type": "integer"}, "code": {"type": "string"}}}
+		}
+	}`))
+	require.Equal(t, []string{"name", "price", "set.code", "set.year", "tcgPlayerIds"}, columns)
+
+	out := encodeRecords(t, &Export{Format: ExportFormatCSV, columns: columns})
+	require.Equal(t,
+		"entityId,entityVersion,schemaVersion,slug,createdAt,name,price,set.code,set.year,tcgPlayerIds\n"+
+			"card-1,1.0.0,1.0.0,black-lotus,2026-10-18T12:00:00Z,Black Lotus,12345678901234567,LEA,1993,\"[1,2]\"\n"+
+			"card-2,1.0.0,1.0.0,mox-pearl,2026-10-18T12:00:00Z,\"Mox, \"\"Pearl\"\"\",,LEA,,\n",
+		out)
+}
+
+func TestExportValidation(t *testing.T) {
+	svc := New(&stubRepository{})
+	ctx := context.Background()
+
+	_, err := svc.Export(ctx, "cards", ExportOptions{Format: "xml"})
+	require.ErrorAs(t, err, new(*ValidationError))
+
+	_, err = svc.Export(ctx, "cards", ExportOptions{Fields: []string{"name", "set..code"}})
+	require.ErrorAs(t, err, new(*ValidationError))
+
+	_, err = svc.Export(ctx, "cards", ExportOptions{Filters: map[string]string{"set code": "LEA"}})
+	require.ErrorAs(t, err, new(*ValidationError))
+}
@@ -0,0 +1,476 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/repo"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/jobs"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/seed"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+// ImportKind runs an import queued by StartImport, reading the upload stored with it. An attempt
+// interrupted by a crash or shutdown is retried from the start of the upload.
+var ImportKind = jobs.Kind[ImportPayload]{Name: "entities.import", MaxAttempts: 3, Timeout: 2 * time.Hour}
+
+// ImportPayload is the payload of ImportKind jobs.
+type ImportPayload struct {
+	ImportID uuid.UUID `json:"importId"`
+}
+
+// ImportReport is the result of an ImportKind job; the line results stay with the import.
+type ImportReport struct {
+	ImportID  uuid.UUID                      `json:"importId"`
+	TableName string                         `json:"tableName"`
+	Status    persistence.EntityImportStatus `json:"status"`
+	Accepted  int64                          `json:"accepted"`
+	Updated   int64                          `json:"updated"`
+	Unchanged int64                          `json:"unchanged"`
+	Rejected  int64                          `json:"rejected"`
+}
+
+// DefaultMaxImportSize caps import uploads unless WithMaxImportSize says otherwise.
+const DefaultMaxImportSize int64 = 256 << 20
+
+const (
+	// importConcurrency bounds the writers of one import so a large upload cannot take over the pool.
+	importConcurrency = 4
+	// importResultBatch is how many line results are buffered before they are stored.
+	importResultBatch = 500
+)
+
+// ImportOptions describe how the records of an upload become documents, the way a seed spec step does.
+type ImportOptions struct {
+	Format       seed.Format           // defaults to ndjson
+	KeyField     string                // payload field (dotted path) holding each record's key; required
+	EntityID     seed.EntityIDStrategy // defaults to seed.EntityIDKey
+	Namespace    uuid.UUID             // for seed.EntityIDUUID; defaults to uuid.NameSpaceURL
+	SlugTemplate string                // overrides the schema's slug template
+	Fields       []string              // top-level payload fields to keep (seed.FieldRules.Allow); every field when empty
+}
+
+// storedImportOptions is the JSON form of ImportOptions kept with the import.
+type storedImportOptions struct {
+	KeyField     string                `json:"keyField"`
+	EntityID     seed.EntityIDStrategy `json:"entityId"`
+	Namespace    *uuid.UUID            `json:"namespace,omitempty"`
+	SlugTemplate string                `json:"slugTemplate,omitempty"`
+	Fields       []string              `json:"fields,omitempty"`
+}
+
+// Import is a bulk import of records into a table, run in the background.
+type Import struct {
+	ImportID   uuid.UUID
+	TableName  string
+	Status     persistence.EntityImportStatus
+	Options    ImportOptions
+	JobID      *uuid.UUID // the ImportKind job running the import
+	Accepted   int64
+	Updated    int64
+	Unchanged  int64
+	Rejected   int64
+	Error      *string
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// ImportResults streams the recorded line results of an import.
+type ImportResults struct {
+	repo     domainrepo.Repository
+	importID uuid.UUID
+	outcome  seed.Outcome
+}
+
+// importResultLine is the JSON shape of a line result.
+type importResultLine struct {
+	Line     int    `json:"line"`
+	EntityID string `json:"entityId,omitempty"`
+	Outcome  string `json:"outcome"`
+	Error    string `json:"error,omitempty"`
+}
+
+// WithMaxImportSize caps import uploads at size bytes; larger ones fail with ErrImportTooLarge.
+func WithMaxImportSize(size int64) Option {
+	return func(s *service) {
+		if size > 0 {
+			s.maxImportSize = size
+		}
+	}
+}
+
+func (s *service) StartImport(ctx context.Context, tableName string, input io.Reader, opts ImportOptions) (Import, error) {
+	ctx, span := tracing.Start(ctx, "entities.StartImport")
+	defer span.End()
+
+	if strings.TrimSpace(tableName) == "" {
+		return Import{}, &ValidationError{Reason: "tableName is required"}
+	}
+	if input == nil {
+		return Import{}, &ValidationError{Reason: "request body is required"}
+	}
+	opts, err := normalizeImportOptions(opts)
+	if err != nil {
+		return Import{}, err
+	}
+
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionWrite); err != nil {
+		return Import{}, err
+	}
+	if s.jobs == nil {
+		return Import{}, errors.New("background jobs are not configured")
+	}
+
+	stored := storedImportOptions{
+		KeyField:     opts.KeyField,
+		EntityID:     opts.EntityID,
+		SlugTemplate: opts.SlugTemplate,
+		Fields:       opts.Fields,
+	}
+	if opts.EntityID == seed.EntityIDUUID {
+		stored.Namespace = &opts.Namespace
+	}
+	encodedOptions, err := json.Marshal(stored)
+	if err != nil {
+		return Import{}, fmt.Errorf("encode import options: %w", err)
+	}
+
+	// The upload is stored with the import so whichever worker claims the job can read it.
+	record, err := s.repo.CreateImport(ctx, persistence.CreateEntityImportParams{
+		ImportID:  uuid.New(),
+		TableName: tableName,
+		Format:    string(opts.Format),
+		Options:   encodedOptions,
+		Upload:    &cappedUpload{r: input, remaining: s.maxImportSize},
+	})
+	if err != nil {
+		return Import{}, translateError(err)
+	}
+
+	job, err := jobs.Enqueue(ctx, s.jobs, ImportKind, ImportPayload{ImportID: record.ImportID})
+	if err != nil {
+		if finishErr := s.repo.FinishImport(ctx, record.ImportID, persistence.EntityImportFailed, err); finishErr != nil {
+			s.logger.Error("fail unqueued entity import", zap.String("import", record.ImportID.String()), zap.Error(finishErr))
+		}
+		return Import{}, err
+	}
+	if err := s.repo.SetImportJob(ctx, record.ImportID, job.JobID); err != nil {
+		return Import{}, translateError(err)
+	}
+	record.JobID = &job.JobID
+
+	return mapImport(record)
+}
+
+// cappedUpload reads an upload, failing with ErrImportTooLarge once it exceeds remaining bytes.
+type cappedUpload struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (u *cappedUpload) Read(p []byte) (int, error) {
+	if u.remaining < 0 {
+		return 0, ErrImportTooLarge
+	}
+	if int64(len(p)) > u.remaining+1 {
+		p = p[:u.remaining+1]
+	}
+	n, err := u.r.Read(p)
+	u.remaining -= int64(n)
+	if u.remaining < 0 {
+		return n, ErrImportTooLarge
+	}
+	return n, err
+}
+
+func (s *service) GetImport(ctx context.Context, tableName string, importID uuid.UUID) (Import, error) {
+	ctx, span := tracing.Start(ctx, "entities.GetImport")
+	defer span.End()
+
+	record, err := s.resolveImport(ctx, tableName, importID)
+	if err != nil {
+		return Import{}, err
+	}
+	return mapImport(record)
+}
+
+func (s *service) ImportResults(ctx context.Context, tableName string, importID uuid.UUID, outcome string) (*ImportResults, error) {
+	ctx, span := tracing.Start(ctx, "entities.ImportResults")
+	defer span.End()
+
+	if outcome != "" && !slices.Contains([]seed.Outcome{seed.OutcomeAccepted, seed.OutcomeUpdated, seed.OutcomeUnchanged, seed.OutcomeRejected}, seed.Outcome(outcome)) {
+		return nil, &ValidationError{Reason: fmt.Sprintf("unsupported outcome %q", outcome)}
+	}
+	if _, err := s.resolveImport(ctx, tableName, importID); err != nil {
+		return nil, err
+	}
+	return &ImportResults{repo: s.repo, importID: importID, outcome: seed.Outcome(outcome)}, nil
+}
+
+// WriteTo streams the results to w as NDJSON and returns how many were written.
+func (r *ImportResults) WriteTo(ctx context.Context, w io.Writer) (int64, error) {
+	buffered := bufio.NewWriterSize(w, 64*1024)
+	encoder := json.NewEncoder(buffered)
+	var count int64
+	err := r.repo.EachImportResult(ctx, r.importID, string(r.outcome), func(result persistence.EntityImportResult) error {
+		count++
+		return encoder.Encode(importResultLine(result))
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, buffered.Flush()
+}
+
+// resolveImport returns the import after checking the caller may read its table.
+func (s *service) resolveImport(ctx context.Context, tableName string, importID uuid.UUID) (persistence.EntityImport, error) {
+	if strings.TrimSpace(tableName) == "" {
+		return persistence.EntityImport{}, &ValidationError{Reason: "tableName is required"}
+	}
+	if importID == uuid.Nil {
+		return persistence.EntityImport{}, &ValidationError{Reason: "importId is required"}
+	}
+
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionRead); err != nil {
+		return persistence.EntityImport{}, err
+	}
+
+	record, err := s.repo.GetImport(ctx, importID)
+	if err != nil {
+		return persistence.EntityImport{}, translateError(err)
+	}
+	if record.TableName != tableName {
+		return persistence.EntityImport{}, ErrImportNotFound
+	}
+	return record, nil
+}
+
+func normalizeImportOptions(opts ImportOptions) (ImportOptions, error) {
+	if opts.Format == "" {
+		opts.Format = seed.FormatNDJSON
+	}
+	if opts.Format != seed.FormatNDJSON && opts.Format != seed.FormatCSV {
+		return opts, &ValidationError{Reason: fmt.Sprintf("unsupported import format %q", opts.Format)}
+	}
+
+	opts.KeyField = strings.TrimSpace(opts.KeyField)
+	if opts.KeyField == "" {
+		return opts, &ValidationError{Reason: "keyField is required"}
+	}
+	if !persistence.IsPayloadPath(opts.KeyField) {
+		return opts, &ValidationError{Reason: fmt.Sprintf("invalid keyField %q", opts.KeyField)}
+	}
+
+	if err := opts.EntityID.Validate(); err != nil {
+		return opts, &ValidationError{Reason: err.Error()}
+	}
+	if opts.EntityID == "" {
+		opts.EntityID = seed.EntityIDKey
+	}
+	if opts.Namespace != uuid.Nil && opts.EntityID != seed.EntityIDUUID {
+		return opts, &ValidationError{Reason: "namespace requires entityId=uuid"}
+	}
+	if opts.EntityID == seed.EntityIDUUID && opts.Namespace == uuid.Nil {
+		opts.Namespace = uuid.NameSpaceURL
+	}
+
+	if opts.SlugTemplate != "" {
+		if _, err := persistence.ParseSlugTemplate(opts.SlugTemplate); err != nil {
+			return opts, &ValidationError{Reason: fmt.Sprintf("invalid slugTemplate: %v", err)}
+		}
+	}
+
+	for _, field := range opts.Fields {
+		if !persistence.IsPayloadPath(field) || strings.Contains(field, ".") {
+			return opts, &ValidationError{Reason: fmt.Sprintf("invalid field %q; fields are top-level payload properties", field)}
+		}
+	}
+	return opts, nil
+}
+
+// runImport runs an attempt of an ImportKind job. An import still running was left behind by an
+// interrupted attempt and starts over: its results are dropped and its lines applied again, those
+// already written coming back unchanged. Failures of the import itself are recorded on it and end the
+// job; only a cancelled attempt (shutdown, timeout, cancelled job) leaves the import to the next one.
+func runImport(ctx context.Context, repo domainrepo.Repository, logger *zap.Logger, importID uuid.UUID) (ImportReport, error) {
+	record, err := repo.GetImport(ctx, importID)
+	if errors.Is(err, persistence.ErrEntityImportNotFound) {
+		return ImportReport{}, jobs.Permanent(err)
+	}
+	if err != nil {
+		return ImportReport{}, err
+	}
+	imp, err := mapImport(record)
+	if err != nil {
+		return ImportReport{}, jobs.Permanent(err)
+	}
+
+	logger = logger.With(zap.String("import", importID.String()), zap.String("table", record.TableName))
+	switch record.Status {
+	case persistence.EntityImportSucceeded, persistence.EntityImportFailed:
+		// An earlier attempt finished the import but not the job.
+		return importReport(imp, seed.Summary{Accepted: imp.Accepted, Updated: imp.Updated, Unchanged: imp.Unchanged, Rejected: imp.Rejected}), nil
+	case persistence.EntityImportRunning:
+		logger.Warn("restarting interrupted entity import")
+		if err := repo.ResetImport(ctx, importID); err != nil {
+			return ImportReport{}, err
+		}
+	}
+
+	if err := repo.StartImport(ctx, importID); err != nil {
+		return ImportReport{}, err
+	}
+
+	summary, err := applyImport(ctx, repo, logger, record, imp.Options)
+	if err != nil && ctx.Err() != nil {
+		return ImportReport{}, err
+	}
+	imp.Status = persistence.EntityImportSucceeded
+	if err != nil {
+		imp.Status = persistence.EntityImportFailed
+		logger.Warn("entity import failed", zap.Error(err))
+	}
+	if finishErr := repo.FinishImport(ctx, importID, imp.Status, err); finishErr != nil {
+		return ImportReport{}, finishErr
+	}
+	if err != nil {
+		return ImportReport{}, jobs.Permanent(err)
+	}
+
+	logger.Info("entity import finished",
+		zap.Int64("accepted", summary.Accepted),
+		zap.Int64("updated", summary.Updated),
+		zap.Int64("unchanged", summary.Unchanged),
+		zap.Int64("rejected", summary.Rejected),
+	)
+	return importReport(imp, summary), nil
+}
+
+func importReport(imp Import, summary seed.Summary) ImportReport {
+	return ImportReport{
+		ImportID:  imp.ImportID,
+		TableName: imp.TableName,
+		Status:    imp.Status,
+		Accepted:  summary.Accepted,
+		Updated:   summary.Updated,
+		Unchanged: summary.Unchanged,
+		Rejected:  summary.Rejected,
+	}
+}
+
+func applyImport(ctx context.Context, repo domainrepo.Repository, logger *zap.Logger, record persistence.EntityImport, opts ImportOptions) (seed.Summary, error) {
+	input, err := repo.OpenImportUpload(ctx, record.ImportID)
+	if err != nil {
+		return seed.Summary{}, fmt.Errorf("open import upload: %w", err)
+	}
+
+	recorder := &importRecorder{repo: repo, ctx: ctx, importID: record.ImportID}
+	seedOpts := seed.Options{
+		Format:      opts.Format,
+		Concurrency: importConcurrency,
+		KeyFunc:     seed.KeyAt(opts.KeyField),
+		Logger:      logger,
+		OnResult:    recorder.add,
+	}
+	if opts.EntityID == seed.EntityIDUUID {
+		seedOpts.Namespace = opts.Namespace
+	}
+	if rules := (seed.FieldRules{Allow: opts.Fields}); !rules.IsZero() {
+		seedOpts.Mutate = rules.Apply
+	}
+
+	summary, err := repo.Import(ctx, record.TableName, input, opts.SlugTemplate, seedOpts)
+	// Results of a failed run are kept too: they show how far the import got.
+	if flushErr := recorder.flush(); err == nil {
+		err = flushErr
+	}
+	return summary, err
+}
+
+// importRecorder buffers line results from the seed workers and stores them in batches.
+type importRecorder struct {
+	repo     domainrepo.Repository
+	ctx      context.Context
+	importID uuid.UUID
+
+	mu      sync.Mutex
+	pending []persistence.EntityImportResult
+}
+
+func (r *importRecorder) add(result seed.LineResult) error {
+	entry := persistence.EntityImportResult{Line: result.Line, EntityID: result.EntityID, Outcome: string(result.Outcome)}
+	if result.Err != nil {
+		entry.Error = result.Err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = append(r.pending, entry)
+	if len(r.pending) < importResultBatch {
+		return nil
+	}
+	return r.flushLocked()
+}
+
+func (r *importRecorder) flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.flushLocked()
+}
+
+func (r *importRecorder) flushLocked() error {
+	if len(r.pending) == 0 {
+		return nil
+	}
+	if err := r.repo.RecordImportResults(r.ctx, r.importID, r.pending); err != nil {
+		return fmt.Errorf("record import results: %w", err)
+	}
+	r.pending = r.pending[:0]
+	return nil
+}
+
+func mapImport(record persistence.EntityImport) (Import, error) {
+	var stored storedImportOptions
+	if err := json.Unmarshal(record.Options, &stored); err != nil {
+		return Import{}, fmt.Errorf("decode import options: %w", err)
+	}
+
+	result := Import{
+		ImportID:  record.ImportID,
+		TableName: record.TableName,
+		Status:    record.Status,
+		Options: ImportOptions{
+			Format:       seed.Format(record.Format),
+			KeyField:     stored.KeyField,
+			EntityID:     stored.EntityID,
+			SlugTemplate: stored.SlugTemplate,
+			Fields:       stored.Fields,
+		},
+		JobID:      record.JobID,
+		Accepted:   record.Accepted,
+		Updated:    record.Updated,
+		Unchanged:  record.Unchanged,
+		Rejected:   record.Rejected,
+		Error:      record.Error,
+		CreatedAt:  record.CreatedAt,
+		StartedAt:  record.StartedAt,
+		FinishedAt: record.FinishedAt,
+	}
+	if stored.Namespace != nil {
+		result.Options.Namespace = *stored.Namespace
+	}
+	return result, nil
+}
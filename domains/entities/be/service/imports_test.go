@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/jobs"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/seed"
+)
+
+func TestStartImportValidation(t *testing.T) {
+	svc := New(&stubRepository{})
+	ctx := context.Background()
+
+	cases := map[string]ImportOptions{
+		"missing key field":         {},
+		"invalid key field":         {KeyField: "set..code"},
+		"unsupported format":        {KeyField: "id", Format: "xml"},
+		"unsupported strategy":      {KeyField: "id", EntityID: "hash"},
+		"namespace without uuid":    {KeyField: "id", Namespace: uuid.New()},
+		"invalid slug template":     {KeyField: "id", SlugTemplate: "{name"},
+		"nested field in allowlist": {KeyField: "id", Fields: []string{"set.code"}},
+	}
+	for name, opts := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.StartImport(ctx, "cards_entities", strings.NewReader(""), opts)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestStartImportQueuesJob(t *testing.T) {
+	var stored string
+	repo := &stubRepository{
+		createImportFn: func(_ context.Context, params persistence.CreateEntityImportParams) (persistence.EntityImport, error) {
+			body, err := io.ReadAll(params.Upload)
+			require.NoError(t, err)
+			stored = string(body)
+			return persistence.EntityImport{ImportID: params.ImportID, TableName: params.TableName, Format: params.Format, Options: params.Options, Status: persistence.EntityImportQueued}, nil
+		},
+	}
+	queue := &recordingEnqueuer{}
+	svc := New(repo, WithJobs(queue))
+
+	imp, err := svc.StartImport(context.Background(), "cards_entities", strings.NewReader("{\"id\":\"a\"}\n"), ImportOptions{
+		KeyField:     "id",
+		EntityID:     seed.EntityIDUUID,
+		SlugTemplate: "{name}",
+	})
+	require.NoError(t, err)
+	require.Equal(t, persistence.EntityImportQueued, imp.Status)
+	require.Equal(t, seed.FormatNDJSON, imp.Options.Format)
+	require.Equal(t, uuid.NameSpaceURL, imp.Options.Namespace, "uuid ids default to the URL namespace")
+	require.Equal(t, "{\"id\":\"a\"}\n", stored)
+
+	require.Len(t, queue.params, 1)
+	require.Equal(t, ImportKind.Name, queue.params[0].Kind)
+	var payload ImportPayload
+	require.NoError(t, json.Unmarshal(queue.params[0].Payload, &payload))
+	require.Equal(t, imp.ImportID, payload.ImportID)
+	require.Equal(t, queue.params[0].JobID, repo.importJobs[imp.ImportID])
+	require.NotNil(t, imp.JobID)
+	require.Equal(t, queue.params[0].JobID, *imp.JobID, "the import names its job")
+}
+
+func TestStartImportRejectsLargeUploads(t *testing.T) {
+	queue := &recordingEnqueuer{}
+	svc := New(&stubRepository{
+		createImportFn: func(_ context.Context, params persistence.CreateEntityImportParams) (persistence.EntityImport, error) {
+			_, err := io.ReadAll(params.Upload)
+			return persistence.EntityImport{}, err
+		},
+	}, WithJobs(queue), WithMaxImportSize(8))
+
+	_, err := svc.StartImport(context.Background(), "cards_entities", strings.NewReader("{\"id\":\"abc\"}\n"), ImportOptions{KeyField: "id"})
+	require.ErrorIs(t, err, ErrImportTooLarge)
+	require.Empty(t, queue.params)
+}
+
+func TestRunImportAppliesStoredUpload(t *testing.T) {
+	importID := uuid.New()
+	status := persistence.EntityImportQueued
+	repo := &stubRepository{
+		uploads: map[uuid.UUID]string{importID: "{\"id\":\"a\"}\n"},
+		getImportFn: func(context.Context, uuid.UUID) (persistence.EntityImport, error) {
+			return persistence.EntityImport{
+				ImportID:  importID,
+				TableName: "cards_entities",
+				Format:    "ndjson",
+				Options:   []byte(`{"keyField":"id","entityId":"uuid","namespace":"6ba7b811-9dad-11d1-80b4-00c04fd430c8","slugTemplate":"{name}"}`),
+				Status:    status,
+			}, nil
+		},
+		importFn: func(_ context.Context, table string, input io.Reader, slugTemplate string, opts seed.Options) (seed.Summary, error) {
+			require.Equal(t, "cards_entities", table)
+			require.Equal(t, "{name}", slugTemplate)
+			body, err := io.ReadAll(input)
+			require.NoError(t, err)
+			require.Equal(t, "{\"id\":\"a\"}\n", string(body))
+			require.Equal(t, uuid.NameSpaceURL, opts.Namespace)
+			key, err := opts.KeyFunc(map[string]any{"id": "a"})
+			require.NoError(t, err)
+			require.Equal(t, "a", key)
+			return seed.Summary{Accepted: 1}, nil
+		},
+	}
+
+	report, err := runImport(context.Background(), repo, zap.NewNop(), importID)
+	require.NoError(t, err)
+	require.Equal(t, persistence.EntityImportSucceeded, report.Status)
+	require.EqualValues(t, 1, report.Accepted)
+	require.Equal(t, []string{"start", "succeeded"}, repo.importStatus)
+
+	// An import left running by a crashed attempt starts over.
+	status = persistence.EntityImportRunning
+	repo.importStatus = nil
+	_, err = runImport(context.Background(), repo, zap.NewNop(), importID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"reset", "start", "succeeded"}, repo.importStatus)
+
+	// A finished import is not run again.
+	status = persistence.EntityImportFailed
+	repo.importStatus = nil
+	_, err = runImport(context.Background(), repo, zap.NewNop(), importID)
+	require.NoError(t, err)
+	require.Empty(t, repo.importStatus)
+}
+
+func TestRunImportFailuresEndTheJob(t *testing.T) {
+	importID := uuid.New()
+	repo := &stubRepository{
+		getImportFn: func(context.Context, uuid.UUID) (persistence.EntityImport, error) {
+			return persistence.EntityImport{ImportID: importID, TableName: "cards_entities", Format: "ndjson", Options: []byte(`{"keyField":"id","entityId":"key"}`), Status: persistence.EntityImportQueued}, nil
+		},
+		importFn: func(context.Context, string, io.Reader, string, seed.Options) (seed.Summary, error) {
+			return seed.Summary{}, persistence.ErrSchemaNotFound
+		},
+	}
+
+	_, err := runImport(context.Background(), repo, zap.NewNop(), importID)
+	require.ErrorIs(t, err, persistence.ErrSchemaNotFound)
+	require.True(t, jobs.IsPermanent(err), "a failed import is recorded, not retried")
+	require.Equal(t, []string{"start", "failed"}, repo.importStatus)
+
+	// A cancelled attempt leaves the import running for the next one.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	repo.importStatus = nil
+	_, err = runImport(ctx, repo, zap.NewNop(), importID)
+	require.ErrorIs(t, err, persistence.ErrSchemaNotFound)
+	require.False(t, jobs.IsPermanent(err))
+	require.Equal(t, []string{"start"}, repo.importStatus)
+}
+
+func TestGetImportChecksTable(t *testing.T) {
+	importID := uuid.New()
+	repo := &stubRepository{
+		getImportFn: func(context.Context, uuid.UUID) (persistence.EntityImport, error) {
+			return persistence.EntityImport{ImportID: importID, TableName: "sets_entities", Options: []byte(`{"keyField":"id","entityId":"key"}`)}, nil
+		},
+	}
+	svc := New(repo)
+
+	_, err := svc.GetImport(context.Background(), "cards_entities", importID)
+	require.ErrorIs(t, err, ErrImportNotFound)
+
+	imp, err := svc.GetImport(context.Background(), "sets_entities", importID)
+	require.NoError(t, err)
+	require.Equal(t, "id", imp.Options.KeyField)
+
+	_, err = svc.ImportResults(context.Background(), "sets_entities", importID, "skipped")
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func TestImportRecorderBatches(t *testing.T) {
+	var batches [][]persistence.EntityImportResult
+	recorder := &importRecorder{repo: &recordingRepository{record: func(results []persistence.EntityImportResult) error {
+		batches = append(batches, append([]persistence.EntityImportResult(nil), results...))
+		return nil
+	}}}
+
+	for line := 1; line <= importResultBatch+1; line++ {
+		require.NoError(t, recorder.add(seed.LineResult{Line: line, Outcome: seed.OutcomeRejected, Err: errors.New("bad")}))
+	}
+	require.Len(t, batches, 1)
+	require.NoError(t, recorder.flush())
+	require.Len(t, batches, 2)
+	require.Len(t, batches[1], 1)
+	require.Equal(t, "bad", batches[1][0].Error)
+}
+
+type recordingRepository struct {
+	stubRepository
+	record func([]persistence.EntityImportResult) error
+}
+
+func (r *recordingRepository) RecordImportResults(_ context.Context, _ uuid.UUID, results []persistence.EntityImportResult) error {
+	return r.record(results)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/repo"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/jobs"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+// revalidateSampleSize caps the invalid documents listed in a revalidation report.
+const revalidateSampleSize = 20
+
+// RevalidateKind re-checks every active document of a table against the table's active schema version,
+// e.g. after a schema change.
+var RevalidateKind = jobs.Kind[RevalidatePayload]{Name: "entities.revalidate", MaxAttempts: 3, Timeout: 2 * time.Hour}
+
+// RevalidatePayload is the payload of RevalidateKind jobs.
+type RevalidatePayload struct {
+	TableName string `json:"tableName"`
+}
+
+// RevalidationReport is the result of a RevalidateKind job.
+type RevalidationReport struct {
+	TableName     string                `json:"tableName"`
+	SchemaVersion string                `json:"schemaVersion"`
+	Checked       int64                 `json:"checked"`
+	Invalid       int64                 `json:"invalid"`
+	Samples       []RevalidationFailure `json:"samples,omitempty"` // the first invalid documents
+}
+
+// RevalidationFailure describes a document that no longer matches the schema.
+type RevalidationFailure struct {
+	EntityID string `json:"entityId"`
+	Error    string `json:"error"`
+}
+
+// JobRef identifies a queued background job; see the /jobs API.
+type JobRef struct {
+	JobID     uuid.UUID
+	Kind      string
+	Status    string
+	CreatedAt time.Time
+}
+
+// WithJobs lets the service queue background jobs, such as imports and revalidations, on enqueuer.
+func WithJobs(enqueuer jobs.Enqueuer) Option {
+	return func(s *service) {
+		s.jobs = enqueuer
+	}
+}
+
+// RegisterJobs registers the entities job handlers; apps/api and apps/worker share it so either can run
+// the jobs the API queues.
+func RegisterJobs(registry *jobs.Registry, repo domainrepo.Repository, logger *zap.Logger) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	jobs.Handle(registry, RevalidateKind, func(ctx context.Context, run *jobs.Run, payload RevalidatePayload) (any, error) {
+		report, err := revalidate(ctx, repo, run, payload.TableName)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("entity revalidation finished",
+			zap.String("table", report.TableName),
+			zap.Int64("checked", report.Checked),
+			zap.Int64("invalid", report.Invalid),
+		)
+		return report, nil
+	})
+	jobs.Handle(registry, ImportKind, func(ctx context.Context, run *jobs.Run, payload ImportPayload) (any, error) {
+		return runImport(ctx, repo, logger, payload.ImportID)
+	})
+}
+
+func (s *service) StartRevalidation(ctx context.Context, tableName string) (JobRef, error) {
+	ctx, span := tracing.Start(ctx, "entities.StartRevalidation")
+	defer span.End()
+
+	if strings.TrimSpace(tableName) == "" {
+		return JobRef{}, &ValidationError{Reason: "tableName is required"}
+	}
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionRead); err != nil {
+		return JobRef{}, err
+	}
+	// Reject unknown tables now rather than failing the job later.
+	if _, err := s.repo.List(ctx, tableName, domainrepo.ListParams{Page: 1, PageSize: 1}); err != nil {
+		return JobRef{}, translateError(err)
+	}
+	if s.jobs == nil {
+		return JobRef{}, errors.New("background jobs are not configured")
+	}
+
+	job, err := jobs.Enqueue(ctx, s.jobs, RevalidateKind, RevalidatePayload{TableName: tableName})
+	if err != nil {
+		return JobRef{}, err
+	}
+	return JobRef{JobID: job.JobID, Kind: job.Kind, Status: string(job.Status), CreatedAt: job.CreatedAt}, nil
+}
+
+// revalidate validates the documents of a consistent snapshot of the table, reporting progress on run.
+func revalidate(ctx context.Context, repo domainrepo.Repository, run *jobs.Run, tableName string) (RevalidationReport, error) {
+	counted, err := repo.List(ctx, tableName, domainrepo.ListParams{Page: 1, PageSize: 1})
+	if err != nil {
+		if errors.Is(err, persistence.ErrSchemaNotFound) {
+			return RevalidationReport{}, jobs.Permanent(err)
+		}
+		return RevalidationReport{}, err
+	}
+
+	export, err := repo.Export(ctx, tableName, persistence.ExportEntitiesParams{})
+	if err != nil {
+		return RevalidationReport{}, err
+	}
+	defer func() {
+		_ = export.Close(context.WithoutCancel(ctx))
+	}()
+
+	report := RevalidationReport{TableName: tableName, SchemaVersion: export.Schema.VersionString()}
+	for {
+		records, err := export.Next(ctx)
+		if err != nil {
+			return RevalidationReport{}, err
+		}
+		if len(records) == 0 {
+			break
+		}
+
+		for _, record := range records {
+			report.Checked++
+			if err := repo.Validate(ctx, export.Schema, record.Payload); err != nil {
+				if ctx.Err() != nil {
+					return RevalidationReport{}, ctx.Err()
+				}
+				report.Invalid++
+				if len(report.Samples) < revalidateSampleSize {
+					report.Samples = append(report.Samples, RevalidationFailure{EntityID: record.EntityID, Error: err.Error()})
+				}
+			}
+		}
+
+		// The count predates the snapshot, so documents created since can push the ratio past 100.
+		if counted.Total > 0 {
+			run.SetProgress(float64(report.Checked)*100/float64(counted.Total),
+				fmt.Sprintf("%d documents checked, %d invalid", report.Checked, report.Invalid))
+		}
+	}
+	return report, nil
+}
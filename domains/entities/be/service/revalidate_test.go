@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+func TestStartRevalidationQueuesJob(t *testing.T) {
+	queue := &recordingEnqueuer{}
+	svc := New(&stubRepository{}, WithJobs(queue))
+	ctx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:     "firebase-uid",
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	job, err := svc.StartRevalidation(ctx, "cards_entities")
+	require.NoError(t, err)
+	require.Equal(t, RevalidateKind.Name, job.Kind)
+	require.Equal(t, string(persistence.JobQueued), job.Status)
+
+	require.Len(t, queue.params, 1)
+	require.Equal(t, RevalidateKind.MaxAttempts, queue.params[0].MaxAttempts)
+	require.Equal(t, "user:user-1", *queue.params[0].CreatedBy)
+	var payload RevalidatePayload
+	require.NoError(t, json.Unmarshal(queue.params[0].Payload, &payload))
+	require.Equal(t, "cards_entities", payload.TableName)
+}
+
+func TestStartRevalidationRejectsUnknownTables(t *testing.T) {
+	queue := &recordingEnqueuer{}
+	repo := &stubRepository{
+		listFn: func(context.Context, string, domainrepo.ListParams) (domainrepo.ListResult, error) {
+			return domainrepo.ListResult{}, persistence.ErrSchemaNotFound
+		},
+	}
+
+	_, err := New(repo, WithJobs(queue)).StartRevalidation(context.Background(), "missing_entities")
+	require.ErrorIs(t, err, ErrTableNotFound)
+	require.Empty(t, queue.params)
+}
+
+type recordingEnqueuer struct {
+	params []persistence.EnqueueJobParams
+}
+
+func (r *recordingEnqueuer) EnqueueJob(_ context.Context, params persistence.EnqueueJobParams) (persistence.Job, error) {
+	r.params = append(r.params, params)
+	return persistence.Job{JobID: params.JobID, Kind: params.Kind, Status: persistence.JobQueued}, nil
+}
@@ -5,15 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/santhosh-tekuri/jsonschema/v5"
+	"go.uber.org/zap"
 
 	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/jobs"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
 )
 
 // ValidationError captures payload validation issues surfaced by the JSON schema validator.
@@ -30,6 +35,10 @@ var (
 	ErrTableNotFound    = errors.New("table not found")
 	ErrDocumentNotFound = errors.New("document not found")
 	ErrConflict         = errors.New("entity conflict")
+	ErrSlugConflict     = errors.New("slug already in use")
+	ErrForbidden        = errors.New("entity access forbidden")
+	ErrImportNotFound   = errors.New("import not found")
+	ErrImportTooLarge   = errors.New("import upload too large")
 )
 
 // Document represents an entity record enriched for API rendering.
@@ -38,6 +47,7 @@ type Document struct {
 	EntityVersion persistence.SemanticVersion
 	SchemaID      uuid.UUID
 	SchemaVersion persistence.SemanticVersion
+	Slug          string
 	Payload       map[string]interface{}
 	CreatedAt     time.Time
 	IsActive      bool
@@ -65,28 +75,69 @@ type Service interface {
 	List(ctx context.Context, tableName string, opts ListOptions) (ListResult, error)
 	Create(ctx context.Context, tableName string, entityID *string, payload map[string]interface{}) (Document, error)
 	Get(ctx context.Context, tableName string, entityID string) (Document, error)
-	Update(ctx context.Context, tableName string, entityID string, payload map[string]interface{}) (Document, error)
+	// GetBySlug returns the document using slug; current is false when slug is a previous slug of the
+	// returned document, which callers answer with a redirect to doc.Slug.
+	GetBySlug(ctx context.Context, tableName string, slug string) (doc Document, current bool, err error)
+	// Update writes a new document version. A nil payload keeps the current body; a non-nil slug renames
+	// the document. At least one of them is required.
+	Update(ctx context.Context, tableName string, entityID string, payload map[string]interface{}, slug *string) (Document, error)
 	Delete(ctx context.Context, tableName string, entityID string) error
+	// Export opens a streaming export of the table's active documents; the caller must Close it.
+	Export(ctx context.Context, tableName string, opts ExportOptions) (*Export, error)
+	// StartImport stores the upload and queues an ImportKind job importing it; the returned import is queued
+	// and names the job.
+	StartImport(ctx context.Context, tableName string, input io.Reader, opts ImportOptions) (Import, error)
+	GetImport(ctx context.Context, tableName string, importID uuid.UUID) (Import, error)
+	// ImportResults opens the line results recorded so far, optionally only those with outcome.
+	ImportResults(ctx context.Context, tableName string, importID uuid.UUID, outcome string) (*ImportResults, error)
+	// StartRevalidation queues a RevalidateKind job checking the table's documents against its active schema.
+	StartRevalidation(ctx context.Context, tableName string) (JobRef, error)
 }
 
 type service struct {
-	repo domainrepo.Repository
+	repo          domainrepo.Repository
+	logger        *zap.Logger
+	jobs          jobs.Enqueuer
+	maxImportSize int64
+}
+
+// Option customizes the service.
+type Option func(*service)
+
+// WithLogger sets the logger for failures the service cannot return to its caller.
+func WithLogger(logger *zap.Logger) Option {
+	return func(s *service) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
 }
 
 // New constructs a Service instance.
-func New(repo domainrepo.Repository) Service {
+func New(repo domainrepo.Repository, opts ...Option) Service {
 	if repo == nil {
 		panic("entities repository is required")
 	}
 
-	return &service{repo: repo}
+	s := &service{repo: repo, logger: zap.NewNop(), maxImportSize: DefaultMaxImportSize}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *service) List(ctx context.Context, tableName string, opts ListOptions) (ListResult, error) {
+	ctx, span := tracing.Start(ctx, "entities.List")
+	defer span.End()
+
 	if strings.TrimSpace(tableName) == "" {
 		return ListResult{}, &ValidationError{Reason: "tableName is required"}
 	}
 
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionRead); err != nil {
+		return ListResult{}, err
+	}
+
 	page := opts.Page
 	if page < 1 {
 		page = 1
@@ -132,6 +183,9 @@ func (s *service) List(ctx context.Context, tableName string, opts ListOptions)
 }
 
 func (s *service) Create(ctx context.Context, tableName string, entityID *string, payload map[string]interface{}) (Document, error) {
+	ctx, span := tracing.Start(ctx, "entities.Create")
+	defer span.End()
+
 	if strings.TrimSpace(tableName) == "" {
 		return Document{}, &ValidationError{Reason: "tableName is required"}
 	}
@@ -139,6 +193,10 @@ func (s *service) Create(ctx context.Context, tableName string, entityID *string
 		return Document{}, &ValidationError{Reason: "payload is required"}
 	}
 
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionWrite); err != nil {
+		return Document{}, err
+	}
+
 	var desiredID string
 	if entityID != nil {
 		desiredID = strings.TrimSpace(*entityID)
@@ -161,6 +219,9 @@ func (s *service) Create(ctx context.Context, tableName string, entityID *string
 }
 
 func (s *service) Get(ctx context.Context, tableName string, entityID string) (Document, error) {
+	ctx, span := tracing.Start(ctx, "entities.Get")
+	defer span.End()
+
 	if strings.TrimSpace(tableName) == "" {
 		return Document{}, &ValidationError{Reason: "tableName is required"}
 	}
@@ -168,6 +229,10 @@ func (s *service) Get(ctx context.Context, tableName string, entityID string) (D
 		return Document{}, &ValidationError{Reason: "entityId is required"}
 	}
 
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionRead); err != nil {
+		return Document{}, err
+	}
+
 	record, err := s.repo.Get(ctx, tableName, entityID)
 	if err != nil {
 		return Document{}, translateError(err)
@@ -176,23 +241,66 @@ func (s *service) Get(ctx context.Context, tableName string, entityID string) (D
 	return mapRecord(record)
 }
 
-func (s *service) Update(ctx context.Context, tableName string, entityID string, payload map[string]interface{}) (Document, error) {
+func (s *service) GetBySlug(ctx context.Context, tableName string, slug string) (Document, bool, error) {
+	ctx, span := tracing.Start(ctx, "entities.GetBySlug")
+	defer span.End()
+
+	if strings.TrimSpace(tableName) == "" {
+		return Document{}, false, &ValidationError{Reason: "tableName is required"}
+	}
+	normalizedSlug, err := persistence.NormalizeSlug(slug)
+	if err != nil {
+		return Document{}, false, &ValidationError{Reason: err.Error()}
+	}
+
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionRead); err != nil {
+		return Document{}, false, err
+	}
+
+	record, current, err := s.repo.GetBySlug(ctx, tableName, normalizedSlug)
+	if err != nil {
+		return Document{}, false, translateError(err)
+	}
+
+	doc, err := mapRecord(record)
+	return doc, current, err
+}
+
+func (s *service) Update(ctx context.Context, tableName string, entityID string, payload map[string]interface{}, slug *string) (Document, error) {
+	ctx, span := tracing.Start(ctx, "entities.Update")
+	defer span.End()
+
 	if strings.TrimSpace(tableName) == "" {
 		return Document{}, &ValidationError{Reason: "tableName is required"}
 	}
 	if strings.TrimSpace(entityID) == "" {
 		return Document{}, &ValidationError{Reason: "entityId is required"}
 	}
-	if payload == nil {
-		return Document{}, &ValidationError{Reason: "payload is required"}
+	if payload == nil && slug == nil {
+		return Document{}, &ValidationError{Reason: "payload or slug is required"}
+	}
+	if slug != nil {
+		normalizedSlug, err := persistence.NormalizeSlug(*slug)
+		if err != nil {
+			return Document{}, &ValidationError{Reason: err.Error()}
+		}
+		slug = &normalizedSlug
 	}
 
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return Document{}, fmt.Errorf("encode payload: %w", err)
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionWrite); err != nil {
+		return Document{}, err
 	}
 
-	record, err := s.repo.Update(ctx, tableName, entityID, body)
+	var body json.RawMessage
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return Document{}, fmt.Errorf("encode payload: %w", err)
+		}
+		body = encoded
+	}
+
+	record, err := s.repo.Update(ctx, tableName, entityID, body, slug)
 	if err != nil {
 		return Document{}, translateError(err)
 	}
@@ -201,6 +309,9 @@ func (s *service) Update(ctx context.Context, tableName string, entityID string,
 }
 
 func (s *service) Delete(ctx context.Context, tableName string, entityID string) error {
+	ctx, span := tracing.Start(ctx, "entities.Delete")
+	defer span.End()
+
 	if strings.TrimSpace(tableName) == "" {
 		return &ValidationError{Reason: "tableName is required"}
 	}
@@ -208,6 +319,10 @@ func (s *service) Delete(ctx context.Context, tableName string, entityID string)
 		return &ValidationError{Reason: "entityId is required"}
 	}
 
+	if err := s.authorize(ctx, tableName, persistence.AccessPermissionWrite); err != nil {
+		return err
+	}
+
 	if err := s.repo.Delete(ctx, tableName, entityID); err != nil {
 		return translateError(err)
 	}
@@ -215,6 +330,39 @@ func (s *service) Delete(ctx context.Context, tableName string, entityID string)
 	return nil
 }
 
+// authorize applies the table's ACL entries (see persistence.AccessControlStore). Callers with the admin role
+// bypass ACLs. Callers who cannot read a restricted table get ErrTableNotFound so its existence is not
+// revealed; callers who can read but not write get ErrForbidden. Service accounts are additionally limited
+// to their table scope, which even the admin role does not bypass.
+func (s *service) authorize(ctx context.Context, tableName string, required persistence.AccessPermission) error {
+	creds, ok := platformauth.UserFromContext(ctx)
+	if ok && !creds.CanAccessTable(tableName) {
+		return ErrTableNotFound
+	}
+	if ok && creds.HasRole(platformauth.RoleAdmin) {
+		return nil
+	}
+
+	subject := persistence.AccessSubject{}
+	if ok && creds != nil {
+		subject = persistence.AccessSubject{UserID: creds.UserID}
+	}
+
+	decision, err := s.repo.ResolveAccess(ctx, tableName, subject)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case decision.Allows(required):
+		return nil
+	case decision.Allows(persistence.AccessPermissionRead):
+		return ErrForbidden
+	default:
+		return ErrTableNotFound
+	}
+}
+
 func mapRecord(record persistence.EntityRecord) (Document, error) {
 	var payload map[string]interface{}
 	if len(record.Payload) > 0 {
@@ -230,6 +378,7 @@ func mapRecord(record persistence.EntityRecord) (Document, error) {
 		EntityVersion: record.EntityVersion,
 		SchemaID:      record.SchemaID,
 		SchemaVersion: record.SchemaVersion,
+		Slug:          record.Slug,
 		Payload:       payload,
 		CreatedAt:     record.CreatedAt,
 		IsActive:      record.IsActive,
@@ -269,6 +418,10 @@ func translateError(err error) error {
 		return ErrDocumentNotFound
 	case errors.Is(err, persistence.ErrEntityAlreadyExists):
 		return ErrConflict
+	case errors.Is(err, persistence.ErrSlugTaken):
+		return ErrSlugConflict
+	case errors.Is(err, persistence.ErrEntityImportNotFound):
+		return ErrImportNotFound
 	default:
 		var validationErr *jsonschema.ValidationError
 		if errors.As(err, &validationErr) {
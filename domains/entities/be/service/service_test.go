@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,7 +12,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/entities/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/seed"
 )
 
 func TestService_ListSuccess(t *testing.T) {
@@ -68,12 +72,54 @@ func TestService_CreateNotFound(t *testing.T) {
 
 func TestService_UpdateRequiresPayload(t *testing.T) {
 	svc := New(&stubRepository{})
-	_, err := svc.Update(context.Background(), "cards_entities", "entity-123", nil)
+	_, err := svc.Update(context.Background(), "cards_entities", "entity-123", nil, nil)
 	require.Error(t, err)
 	var valErr *ValidationError
 	require.ErrorAs(t, err, &valErr)
 }
 
+func TestService_UpdateSlugOnly(t *testing.T) {
+	repo := &stubRepository{
+		updateFn: func(_ context.Context, _ string, _ string, payload json.RawMessage, slug *string) (persistence.EntityRecord, error) {
+			require.Nil(t, payload)
+			require.NotNil(t, slug)
+			require.Equal(t, "black-lotus-alpha", *slug)
+			return persistence.EntityRecord{}, persistence.ErrSlugTaken
+		},
+	}
+	svc := New(repo)
+
+	slug := " Black-Lotus-Alpha "
+	_, err := svc.Update(context.Background(), "cards_entities", "entity-123", nil, &slug)
+	require.ErrorIs(t, err, ErrSlugConflict)
+
+	invalid := "black lotus"
+	_, err = svc.Update(context.Background(), "cards_entities", "entity-123", nil, &invalid)
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+}
+
+func TestService_GetBySlugRedirect(t *testing.T) {
+	repo := &stubRepository{
+		slugFn: func(_ context.Context, table string, slug string) (persistence.EntityRecord, bool, error) {
+			require.Equal(t, "cards_entities", table)
+			if slug == "black-lotus" {
+				return persistence.EntityRecord{EntityID: "entity-1", Slug: "black-lotus-alpha", Payload: []byte(`{}`)}, false, nil
+			}
+			return persistence.EntityRecord{}, false, persistence.ErrEntityNotFound
+		},
+	}
+	svc := New(repo)
+
+	doc, current, err := svc.GetBySlug(context.Background(), "cards_entities", "black-lotus")
+	require.NoError(t, err)
+	require.False(t, current)
+	require.Equal(t, "black-lotus-alpha", doc.Slug)
+
+	_, _, err = svc.GetBySlug(context.Background(), "cards_entities", "mox-pearl")
+	require.ErrorIs(t, err, ErrDocumentNotFound)
+}
+
 func TestService_DeleteNotFound(t *testing.T) {
 	repo := &stubRepository{
 		deleteFn: func(context.Context, string, string) error {
@@ -85,12 +131,78 @@ func TestService_DeleteNotFound(t *testing.T) {
 	require.ErrorIs(t, err, ErrDocumentNotFound)
 }
 
+func TestService_AccessControl(t *testing.T) {
+	readOnly := &stubRepository{
+		accessFn: func(_ context.Context, table string, subject persistence.AccessSubject) (persistence.AccessDecision, error) {
+			require.Equal(t, "pricing_entities", table)
+			require.Equal(t, "user-1", subject.UserID)
+			return persistence.AccessDecision{Restricted: true, Permission: persistence.AccessPermissionRead}, nil
+		},
+	}
+	ctx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:     "firebase-uid",
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	svc := New(readOnly)
+	_, err := svc.Get(ctx, "pricing_entities", "entity-1")
+	require.NoError(t, err)
+	_, err = svc.Update(ctx, "pricing_entities", "entity-1", map[string]interface{}{"price": 1}, nil)
+	require.ErrorIs(t, err, ErrForbidden)
+
+	hidden := &stubRepository{
+		accessFn: func(context.Context, string, persistence.AccessSubject) (persistence.AccessDecision, error) {
+			return persistence.AccessDecision{Restricted: true}, nil
+		},
+	}
+	_, err = New(hidden).List(ctx, "pricing_entities", ListOptions{})
+	require.ErrorIs(t, err, ErrTableNotFound)
+
+	adminCtx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:    "admin-1",
+		Roles: []platformauth.Role{platformauth.RoleAdmin},
+	})
+	err = New(hidden).Delete(adminCtx, "pricing_entities", "entity-1")
+	require.NoError(t, err)
+}
+
+func TestService_ServiceAccountTableScope(t *testing.T) {
+	repo := &stubRepository{
+		accessFn: func(context.Context, string, persistence.AccessSubject) (persistence.AccessDecision, error) {
+			return persistence.AccessDecision{}, nil
+		},
+	}
+	ctx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:               "sa-1",
+		ServiceAccountID: "sa-1",
+		Roles:            []platformauth.Role{platformauth.RoleAdmin},
+		TableScope:       []string{"cards_entities"},
+	})
+
+	svc := New(repo)
+	_, err := svc.List(ctx, "cards_entities", ListOptions{})
+	require.NoError(t, err)
+	_, err = svc.List(ctx, "pricing_entities", ListOptions{})
+	require.ErrorIs(t, err, ErrTableNotFound)
+}
+
 type stubRepository struct {
 	listFn   func(context.Context, string, domainrepo.ListParams) (domainrepo.ListResult, error)
 	createFn func(context.Context, string, string, json.RawMessage) (persistence.EntityRecord, error)
 	getFn    func(context.Context, string, string) (persistence.EntityRecord, error)
-	updateFn func(context.Context, string, string, json.RawMessage) (persistence.EntityRecord, error)
+	slugFn   func(context.Context, string, string) (persistence.EntityRecord, bool, error)
+	updateFn func(context.Context, string, string, json.RawMessage, *string) (persistence.EntityRecord, error)
 	deleteFn func(context.Context, string, string) error
+	accessFn func(context.Context, string, persistence.AccessSubject) (persistence.AccessDecision, error)
+	exportFn func(context.Context, string, persistence.ExportEntitiesParams) (*persistence.EntityExport, error)
+
+	importFn       func(context.Context, string, io.Reader, string, seed.Options) (seed.Summary, error)
+	createImportFn func(context.Context, persistence.CreateEntityImportParams) (persistence.EntityImport, error)
+	getImportFn    func(context.Context, uuid.UUID) (persistence.EntityImport, error)
+	importJobs     map[uuid.UUID]uuid.UUID
+	uploads        map[uuid.UUID]string
+	importStatus   []string // StartImport, ResetImport and FinishImport calls, in order
 }
 
 func (s *stubRepository) List(ctx context.Context, table string, params domainrepo.ListParams) (domainrepo.ListResult, error) {
@@ -114,11 +226,18 @@ func (s *stubRepository) Get(ctx context.Context, table string, entityID string)
 	return s.getFn(ctx, table, entityID)
 }
 
-func (s *stubRepository) Update(ctx context.Context, table string, entityID string, payload json.RawMessage) (persistence.EntityRecord, error) {
+func (s *stubRepository) GetBySlug(ctx context.Context, table string, slug string) (persistence.EntityRecord, bool, error) {
+	if s.slugFn == nil {
+		return persistence.EntityRecord{}, true, nil
+	}
+	return s.slugFn(ctx, table, slug)
+}
+
+func (s *stubRepository) Update(ctx context.Context, table string, entityID string, payload json.RawMessage, slug *string) (persistence.EntityRecord, error) {
 	if s.updateFn == nil {
 		return persistence.EntityRecord{}, nil
 	}
-	return s.updateFn(ctx, table, entityID, payload)
+	return s.updateFn(ctx, table, entityID, payload, slug)
 }
 
 func (s *stubRepository) Delete(ctx context.Context, table string, entityID string) error {
@@ -127,3 +246,77 @@ func (s *stubRepository) Delete(ctx context.Context, table string, entityID stri
 	}
 	return s.deleteFn(ctx, table, entityID)
 }
+
+func (s *stubRepository) ResolveAccess(ctx context.Context, table string, subject persistence.AccessSubject) (persistence.AccessDecision, error) {
+	if s.accessFn == nil {
+		return persistence.AccessDecision{}, nil
+	}
+	return s.accessFn(ctx, table, subject)
+}
+
+func (s *stubRepository) Export(ctx context.Context, table string, params persistence.ExportEntitiesParams) (*persistence.EntityExport, error) {
+	if s.exportFn == nil {
+		return nil, nil
+	}
+	return s.exportFn(ctx, table, params)
+}
+
+func (s *stubRepository) Validate(context.Context, persistence.SchemaRecord, []byte) error {
+	return nil
+}
+
+func (s *stubRepository) Import(ctx context.Context, table string, input io.Reader, slugTemplate string, opts seed.Options) (seed.Summary, error) {
+	if s.importFn == nil {
+		return seed.Summary{}, nil
+	}
+	return s.importFn(ctx, table, input, slugTemplate, opts)
+}
+
+func (s *stubRepository) CreateImport(ctx context.Context, params persistence.CreateEntityImportParams) (persistence.EntityImport, error) {
+	if s.createImportFn == nil {
+		return persistence.EntityImport{ImportID: params.ImportID, TableName: params.TableName, Format: params.Format, Options: params.Options, Status: persistence.EntityImportQueued}, nil
+	}
+	return s.createImportFn(ctx, params)
+}
+
+func (s *stubRepository) GetImport(ctx context.Context, importID uuid.UUID) (persistence.EntityImport, error) {
+	if s.getImportFn == nil {
+		return persistence.EntityImport{}, persistence.ErrEntityImportNotFound
+	}
+	return s.getImportFn(ctx, importID)
+}
+
+func (s *stubRepository) SetImportJob(_ context.Context, importID, jobID uuid.UUID) error {
+	if s.importJobs == nil {
+		s.importJobs = map[uuid.UUID]uuid.UUID{}
+	}
+	s.importJobs[importID] = jobID
+	return nil
+}
+
+func (s *stubRepository) OpenImportUpload(_ context.Context, importID uuid.UUID) (io.Reader, error) {
+	return strings.NewReader(s.uploads[importID]), nil
+}
+
+func (s *stubRepository) StartImport(context.Context, uuid.UUID) error {
+	s.importStatus = append(s.importStatus, "start")
+	return nil
+}
+
+func (s *stubRepository) ResetImport(context.Context, uuid.UUID) error {
+	s.importStatus = append(s.importStatus, "reset")
+	return nil
+}
+
+func (s *stubRepository) FinishImport(_ context.Context, _ uuid.UUID, status persistence.EntityImportStatus, _ error) error {
+	s.importStatus = append(s.importStatus, string(status))
+	return nil
+}
+
+func (s *stubRepository) RecordImportResults(context.Context, uuid.UUID, []persistence.EntityImportResult) error {
+	return nil
+}
+
+func (s *stubRepository) EachImportResult(context.Context, uuid.UUID, string, func(persistence.EntityImportResult) error) error {
+	return nil
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/jobs/be/service"
+	externalRef2 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	externalRef3 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
+	jobsapi "github.com/zenGate-Global/palmyra-pro-saas/generated/go/jobs"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+)
+
+const (
+	problemTypeValidation = "https://palmyra.pro/problems/validation-error"
+	problemTypeNotFound   = "https://palmyra.pro/problems/not-found"
+	problemTypeConflict   = "https://palmyra.pro/problems/conflict"
+	problemTypeInternal   = "https://palmyra.pro/problems/internal-error"
+)
+
+type operation string
+
+const (
+	listOperation   operation = "listJobs"
+	getOperation    operation = "getJob"
+	cancelOperation operation = "cancelJob"
+	eventsOperation operation = "streamJobEvents"
+)
+
+// Handler wires the jobs service to the generated HTTP contract.
+type Handler struct {
+	svc    service.Service
+	logger *zap.Logger
+}
+
+// New constructs a Handler instance.
+func New(svc service.Service, logger *zap.Logger) *Handler {
+	if svc == nil {
+		panic("jobs service is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+
+	return &Handler{svc: svc, logger: logger}
+}
+
+func (h *Handler) ListJobs(ctx context.Context, request jobsapi.ListJobsRequestObject) (jobsapi.ListJobsResponseObject, error) {
+	opts := service.ListOptions{Kind: request.Params.Kind}
+	if request.Params.Page != nil {
+		opts.Page = int(*request.Params.Page)
+	}
+	if request.Params.PageSize != nil {
+		opts.PageSize = int(*request.Params.PageSize)
+	}
+	if request.Params.Status != nil {
+		status := string(*request.Params.Status)
+		opts.Status = &status
+	}
+
+	result, err := h.svc.List(ctx, opts)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, listOperation)
+		return jobsapi.ListJobsdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	items := make([]jobsapi.Job, 0, len(result.Items))
+	for _, job := range result.Items {
+		items = append(items, toAPIJob(job))
+	}
+
+	return jobsapi.ListJobs200JSONResponse{
+		Items:      items,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalItems: result.TotalItems,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+func (h *Handler) GetJob(ctx context.Context, request jobsapi.GetJobRequestObject) (jobsapi.GetJobResponseObject, error) {
+	job, err := h.svc.Get(ctx, uuid.UUID(request.JobId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, getOperation)
+		return jobsapi.GetJobdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return jobsapi.GetJob200JSONResponse(toAPIJob(job)), nil
+}
+
+func (h *Handler) CancelJob(ctx context.Context, request jobsapi.CancelJobRequestObject) (jobsapi.CancelJobResponseObject, error) {
+	job, err := h.svc.Cancel(ctx, uuid.UUID(request.JobId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, cancelOperation)
+		return jobsapi.CancelJobdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return jobsapi.CancelJob200JSONResponse(toAPIJob(job)), nil
+}
+
+func (h *Handler) StreamJobEvents(ctx context.Context, request jobsapi.StreamJobEventsRequestObject) (jobsapi.StreamJobEventsResponseObject, error) {
+	// Resolve the job first so a missing job still gets a problem response rather than an empty stream.
+	job, err := h.svc.Get(ctx, uuid.UUID(request.JobId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, eventsOperation)
+		return jobsapi.StreamJobEventsdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return jobEventsResponse{ctx: ctx, svc: h.svc, logger: h.loggerFrom(ctx), jobID: job.ID}, nil
+}
+
+// jobEventsResponse streams server-sent events until the job finishes or the client goes away.
+type jobEventsResponse struct {
+	ctx    context.Context
+	svc    service.Service
+	logger *zap.Logger
+	jobID  uuid.UUID
+}
+
+func (response jobEventsResponse) VisitStreamJobEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	send := func(event string, data []byte) error {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
+		}
+		// Writers that cannot flush still deliver the events, only later.
+		_ = controller.Flush()
+		return nil
+	}
+
+	err := response.svc.Watch(response.ctx, response.jobID, func(job service.Job) error {
+		data, err := json.Marshal(toAPIJob(job))
+		if err != nil {
+			return err
+		}
+		return send("job", data)
+	})
+	if err == nil {
+		err = send("done", []byte("{}"))
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		response.logger.Warn("job event stream failed", zap.String("job_id", response.jobID.String()), zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
+func toAPIJob(job service.Job) jobsapi.Job {
+	apiJob := jobsapi.Job{
+		JobId:           externalRef2.UUID(job.ID),
+		Kind:            job.Kind,
+		Status:          jobsapi.JobStatus(job.Status),
+		Attempts:        job.Attempts,
+		MaxAttempts:     job.MaxAttempts,
+		Progress:        job.Progress,
+		ProgressMessage: job.ProgressMessage,
+		Error:           job.Error,
+		CancelRequested: job.CancelRequested,
+		CreatedBy:       job.CreatedBy,
+		RunAt:           optionalTimestamp(&job.RunAt),
+		CreatedAt:       externalRef2.Timestamp(job.CreatedAt),
+		StartedAt:       optionalTimestamp(job.StartedAt),
+		FinishedAt:      optionalTimestamp(job.FinishedAt),
+		UpdatedAt:       externalRef2.Timestamp(job.UpdatedAt),
+	}
+	if job.Payload != nil {
+		apiJob.Payload = &job.Payload
+	}
+	if job.Result != nil {
+		apiJob.Result = &job.Result
+	}
+	return apiJob
+}
+
+func optionalTimestamp(t *time.Time) *externalRef2.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	ts := externalRef2.Timestamp(*t)
+	return &ts
+}
+
+func (h *Handler) problemForError(ctx context.Context, err error, op operation) (int, externalRef3.ProblemDetails) {
+	status, title, detail, problemType, fieldErrors := h.classifyError(err)
+
+	logger := h.loggerFrom(ctx)
+	fields := []zap.Field{
+		zap.String("operation", string(op)),
+		zap.Int("status", status),
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		logger.Error("job operation failed", append(fields, zap.Error(err))...)
+	case status == http.StatusNotFound:
+		logger.Info("job not found", append(fields, zap.Error(err))...)
+	default:
+		logger.Warn("job request rejected", append(fields, zap.Error(err))...)
+	}
+
+	return status, h.buildProblem(title, detail, problemType, status, fieldErrors)
+}
+
+func (h *Handler) classifyError(err error) (status int, title, detail, problemType string, fieldErrors service.FieldErrors) {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest,
+			"Validation failed",
+			"one or more fields are invalid",
+			problemTypeValidation,
+			validationErr.Fields
+	case errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound,
+			"Job not found",
+			"job not found",
+			problemTypeNotFound,
+			nil
+	case errors.Is(err, service.ErrFinished):
+		return http.StatusConflict,
+			"Job already finished",
+			"only queued or running jobs can be cancelled",
+			problemTypeConflict,
+			nil
+	default:
+		return http.StatusInternalServerError,
+			"Internal server error",
+			"an unexpected error occurred",
+			problemTypeInternal,
+			nil
+	}
+}
+
+func (h *Handler) buildProblem(title, detail, problemType string, status int, fieldErrors service.FieldErrors) externalRef3.ProblemDetails {
+	problem := externalRef3.ProblemDetails{
+		Title:  title,
+		Status: status,
+	}
+
+	if detail != "" {
+		problem.Detail = &detail
+	}
+	if problemType != "" {
+		problem.Type = &problemType
+	}
+
+	if len(fieldErrors) > 0 {
+		copied := make(map[string][]string, len(fieldErrors))
+		for field, messages := range fieldErrors {
+			copied[field] = append([]string(nil), messages...)
+		}
+		problem.Errors = &copied
+	}
+
+	return problem
+}
+
+func (h *Handler) loggerFrom(ctx context.Context) *zap.Logger {
+	if logger, ok := platformlogging.FromContext(ctx); ok {
+		return logger
+	}
+	return h.logger
+}
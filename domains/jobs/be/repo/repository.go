@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// Repository exposes persistence operations required by the jobs service.
+type Repository interface {
+	List(ctx context.Context, params persistence.ListJobsParams) (persistence.ListJobsResult, error)
+	Get(ctx context.Context, id uuid.UUID) (persistence.Job, error)
+	Cancel(ctx context.Context, id uuid.UUID) (persistence.Job, error)
+}
+
+type postgresRepository struct {
+	store *persistence.JobStore
+}
+
+// NewPostgresRepository builds a Repository backed by the shared persistence layer.
+func NewPostgresRepository(store *persistence.JobStore) Repository {
+	if store == nil {
+		panic("job store is required")
+	}
+	return &postgresRepository{store: store}
+}
+
+func (r *postgresRepository) List(ctx context.Context, params persistence.ListJobsParams) (persistence.ListJobsResult, error) {
+	return r.store.ListJobs(ctx, params)
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID) (persistence.Job, error) {
+	return r.store.GetJob(ctx, id)
+}
+
+func (r *postgresRepository) Cancel(ctx context.Context, id uuid.UUID) (persistence.Job, error) {
+	return r.store.CancelJob(ctx, id)
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/jobs/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+// FieldErrors maps request fields to validation issues.
+type FieldErrors map[string][]string
+
+// ValidationError captures input validation problems surfaced by the service.
+type ValidationError struct {
+	Fields FieldErrors
+}
+
+func (v *ValidationError) Error() string {
+	return "validation error"
+}
+
+// Domain-level error sentinel values.
+var (
+	ErrNotFound = errors.New("job not found")
+	ErrFinished = errors.New("job already finished")
+)
+
+const defaultWatchInterval = time.Second
+
+// Job is a background job as seen by API callers.
+type Job struct {
+	ID              uuid.UUID
+	Kind            string
+	Payload         map[string]any
+	Status          string
+	Attempts        int
+	MaxAttempts     int
+	Progress        float64
+	ProgressMessage *string
+	Result          map[string]any
+	Error           *string
+	CancelRequested bool
+	CreatedBy       *string
+	RunAt           time.Time
+	CreatedAt       time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	UpdatedAt       time.Time
+}
+
+// Finished reports whether the job reached a final status.
+func (j Job) Finished() bool {
+	return persistence.JobStatus(j.Status).Final()
+}
+
+// ListOptions filters and paginates jobs.
+type ListOptions struct {
+	Page     int
+	PageSize int
+	Kind     *string
+	Status   *string
+}
+
+// ListResult is a page of jobs.
+type ListResult struct {
+	Items      []Job
+	Page       int
+	PageSize   int
+	TotalItems int
+	TotalPages int
+}
+
+// Service exposes the jobs domain operations. Callers without the admin role only see the jobs they queued.
+type Service interface {
+	List(ctx context.Context, opts ListOptions) (ListResult, error)
+	Get(ctx context.Context, id uuid.UUID) (Job, error)
+	Cancel(ctx context.Context, id uuid.UUID) (Job, error)
+	// Watch calls emit with the job now and whenever its status or progress changes, until the job has
+	// finished, emit fails or ctx is done.
+	Watch(ctx context.Context, id uuid.UUID, emit func(Job) error) error
+}
+
+// Option customises the service.
+type Option func(*service)
+
+// WithWatchInterval sets how often Watch polls for changes.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(s *service) {
+		if interval > 0 {
+			s.watchInterval = interval
+		}
+	}
+}
+
+type service struct {
+	repo          domainrepo.Repository
+	watchInterval time.Duration
+}
+
+// New builds a jobs Service backed by the provided repository.
+func New(repo domainrepo.Repository, opts ...Option) Service {
+	if repo == nil {
+		panic("job repository is required")
+	}
+	s := &service{repo: repo, watchInterval: defaultWatchInterval}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) List(ctx context.Context, opts ListOptions) (ListResult, error) {
+	ctx, span := tracing.Start(ctx, "jobs.List")
+	defer span.End()
+
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = 20
+	}
+	if opts.PageSize > 100 {
+		return ListResult{}, &ValidationError{Fields: FieldErrors{"pageSize": {"must be at most 100"}}}
+	}
+
+	params := persistence.ListJobsParams{Page: opts.Page, PageSize: opts.PageSize, Kind: opts.Kind}
+	if opts.Status != nil {
+		status := persistence.JobStatus(*opts.Status)
+		params.Status = &status
+	}
+	if owner, restricted := ownerFilter(ctx); restricted {
+		params.CreatedBy = &owner
+	}
+
+	result, err := s.repo.List(ctx, params)
+	if err != nil {
+		return ListResult{}, translateError(err)
+	}
+
+	items := make([]Job, 0, len(result.Jobs))
+	for _, record := range result.Jobs {
+		items = append(items, mapJob(record))
+	}
+	return ListResult{
+		Items:      items,
+		Page:       opts.Page,
+		PageSize:   opts.PageSize,
+		TotalItems: result.TotalItems,
+		TotalPages: int(math.Ceil(float64(result.TotalItems) / float64(opts.PageSize))),
+	}, nil
+}
+
+func (s *service) Get(ctx context.Context, id uuid.UUID) (Job, error) {
+	ctx, span := tracing.Start(ctx, "jobs.Get")
+	defer span.End()
+
+	record, err := s.get(ctx, id)
+	if err != nil {
+		return Job{}, err
+	}
+	return mapJob(record), nil
+}
+
+func (s *service) Cancel(ctx context.Context, id uuid.UUID) (Job, error) {
+	ctx, span := tracing.Start(ctx, "jobs.Cancel")
+	defer span.End()
+
+	if _, err := s.get(ctx, id); err != nil {
+		return Job{}, err
+	}
+	record, err := s.repo.Cancel(ctx, id)
+	if err != nil {
+		return Job{}, translateError(err)
+	}
+	return mapJob(record), nil
+}
+
+func (s *service) Watch(ctx context.Context, id uuid.UUID, emit func(Job) error) error {
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	var last time.Time
+	for {
+		record, err := s.get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !record.UpdatedAt.Equal(last) {
+			last = record.UpdatedAt
+			if err := emit(mapJob(record)); err != nil {
+				return err
+			}
+		}
+		if record.Status.Final() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// get loads the job and hides it from callers who may not see it.
+func (s *service) get(ctx context.Context, id uuid.UUID) (persistence.Job, error) {
+	if id == uuid.Nil {
+		return persistence.Job{}, ErrNotFound
+	}
+
+	record, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return persistence.Job{}, translateError(err)
+	}
+	if owner, restricted := ownerFilter(ctx); restricted && (record.CreatedBy == nil || *record.CreatedBy != owner) {
+		return persistence.Job{}, ErrNotFound
+	}
+	return record, nil
+}
+
+// ownerFilter returns the principal whose jobs the caller is limited to; admins are not restricted.
+func ownerFilter(ctx context.Context) (string, bool) {
+	creds, ok := platformauth.UserFromContext(ctx)
+	if ok && creds.HasRole(platformauth.RoleAdmin) {
+		return "", false
+	}
+	return creds.PrincipalKey(), true
+}
+
+func translateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, persistence.ErrJobNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrJobFinished):
+		return ErrFinished
+	default:
+		return err
+	}
+}
+
+func mapJob(record persistence.Job) Job {
+	return Job{
+		ID:              record.JobID,
+		Kind:            record.Kind,
+		Payload:         decodeObject(record.Payload),
+		Status:          string(record.Status),
+		Attempts:        record.Attempts,
+		MaxAttempts:     record.MaxAttempts,
+		Progress:        record.Progress,
+		ProgressMessage: record.ProgressMessage,
+		Result:          decodeObject(record.Result),
+		Error:           record.Error,
+		CancelRequested: record.CancelRequested,
+		CreatedBy:       record.CreatedBy,
+		RunAt:           record.RunAt,
+		CreatedAt:       record.CreatedAt,
+		StartedAt:       record.StartedAt,
+		FinishedAt:      record.FinishedAt,
+		UpdatedAt:       record.UpdatedAt,
+	}
+}
+
+// decodeObject returns raw as a JSON object, or nil when it is empty or not an object.
+func decodeObject(raw json.RawMessage) map[string]any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var object map[string]any
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil
+	}
+	return object
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+func userContext(userID string, roles ...platformauth.Role) context.Context {
+	return platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{Id: userID, UserID: userID, Roles: roles})
+}
+
+func TestListRestrictsNonAdminsToTheirJobs(t *testing.T) {
+	repo := &stubRepository{}
+	svc := New(repo)
+
+	res, err := svc.List(userContext("user-1", platformauth.RoleUser), ListOptions{PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Page)
+	require.NotNil(t, repo.listParams.CreatedBy)
+	require.Equal(t, "user:user-1", *repo.listParams.CreatedBy)
+
+	_, err = svc.List(userContext("admin-1", platformauth.RoleAdmin), ListOptions{})
+	require.NoError(t, err)
+	require.Nil(t, repo.listParams.CreatedBy)
+	require.Equal(t, 20, repo.listParams.PageSize)
+}
+
+func TestGetHidesOtherCallersJobs(t *testing.T) {
+	owner := "user:user-1"
+	job := persistence.Job{JobID: uuid.New(), Kind: "entities.revalidate", Status: persistence.JobRunning, CreatedBy: &owner, Payload: []byte(`{"tableName":"cards_entities"}`)}
+	svc := New(&stubRepository{jobs: []persistence.Job{job}})
+
+	got, err := svc.Get(userContext("user-1", platformauth.RoleUser), job.JobID)
+	require.NoError(t, err)
+	require.Equal(t, "cards_entities", got.Payload["tableName"])
+
+	_, err = svc.Get(userContext("user-2", platformauth.RoleUser), job.JobID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = svc.Cancel(userContext("user-2", platformauth.RoleUser), job.JobID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = svc.Get(userContext("admin-1", platformauth.RoleAdmin), job.JobID)
+	require.NoError(t, err)
+}
+
+func TestCancelFinishedJob(t *testing.T) {
+	job := persistence.Job{JobID: uuid.New(), Status: persistence.JobSucceeded}
+	svc := New(&stubRepository{jobs: []persistence.Job{job}})
+
+	_, err := svc.Cancel(userContext("admin-1", platformauth.RoleAdmin), job.JobID)
+	require.ErrorIs(t, err, ErrFinished)
+}
+
+func TestWatchEmitsChangesUntilFinished(t *testing.T) {
+	id := uuid.New()
+	base := time.Now()
+	repo := &stubRepository{sequence: []persistence.Job{
+		{JobID: id, Status: persistence.JobRunning, Progress: 10, UpdatedAt: base},
+		{JobID: id, Status: persistence.JobRunning, Progress: 10, UpdatedAt: base},
+		{JobID: id, Status: persistence.JobRunning, Progress: 60, UpdatedAt: base.Add(time.Second)},
+		{JobID: id, Status: persistence.JobSucceeded, Progress: 100, UpdatedAt: base.Add(2 * time.Second)},
+	}}
+	svc := New(repo, WithWatchInterval(time.Millisecond))
+
+	var progress []float64
+	err := svc.Watch(userContext("admin-1", platformauth.RoleAdmin), id, func(job Job) error {
+		progress = append(progress, job.Progress)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []float64{10, 60, 100}, progress, "unchanged polls are not emitted")
+}
+
+type stubRepository struct {
+	jobs       []persistence.Job
+	sequence   []persistence.Job // returned by successive Get calls
+	listParams persistence.ListJobsParams
+}
+
+func (s *stubRepository) List(_ context.Context, params persistence.ListJobsParams) (persistence.ListJobsResult, error) {
+	s.listParams = params
+	return persistence.ListJobsResult{Jobs: s.jobs, TotalItems: len(s.jobs)}, nil
+}
+
+func (s *stubRepository) Get(_ context.Context, id uuid.UUID) (persistence.Job, error) {
+	if len(s.sequence) > 0 {
+		job := s.sequence[0]
+		if len(s.sequence) > 1 {
+			s.sequence = s.sequence[1:]
+		}
+		return job, nil
+	}
+	for _, job := range s.jobs {
+		if job.JobID == id {
+			return job, nil
+		}
+	}
+	return persistence.Job{}, persistence.ErrJobNotFound
+}
+
+func (s *stubRepository) Cancel(ctx context.Context, id uuid.UUID) (persistence.Job, error) {
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return persistence.Job{}, err
+	}
+	if job.Status.Final() {
+		return persistence.Job{}, persistence.ErrJobFinished
+	}
+	job.CancelRequested = true
+	return job, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-categories/be/repo"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
 )
 
 // FieldErrors maps request fields to validation issues.
@@ -81,6 +82,9 @@ func New(repo domainrepo.Repository) Service {
 }
 
 func (s *service) List(ctx context.Context, includeDeleted bool) ([]Category, error) {
+	ctx, span := tracing.Start(ctx, "schema-categories.List")
+	defer span.End()
+
 	records, err := s.repo.List(ctx, includeDeleted)
 	if err != nil {
 		return nil, err
@@ -95,6 +99,9 @@ func (s *service) List(ctx context.Context, includeDeleted bool) ([]Category, er
 }
 
 func (s *service) Create(ctx context.Context, input CreateInput) (Category, error) {
+	ctx, span := tracing.Start(ctx, "schema-categories.Create")
+	defer span.End()
+
 	if err := s.ensureParentExists(ctx, input.ParentID, uuid.Nil); err != nil {
 		return Category{}, err
 	}
@@ -124,6 +131,9 @@ func (s *service) Create(ctx context.Context, input CreateInput) (Category, erro
 }
 
 func (s *service) Get(ctx context.Context, id uuid.UUID) (Category, error) {
+	ctx, span := tracing.Start(ctx, "schema-categories.Get")
+	defer span.End()
+
 	record, err := s.repo.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, persistence.ErrSchemaNotFound) {
@@ -136,6 +146,9 @@ func (s *service) Get(ctx context.Context, id uuid.UUID) (Category, error) {
 }
 
 func (s *service) Update(ctx context.Context, id uuid.UUID, input UpdateInput) (Category, error) {
+	ctx, span := tracing.Start(ctx, "schema-categories.Update")
+	defer span.End()
+
 	if id == uuid.Nil {
 		return Category{}, ErrNotFound
 	}
@@ -172,6 +185,9 @@ func (s *service) Update(ctx context.Context, id uuid.UUID, input UpdateInput) (
 }
 
 func (s *service) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "schema-categories.Delete")
+	defer span.End()
+
 	if id == uuid.Nil {
 		return ErrNotFound
 	}
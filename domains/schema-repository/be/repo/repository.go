@@ -19,18 +19,23 @@ type Repository interface {
 	GetLatestBySlug(ctx context.Context, slug string) (persistence.SchemaRecord, error)
 	Activate(ctx context.Context, schemaID uuid.UUID, version persistence.SemanticVersion) error
 	SoftDelete(ctx context.Context, schemaID uuid.UUID, version persistence.SemanticVersion, deletedAt time.Time) error
+	ResolveAccess(ctx context.Context, subject persistence.AccessSubject, tableNames []string) (map[string]persistence.AccessDecision, error)
 }
 
 type postgresRepository struct {
-	store *persistence.SchemaRepositoryStore
+	store       *persistence.SchemaRepositoryStore
+	accessStore *persistence.AccessControlStore
 }
 
 // NewPostgresRepository constructs a Repository backed by the shared persistence layer.
-func NewPostgresRepository(store *persistence.SchemaRepositoryStore) Repository {
+func NewPostgresRepository(store *persistence.SchemaRepositoryStore, accessStore *persistence.AccessControlStore) Repository {
 	if store == nil {
 		panic("schema repository store is required")
 	}
-	return &postgresRepository{store: store}
+	if accessStore == nil {
+		panic("access control store is required")
+	}
+	return &postgresRepository{store: store, accessStore: accessStore}
 }
 
 func (r *postgresRepository) Upsert(ctx context.Context, params persistence.CreateSchemaParams) (persistence.SchemaRecord, error) {
@@ -64,3 +69,7 @@ func (r *postgresRepository) Activate(ctx context.Context, schemaID uuid.UUID, v
 func (r *postgresRepository) SoftDelete(ctx context.Context, schemaID uuid.UUID, version persistence.SemanticVersion, deletedAt time.Time) error {
 	return r.store.SoftDeleteSchema(ctx, schemaID, version, deletedAt)
 }
+
+func (r *postgresRepository) ResolveAccess(ctx context.Context, subject persistence.AccessSubject, tableNames []string) (map[string]persistence.AccessDecision, error) {
+	return r.accessStore.ResolveTableAccess(ctx, subject, tableNames)
+}
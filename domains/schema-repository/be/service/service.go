@@ -13,7 +13,9 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 
 	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-repository/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
 )
 
 // FieldErrors maps request fields to validation issues.
@@ -88,6 +90,9 @@ func New(repo domainrepo.Repository) Service {
 }
 
 func (s *service) Create(ctx context.Context, input CreateInput) (Schema, error) {
+	ctx, span := tracing.Start(ctx, "schema-repository.Create")
+	defer span.End()
+
 	normalized, validationErr := s.validateCreateInput(input)
 	if validationErr != nil {
 		return Schema{}, validationErr
@@ -132,6 +137,9 @@ func (s *service) Create(ctx context.Context, input CreateInput) (Schema, error)
 }
 
 func (s *service) List(ctx context.Context, schemaID uuid.UUID, includeDeleted bool) ([]Schema, error) {
+	ctx, span := tracing.Start(ctx, "schema-repository.List")
+	defer span.End()
+
 	if schemaID == uuid.Nil {
 		return nil, ErrNotFound
 	}
@@ -156,23 +164,72 @@ func (s *service) List(ctx context.Context, schemaID uuid.UUID, includeDeleted b
 }
 
 func (s *service) ListAll(ctx context.Context, includeInactive bool) ([]Schema, error) {
+	ctx, span := tracing.Start(ctx, "schema-repository.ListAll")
+	defer span.End()
+
 	records, err := s.repo.ListAll(ctx, includeInactive)
 	if err != nil {
 		return nil, err
 	}
 
+	decisions, err := s.resolveAccess(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	// Service accounts only see the tables in their scope.
+	creds, _ := platformauth.UserFromContext(ctx)
+
 	results := make([]Schema, 0, len(records))
 	for _, record := range records {
 		if !includeInactive && !record.IsActive {
 			continue
 		}
+		if !creds.CanAccessTable(record.TableName) {
+			continue
+		}
+		if decisions != nil && !decisions[record.TableName].Allows(persistence.AccessPermissionRead) {
+			continue
+		}
 		results = append(results, mapRecord(record))
 	}
 
 	return results, nil
 }
 
+// resolveAccess returns the caller's read decisions for the tables backing the
+// given records. A nil map means the caller bypasses access control lists.
+func (s *service) resolveAccess(ctx context.Context, records []persistence.SchemaRecord) (map[string]persistence.AccessDecision, error) {
+	creds, ok := platformauth.UserFromContext(ctx)
+	if ok && creds.HasRole(platformauth.RoleAdmin) {
+		return nil, nil
+	}
+	if len(records) == 0 {
+		return map[string]persistence.AccessDecision{}, nil
+	}
+
+	subject := persistence.AccessSubject{}
+	if ok && creds != nil {
+		subject = persistence.AccessSubject{UserID: creds.UserID}
+	}
+
+	seen := make(map[string]struct{}, len(records))
+	tableNames := make([]string, 0, len(records))
+	for _, record := range records {
+		if _, dup := seen[record.TableName]; dup {
+			continue
+		}
+		seen[record.TableName] = struct{}{}
+		tableNames = append(tableNames, record.TableName)
+	}
+
+	return s.repo.ResolveAccess(ctx, subject, tableNames)
+}
+
 func (s *service) Get(ctx context.Context, schemaID uuid.UUID, version persistence.SemanticVersion) (Schema, error) {
+	ctx, span := tracing.Start(ctx, "schema-repository.Get")
+	defer span.End()
+
 	if schemaID == uuid.Nil {
 		return Schema{}, ErrNotFound
 	}
@@ -189,6 +246,9 @@ func (s *service) Get(ctx context.Context, schemaID uuid.UUID, version persisten
 }
 
 func (s *service) GetActive(ctx context.Context, schemaID uuid.UUID) (Schema, error) {
+	ctx, span := tracing.Start(ctx, "schema-repository.GetActive")
+	defer span.End()
+
 	if schemaID == uuid.Nil {
 		return Schema{}, ErrNotFound
 	}
@@ -205,6 +265,9 @@ func (s *service) GetActive(ctx context.Context, schemaID uuid.UUID) (Schema, er
 }
 
 func (s *service) Activate(ctx context.Context, schemaID uuid.UUID, version persistence.SemanticVersion) (Schema, error) {
+	ctx, span := tracing.Start(ctx, "schema-repository.Activate")
+	defer span.End()
+
 	if schemaID == uuid.Nil {
 		return Schema{}, ErrNotFound
 	}
@@ -228,6 +291,9 @@ func (s *service) Activate(ctx context.Context, schemaID uuid.UUID, version pers
 }
 
 func (s *service) Delete(ctx context.Context, schemaID uuid.UUID, version persistence.SemanticVersion) error {
+	ctx, span := tracing.Start(ctx, "schema-repository.Delete")
+	defer span.End()
+
 	if schemaID == uuid.Nil {
 		return ErrNotFound
 	}
@@ -280,6 +346,8 @@ func (s *service) validateCreateInput(input CreateInput) (normalizedCreateInput,
 		addFieldError(fieldErrors, "schemaDefinition", "schemaDefinition is required")
 	} else if !isJSONObject(input.Definition) {
 		addFieldError(fieldErrors, "schemaDefinition", "schemaDefinition must be a JSON object")
+	} else if _, _, err := persistence.SchemaSlugTemplate(persistence.SchemaDefinition(input.Definition)); err != nil {
+		addFieldError(fieldErrors, "schemaDefinition", err.Error())
 	}
 
 	if len(fieldErrors) > 0 {
@@ -374,6 +442,9 @@ func (s *service) translateUpsertError(err error) error {
 	if errors.Is(err, persistence.ErrSchemaNotFound) {
 		return ErrNotFound
 	}
+	if errors.Is(err, persistence.ErrSchemaConflict) {
+		return ErrConflict
+	}
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/schema-repository/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
 )
 
@@ -86,6 +87,23 @@ func TestServiceCreateRejectsSlugChange(t *testing.T) {
 	require.Contains(t, validationErr.Fields, "slug")
 }
 
+func TestServiceCreateRejectsInvalidSlugTemplate(t *testing.T) {
+	t.Parallel()
+
+	svc := New(newFakeRepository())
+
+	_, err := svc.Create(context.Background(), CreateInput{
+		Definition: json.RawMessage(`{"title":"schema-v1","x-slug-template":"{name"}`),
+		TableName:  "cards_entities",
+		Slug:       "cards-schema",
+		CategoryID: uuid.New(),
+	})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Contains(t, validationErr.Fields, "schemaDefinition")
+}
+
 func TestServiceListFiltersDeleted(t *testing.T) {
 	t.Parallel()
 
@@ -158,6 +176,57 @@ func TestServiceListAll(t *testing.T) {
 	require.Len(t, withDeleted, 2)
 }
 
+func TestServiceListAllFiltersByAccess(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeRepository()
+	svc := New(repo)
+
+	_, err := svc.Create(context.Background(), CreateInput{
+		Definition: json.RawMessage(`{"title":"prices"}`),
+		TableName:  "price_entities",
+		Slug:       "prices",
+		CategoryID: uuid.New(),
+	})
+	require.NoError(t, err)
+	_, err = svc.Create(context.Background(), CreateInput{
+		Definition: json.RawMessage(`{"title":"cards"}`),
+		TableName:  "cards_entities",
+		Slug:       "cards",
+		CategoryID: uuid.New(),
+	})
+	require.NoError(t, err)
+
+	repo.access = map[string]persistence.AccessDecision{
+		"price_entities": {Restricted: true},
+	}
+
+	userCtx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:    uuid.NewString(),
+		Roles: []platformauth.Role{platformauth.RoleUser},
+	})
+	visible, err := svc.ListAll(userCtx, false)
+	require.NoError(t, err)
+	require.Len(t, visible, 1)
+	require.Equal(t, "cards_entities", visible[0].TableName)
+
+	repo.access["price_entities"] = persistence.AccessDecision{Restricted: true, Permission: persistence.AccessPermissionRead}
+	visible, err = svc.ListAll(userCtx, false)
+	require.NoError(t, err)
+	require.Len(t, visible, 2)
+
+	repo.access["price_entities"] = persistence.AccessDecision{Restricted: true}
+	repo.resolved = nil
+	adminCtx := platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+		Id:    uuid.NewString(),
+		Roles: []platformauth.Role{platformauth.RoleAdmin},
+	})
+	visible, err = svc.ListAll(adminCtx, false)
+	require.NoError(t, err)
+	require.Len(t, visible, 2)
+	require.Empty(t, repo.resolved)
+}
+
 func TestServiceActivateSwitchesActiveVersion(t *testing.T) {
 	t.Parallel()
 
@@ -208,7 +277,9 @@ func extractTitle(t *testing.T, raw json.RawMessage) string {
 }
 
 type fakeRepository struct {
-	records map[uuid.UUID]map[string]persistence.SchemaRecord
+	records  map[uuid.UUID]map[string]persistence.SchemaRecord
+	access   map[string]persistence.AccessDecision
+	resolved [][]string
 }
 
 func newFakeRepository() *fakeRepository {
@@ -377,6 +448,15 @@ func (f *fakeRepository) SoftDelete(ctx context.Context, schemaID uuid.UUID, ver
 	return nil
 }
 
+func (f *fakeRepository) ResolveAccess(ctx context.Context, subject persistence.AccessSubject, tableNames []string) (map[string]persistence.AccessDecision, error) {
+	f.resolved = append(f.resolved, tableNames)
+	decisions := make(map[string]persistence.AccessDecision, len(tableNames))
+	for _, name := range tableNames {
+		decisions[name] = f.access[name]
+	}
+	return decisions, nil
+}
+
 func (f *fakeRepository) deactivateAll(schemaID uuid.UUID) {
 	schemaMap := f.records[schemaID]
 	for key, record := range schemaMap {
@@ -0,0 +1,401 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/service-accounts/be/service"
+	externalRef0 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/iam"
+	externalRef2 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	externalRef3 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
+	serviceaccounts "github.com/zenGate-Global/palmyra-pro-saas/generated/go/service-accounts"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+)
+
+const (
+	problemTypeValidation   = "https://palmyra.pro/problems/validation-error"
+	problemTypeNotFound     = "https://palmyra.pro/problems/not-found"
+	problemTypeConflict     = "https://palmyra.pro/problems/conflict"
+	problemTypeInternal     = "https://palmyra.pro/problems/internal-error"
+	serviceAccountsBasePath = "/api/v1/admin/service-accounts"
+)
+
+type operation string
+
+const (
+	listOperation      operation = "listServiceAccounts"
+	createOperation    operation = "createServiceAccount"
+	getOperation       operation = "getServiceAccount"
+	updateOperation    operation = "updateServiceAccount"
+	deleteOperation    operation = "deleteServiceAccount"
+	listKeysOperation  operation = "listServiceAccountKeys"
+	createKeyOperation operation = "createServiceAccountKey"
+	rotateKeyOperation operation = "rotateServiceAccountKey"
+	revokeKeyOperation operation = "revokeServiceAccountKey"
+)
+
+// Handler wires the service accounts service to the generated HTTP contract.
+type Handler struct {
+	svc    service.Service
+	logger *zap.Logger
+}
+
+// New constructs a Handler instance.
+func New(svc service.Service, logger *zap.Logger) *Handler {
+	if svc == nil {
+		panic("service accounts service is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+
+	return &Handler{svc: svc, logger: logger}
+}
+
+func (h *Handler) ListServiceAccounts(ctx context.Context, _ serviceaccounts.ListServiceAccountsRequestObject) (serviceaccounts.ListServiceAccountsResponseObject, error) {
+	accounts, err := h.svc.List(ctx)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, listOperation)
+		return serviceaccounts.ListServiceAccountsdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	items := make([]serviceaccounts.ServiceAccount, 0, len(accounts))
+	for _, account := range accounts {
+		items = append(items, toAPIServiceAccount(account))
+	}
+
+	return serviceaccounts.ListServiceAccounts200JSONResponse(serviceaccounts.ServiceAccountList{Items: items}), nil
+}
+
+func (h *Handler) CreateServiceAccount(ctx context.Context, request serviceaccounts.CreateServiceAccountRequestObject) (serviceaccounts.CreateServiceAccountResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return serviceaccounts.CreateServiceAccountdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: http.StatusBadRequest,
+		}, nil
+	}
+
+	input := service.CreateInput{
+		Name:        request.Body.Name,
+		Description: request.Body.Description,
+		Roles:       fromAPIRoles(request.Body.Roles),
+	}
+	if request.Body.Tables != nil {
+		input.Tables = fromAPITables(*request.Body.Tables)
+	}
+
+	account, err := h.svc.Create(ctx, input)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, createOperation)
+		return serviceaccounts.CreateServiceAccountdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	location := fmt.Sprintf("%s/%s", serviceAccountsBasePath, account.ID)
+	return serviceaccounts.CreateServiceAccount201JSONResponse{
+		Body:    toAPIServiceAccount(account),
+		Headers: serviceaccounts.CreateServiceAccount201ResponseHeaders{Location: location},
+	}, nil
+}
+
+func (h *Handler) GetServiceAccount(ctx context.Context, request serviceaccounts.GetServiceAccountRequestObject) (serviceaccounts.GetServiceAccountResponseObject, error) {
+	account, err := h.svc.Get(ctx, uuidFromExternal(request.ServiceAccountId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, getOperation)
+		return serviceaccounts.GetServiceAccountdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return serviceaccounts.GetServiceAccount200JSONResponse(toAPIServiceAccount(account)), nil
+}
+
+func (h *Handler) UpdateServiceAccount(ctx context.Context, request serviceaccounts.UpdateServiceAccountRequestObject) (serviceaccounts.UpdateServiceAccountResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return serviceaccounts.UpdateServiceAccountdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: http.StatusBadRequest,
+		}, nil
+	}
+
+	input := service.UpdateInput{
+		Name:        request.Body.Name,
+		Description: request.Body.Description,
+	}
+	if request.Body.Roles != nil {
+		input.Roles = fromAPIRoles(*request.Body.Roles)
+	}
+	if request.Body.Tables != nil {
+		tables := fromAPITables(*request.Body.Tables)
+		input.Tables = &tables
+	}
+
+	account, err := h.svc.Update(ctx, uuidFromExternal(request.ServiceAccountId), input)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, updateOperation)
+		return serviceaccounts.UpdateServiceAccountdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return serviceaccounts.UpdateServiceAccount200JSONResponse(toAPIServiceAccount(account)), nil
+}
+
+func (h *Handler) DeleteServiceAccount(ctx context.Context, request serviceaccounts.DeleteServiceAccountRequestObject) (serviceaccounts.DeleteServiceAccountResponseObject, error) {
+	if err := h.svc.Delete(ctx, uuidFromExternal(request.ServiceAccountId)); err != nil {
+		status, problem := h.problemForError(ctx, err, deleteOperation)
+		return serviceaccounts.DeleteServiceAccountdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return serviceaccounts.DeleteServiceAccount204Response{}, nil
+}
+
+func (h *Handler) ListServiceAccountKeys(ctx context.Context, request serviceaccounts.ListServiceAccountKeysRequestObject) (serviceaccounts.ListServiceAccountKeysResponseObject, error) {
+	keys, err := h.svc.ListKeys(ctx, uuidFromExternal(request.ServiceAccountId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, listKeysOperation)
+		return serviceaccounts.ListServiceAccountKeysdefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	items := make([]serviceaccounts.ApiKey, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, toAPIKey(key))
+	}
+
+	return serviceaccounts.ListServiceAccountKeys200JSONResponse(serviceaccounts.ApiKeyList{Items: items}), nil
+}
+
+func (h *Handler) CreateServiceAccountKey(ctx context.Context, request serviceaccounts.CreateServiceAccountKeyRequestObject) (serviceaccounts.CreateServiceAccountKeyResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return serviceaccounts.CreateServiceAccountKeydefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: http.StatusBadRequest,
+		}, nil
+	}
+
+	input := service.CreateKeyInput{Name: request.Body.Name}
+	if request.Body.ExpiresAt != nil {
+		expiresAt := time.Time(*request.Body.ExpiresAt)
+		input.ExpiresAt = &expiresAt
+	}
+
+	key, err := h.svc.CreateKey(ctx, uuidFromExternal(request.ServiceAccountId), input)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, createKeyOperation)
+		return serviceaccounts.CreateServiceAccountKeydefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return serviceaccounts.CreateServiceAccountKey201JSONResponse(toAPIKeySecret(key)), nil
+}
+
+func (h *Handler) RotateServiceAccountKey(ctx context.Context, request serviceaccounts.RotateServiceAccountKeyRequestObject) (serviceaccounts.RotateServiceAccountKeyResponseObject, error) {
+	key, err := h.svc.RotateKey(ctx, uuidFromExternal(request.ServiceAccountId), uuidFromExternal(request.KeyId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, rotateKeyOperation)
+		return serviceaccounts.RotateServiceAccountKeydefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return serviceaccounts.RotateServiceAccountKey201JSONResponse(toAPIKeySecret(key)), nil
+}
+
+func (h *Handler) RevokeServiceAccountKey(ctx context.Context, request serviceaccounts.RevokeServiceAccountKeyRequestObject) (serviceaccounts.RevokeServiceAccountKeyResponseObject, error) {
+	if err := h.svc.RevokeKey(ctx, uuidFromExternal(request.ServiceAccountId), uuidFromExternal(request.KeyId)); err != nil {
+		status, problem := h.problemForError(ctx, err, revokeKeyOperation)
+		return serviceaccounts.RevokeServiceAccountKeydefaultApplicationProblemPlusJSONResponse{
+			Body:       problem,
+			StatusCode: status,
+		}, nil
+	}
+
+	return serviceaccounts.RevokeServiceAccountKey204Response{}, nil
+}
+
+func toAPIServiceAccount(account service.ServiceAccount) serviceaccounts.ServiceAccount {
+	roles := make([]externalRef0.UserRole, 0, len(account.Roles))
+	for _, role := range account.Roles {
+		roles = append(roles, externalRef0.UserRole(role))
+	}
+
+	apiAccount := serviceaccounts.ServiceAccount{
+		ServiceAccountId: externalRef2.UUID(account.ID),
+		Name:             account.Name,
+		Description:      account.Description,
+		Roles:            roles,
+		CreatedAt:        externalRef2.Timestamp(account.CreatedAt),
+		UpdatedAt:        externalRef2.Timestamp(account.UpdatedAt),
+	}
+
+	if account.Tables != nil {
+		tables := make([]externalRef2.TableName, 0, len(account.Tables))
+		for _, table := range account.Tables {
+			tables = append(tables, externalRef2.TableName(table))
+		}
+		apiAccount.Tables = &tables
+	}
+
+	return apiAccount
+}
+
+func toAPIKey(key service.Key) serviceaccounts.ApiKey {
+	return serviceaccounts.ApiKey{
+		KeyId:      externalRef2.UUID(key.ID),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		CreatedAt:  externalRef2.Timestamp(key.CreatedAt),
+		ExpiresAt:  optionalTimestamp(key.ExpiresAt),
+		LastUsedAt: optionalTimestamp(key.LastUsedAt),
+		RevokedAt:  optionalTimestamp(key.RevokedAt),
+	}
+}
+
+func toAPIKeySecret(key service.IssuedKey) serviceaccounts.ApiKeySecret {
+	apiKey := toAPIKey(key.Key)
+	return serviceaccounts.ApiKeySecret{
+		KeyId:      apiKey.KeyId,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		CreatedAt:  apiKey.CreatedAt,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+		Secret:     key.Secret,
+	}
+}
+
+func fromAPIRoles(roles []externalRef0.UserRole) []string {
+	values := make([]string, 0, len(roles))
+	for _, role := range roles {
+		values = append(values, string(role))
+	}
+	return values
+}
+
+func fromAPITables(tables []externalRef2.TableName) []string {
+	values := make([]string, 0, len(tables))
+	for _, table := range tables {
+		values = append(values, string(table))
+	}
+	return values
+}
+
+func optionalTimestamp(t *time.Time) *externalRef2.Timestamp {
+	if t == nil {
+		return nil
+	}
+	ts := externalRef2.Timestamp(*t)
+	return &ts
+}
+
+func uuidFromExternal(id externalRef2.UUID) uuid.UUID {
+	return uuid.UUID(id)
+}
+
+func (h *Handler) problemForError(ctx context.Context, err error, op operation) (int, externalRef3.ProblemDetails) {
+	status, title, detail, problemType, fieldErrors := h.classifyError(err)
+
+	logger := h.loggerFrom(ctx)
+	fields := []zap.Field{
+		zap.String("operation", string(op)),
+		zap.Int("status", status),
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		logger.Error("service account operation failed", append(fields, zap.Error(err))...)
+	case status == http.StatusNotFound:
+		logger.Info("service account resource not found", append(fields, zap.Error(err))...)
+	default:
+		logger.Warn("service account request rejected", append(fields, zap.Error(err))...)
+	}
+
+	return status, h.buildProblem(title, detail, problemType, status, fieldErrors)
+}
+
+func (h *Handler) classifyError(err error) (status int, title, detail, problemType string, fieldErrors service.FieldErrors) {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest,
+			"Validation failed",
+			"one or more fields are invalid",
+			problemTypeValidation,
+			validationErr.Fields
+	case errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound,
+			"Resource not found",
+			"service account or api key not found",
+			problemTypeNotFound,
+			nil
+	case errors.Is(err, service.ErrConflict):
+		return http.StatusConflict,
+			"Conflict",
+			"service account name is taken or the api key is revoked",
+			problemTypeConflict,
+			nil
+	default:
+		return http.StatusInternalServerError,
+			"Internal server error",
+			"an unexpected error occurred",
+			problemTypeInternal,
+			nil
+	}
+}
+
+func (h *Handler) buildProblem(title, detail, problemType string, status int, fieldErrors service.FieldErrors) externalRef3.ProblemDetails {
+	problem := externalRef3.ProblemDetails{
+		Title:  title,
+		Status: status,
+	}
+
+	if detail != "" {
+		problem.Detail = &detail
+	}
+	if problemType != "" {
+		problem.Type = &problemType
+	}
+
+	if len(fieldErrors) > 0 {
+		copied := make(map[string][]string, len(fieldErrors))
+		for field, messages := range fieldErrors {
+			copied[field] = append([]string(nil), messages...)
+		}
+		problem.Errors = &copied
+	}
+
+	return problem
+}
+
+func (h *Handler) loggerFrom(ctx context.Context) *zap.Logger {
+	if logger, ok := platformlogging.FromContext(ctx); ok {
+		return logger
+	}
+	return h.logger
+}
@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// Repository exposes persistence operations required by the service accounts service.
+type Repository interface {
+	List(ctx context.Context) ([]persistence.ServiceAccount, error)
+	Get(ctx context.Context, id uuid.UUID) (persistence.ServiceAccount, error)
+	Create(ctx context.Context, params persistence.CreateServiceAccountParams) (persistence.ServiceAccount, error)
+	Update(ctx context.Context, id uuid.UUID, params persistence.UpdateServiceAccountParams) (persistence.ServiceAccount, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]persistence.APIKey, error)
+	CreateKey(ctx context.Context, params persistence.CreateAPIKeyParams) (persistence.APIKey, error)
+	RotateKey(ctx context.Context, serviceAccountID, keyID uuid.UUID, replacement persistence.CreateAPIKeyParams) (persistence.APIKey, error)
+	RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error
+	GetKeyByPrefix(ctx context.Context, prefix string) (persistence.APIKey, error)
+	TouchKey(ctx context.Context, keyID uuid.UUID) error
+	TableExists(ctx context.Context, tableName string) (bool, error)
+}
+
+type postgresRepository struct {
+	store       *persistence.ServiceAccountStore
+	schemaStore *persistence.SchemaRepositoryStore
+}
+
+// NewPostgresRepository builds a Repository backed by the shared persistence layer.
+func NewPostgresRepository(store *persistence.ServiceAccountStore, schemaStore *persistence.SchemaRepositoryStore) Repository {
+	if store == nil {
+		panic("service account store is required")
+	}
+	if schemaStore == nil {
+		panic("schema repository store is required")
+	}
+	return &postgresRepository{store: store, schemaStore: schemaStore}
+}
+
+func (r *postgresRepository) List(ctx context.Context) ([]persistence.ServiceAccount, error) {
+	return r.store.ListServiceAccounts(ctx)
+}
+
+func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID) (persistence.ServiceAccount, error) {
+	return r.store.GetServiceAccount(ctx, id)
+}
+
+func (r *postgresRepository) Create(ctx context.Context, params persistence.CreateServiceAccountParams) (persistence.ServiceAccount, error) {
+	return r.store.CreateServiceAccount(ctx, params)
+}
+
+func (r *postgresRepository) Update(ctx context.Context, id uuid.UUID, params persistence.UpdateServiceAccountParams) (persistence.ServiceAccount, error) {
+	return r.store.UpdateServiceAccount(ctx, id, params)
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.DeleteServiceAccount(ctx, id)
+}
+
+func (r *postgresRepository) ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]persistence.APIKey, error) {
+	return r.store.ListAPIKeys(ctx, serviceAccountID)
+}
+
+func (r *postgresRepository) CreateKey(ctx context.Context, params persistence.CreateAPIKeyParams) (persistence.APIKey, error) {
+	return r.store.CreateAPIKey(ctx, params)
+}
+
+func (r *postgresRepository) RotateKey(ctx context.Context, serviceAccountID, keyID uuid.UUID, replacement persistence.CreateAPIKeyParams) (persistence.APIKey, error) {
+	return r.store.RotateAPIKey(ctx, serviceAccountID, keyID, replacement)
+}
+
+func (r *postgresRepository) RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	return r.store.RevokeAPIKey(ctx, serviceAccountID, keyID)
+}
+
+func (r *postgresRepository) GetKeyByPrefix(ctx context.Context, prefix string) (persistence.APIKey, error) {
+	return r.store.GetAPIKeyByPrefix(ctx, prefix)
+}
+
+func (r *postgresRepository) TouchKey(ctx context.Context, keyID uuid.UUID) error {
+	return r.store.TouchAPIKey(ctx, keyID)
+}
+
+func (r *postgresRepository) TableExists(ctx context.Context, tableName string) (bool, error) {
+	if _, err := r.schemaStore.GetActiveSchemaByTableName(ctx, tableName); err != nil {
+		if errors.Is(err, persistence.ErrSchemaNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,513 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/service-accounts/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
+)
+
+// FieldErrors maps request fields to validation issues.
+type FieldErrors map[string][]string
+
+// ValidationError captures input validation problems surfaced by the service.
+type ValidationError struct {
+	Fields FieldErrors
+}
+
+func (v *ValidationError) Error() string {
+	return "validation error"
+}
+
+// Domain-level error sentinel values.
+var (
+	ErrNotFound = errors.New("service account resource not found")
+	ErrConflict = errors.New("service account conflict")
+)
+
+const maxNameLength = 128
+
+var tableNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ServiceAccount represents a machine client managed by the domain service.
+type ServiceAccount struct {
+	ID          uuid.UUID
+	Name        string
+	Description *string
+	Roles       []string
+	Tables      []string // nil means every table the roles allow
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CreateInput defines the payload required to create a service account.
+type CreateInput struct {
+	Name        string
+	Description *string
+	Roles       []string
+	Tables      []string
+}
+
+// UpdateInput lists the editable fields; nil fields are left untouched.
+type UpdateInput struct {
+	Name        *string
+	Description *string
+	Roles       []string
+	Tables      *[]string // an empty slice removes the restriction
+}
+
+// Key describes an API key without its secret.
+type Key struct {
+	ID         uuid.UUID
+	Name       string
+	Prefix     string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IssuedKey is a newly created API key; Secret is only available at creation time.
+type IssuedKey struct {
+	Key
+	Secret string
+}
+
+// CreateKeyInput defines the payload required to issue an API key.
+type CreateKeyInput struct {
+	Name      string
+	ExpiresAt *time.Time
+}
+
+// Service exposes the service accounts domain operations.
+type Service interface {
+	List(ctx context.Context) ([]ServiceAccount, error)
+	Get(ctx context.Context, id uuid.UUID) (ServiceAccount, error)
+	Create(ctx context.Context, input CreateInput) (ServiceAccount, error)
+	Update(ctx context.Context, id uuid.UUID, input UpdateInput) (ServiceAccount, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]Key, error)
+	CreateKey(ctx context.Context, serviceAccountID uuid.UUID, input CreateKeyInput) (IssuedKey, error)
+	RotateKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (IssuedKey, error)
+	RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error
+	// Authenticate resolves an API key into the credentials of its service account. It implements
+	// platformauth.APIKeyLookup.
+	Authenticate(ctx context.Context, key string) (*platformauth.UserCredentials, error)
+}
+
+type service struct {
+	repo domainrepo.Repository
+	now  func() time.Time
+}
+
+// New builds a service accounts Service backed by the provided repository.
+func New(repo domainrepo.Repository) Service {
+	if repo == nil {
+		panic("service account repository is required")
+	}
+	return &service{repo: repo, now: time.Now}
+}
+
+func (s *service) List(ctx context.Context) ([]ServiceAccount, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.List")
+	defer span.End()
+
+	records, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]ServiceAccount, 0, len(records))
+	for _, record := range records {
+		accounts = append(accounts, mapServiceAccount(record))
+	}
+	return accounts, nil
+}
+
+func (s *service) Get(ctx context.Context, id uuid.UUID) (ServiceAccount, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.Get")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return ServiceAccount{}, ErrNotFound
+	}
+
+	record, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return ServiceAccount{}, translateError(err)
+	}
+	return mapServiceAccount(record), nil
+}
+
+func (s *service) Create(ctx context.Context, input CreateInput) (ServiceAccount, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.Create")
+	defer span.End()
+
+	errs := FieldErrors{}
+	name := validateName(errs, "name", input.Name)
+	roles := validateRoles(errs, input.Roles)
+	tables, err := s.validateTables(ctx, errs, input.Tables)
+	if err != nil {
+		return ServiceAccount{}, err
+	}
+	if len(errs) > 0 {
+		return ServiceAccount{}, &ValidationError{Fields: errs}
+	}
+
+	record, err := s.repo.Create(ctx, persistence.CreateServiceAccountParams{
+		ServiceAccountID: uuid.New(),
+		Name:             name,
+		Description:      normalizeDescription(input.Description),
+		Roles:            roles,
+		TableScope:       tables,
+	})
+	if err != nil {
+		return ServiceAccount{}, translateError(err)
+	}
+
+	return mapServiceAccount(record), nil
+}
+
+func (s *service) Update(ctx context.Context, id uuid.UUID, input UpdateInput) (ServiceAccount, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.Update")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return ServiceAccount{}, ErrNotFound
+	}
+
+	errs := FieldErrors{}
+	params := persistence.UpdateServiceAccountParams{}
+	if input.Name != nil {
+		name := validateName(errs, "name", *input.Name)
+		params.Name = &name
+	}
+	if input.Description != nil {
+		description := strings.TrimSpace(*input.Description)
+		params.Description = &description
+	}
+	if input.Roles != nil {
+		params.Roles = validateRoles(errs, input.Roles)
+	}
+	if input.Tables != nil {
+		tables, err := s.validateTables(ctx, errs, *input.Tables)
+		if err != nil {
+			return ServiceAccount{}, err
+		}
+		params.TableScope = &tables
+	}
+
+	if params.Name == nil && params.Description == nil && params.Roles == nil && params.TableScope == nil {
+		errs.add("body", "at least one field must be provided")
+	}
+	if len(errs) > 0 {
+		return ServiceAccount{}, &ValidationError{Fields: errs}
+	}
+
+	record, err := s.repo.Update(ctx, id, params)
+	if err != nil {
+		return ServiceAccount{}, translateError(err)
+	}
+
+	return mapServiceAccount(record), nil
+}
+
+func (s *service) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "service-accounts.Delete")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return ErrNotFound
+	}
+
+	return translateError(s.repo.Delete(ctx, id))
+}
+
+func (s *service) ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]Key, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.ListKeys")
+	defer span.End()
+
+	if _, err := s.Get(ctx, serviceAccountID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.ListKeys(ctx, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, mapKey(record))
+	}
+	return keys, nil
+}
+
+func (s *service) CreateKey(ctx context.Context, serviceAccountID uuid.UUID, input CreateKeyInput) (IssuedKey, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.CreateKey")
+	defer span.End()
+
+	if serviceAccountID == uuid.Nil {
+		return IssuedKey{}, ErrNotFound
+	}
+
+	errs := FieldErrors{}
+	name := validateName(errs, "name", input.Name)
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(s.now()) {
+		errs.add("expiresAt", "expiresAt must be in the future")
+	}
+	if len(errs) > 0 {
+		return IssuedKey{}, &ValidationError{Fields: errs}
+	}
+
+	generated, err := platformauth.GenerateAPIKey()
+	if err != nil {
+		return IssuedKey{}, err
+	}
+
+	record, err := s.repo.CreateKey(ctx, persistence.CreateAPIKeyParams{
+		KeyID:            uuid.New(),
+		ServiceAccountID: serviceAccountID,
+		Name:             name,
+		Prefix:           generated.Prefix,
+		SecretHash:       generated.Hash,
+		ExpiresAt:        input.ExpiresAt,
+	})
+	if err != nil {
+		return IssuedKey{}, translateError(err)
+	}
+
+	return IssuedKey{Key: mapKey(record), Secret: generated.Key}, nil
+}
+
+func (s *service) RotateKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (IssuedKey, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.RotateKey")
+	defer span.End()
+
+	if serviceAccountID == uuid.Nil || keyID == uuid.Nil {
+		return IssuedKey{}, ErrNotFound
+	}
+
+	generated, err := platformauth.GenerateAPIKey()
+	if err != nil {
+		return IssuedKey{}, err
+	}
+
+	record, err := s.repo.RotateKey(ctx, serviceAccountID, keyID, persistence.CreateAPIKeyParams{
+		KeyID:            uuid.New(),
+		ServiceAccountID: serviceAccountID,
+		Prefix:           generated.Prefix,
+		SecretHash:       generated.Hash,
+	})
+	if err != nil {
+		return IssuedKey{}, translateError(err)
+	}
+
+	return IssuedKey{Key: mapKey(record), Secret: generated.Key}, nil
+}
+
+func (s *service) RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "service-accounts.RevokeKey")
+	defer span.End()
+
+	if serviceAccountID == uuid.Nil || keyID == uuid.Nil {
+		return ErrNotFound
+	}
+
+	return translateError(s.repo.RevokeKey(ctx, serviceAccountID, keyID))
+}
+
+func (s *service) Authenticate(ctx context.Context, key string) (*platformauth.UserCredentials, error) {
+	ctx, span := tracing.Start(ctx, "service-accounts.Authenticate")
+	defer span.End()
+
+	prefix, ok := platformauth.APIKeyPrefixOf(key)
+	if !ok {
+		return nil, platformauth.ErrInvalidAPIKey
+	}
+
+	record, err := s.repo.GetKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, persistence.ErrAPIKeyNotFound) {
+			return nil, platformauth.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if !platformauth.APIKeyMatches(key, record.SecretHash) {
+		return nil, platformauth.ErrInvalidAPIKey
+	}
+	if record.RevokedAt != nil || (record.ExpiresAt != nil && !record.ExpiresAt.After(s.now())) {
+		return nil, platformauth.ErrInvalidAPIKey
+	}
+
+	// The key tells which tenant the account lives in; the account itself is tenant-scoped.
+	ctx = tenant.WithID(ctx, record.TenantID)
+	account, err := s.repo.Get(ctx, record.ServiceAccountID)
+	if err != nil {
+		if errors.Is(err, persistence.ErrServiceAccountNotFound) {
+			return nil, platformauth.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if err = s.repo.TouchKey(ctx, record.KeyID); err != nil {
+		return nil, err
+	}
+
+	roles := make([]platformauth.Role, 0, len(account.Roles))
+	for _, raw := range account.Roles {
+		if role, known := platformauth.ParseRole(raw); known {
+			roles = append(roles, role)
+		}
+	}
+
+	id := account.ServiceAccountID.String()
+	name := account.Name
+	tenantID := account.TenantID
+	creds := &platformauth.UserCredentials{
+		Id:               id,
+		Name:             &name,
+		TenantID:         &tenantID,
+		Roles:            roles,
+		ServiceAccountID: id,
+		TableScope:       account.TableScope,
+	}
+	creds.IsAdmin = creds.HasRole(platformauth.RoleAdmin)
+
+	return creds, nil
+}
+
+func validateName(errs FieldErrors, field, raw string) string {
+	name := strings.TrimSpace(raw)
+	switch {
+	case name == "":
+		errs.add(field, field+" is required")
+	case utf8.RuneCountInString(name) > maxNameLength:
+		errs.add(field, fmt.Sprintf("%s must be at most %d characters", field, maxNameLength))
+	}
+	return name
+}
+
+func validateRoles(errs FieldErrors, raw []string) []string {
+	if len(raw) == 0 {
+		errs.add("roles", "at least one role is required")
+		return nil
+	}
+
+	seen := make(map[platformauth.Role]struct{}, len(raw))
+	roles := make([]string, 0, len(raw))
+	for _, value := range raw {
+		role, ok := platformauth.ParseRole(value)
+		if !ok {
+			errs.add("roles", fmt.Sprintf("unsupported role %q", value))
+			continue
+		}
+		if _, dup := seen[role]; dup {
+			continue
+		}
+		seen[role] = struct{}{}
+		roles = append(roles, string(role))
+	}
+	return roles
+}
+
+// validateTables normalizes the table scope and checks every table has an active schema. A nil or
+// empty input yields nil, which leaves the account unrestricted.
+func (s *service) validateTables(ctx context.Context, errs FieldErrors, raw []string) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]struct{}, len(raw))
+	tables := make([]string, 0, len(raw))
+	for _, value := range raw {
+		tableName := strings.ToLower(strings.TrimSpace(value))
+		if !tableNamePattern.MatchString(tableName) {
+			errs.add("tables", fmt.Sprintf("invalid table name %q", value))
+			continue
+		}
+		if _, dup := seen[tableName]; dup {
+			continue
+		}
+		seen[tableName] = struct{}{}
+
+		exists, err := s.repo.TableExists(ctx, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			errs.add("tables", fmt.Sprintf("table %q not found", tableName))
+			continue
+		}
+		tables = append(tables, tableName)
+	}
+	return tables, nil
+}
+
+func normalizeDescription(description *string) *string {
+	if description == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*description)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func translateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, persistence.ErrServiceAccountNotFound), errors.Is(err, persistence.ErrAPIKeyNotFound):
+		return ErrNotFound
+	case errors.Is(err, persistence.ErrServiceAccountConflict), errors.Is(err, persistence.ErrAPIKeyRevoked):
+		return ErrConflict
+	default:
+		return err
+	}
+}
+
+func mapServiceAccount(record persistence.ServiceAccount) ServiceAccount {
+	return ServiceAccount{
+		ID:          record.ServiceAccountID,
+		Name:        record.Name,
+		Description: record.Description,
+		Roles:       record.Roles,
+		Tables:      record.TableScope,
+		CreatedAt:   record.CreatedAt,
+		UpdatedAt:   record.UpdatedAt,
+	}
+}
+
+func mapKey(record persistence.APIKey) Key {
+	return Key{
+		ID:         record.KeyID,
+		Name:       record.Name,
+		Prefix:     record.Prefix,
+		CreatedAt:  record.CreatedAt,
+		ExpiresAt:  record.ExpiresAt,
+		LastUsedAt: record.LastUsedAt,
+		RevokedAt:  record.RevokedAt,
+	}
+}
+
+func (f FieldErrors) add(field, message string) {
+	if _, ok := f[field]; !ok {
+		f[field] = []string{message}
+		return
+	}
+	f[field] = append(f[field], message)
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	domainrepo "github.com/zenGate-Global/palmyra-pro-saas/domains/service-accounts/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+func TestServiceCreateValidation(t *testing.T) {
+	repo := &stubRepository{
+		tableExistsFn: func(_ context.Context, tableName string) (bool, error) {
+			return tableName == "cards_entities", nil
+		},
+	}
+	svc := New(repo)
+
+	_, err := svc.Create(context.Background(), CreateInput{
+		Name:   " ",
+		Roles:  []string{"robot"},
+		Tables: []string{"cards_entities", "Bad-Name", "pricing_entities"},
+	})
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	require.Contains(t, valErr.Fields, "name")
+	require.Contains(t, valErr.Fields, "roles")
+	require.Len(t, valErr.Fields["tables"], 2)
+}
+
+func TestServiceCreateNormalizesInput(t *testing.T) {
+	var captured persistence.CreateServiceAccountParams
+	repo := &stubRepository{
+		createFn: func(_ context.Context, params persistence.CreateServiceAccountParams) (persistence.ServiceAccount, error) {
+			captured = params
+			return persistence.ServiceAccount{
+				ServiceAccountID: params.ServiceAccountID,
+				Name:             params.Name,
+				Roles:            params.Roles,
+				TableScope:       params.TableScope,
+			}, nil
+		},
+	}
+	svc := New(repo)
+
+	blank := "  "
+	account, err := svc.Create(context.Background(), CreateInput{
+		Name:        " seeder ",
+		Description: &blank,
+		Roles:       []string{"user", "user"},
+		Tables:      []string{" Cards_Entities ", "cards_entities"},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, captured.ServiceAccountID)
+	require.Equal(t, "seeder", captured.Name)
+	require.Nil(t, captured.Description)
+	require.Equal(t, []string{"user"}, captured.Roles)
+	require.Equal(t, []string{"cards_entities"}, captured.TableScope)
+	require.Equal(t, []string{"cards_entities"}, account.Tables)
+}
+
+func TestServiceUpdateClearsTableScope(t *testing.T) {
+	var captured persistence.UpdateServiceAccountParams
+	repo := &stubRepository{
+		updateFn: func(_ context.Context, _ uuid.UUID, params persistence.UpdateServiceAccountParams) (persistence.ServiceAccount, error) {
+			captured = params
+			return persistence.ServiceAccount{}, nil
+		},
+	}
+	svc := New(repo)
+
+	_, err := svc.Update(context.Background(), uuid.New(), UpdateInput{})
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+
+	_, err = svc.Update(context.Background(), uuid.New(), UpdateInput{Tables: &[]string{}})
+	require.NoError(t, err)
+	require.NotNil(t, captured.TableScope)
+	require.Empty(t, *captured.TableScope)
+}
+
+func TestServiceCreateKey(t *testing.T) {
+	accountID := uuid.New()
+	var captured persistence.CreateAPIKeyParams
+	repo := &stubRepository{
+		createKeyFn: func(_ context.Context, params persistence.CreateAPIKeyParams) (persistence.APIKey, error) {
+			captured = params
+			return persistence.APIKey{KeyID: params.KeyID, Name: params.Name, Prefix: params.Prefix, ExpiresAt: params.ExpiresAt}, nil
+		},
+	}
+	svc := New(repo)
+
+	past := time.Now().Add(-time.Hour)
+	_, err := svc.CreateKey(context.Background(), accountID, CreateKeyInput{Name: "ci", ExpiresAt: &past})
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	require.Contains(t, valErr.Fields, "expiresAt")
+
+	key, err := svc.CreateKey(context.Background(), accountID, CreateKeyInput{Name: "ci"})
+	require.NoError(t, err)
+	require.Equal(t, accountID, captured.ServiceAccountID)
+	require.Equal(t, captured.Prefix, key.Prefix)
+	require.True(t, platformauth.APIKeyMatches(key.Secret, captured.SecretHash))
+	prefix, ok := platformauth.APIKeyPrefixOf(key.Secret)
+	require.True(t, ok)
+	require.Equal(t, key.Prefix, prefix)
+}
+
+func TestServiceRotateRevokedKeyConflicts(t *testing.T) {
+	repo := &stubRepository{
+		rotateKeyFn: func(context.Context, uuid.UUID, uuid.UUID, persistence.CreateAPIKeyParams) (persistence.APIKey, error) {
+			return persistence.APIKey{}, persistence.ErrAPIKeyRevoked
+		},
+	}
+	svc := New(repo)
+
+	_, err := svc.RotateKey(context.Background(), uuid.New(), uuid.New())
+	require.ErrorIs(t, err, ErrConflict)
+}
+
+func TestServiceAuthenticate(t *testing.T) {
+	generated, err := platformauth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	now := time.Now()
+	accountID := uuid.New()
+	keyID := uuid.New()
+	key := persistence.APIKey{
+		KeyID:            keyID,
+		TenantID:         "tenant-a",
+		ServiceAccountID: accountID,
+		Prefix:           generated.Prefix,
+		SecretHash:       generated.Hash,
+	}
+
+	var touched []uuid.UUID
+	repo := &stubRepository{
+		keyByPrefixFn: func(_ context.Context, prefix string) (persistence.APIKey, error) {
+			if prefix != key.Prefix {
+				return persistence.APIKey{}, persistence.ErrAPIKeyNotFound
+			}
+			return key, nil
+		},
+		getFn: func(ctx context.Context, id uuid.UUID) (persistence.ServiceAccount, error) {
+			tenantID, err := tenant.Require(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "tenant-a", tenantID)
+			require.Equal(t, accountID, id)
+			return persistence.ServiceAccount{
+				ServiceAccountID: id,
+				TenantID:         tenantID,
+				Name:             "seeder",
+				Roles:            []string{"admin", "retired_role"},
+				TableScope:       []string{"cards_entities"},
+			}, nil
+		},
+		touchKeyFn: func(_ context.Context, id uuid.UUID) error {
+			touched = append(touched, id)
+			return nil
+		},
+	}
+	svc := New(repo)
+
+	creds, err := svc.Authenticate(context.Background(), generated.Key)
+	require.NoError(t, err)
+	require.Equal(t, accountID.String(), creds.Id)
+	require.True(t, creds.IsServiceAccount())
+	require.Equal(t, "tenant-a", *creds.TenantID)
+	require.Equal(t, []platformauth.Role{platformauth.RoleAdmin}, creds.Roles)
+	require.True(t, creds.IsAdmin)
+	require.Equal(t, []string{"cards_entities"}, creds.TableScope)
+	require.Equal(t, []uuid.UUID{keyID}, touched)
+
+	invalid := []string{
+		"plm_unknown_secret",
+		generated.Prefix + "_wrong-secret",
+		"not-an-api-key",
+	}
+	for _, candidate := range invalid {
+		_, err = svc.Authenticate(context.Background(), candidate)
+		require.ErrorIs(t, err, platformauth.ErrInvalidAPIKey, candidate)
+	}
+
+	expired := now.Add(-time.Minute)
+	key.ExpiresAt = &expired
+	_, err = svc.Authenticate(context.Background(), generated.Key)
+	require.ErrorIs(t, err, platformauth.ErrInvalidAPIKey)
+
+	key.ExpiresAt = nil
+	key.RevokedAt = &now
+	_, err = svc.Authenticate(context.Background(), generated.Key)
+	require.ErrorIs(t, err, platformauth.ErrInvalidAPIKey)
+	require.Len(t, touched, 1)
+}
+
+type stubRepository struct {
+	getFn         func(context.Context, uuid.UUID) (persistence.ServiceAccount, error)
+	createFn      func(context.Context, persistence.CreateServiceAccountParams) (persistence.ServiceAccount, error)
+	updateFn      func(context.Context, uuid.UUID, persistence.UpdateServiceAccountParams) (persistence.ServiceAccount, error)
+	createKeyFn   func(context.Context, persistence.CreateAPIKeyParams) (persistence.APIKey, error)
+	rotateKeyFn   func(context.Context, uuid.UUID, uuid.UUID, persistence.CreateAPIKeyParams) (persistence.APIKey, error)
+	keyByPrefixFn func(context.Context, string) (persistence.APIKey, error)
+	touchKeyFn    func(context.Context, uuid.UUID) error
+	tableExistsFn func(context.Context, string) (bool, error)
+}
+
+func (s *stubRepository) List(context.Context) ([]persistence.ServiceAccount, error) {
+	return nil, nil
+}
+
+func (s *stubRepository) Get(ctx context.Context, id uuid.UUID) (persistence.ServiceAccount, error) {
+	if s.getFn != nil {
+		return s.getFn(ctx, id)
+	}
+	return persistence.ServiceAccount{}, persistence.ErrServiceAccountNotFound
+}
+
+func (s *stubRepository) Create(ctx context.Context, params persistence.CreateServiceAccountParams) (persistence.ServiceAccount, error) {
+	if s.createFn != nil {
+		return s.createFn(ctx, params)
+	}
+	return persistence.ServiceAccount{}, nil
+}
+
+func (s *stubRepository) Update(ctx context.Context, id uuid.UUID, params persistence.UpdateServiceAccountParams) (persistence.ServiceAccount, error) {
+	if s.updateFn != nil {
+		return s.updateFn(ctx, id, params)
+	}
+	return persistence.ServiceAccount{}, nil
+}
+
+func (s *stubRepository) Delete(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (s *stubRepository) ListKeys(context.Context, uuid.UUID) ([]persistence.APIKey, error) {
+	return nil, nil
+}
+
+func (s *stubRepository) CreateKey(ctx context.Context, params persistence.CreateAPIKeyParams) (persistence.APIKey, error) {
+	if s.createKeyFn != nil {
+		return s.createKeyFn(ctx, params)
+	}
+	return persistence.APIKey{}, nil
+}
+
+func (s *stubRepository) RotateKey(ctx context.Context, serviceAccountID, keyID uuid.UUID, replacement persistence.CreateAPIKeyParams) (persistence.APIKey, error) {
+	if s.rotateKeyFn != nil {
+		return s.rotateKeyFn(ctx, serviceAccountID, keyID, replacement)
+	}
+	return persistence.APIKey{}, nil
+}
+
+func (s *stubRepository) RevokeKey(context.Context, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+
+func (s *stubRepository) GetKeyByPrefix(ctx context.Context, prefix string) (persistence.APIKey, error) {
+	if s.keyByPrefixFn != nil {
+		return s.keyByPrefixFn(ctx, prefix)
+	}
+	return persistence.APIKey{}, persistence.ErrAPIKeyNotFound
+}
+
+func (s *stubRepository) TouchKey(ctx context.Context, keyID uuid.UUID) error {
+	if s.touchKeyFn != nil {
+		return s.touchKeyFn(ctx, keyID)
+	}
+	return nil
+}
+
+func (s *stubRepository) TableExists(ctx context.Context, tableName string) (bool, error) {
+	if s.tableExistsFn != nil {
+		return s.tableExistsFn(ctx, tableName)
+	}
+	return true, nil
+}
+
+var _ domainrepo.Repository = (*stubRepository)(nil)
@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/service"
+	externalRef0 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/iam"
 	externalRef2 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
 	externalRef3 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
 	users "github.com/zenGate-Global/palmyra-pro-saas/generated/go/users"
@@ -22,6 +23,7 @@ const (
 	problemTypeValidation = "https://palmyra.pro/problems/validation-error"
 	problemTypeNotFound   = "https://palmyra.pro/problems/not-found"
 	problemTypeConflict   = "https://palmyra.pro/problems/conflict"
+	problemTypeForbidden  = "https://palmyra.pro/problems/forbidden"
 	problemTypeInternal   = "https://palmyra.pro/problems/internal-error"
 )
 
@@ -35,6 +37,10 @@ const (
 	meGetOperation    operation = "usersMe"
 	meUpdateOperation operation = "usersUpdateMe"
 	deleteOperation   operation = "usersDelete"
+	approveOperation  operation = "usersApprove"
+	rejectOperation   operation = "usersReject"
+	disableOperation  operation = "usersDisable"
+	enableOperation   operation = "usersEnable"
 )
 
 // Handler wires the users service to the generated HTTP contract.
@@ -175,6 +181,51 @@ func (h *Handler) UsersDelete(ctx context.Context, request users.UsersDeleteRequ
 	return users.UsersDelete204Response{}, nil
 }
 
+func (h *Handler) UsersApprove(ctx context.Context, request users.UsersApproveRequestObject) (users.UsersApproveResponseObject, error) {
+	user, err := h.svc.Approve(ctx, uuid.UUID(request.UserId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, approveOperation)
+		return users.UsersApprovedefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return users.UsersApprove200JSONResponse(toAPIUser(user)), nil
+}
+
+func (h *Handler) UsersReject(ctx context.Context, request users.UsersRejectRequestObject) (users.UsersRejectResponseObject, error) {
+	if request.Body == nil {
+		problem := h.buildProblem("Invalid request body", "request body is required", problemTypeValidation, http.StatusBadRequest, nil)
+		return users.UsersRejectdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: http.StatusBadRequest}, nil
+	}
+
+	user, err := h.svc.Reject(ctx, uuid.UUID(request.UserId), request.Body.Reason)
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, rejectOperation)
+		return users.UsersRejectdefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return users.UsersReject200JSONResponse(toAPIUser(user)), nil
+}
+
+func (h *Handler) UsersDisable(ctx context.Context, request users.UsersDisableRequestObject) (users.UsersDisableResponseObject, error) {
+	user, err := h.svc.Disable(ctx, uuid.UUID(request.UserId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, disableOperation)
+		return users.UsersDisabledefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return users.UsersDisable200JSONResponse(toAPIUser(user)), nil
+}
+
+func (h *Handler) UsersEnable(ctx context.Context, request users.UsersEnableRequestObject) (users.UsersEnableResponseObject, error) {
+	user, err := h.svc.Enable(ctx, uuid.UUID(request.UserId))
+	if err != nil {
+		status, problem := h.problemForError(ctx, err, enableOperation)
+		return users.UsersEnabledefaultApplicationProblemPlusJSONResponse{Body: problem, StatusCode: status}, nil
+	}
+
+	return users.UsersEnable200JSONResponse(toAPIUser(user)), nil
+}
+
 func buildListOptions(params users.UsersListParams) service.ListOptions {
 	opts := service.ListOptions{}
 
@@ -188,6 +239,14 @@ func buildListOptions(params users.UsersListParams) service.ListOptions {
 		email := strings.TrimSpace(*params.Email)
 		opts.Email = &email
 	}
+	if params.Status != nil {
+		status := string(*params.Status)
+		opts.Status = &status
+	}
+	if params.Role != nil {
+		role := string(*params.Role)
+		opts.Role = &role
+	}
 	if params.Sort != nil {
 		s := string(*params.Sort)
 		opts.Sort = &s
@@ -198,11 +257,14 @@ func buildListOptions(params users.UsersListParams) service.ListOptions {
 
 func toAPIUser(user service.User) users.User {
 	return users.User{
-		Id:        externalRef2.UUID(user.ID),
-		Email:     externalRef2.Email(user.Email),
-		FullName:  user.FullName,
-		CreatedAt: externalRef2.Timestamp(user.CreatedAt),
-		UpdatedAt: externalRef2.Timestamp(user.UpdatedAt),
+		Id:           externalRef2.UUID(user.ID),
+		Email:        externalRef2.Email(user.Email),
+		FullName:     user.FullName,
+		Roles:        toAPIRoles(user.Roles),
+		Status:       users.UserStatus(user.Status),
+		StatusReason: user.StatusReason,
+		CreatedAt:    externalRef2.Timestamp(user.CreatedAt),
+		UpdatedAt:    externalRef2.Timestamp(user.UpdatedAt),
 	}
 }
 
@@ -212,6 +274,10 @@ func toServiceCreateInput(body *users.CreateUser) service.CreateInput {
 		FullName: body.FullName,
 	}
 
+	if body.Roles != nil {
+		input.Roles = toServiceRoles(*body.Roles)
+	}
+
 	return input
 }
 
@@ -222,18 +288,39 @@ func toServiceUpdateInput(body *users.UsersUpdateJSONRequestBody) service.Update
 		input.FullName = body.FullName
 	}
 
+	if body.Roles != nil {
+		input.Roles = toServiceRoles(*body.Roles)
+	}
+
 	return input
 }
 
+func toAPIRoles(roles []string) []externalRef0.UserRole {
+	result := make([]externalRef0.UserRole, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, externalRef0.UserRole(role))
+	}
+	return result
+}
+
+func toServiceRoles(roles []externalRef0.UserRole) []string {
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, string(role))
+	}
+	return result
+}
+
+// extractUserID returns the internal user ID resolved from the caller's identity by RequireActiveUser.
 func (h *Handler) extractUserID(ctx context.Context) (uuid.UUID, error) {
 	credentials, ok := platformauth.UserFromContext(ctx)
 	if !ok || credentials == nil {
 		return uuid.Nil, errors.New("missing credentials")
 	}
 
-	id, err := uuid.Parse(credentials.Id)
+	id, err := uuid.Parse(credentials.UserID)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("invalid user id")
+		return uuid.Nil, errors.New("caller has no user record")
 	}
 
 	return id, nil
@@ -281,6 +368,18 @@ func (h *Handler) classifyError(err error) (status int, title, detail, problemTy
 			"user conflict",
 			problemTypeConflict,
 			nil
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden,
+			"Forbidden",
+			err.Error(),
+			problemTypeForbidden,
+			nil
+	case errors.Is(err, service.ErrInvalidTransition):
+		return http.StatusConflict,
+			"Conflict",
+			err.Error(),
+			problemTypeConflict,
+			nil
 	default:
 		return http.StatusInternalServerError,
 			"Internal server error",
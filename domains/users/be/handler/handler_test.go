@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -25,6 +26,8 @@ type mockService struct {
 	updateFn     func(ctx context.Context, id uuid.UUID, input service.UpdateInput) (service.User, error)
 	updateSelfFn func(ctx context.Context, id uuid.UUID, input service.UpdateSelfInput) (service.User, error)
 	deleteFn     func(ctx context.Context, id uuid.UUID) error
+	approveFn    func(ctx context.Context, id uuid.UUID) (service.User, error)
+	rejectFn     func(ctx context.Context, id uuid.UUID, reason string) (service.User, error)
 }
 
 func (m *mockService) Create(ctx context.Context, input service.CreateInput) (service.User, error) {
@@ -69,6 +72,32 @@ func (m *mockService) Delete(ctx context.Context, id uuid.UUID) error {
 	return m.deleteFn(ctx, id)
 }
 
+func (m *mockService) Approve(ctx context.Context, id uuid.UUID) (service.User, error) {
+	if m.approveFn == nil {
+		panic("approveFn not configured")
+	}
+	return m.approveFn(ctx, id)
+}
+
+func (m *mockService) Reject(ctx context.Context, id uuid.UUID, reason string) (service.User, error) {
+	if m.rejectFn == nil {
+		panic("rejectFn not configured")
+	}
+	return m.rejectFn(ctx, id, reason)
+}
+
+func (m *mockService) Disable(ctx context.Context, id uuid.UUID) (service.User, error) {
+	panic("Disable not configured")
+}
+
+func (m *mockService) Enable(ctx context.Context, id uuid.UUID) (service.User, error) {
+	panic("Enable not configured")
+}
+
+func (m *mockService) ResolveCaller(ctx context.Context, input service.CallerInput) (service.User, error) {
+	panic("ResolveCaller not configured")
+}
+
 func TestUsersListSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -220,8 +249,9 @@ func TestUsersUpdateMeSuccess(t *testing.T) {
 	h := New(svc, zaptest.NewLogger(t))
 
 	ctx := contextWithCredentials(t, platformauth.UserCredentials{
-		Id:    userID.String(),
-		Email: "user@example.com",
+		Id:     "firebase-uid",
+		UserID: userID.String(),
+		Email:  "user@example.com",
 	})
 
 	fullName := " User "
@@ -278,6 +308,48 @@ func TestUsersDeleteNotFound(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, problem.StatusCode)
 }
 
+func TestUsersApproveSuccess(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	svc := &mockService{}
+	svc.approveFn = func(ctx context.Context, id uuid.UUID) (service.User, error) {
+		require.Equal(t, userID, id)
+		return service.User{ID: id, Email: "user@example.com", Status: service.StatusApproved}, nil
+	}
+
+	h := New(svc, zaptest.NewLogger(t))
+
+	resp, err := h.UsersApprove(context.Background(), users.UsersApproveRequestObject{UserId: externalRef2.UUID(userID)})
+	require.NoError(t, err)
+
+	success, ok := resp.(users.UsersApprove200JSONResponse)
+	require.True(t, ok)
+	require.Equal(t, users.Approved, success.Status)
+}
+
+func TestUsersRejectInvalidTransition(t *testing.T) {
+	t.Parallel()
+
+	svc := &mockService{}
+	svc.rejectFn = func(ctx context.Context, id uuid.UUID, reason string) (service.User, error) {
+		require.Equal(t, "spam", reason)
+		return service.User{}, fmt.Errorf("%w: approved -> rejected", service.ErrInvalidTransition)
+	}
+
+	h := New(svc, zaptest.NewLogger(t))
+
+	resp, err := h.UsersReject(context.Background(), users.UsersRejectRequestObject{
+		UserId: externalRef2.UUID(uuid.New()),
+		Body:   &users.UsersRejectJSONRequestBody{Reason: "spam"},
+	})
+	require.NoError(t, err)
+
+	problem, ok := resp.(users.UsersRejectdefaultApplicationProblemPlusJSONResponse)
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, problem.StatusCode)
+}
+
 func contextWithCredentials(t *testing.T, creds platformauth.UserCredentials) context.Context {
 	t.Helper()
 
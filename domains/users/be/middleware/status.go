@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/service"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+)
+
+// RequireActiveUser resolves the caller's user record through their external identity (token issuer and
+// subject, see service.ResolveCaller), stores its ID on the credentials as UserID and blocks callers whose
+// record is not approved or enabled. Unknown callers are registered through the users service so sign-ups
+// land in the approval queue; unknown callers that already hold the admin role through their token are let
+// through without a record (bootstrap admins). Requests to exemptPaths (e.g. /api/v1/users/me) still resolve
+// the caller but are never blocked, so pending users can read their own status. Service accounts have no
+// user record and are not checked.
+func RequireActiveUser(svc service.Service, logger *zap.Logger, exemptPaths ...string) func(http.Handler) http.Handler {
+	if svc == nil {
+		panic("users service is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+
+	exempt := make(map[string]struct{}, len(exemptPaths))
+	for _, path := range exemptPaths {
+		exempt[path] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			creds, ok := platformauth.UserFromContext(r.Context())
+			if !ok || creds == nil || creds.IsServiceAccount() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			isAdmin := creds.HasRole(platformauth.RoleAdmin)
+			input := service.CallerInput{
+				Provider:      creds.Issuer,
+				ID:            creds.Id,
+				Email:         creds.Email,
+				EmailVerified: creds.EmailVerified,
+				Register:      !isAdmin,
+			}
+			if creds.Name != nil {
+				input.FullName = *creds.Name
+			}
+
+			user, err := svc.ResolveCaller(r.Context(), input)
+
+			var detail string
+			switch {
+			case errors.Is(err, service.ErrNotFound) && isAdmin:
+				next.ServeHTTP(w, r)
+				return
+			case errors.Is(err, service.ErrNotFound):
+				detail = "caller has no user record and cannot be registered without an email"
+			case errors.Is(err, service.ErrEmailNotVerified):
+				detail = "email must be verified to sign in to the existing user account"
+			case errors.Is(err, service.ErrAccountNotVerified):
+				detail = "an account registered with an unverified password already uses this email; an administrator must remove it first"
+			case err != nil:
+				loggerFrom(r, logger).Error("resolve caller status", zap.Error(err))
+				platformhttp.WriteProblem(w, r, http.StatusInternalServerError, platformhttp.ProblemTypeInternal,
+					"Internal server error", "could not resolve caller status")
+				return
+			default:
+				resolved := *creds
+				resolved.UserID = user.ID.String()
+				r = r.WithContext(platformauth.WithUserCredentials(r.Context(), &resolved))
+				if !user.Status.Active() {
+					detail = fmt.Sprintf("user account is %s", user.Status)
+				}
+			}
+
+			if _, skip := exempt[r.URL.Path]; detail == "" || skip {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			platformhttp.WriteProblem(w, r, http.StatusForbidden, platformhttp.ProblemTypeForbidden, "Forbidden", detail)
+		})
+	}
+}
+
+func loggerFrom(r *http.Request, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := platformlogging.FromContext(r.Context()); ok {
+		return logger
+	}
+	return fallback
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/service"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+)
+
+type stubService struct {
+	service.Service
+	resolveFn func(ctx context.Context, input service.CallerInput) (service.User, error)
+}
+
+func (s *stubService) ResolveCaller(ctx context.Context, input service.CallerInput) (service.User, error) {
+	return s.resolveFn(ctx, input)
+}
+
+func TestRequireActiveUser(t *testing.T) {
+	t.Parallel()
+
+	statuses := map[string]service.Status{
+		"approved@example.com": service.StatusApproved,
+		"enabled@example.com":  service.StatusEnabled,
+		"pending@example.com":  service.StatusPending,
+		"disabled@example.com": service.StatusDisabled,
+	}
+	svc := &stubService{resolveFn: func(ctx context.Context, input service.CallerInput) (service.User, error) {
+		status, ok := statuses[input.Email]
+		if !ok {
+			return service.User{}, service.ErrNotFound
+		}
+		return service.User{Email: input.Email, Status: status}, nil
+	}}
+
+	handler := RequireActiveUser(svc, zaptest.NewLogger(t), "/api/v1/users/me")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serve := func(path, email string, roles ...platformauth.Role) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(platformauth.WithUserCredentials(req.Context(), &platformauth.UserCredentials{
+			Id:    "uid",
+			Email: email,
+			Roles: roles,
+		}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusNoContent, serve("/api/v1/entities", "approved@example.com"))
+	require.Equal(t, http.StatusNoContent, serve("/api/v1/entities", "enabled@example.com"))
+	require.Equal(t, http.StatusForbidden, serve("/api/v1/entities", "pending@example.com"))
+	require.Equal(t, http.StatusForbidden, serve("/api/v1/entities", "disabled@example.com", platformauth.RoleAdmin))
+	require.Equal(t, http.StatusNoContent, serve("/api/v1/users/me", "pending@example.com"))
+	require.Equal(t, http.StatusForbidden, serve("/api/v1/entities", ""))
+	require.Equal(t, http.StatusNoContent, serve("/api/v1/entities", "", platformauth.RoleAdmin))
+}
+
+func TestRequireActiveUserStoresResolvedUserID(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	svc := &stubService{resolveFn: func(ctx context.Context, input service.CallerInput) (service.User, error) {
+		require.Equal(t, "https://issuer", input.Provider)
+		require.Equal(t, "firebase-uid", input.ID)
+		if !input.EmailVerified {
+			return service.User{}, service.ErrEmailNotVerified
+		}
+		return service.User{ID: userID, Status: service.StatusPending}, nil
+	}}
+
+	var got *platformauth.UserCredentials
+	handler := RequireActiveUser(svc, zaptest.NewLogger(t), "/api/v1/users/me")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = platformauth.UserFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serve := func(emailVerified bool) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+		req = req.WithContext(platformauth.WithUserCredentials(req.Context(), &platformauth.UserCredentials{
+			Id:            "firebase-uid",
+			Issuer:        "https://issuer",
+			Email:         "linked@example.com",
+			EmailVerified: emailVerified,
+		}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusNoContent, serve(true))
+	require.Equal(t, userID.String(), got.UserID)
+	require.Equal(t, "firebase-uid", got.Id)
+
+	got = nil
+	require.Equal(t, http.StatusNoContent, serve(false), "exempt paths are never blocked")
+	require.Empty(t, got.UserID)
+}
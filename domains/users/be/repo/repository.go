@@ -13,8 +13,14 @@ type Repository interface {
 	Create(ctx context.Context, params persistence.CreateUserParams) (persistence.User, error)
 	List(ctx context.Context, params persistence.ListUsersParams) (persistence.ListUsersResult, error)
 	Get(ctx context.Context, id uuid.UUID) (persistence.User, error)
+	GetByEmail(ctx context.Context, email string) (persistence.User, error)
+	GetByIdentity(ctx context.Context, provider, subject string) (persistence.User, error)
+	LinkIdentity(ctx context.Context, userID uuid.UUID, identity persistence.UserIdentityParams) error
+	CreateWithIdentity(ctx context.Context, params persistence.CreateUserParams, identity persistence.UserIdentityParams) (persistence.User, error)
+	HasPassword(ctx context.Context, id uuid.UUID) (bool, error)
 	Update(ctx context.Context, id uuid.UUID, params persistence.UpdateUserParams) (persistence.User, error)
 	UpdateFullName(ctx context.Context, id uuid.UUID, fullName string) (persistence.User, error)
+	TransitionStatus(ctx context.Context, id uuid.UUID, params persistence.TransitionUserStatusParams) (persistence.User, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -42,6 +48,27 @@ func (r *postgresRepository) Get(ctx context.Context, id uuid.UUID) (persistence
 	return r.store.GetUser(ctx, id)
 }
 
+func (r *postgresRepository) GetByEmail(ctx context.Context, email string) (persistence.User, error) {
+	return r.store.GetUserByEmail(ctx, email)
+}
+
+func (r *postgresRepository) GetByIdentity(ctx context.Context, provider, subject string) (persistence.User, error) {
+	return r.store.GetUserByIdentity(ctx, provider, subject)
+}
+
+func (r *postgresRepository) LinkIdentity(ctx context.Context, userID uuid.UUID, identity persistence.UserIdentityParams) error {
+	_, err := r.store.LinkUserIdentity(ctx, userID, identity)
+	return err
+}
+
+func (r *postgresRepository) CreateWithIdentity(ctx context.Context, params persistence.CreateUserParams, identity persistence.UserIdentityParams) (persistence.User, error) {
+	return r.store.CreateUserWithIdentity(ctx, params, identity)
+}
+
+func (r *postgresRepository) HasPassword(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.store.HasPasswordCredential(ctx, id)
+}
+
 func (r *postgresRepository) Update(ctx context.Context, id uuid.UUID, params persistence.UpdateUserParams) (persistence.User, error) {
 	return r.store.UpdateUser(ctx, id, params)
 }
@@ -50,6 +77,10 @@ func (r *postgresRepository) UpdateFullName(ctx context.Context, id uuid.UUID, f
 	return r.store.UpdateUserFullName(ctx, id, fullName)
 }
 
+func (r *postgresRepository) TransitionStatus(ctx context.Context, id uuid.UUID, params persistence.TransitionUserStatusParams) (persistence.User, error) {
+	return r.store.TransitionUserStatus(ctx, id, params)
+}
+
 func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.store.DeleteUser(ctx, id)
 }
@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/zenGate-Global/palmyra-pro-saas/domains/users/be/repo"
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
 )
 
 // FieldErrors maps request fields to validation issues.
@@ -27,22 +30,84 @@ func (v *ValidationError) Error() string {
 
 // Domain sentinel errors.
 var (
-	ErrNotFound = errors.New("user not found")
-	ErrConflict = errors.New("user conflict")
+	ErrNotFound          = errors.New("user not found")
+	ErrConflict          = errors.New("user conflict")
+	ErrInvalidTransition = errors.New("invalid user status transition")
+	// ErrEmailNotVerified is returned when an unlinked identity carries the unverified email of an existing user.
+	ErrEmailNotVerified = errors.New("email not verified")
+	// ErrAccountNotVerified is returned when a verified email matches a user registered with a local
+	// password, whose own email was never verified.
+	ErrAccountNotVerified = errors.New("existing account not verified")
+	// ErrForbidden is returned when the caller may not grant or remove the requested roles.
+	ErrForbidden = errors.New("user role change forbidden")
 )
 
+// privilegedRoles may only be granted or removed by administrators.
+var privilegedRoles = []platformauth.Role{platformauth.RoleAdmin, platformauth.RoleUserManager}
+
+// defaultIdentityProvider records identities whose token carries no issuer (e.g. AUTH_PROVIDER=dev).
+const defaultIdentityProvider = "default"
+
+// Status is the approval lifecycle state of a user.
+type Status string
+
+// Supported user statuses (contracts/users.yaml#/components/schemas/UserStatus).
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+	StatusEnabled  Status = "enabled"
+	StatusDisabled Status = "disabled"
+)
+
+// statusTransitions lists the statuses reachable from each status.
+var statusTransitions = map[Status][]Status{
+	StatusPending:  {StatusApproved, StatusRejected},
+	StatusRejected: {StatusApproved},
+	StatusApproved: {StatusDisabled},
+	StatusEnabled:  {StatusDisabled},
+	StatusDisabled: {StatusEnabled},
+}
+
+// ParseStatus converts a raw value into a Status, reporting whether it is supported.
+func ParseStatus(raw string) (Status, bool) {
+	status := Status(strings.ToLower(strings.TrimSpace(raw)))
+	_, ok := statusTransitions[status]
+	return status, ok
+}
+
+// Active reports whether users in this status may access the API.
+func (s Status) Active() bool {
+	return s == StatusApproved || s == StatusEnabled
+}
+
+// CanTransitionTo reports whether the state machine allows moving to next.
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 // User represents the domain view of a user record.
 type User struct {
-	ID        uuid.UUID
-	Email     string
-	FullName  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           uuid.UUID
+	Email        string
+	FullName     string
+	Roles        []string
+	Status       Status
+	StatusReason *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 // ListOptions controls filtering and pagination.
 type ListOptions struct {
 	Email    *string
+	Status   *string
+	Role     *string
 	Page     int
 	PageSize int
 	Sort     *string
@@ -61,11 +126,13 @@ type ListResult struct {
 type CreateInput struct {
 	Email    string
 	FullName string
+	Roles    []string
 }
 
 // UpdateInput encapsulates fields that can be modified by administrators.
 type UpdateInput struct {
 	FullName *string
+	Roles    []string
 }
 
 // UpdateSelfInput encapsulates fields that the authenticated user can modify.
@@ -73,6 +140,17 @@ type UpdateSelfInput struct {
 	FullName *string
 }
 
+// CallerInput identifies an authenticated caller by the claims carried in their token.
+type CallerInput struct {
+	Provider      string // token issuer; identities without one are recorded under defaultIdentityProvider
+	ID            string // subject at the provider
+	Email         string
+	EmailVerified bool
+	FullName      string
+	// Register creates a user record for unknown callers instead of returning ErrNotFound.
+	Register bool
+}
+
 // Service defines the business operations for the users domain.
 type Service interface {
 	Create(ctx context.Context, input CreateInput) (User, error)
@@ -81,21 +159,59 @@ type Service interface {
 	Update(ctx context.Context, id uuid.UUID, input UpdateInput) (User, error)
 	UpdateSelf(ctx context.Context, id uuid.UUID, input UpdateSelfInput) (User, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	Approve(ctx context.Context, id uuid.UUID) (User, error)
+	Reject(ctx context.Context, id uuid.UUID, reason string) (User, error)
+	Disable(ctx context.Context, id uuid.UUID) (User, error)
+	Enable(ctx context.Context, id uuid.UUID) (User, error)
+	ResolveCaller(ctx context.Context, input CallerInput) (User, error)
 }
 
 type service struct {
-	repo repo.Repository
+	repo               repo.Repository
+	autoApproveDomains map[string]struct{}
+	localIssuer        string
+}
+
+// Option customises the users service.
+type Option func(*service)
+
+// WithAutoApproveDomains approves self-registered users whose verified email belongs to one of the domains.
+func WithAutoApproveDomains(domains ...string) Option {
+	return func(s *service) {
+		for _, domain := range domains {
+			domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+			if domain != "" {
+				s.autoApproveDomains[domain] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithLocalIssuer names the issuer of the tokens signed by the local auth provider (AUTH_ISSUER), whose
+// subjects are user IDs. Only its identities are linked by subject; without it every identity is linked
+// through its verified email.
+func WithLocalIssuer(issuer string) Option {
+	return func(s *service) {
+		s.localIssuer = strings.TrimSpace(issuer)
+	}
 }
 
 // New constructs a users Service instance backed by the provided repository.
-func New(r repo.Repository) Service {
+func New(r repo.Repository, opts ...Option) Service {
 	if r == nil {
 		panic("users repository is required")
 	}
-	return &service{repo: r}
+	s := &service{repo: r, autoApproveDomains: map[string]struct{}{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *service) List(ctx context.Context, opts ListOptions) (ListResult, error) {
+	ctx, span := tracing.Start(ctx, "users.List")
+	defer span.End()
+
 	page := opts.Page
 	if page < 1 {
 		page = 1
@@ -124,6 +240,27 @@ func (s *service) List(ctx context.Context, opts ListOptions) (ListResult, error
 		repoParams.Email = &email
 	}
 
+	fieldErrors := FieldErrors{}
+	if opts.Status != nil && strings.TrimSpace(*opts.Status) != "" {
+		if status, ok := ParseStatus(*opts.Status); ok {
+			value := string(status)
+			repoParams.Status = &value
+		} else {
+			fieldErrors.add("status", fmt.Sprintf("unsupported status %q", *opts.Status))
+		}
+	}
+	if opts.Role != nil && strings.TrimSpace(*opts.Role) != "" {
+		if role, ok := platformauth.ParseRole(*opts.Role); ok {
+			value := string(role)
+			repoParams.Role = &value
+		} else {
+			fieldErrors.add("role", fmt.Sprintf("unsupported role %q", *opts.Role))
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return ListResult{}, &ValidationError{Fields: fieldErrors}
+	}
+
 	result, err := s.repo.List(ctx, repoParams)
 	if err != nil {
 		return ListResult{}, err
@@ -149,6 +286,9 @@ func (s *service) List(ctx context.Context, opts ListOptions) (ListResult, error
 }
 
 func (s *service) Create(ctx context.Context, input CreateInput) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.Create")
+	defer span.End()
+
 	fieldErrors := FieldErrors{}
 
 	email := strings.TrimSpace(input.Email)
@@ -163,14 +303,27 @@ func (s *service) Create(ctx context.Context, input CreateInput) (User, error) {
 		fieldErrors.add("fullName", "fullName is required")
 	}
 
+	var roles []string
+	if input.Roles != nil {
+		roles = normalizeRoles(input.Roles, fieldErrors)
+	}
+
 	if len(fieldErrors) > 0 {
 		return User{}, &ValidationError{Fields: fieldErrors}
 	}
+	if roles != nil {
+		if err := authorizeRoleChange(ctx, nil, roles); err != nil {
+			return User{}, err
+		}
+	}
 
+	// Users created by an administrator skip the approval queue.
 	record, err := s.repo.Create(ctx, persistence.CreateUserParams{
 		UserID:   uuid.New(),
 		Email:    strings.ToLower(email),
 		FullName: fullName,
+		Roles:    roles,
+		Status:   string(StatusApproved),
 	})
 	if err != nil {
 		return User{}, mapPersistenceError(err)
@@ -180,6 +333,9 @@ func (s *service) Create(ctx context.Context, input CreateInput) (User, error) {
 }
 
 func (s *service) Get(ctx context.Context, id uuid.UUID) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.Get")
+	defer span.End()
+
 	if id == uuid.Nil {
 		return User{}, ErrNotFound
 	}
@@ -193,6 +349,9 @@ func (s *service) Get(ctx context.Context, id uuid.UUID) (User, error) {
 }
 
 func (s *service) Update(ctx context.Context, id uuid.UUID, input UpdateInput) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.Update")
+	defer span.End()
+
 	if id == uuid.Nil {
 		return User{}, ErrNotFound
 	}
@@ -201,6 +360,15 @@ func (s *service) Update(ctx context.Context, id uuid.UUID, input UpdateInput) (
 	if err != nil {
 		return User{}, err
 	}
+	if params.Roles != nil {
+		current, getErr := s.repo.Get(ctx, id)
+		if getErr != nil {
+			return User{}, mapPersistenceError(getErr)
+		}
+		if err := authorizeRoleChange(ctx, current.Roles, params.Roles); err != nil {
+			return User{}, err
+		}
+	}
 
 	record, repoErr := s.repo.Update(ctx, id, params)
 	if repoErr != nil {
@@ -211,6 +379,9 @@ func (s *service) Update(ctx context.Context, id uuid.UUID, input UpdateInput) (
 }
 
 func (s *service) UpdateSelf(ctx context.Context, id uuid.UUID, input UpdateSelfInput) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.UpdateSelf")
+	defer span.End()
+
 	if id == uuid.Nil {
 		return User{}, ErrNotFound
 	}
@@ -233,6 +404,9 @@ func (s *service) UpdateSelf(ctx context.Context, id uuid.UUID, input UpdateSelf
 }
 
 func (s *service) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "users.Delete")
+	defer span.End()
+
 	if id == uuid.Nil {
 		return ErrNotFound
 	}
@@ -244,6 +418,195 @@ func (s *service) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (s *service) Approve(ctx context.Context, id uuid.UUID) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.Approve")
+	defer span.End()
+
+	return s.transition(ctx, id, StatusApproved, nil)
+}
+
+func (s *service) Reject(ctx context.Context, id uuid.UUID, reason string) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.Reject")
+	defer span.End()
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return User{}, newValidationError(map[string]string{"reason": "reason is required"})
+	}
+	return s.transition(ctx, id, StatusRejected, &reason)
+}
+
+func (s *service) Disable(ctx context.Context, id uuid.UUID) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.Disable")
+	defer span.End()
+
+	return s.transition(ctx, id, StatusDisabled, nil)
+}
+
+func (s *service) Enable(ctx context.Context, id uuid.UUID) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.Enable")
+	defer span.End()
+
+	return s.transition(ctx, id, StatusEnabled, nil)
+}
+
+// ResolveCaller finds the user record linked to the caller's external identity (input.Provider, input.ID).
+// An identity seen for the first time is linked to an existing user when it comes from the local provider
+// and its subject is that user's ID, or when its verified email matches; an unverified email matching
+// an existing user returns ErrEmailNotVerified rather than linking, and so does, as ErrAccountNotVerified,
+// a verified email matching a user who signed up with a local password. Unknown callers are registered as
+// pending (or approved when their verified email domain is auto-approved), together with their identity,
+// when input.Register is set.
+func (s *service) ResolveCaller(ctx context.Context, input CallerInput) (User, error) {
+	ctx, span := tracing.Start(ctx, "users.ResolveCaller")
+	defer span.End()
+
+	identity := persistence.UserIdentityParams{
+		Provider: strings.TrimSpace(input.Provider),
+		Subject:  strings.TrimSpace(input.ID),
+		Email:    strings.ToLower(strings.TrimSpace(input.Email)),
+	}
+	if identity.Provider == "" {
+		identity.Provider = defaultIdentityProvider
+	}
+	if identity.Subject == "" {
+		return User{}, ErrNotFound
+	}
+
+	record, err := s.repo.GetByIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return mapUser(record), nil
+	}
+	if !errors.Is(err, persistence.ErrUserNotFound) {
+		return User{}, err
+	}
+
+	record, err = s.findLinkCandidate(ctx, input)
+	switch {
+	case err == nil:
+		return s.linkIdentity(ctx, record, identity)
+	case errors.Is(err, ErrEmailNotVerified), errors.Is(err, ErrAccountNotVerified):
+		return User{}, err
+	case !errors.Is(err, persistence.ErrUserNotFound):
+		return User{}, mapPersistenceError(err)
+	}
+
+	email := identity.Email
+	if !input.Register || !strings.Contains(email, "@") {
+		return User{}, ErrNotFound
+	}
+
+	fullName := strings.TrimSpace(input.FullName)
+	if fullName == "" {
+		fullName = email[:strings.Index(email, "@")]
+	}
+
+	status := StatusPending
+	if input.EmailVerified && s.autoApproved(email) {
+		status = StatusApproved
+	}
+
+	record, err = s.repo.CreateWithIdentity(ctx, persistence.CreateUserParams{
+		UserID:   uuid.New(),
+		Email:    email,
+		FullName: fullName,
+		Status:   string(status),
+	}, identity)
+	if errors.Is(err, persistence.ErrUserConflict) || errors.Is(err, persistence.ErrUserIdentityConflict) {
+		// A concurrent request registered the same caller first.
+		record, err = s.repo.GetByIdentity(ctx, identity.Provider, identity.Subject)
+	}
+	if err != nil {
+		return User{}, mapPersistenceError(err)
+	}
+
+	return mapUser(record), nil
+}
+
+// findLinkCandidate returns the existing user an unlinked identity belongs to, matching the subject
+// against user IDs first for the local provider and the email otherwise. Other providers choose their
+// own subjects, so a subject that happens to be a user ID proves nothing there.
+func (s *service) findLinkCandidate(ctx context.Context, input CallerInput) (persistence.User, error) {
+	if id, err := uuid.Parse(input.ID); err == nil && s.localIssuer != "" && strings.TrimSpace(input.Provider) == s.localIssuer {
+		record, getErr := s.repo.Get(ctx, id)
+		if !errors.Is(getErr, persistence.ErrUserNotFound) {
+			return record, getErr
+		}
+	}
+	if strings.TrimSpace(input.Email) == "" {
+		return persistence.User{}, persistence.ErrUserNotFound
+	}
+
+	record, err := s.repo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		return persistence.User{}, err
+	}
+	if !input.EmailVerified {
+		return persistence.User{}, ErrEmailNotVerified
+	}
+
+	// Local signups never verify their email, so anyone could have registered this one ahead of its
+	// owner; linking would let the squatter's password into the account the owner then uses.
+	hasPassword, err := s.repo.HasPassword(ctx, record.UserID)
+	if err != nil {
+		return persistence.User{}, err
+	}
+	if hasPassword {
+		return persistence.User{}, ErrAccountNotVerified
+	}
+	return record, nil
+}
+
+func (s *service) linkIdentity(ctx context.Context, record persistence.User, identity persistence.UserIdentityParams) (User, error) {
+	err := s.repo.LinkIdentity(ctx, record.UserID, identity)
+	if errors.Is(err, persistence.ErrUserIdentityConflict) {
+		// A concurrent request linked the identity first.
+		record, err = s.repo.GetByIdentity(ctx, identity.Provider, identity.Subject)
+	}
+	if err != nil {
+		return User{}, mapPersistenceError(err)
+	}
+
+	return mapUser(record), nil
+}
+
+func (s *service) autoApproved(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	_, ok := s.autoApproveDomains[email[at+1:]]
+	return ok
+}
+
+// transition enforces the status state machine before persisting the change.
+func (s *service) transition(ctx context.Context, id uuid.UUID, next Status, reason *string) (User, error) {
+	if id == uuid.Nil {
+		return User{}, ErrNotFound
+	}
+
+	record, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return User{}, mapPersistenceError(err)
+	}
+
+	current := Status(record.Status)
+	if !current.CanTransitionTo(next) {
+		return User{}, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, current, next)
+	}
+
+	updated, err := s.repo.TransitionStatus(ctx, id, persistence.TransitionUserStatusParams{
+		From:   string(current),
+		To:     string(next),
+		Reason: reason,
+	})
+	if err != nil {
+		return User{}, mapPersistenceError(err)
+	}
+
+	return mapUser(updated), nil
+}
+
 func (s *service) buildUpdateParams(input UpdateInput) (persistence.UpdateUserParams, error) {
 	fieldErrors := FieldErrors{}
 	params := persistence.UpdateUserParams{}
@@ -259,6 +622,13 @@ func (s *service) buildUpdateParams(input UpdateInput) (persistence.UpdateUserPa
 		}
 	}
 
+	if input.Roles != nil {
+		if roles := normalizeRoles(input.Roles, fieldErrors); roles != nil {
+			params.Roles = roles
+			fieldsSet++
+		}
+	}
+
 	if fieldsSet == 0 {
 		fieldErrors.add("payload", "at least one field must be provided")
 	}
@@ -282,6 +652,7 @@ func sanitizeSort(sort *string) (*string, error) {
 	allowed := map[string]struct{}{
 		"email":     {},
 		"fullName":  {},
+		"status":    {},
 		"createdAt": {},
 		"updatedAt": {},
 	}
@@ -304,12 +675,81 @@ func sanitizeSort(sort *string) (*string, error) {
 
 func mapUser(record persistence.User) User {
 	return User{
-		ID:        record.UserID,
-		Email:     record.Email,
-		FullName:  record.FullName,
-		CreatedAt: record.CreatedAt,
-		UpdatedAt: record.UpdatedAt,
+		ID:           record.UserID,
+		Email:        record.Email,
+		FullName:     record.FullName,
+		Roles:        record.Roles,
+		Status:       Status(record.Status),
+		StatusReason: record.StatusReason,
+		CreatedAt:    record.CreatedAt,
+		UpdatedAt:    record.UpdatedAt,
+	}
+}
+
+// normalizeRoles validates the requested roles against contracts/common/iam.yaml#/components/schemas/UserRole
+// and drops duplicates. It returns nil and records a field error when the list is empty or invalid.
+func normalizeRoles(raw []string, fieldErrors FieldErrors) []string {
+	if len(raw) == 0 {
+		fieldErrors.add("roles", "at least one role must be provided")
+		return nil
+	}
+
+	roles := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	valid := true
+	for _, value := range raw {
+		role, ok := platformauth.ParseRole(value)
+		if !ok {
+			fieldErrors.add("roles", fmt.Sprintf("unsupported role %q", value))
+			valid = false
+			continue
+		}
+		if _, dup := seen[string(role)]; dup {
+			continue
+		}
+		seen[string(role)] = struct{}{}
+		roles = append(roles, string(role))
+	}
+
+	if !valid {
+		return nil
+	}
+	return roles
+}
+
+// authorizeRoleChange checks that the caller may move a user from the current roles to the requested ones:
+// only administrators grant or remove privilegedRoles, and nobody grants a role they don't hold themselves
+// (administrators hold every role). Callers without credentials may not change roles at all.
+func authorizeRoleChange(ctx context.Context, current, requested []string) error {
+	creds, ok := platformauth.UserFromContext(ctx)
+	if !ok || creds == nil {
+		return ErrForbidden
+	}
+	isAdmin := creds.HasRole(platformauth.RoleAdmin)
+
+	added := roleDifference(requested, current)
+	for _, role := range append(added, roleDifference(current, requested)...) {
+		if !isAdmin && slices.Contains(privilegedRoles, role) {
+			return fmt.Errorf("%w: only administrators may grant or remove the %s role", ErrForbidden, role)
+		}
+	}
+	for _, role := range added {
+		if !isAdmin && !creds.HasRole(role) {
+			return fmt.Errorf("%w: cannot grant the %s role without holding it", ErrForbidden, role)
+		}
+	}
+	return nil
+}
+
+// roleDifference returns the roles of a that are not in b.
+func roleDifference(a, b []string) []platformauth.Role {
+	var diff []platformauth.Role
+	for _, role := range a {
+		if !slices.Contains(b, role) {
+			diff = append(diff, platformauth.Role(role))
+		}
 	}
+	return diff
 }
 
 func mapPersistenceError(err error) error {
@@ -318,6 +758,8 @@ func mapPersistenceError(err error) error {
 		return ErrNotFound
 	case errors.Is(err, persistence.ErrUserConflict):
 		return ErrConflict
+	case errors.Is(err, persistence.ErrUserStatusConflict):
+		return fmt.Errorf("%w: status changed concurrently", ErrInvalidTransition)
 	default:
 		return err
 	}
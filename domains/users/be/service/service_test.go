@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
 	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
 )
 
@@ -19,6 +20,13 @@ type mockRepository struct {
 	updateFn     func(ctx context.Context, id uuid.UUID, params persistence.UpdateUserParams) (persistence.User, error)
 	updateNameFn func(ctx context.Context, id uuid.UUID, fullName string) (persistence.User, error)
 	deleteFn     func(ctx context.Context, id uuid.UUID) error
+	getByEmailFn func(ctx context.Context, email string) (persistence.User, error)
+	transitionFn func(ctx context.Context, id uuid.UUID, params persistence.TransitionUserStatusParams) (persistence.User, error)
+
+	getByIdentityFn      func(ctx context.Context, provider, subject string) (persistence.User, error)
+	linkIdentityFn       func(ctx context.Context, userID uuid.UUID, identity persistence.UserIdentityParams) error
+	createWithIdentityFn func(ctx context.Context, params persistence.CreateUserParams, identity persistence.UserIdentityParams) (persistence.User, error)
+	hasPasswordFn        func(ctx context.Context, id uuid.UUID) (bool, error)
 }
 
 func (m *mockRepository) Create(ctx context.Context, params persistence.CreateUserParams) (persistence.User, error) {
@@ -42,6 +50,48 @@ func (m *mockRepository) Get(ctx context.Context, id uuid.UUID) (persistence.Use
 	return m.getFn(ctx, id)
 }
 
+func (m *mockRepository) GetByEmail(ctx context.Context, email string) (persistence.User, error) {
+	if m.getByEmailFn == nil {
+		panic("getByEmailFn not configured")
+	}
+	return m.getByEmailFn(ctx, email)
+}
+
+func (m *mockRepository) GetByIdentity(ctx context.Context, provider, subject string) (persistence.User, error) {
+	if m.getByIdentityFn == nil {
+		panic("getByIdentityFn not configured")
+	}
+	return m.getByIdentityFn(ctx, provider, subject)
+}
+
+func (m *mockRepository) LinkIdentity(ctx context.Context, userID uuid.UUID, identity persistence.UserIdentityParams) error {
+	if m.linkIdentityFn == nil {
+		panic("linkIdentityFn not configured")
+	}
+	return m.linkIdentityFn(ctx, userID, identity)
+}
+
+func (m *mockRepository) CreateWithIdentity(ctx context.Context, params persistence.CreateUserParams, identity persistence.UserIdentityParams) (persistence.User, error) {
+	if m.createWithIdentityFn == nil {
+		panic("createWithIdentityFn not configured")
+	}
+	return m.createWithIdentityFn(ctx, params, identity)
+}
+
+func (m *mockRepository) HasPassword(ctx context.Context, id uuid.UUID) (bool, error) {
+	if m.hasPasswordFn == nil {
+		panic("hasPasswordFn not configured")
+	}
+	return m.hasPasswordFn(ctx, id)
+}
+
+func (m *mockRepository) TransitionStatus(ctx context.Context, id uuid.UUID, params persistence.TransitionUserStatusParams) (persistence.User, error) {
+	if m.transitionFn == nil {
+		panic("transitionFn not configured")
+	}
+	return m.transitionFn(ctx, id, params)
+}
+
 func (m *mockRepository) Update(ctx context.Context, id uuid.UUID, params persistence.UpdateUserParams) (persistence.User, error) {
 	if m.updateFn == nil {
 		panic("updateFn not configured")
@@ -214,6 +264,100 @@ func TestServiceUpdateSuccess(t *testing.T) {
 	require.Equal(t, "Admin", updated.FullName)
 }
 
+func TestServiceUpdateRoles(t *testing.T) {
+	t.Parallel()
+
+	repository := &mockRepository{}
+	userID := uuid.New()
+
+	repository.updateFn = func(ctx context.Context, id uuid.UUID, params persistence.UpdateUserParams) (persistence.User, error) {
+		require.Nil(t, params.FullName)
+		require.Equal(t, []string{"user_manager", "user"}, params.Roles)
+
+		return persistence.User{UserID: id, Email: "manager@example.com", FullName: "Manager", Roles: params.Roles}, nil
+	}
+	repository.getFn = func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+		return persistence.User{UserID: id, Roles: []string{"user"}}, nil
+	}
+
+	svc := New(repository)
+	ctx := withCaller(platformauth.RoleAdmin, platformauth.RoleUser)
+
+	updated, err := svc.Update(ctx, userID, UpdateInput{
+		Roles: []string{"user_manager", "user", "user_manager"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"user_manager", "user"}, updated.Roles)
+
+	_, err = svc.Update(ctx, userID, UpdateInput{Roles: []string{"superuser"}})
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Contains(t, validationErr.Fields, "roles")
+}
+
+func TestServiceRoleChangesNeedAdmin(t *testing.T) {
+	t.Parallel()
+
+	managerID := uuid.New()
+	repository := &mockRepository{
+		getFn: func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+			return persistence.User{UserID: id, Roles: []string{"user_manager", "user"}}, nil
+		},
+		updateFn: func(ctx context.Context, id uuid.UUID, params persistence.UpdateUserParams) (persistence.User, error) {
+			return persistence.User{UserID: id, Roles: params.Roles}, nil
+		},
+		createFn: func(ctx context.Context, params persistence.CreateUserParams) (persistence.User, error) {
+			return persistence.User{UserID: params.UserID, Roles: params.Roles}, nil
+		},
+	}
+	svc := New(repository)
+	manager := withCaller(platformauth.RoleUserManager, platformauth.RoleUser)
+
+	// A user manager escalating their own record.
+	_, err := svc.Update(manager, managerID, UpdateInput{Roles: []string{"admin", "user_manager", "user"}})
+	require.ErrorIs(t, err, ErrForbidden)
+
+	_, err = svc.Create(manager, CreateInput{Email: "new@example.com", FullName: "New", Roles: []string{"user_manager"}})
+	require.ErrorIs(t, err, ErrForbidden, "user managers cannot mint other user managers")
+
+	_, err = svc.Update(manager, uuid.New(), UpdateInput{Roles: []string{"user"}})
+	require.ErrorIs(t, err, ErrForbidden, "removing user_manager is reserved to administrators")
+
+	_, err = svc.Update(context.Background(), uuid.New(), UpdateInput{Roles: []string{"user"}})
+	require.ErrorIs(t, err, ErrForbidden, "callers without credentials cannot change roles")
+
+	created, err := svc.Create(manager, CreateInput{Email: "new@example.com", FullName: "New", Roles: []string{"user"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"user"}, created.Roles)
+
+	updated, err := svc.Update(withCaller(platformauth.RoleAdmin), managerID, UpdateInput{Roles: []string{"admin", "user"}})
+	require.NoError(t, err, "administrators grant and remove any role")
+	require.Equal(t, []string{"admin", "user"}, updated.Roles)
+}
+
+func TestServiceCannotGrantRolesNotHeld(t *testing.T) {
+	t.Parallel()
+
+	repository := &mockRepository{
+		getFn: func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+			return persistence.User{UserID: id}, nil
+		},
+	}
+	svc := New(repository)
+
+	// A caller with neither user_manager nor user (e.g. a scoped service account) grants nothing.
+	_, err := svc.Update(withCaller(), uuid.New(), UpdateInput{Roles: []string{"user"}})
+	require.ErrorIs(t, err, ErrForbidden)
+	require.ErrorContains(t, err, "without holding it")
+
+	_, err = svc.Create(withCaller(platformauth.RoleUserManager), CreateInput{Email: "new@example.com", FullName: "New", Roles: []string{"user"}})
+	require.ErrorIs(t, err, ErrForbidden)
+}
+
+func withCaller(roles ...platformauth.Role) context.Context {
+	return platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{Id: "caller", Roles: roles})
+}
+
 func TestServiceUpdateSelfValidation(t *testing.T) {
 	t.Parallel()
 
@@ -289,6 +433,255 @@ func TestServiceDeleteSuccess(t *testing.T) {
 	require.True(t, called)
 }
 
+func TestServiceStatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	stored := persistence.User{UserID: userID, Email: "user@example.com", Status: string(StatusPending)}
+
+	repository := &mockRepository{}
+	repository.getFn = func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+		return stored, nil
+	}
+	repository.transitionFn = func(ctx context.Context, id uuid.UUID, params persistence.TransitionUserStatusParams) (persistence.User, error) {
+		require.Equal(t, stored.Status, params.From)
+		stored.Status = params.To
+		stored.StatusReason = params.Reason
+		return stored, nil
+	}
+
+	svc := New(repository)
+
+	_, err := svc.Enable(context.Background(), userID)
+	require.ErrorIs(t, err, ErrInvalidTransition)
+
+	_, err = svc.Reject(context.Background(), userID, "  ")
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Contains(t, validationErr.Fields, "reason")
+
+	user, err := svc.Reject(context.Background(), userID, " duplicate account ")
+	require.NoError(t, err)
+	require.Equal(t, StatusRejected, user.Status)
+	require.Equal(t, "duplicate account", *user.StatusReason)
+
+	user, err = svc.Approve(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, StatusApproved, user.Status)
+	require.Nil(t, user.StatusReason)
+	require.True(t, user.Status.Active())
+
+	user, err = svc.Disable(context.Background(), userID)
+	require.NoError(t, err)
+	require.False(t, user.Status.Active())
+
+	_, err = svc.Approve(context.Background(), userID)
+	require.ErrorIs(t, err, ErrInvalidTransition)
+
+	user, err = svc.Enable(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, StatusEnabled, user.Status)
+}
+
+func TestServiceTransitionConcurrentChange(t *testing.T) {
+	t.Parallel()
+
+	repository := &mockRepository{}
+	repository.getFn = func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+		return persistence.User{UserID: id, Status: string(StatusPending)}, nil
+	}
+	repository.transitionFn = func(ctx context.Context, id uuid.UUID, params persistence.TransitionUserStatusParams) (persistence.User, error) {
+		return persistence.User{}, persistence.ErrUserStatusConflict
+	}
+
+	svc := New(repository)
+
+	_, err := svc.Approve(context.Background(), uuid.New())
+	require.ErrorIs(t, err, ErrInvalidTransition)
+}
+
+func TestServiceListStatusAndRoleFilters(t *testing.T) {
+	t.Parallel()
+
+	repository := &mockRepository{}
+	repository.listFn = func(ctx context.Context, params persistence.ListUsersParams) (persistence.ListUsersResult, error) {
+		require.Equal(t, "pending", *params.Status)
+		require.Equal(t, "user_manager", *params.Role)
+		return persistence.ListUsersResult{}, nil
+	}
+
+	svc := New(repository)
+
+	_, err := svc.List(context.Background(), ListOptions{Status: ptrString(" Pending "), Role: ptrString("user_manager")})
+	require.NoError(t, err)
+
+	_, err = svc.List(context.Background(), ListOptions{Status: ptrString("archived"), Role: ptrString("owner")})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Contains(t, validationErr.Fields, "status")
+	require.Contains(t, validationErr.Fields, "role")
+}
+
+func TestServiceResolveCallerRegistersPendingUser(t *testing.T) {
+	t.Parallel()
+
+	var identities []persistence.UserIdentityParams
+	repository := &mockRepository{}
+	repository.getByIdentityFn = func(ctx context.Context, provider, subject string) (persistence.User, error) {
+		return persistence.User{}, persistence.ErrUserNotFound
+	}
+	repository.getByEmailFn = func(ctx context.Context, email string) (persistence.User, error) {
+		return persistence.User{}, persistence.ErrUserNotFound
+	}
+	repository.createWithIdentityFn = func(ctx context.Context, params persistence.CreateUserParams, identity persistence.UserIdentityParams) (persistence.User, error) {
+		identities = append(identities, identity)
+		return persistence.User{UserID: params.UserID, Email: params.Email, FullName: params.FullName, Status: params.Status}, nil
+	}
+
+	svc := New(repository, WithAutoApproveDomains("@Palmyra.dev"))
+
+	user, err := svc.ResolveCaller(context.Background(), CallerInput{Provider: "https://issuer", ID: "firebase-uid", Email: "New@Example.com", Register: true})
+	require.NoError(t, err)
+	require.Equal(t, StatusPending, user.Status)
+	require.Equal(t, "new@example.com", user.Email)
+	require.Equal(t, "new", user.FullName)
+	require.Equal(t, persistence.UserIdentityParams{Provider: "https://issuer", Subject: "firebase-uid", Email: "new@example.com"}, identities[0])
+
+	user, err = svc.ResolveCaller(context.Background(), CallerInput{ID: "dev-uid", Email: "dev@palmyra.dev", Register: true})
+	require.NoError(t, err)
+	require.Equal(t, StatusPending, user.Status, "unverified emails are never auto-approved")
+	require.Equal(t, defaultIdentityProvider, identities[1].Provider)
+
+	user, err = svc.ResolveCaller(context.Background(), CallerInput{ID: "dev-uid", Email: "dev@palmyra.dev", EmailVerified: true, Register: true})
+	require.NoError(t, err)
+	require.Equal(t, StatusApproved, user.Status)
+
+	_, err = svc.ResolveCaller(context.Background(), CallerInput{ID: "admin-uid", Email: "admin@example.com"})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = svc.ResolveCaller(context.Background(), CallerInput{Email: "nobody@example.com", Register: true})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestServiceResolveCallerUsesLinkedIdentity(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	repository := &mockRepository{}
+	repository.getByIdentityFn = func(ctx context.Context, provider, subject string) (persistence.User, error) {
+		require.Equal(t, "https://issuer", provider)
+		require.Equal(t, "firebase-uid", subject)
+		return persistence.User{UserID: userID, Status: string(StatusApproved)}, nil
+	}
+
+	svc := New(repository)
+
+	user, err := svc.ResolveCaller(context.Background(), CallerInput{Provider: "https://issuer", ID: "firebase-uid", Email: "changed@example.com", Register: true})
+	require.NoError(t, err)
+	require.Equal(t, userID, user.ID)
+}
+
+func TestServiceResolveCallerMatchesExistingUser(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	var linked []uuid.UUID
+	repository := &mockRepository{}
+	repository.getByIdentityFn = func(ctx context.Context, provider, subject string) (persistence.User, error) {
+		return persistence.User{}, persistence.ErrUserNotFound
+	}
+	repository.getFn = func(ctx context.Context, id uuid.UUID) (persistence.User, error) {
+		if id != userID {
+			return persistence.User{}, persistence.ErrUserNotFound
+		}
+		return persistence.User{UserID: id, Status: string(StatusDisabled)}, nil
+	}
+	repository.getByEmailFn = func(ctx context.Context, email string) (persistence.User, error) {
+		require.Equal(t, "invited@example.com", email)
+		return persistence.User{UserID: userID, Email: email, Status: string(StatusApproved)}, nil
+	}
+	repository.linkIdentityFn = func(ctx context.Context, id uuid.UUID, identity persistence.UserIdentityParams) error {
+		linked = append(linked, id)
+		return nil
+	}
+	repository.hasPasswordFn = func(ctx context.Context, id uuid.UUID) (bool, error) {
+		return false, nil
+	}
+
+	svc := New(repository, WithLocalIssuer("palmyra-api"))
+
+	user, err := svc.ResolveCaller(context.Background(), CallerInput{Provider: "palmyra-api", ID: userID.String(), Register: true})
+	require.NoError(t, err)
+	require.Equal(t, StatusDisabled, user.Status)
+
+	_, err = svc.ResolveCaller(context.Background(), CallerInput{Provider: "https://issuer", ID: userID.String()})
+	require.ErrorIs(t, err, ErrNotFound, "other providers' subjects never match user IDs")
+	require.Len(t, linked, 1)
+
+	user, err = svc.ResolveCaller(context.Background(), CallerInput{Provider: "https://issuer", ID: "firebase-uid", Email: "invited@example.com", EmailVerified: true, Register: true})
+	require.NoError(t, err)
+	require.Equal(t, userID, user.ID)
+	require.Equal(t, []uuid.UUID{userID, userID}, linked)
+
+	_, err = svc.ResolveCaller(context.Background(), CallerInput{Provider: "https://issuer", ID: "other-uid", Email: "invited@example.com", Register: true})
+	require.ErrorIs(t, err, ErrEmailNotVerified)
+	require.Len(t, linked, 2, "unverified emails are never linked")
+}
+
+func TestServiceResolveCallerRefusesUnverifiedPasswordAccount(t *testing.T) {
+	t.Parallel()
+
+	// Someone signed up locally with the owner's email; the owner then signs in through a verified provider.
+	squatted := uuid.New()
+	repository := &mockRepository{}
+	repository.getByIdentityFn = func(ctx context.Context, provider, subject string) (persistence.User, error) {
+		return persistence.User{}, persistence.ErrUserNotFound
+	}
+	repository.getByEmailFn = func(ctx context.Context, email string) (persistence.User, error) {
+		return persistence.User{UserID: squatted, Email: email, Status: string(StatusApproved)}, nil
+	}
+	repository.hasPasswordFn = func(ctx context.Context, id uuid.UUID) (bool, error) {
+		require.Equal(t, squatted, id)
+		return true, nil
+	}
+	repository.linkIdentityFn = func(ctx context.Context, id uuid.UUID, identity persistence.UserIdentityParams) error {
+		t.Fatal("the identity must not be linked into the password account")
+		return nil
+	}
+
+	svc := New(repository)
+
+	_, err := svc.ResolveCaller(context.Background(), CallerInput{Provider: "https://issuer", ID: "google-uid", Email: "owner@example.com", EmailVerified: true, Register: true})
+	require.ErrorIs(t, err, ErrAccountNotVerified)
+}
+
+func TestServiceResolveCallerConcurrentLink(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	lookups := 0
+	repository := &mockRepository{}
+	repository.getByIdentityFn = func(ctx context.Context, provider, subject string) (persistence.User, error) {
+		lookups++
+		if lookups == 1 {
+			return persistence.User{}, persistence.ErrUserNotFound
+		}
+		return persistence.User{UserID: userID, Status: string(StatusPending)}, nil
+	}
+	repository.getByEmailFn = func(ctx context.Context, email string) (persistence.User, error) {
+		return persistence.User{}, persistence.ErrUserNotFound
+	}
+	repository.createWithIdentityFn = func(ctx context.Context, params persistence.CreateUserParams, identity persistence.UserIdentityParams) (persistence.User, error) {
+		return persistence.User{}, persistence.ErrUserIdentityConflict
+	}
+
+	svc := New(repository)
+
+	user, err := svc.ResolveCaller(context.Background(), CallerInput{Provider: "https://issuer", ID: "firebase-uid", Email: "race@example.com", Register: true})
+	require.NoError(t, err)
+	require.Equal(t, userID, user.ID)
+}
+
 func ptrString(v string) *string {
 	s := v
 	return &s
@@ -0,0 +1,1378 @@
+// Package accesscontrol provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package accesscontrol
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	externalRef0 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/iam"
+	externalRef1 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/pagination"
+	externalRef2 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	externalRef3 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AccessPermission.
+const (
+	Admin AccessPermission = "admin"
+	Read  AccessPermission = "read"
+	Write AccessPermission = "write"
+)
+
+// Defines values for PrincipalType.
+const (
+	Group PrincipalType = "group"
+	User  PrincipalType = "user"
+)
+
+// AccessControlEntry Grant of a permission on a schema category or an entity table.
+type AccessControlEntry struct {
+	// CategoryId Schema category the grant applies to, including its sub-categories.
+	CategoryId *externalRef2.UUID `json:"categoryId"`
+
+	// CreatedAt ISO 8601 timestamp in UTC
+	CreatedAt externalRef2.Timestamp `json:"createdAt"`
+
+	// EntryId RFC 4122 UUID string
+	EntryId externalRef2.UUID `json:"entryId"`
+
+	// Permission Permission level; each level includes the ones before it (read < write < admin).
+	Permission AccessPermission `json:"permission"`
+
+	// PrincipalId RFC 4122 UUID string
+	PrincipalId externalRef2.UUID `json:"principalId"`
+
+	// PrincipalType Kind of principal an ACL entry targets.
+	PrincipalType PrincipalType `json:"principalType"`
+
+	// TableName Entity table the grant applies to.
+	TableName *externalRef2.TableName `json:"tableName"`
+}
+
+// AccessControlEntryList defines model for AccessControlEntryList.
+type AccessControlEntryList struct {
+	Items []AccessControlEntry `json:"items"`
+}
+
+// AccessGroup defines model for AccessGroup.
+type AccessGroup struct {
+	// CreatedAt ISO 8601 timestamp in UTC
+	CreatedAt   externalRef2.Timestamp `json:"createdAt"`
+	Description *string                `json:"description"`
+
+	// GroupId RFC 4122 UUID string
+	GroupId externalRef2.UUID `json:"groupId"`
+	Name    string            `json:"name"`
+}
+
+// AccessGroupList defines model for AccessGroupList.
+type AccessGroupList struct {
+	Items []AccessGroup `json:"items"`
+}
+
+// AccessGroupMember defines model for AccessGroupMember.
+type AccessGroupMember struct {
+	// AddedAt ISO 8601 timestamp in UTC
+	AddedAt externalRef2.Timestamp `json:"addedAt"`
+
+	// UserId RFC 4122 UUID string
+	UserId externalRef2.UUID `json:"userId"`
+}
+
+// AccessGroupMemberList defines model for AccessGroupMemberList.
+type AccessGroupMemberList struct {
+	Items []AccessGroupMember `json:"items"`
+}
+
+// AccessPermission Permission level; each level includes the ones before it (read < write < admin).
+type AccessPermission string
+
+// CreateAccessControlEntryRequest Exactly one of categoryId or tableName must be provided.
+type CreateAccessControlEntryRequest struct {
+	// CategoryId RFC 4122 UUID string
+	CategoryId *externalRef2.UUID `json:"categoryId,omitempty"`
+
+	// Permission Permission level; each level includes the ones before it (read < write < admin).
+	Permission AccessPermission `json:"permission"`
+
+	// PrincipalId RFC 4122 UUID string
+	PrincipalId externalRef2.UUID `json:"principalId"`
+
+	// PrincipalType Kind of principal an ACL entry targets.
+	PrincipalType PrincipalType `json:"principalType"`
+
+	// TableName Lowercase snake_case PostgreSQL table identifier
+	TableName *externalRef2.TableName `json:"tableName,omitempty"`
+}
+
+// CreateAccessGroupRequest defines model for CreateAccessGroupRequest.
+type CreateAccessGroupRequest struct {
+	Description *string `json:"description"`
+	Name        string  `json:"name"`
+}
+
+// PrincipalType Kind of principal an ACL entry targets.
+type PrincipalType string
+
+// ListAccessControlEntriesParams defines parameters for ListAccessControlEntries.
+type ListAccessControlEntriesParams struct {
+	// CategoryId Only return entries granted on this schema category.
+	CategoryId *externalRef2.UUID `form:"categoryId,omitempty" json:"categoryId,omitempty"`
+
+	// TableName Only return entries granted on this entity table.
+	TableName *externalRef2.TableName `form:"tableName,omitempty" json:"tableName,omitempty"`
+}
+
+// CreateAccessControlEntryJSONRequestBody defines body for CreateAccessControlEntry for application/json ContentType.
+type CreateAccessControlEntryJSONRequestBody = CreateAccessControlEntryRequest
+
+// CreateAccessGroupJSONRequestBody defines body for CreateAccessGroup for application/json ContentType.
+type CreateAccessGroupJSONRequestBody = CreateAccessGroupRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List ACL entries
+	// (GET /access-control/entries)
+	ListAccessControlEntries(w http.ResponseWriter, r *http.Request, params ListAccessControlEntriesParams)
+	// Create ACL entry
+	// (POST /access-control/entries)
+	CreateAccessControlEntry(w http.ResponseWriter, r *http.Request)
+	// Delete ACL entry
+	// (DELETE /access-control/entries/{entryId})
+	DeleteAccessControlEntry(w http.ResponseWriter, r *http.Request, entryId externalRef2.UUID)
+	// List access groups
+	// (GET /access-control/groups)
+	ListAccessGroups(w http.ResponseWriter, r *http.Request)
+	// Create access group
+	// (POST /access-control/groups)
+	CreateAccessGroup(w http.ResponseWriter, r *http.Request)
+	// Delete access group
+	// (DELETE /access-control/groups/{groupId})
+	DeleteAccessGroup(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID)
+	// List group members
+	// (GET /access-control/groups/{groupId}/members)
+	ListAccessGroupMembers(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID)
+	// Remove group member
+	// (DELETE /access-control/groups/{groupId}/members/{userId})
+	RemoveAccessGroupMember(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID, userId externalRef2.UUID)
+	// Add group member
+	// (PUT /access-control/groups/{groupId}/members/{userId})
+	AddAccessGroupMember(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID, userId externalRef2.UUID)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List ACL entries
+// (GET /access-control/entries)
+func (_ Unimplemented) ListAccessControlEntries(w http.ResponseWriter, r *http.Request, params ListAccessControlEntriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create ACL entry
+// (POST /access-control/entries)
+func (_ Unimplemented) CreateAccessControlEntry(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete ACL entry
+// (DELETE /access-control/entries/{entryId})
+func (_ Unimplemented) DeleteAccessControlEntry(w http.ResponseWriter, r *http.Request, entryId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List access groups
+// (GET /access-control/groups)
+func (_ Unimplemented) ListAccessGroups(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create access group
+// (POST /access-control/groups)
+func (_ Unimplemented) CreateAccessGroup(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete access group
+// (DELETE /access-control/groups/{groupId})
+func (_ Unimplemented) DeleteAccessGroup(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List group members
+// (GET /access-control/groups/{groupId}/members)
+func (_ Unimplemented) ListAccessGroupMembers(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove group member
+// (DELETE /access-control/groups/{groupId}/members/{userId})
+func (_ Unimplemented) RemoveAccessGroupMember(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID, userId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add group member
+// (PUT /access-control/groups/{groupId}/members/{userId})
+func (_ Unimplemented) AddAccessGroupMember(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID, userId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAccessControlEntries operation middleware
+func (siw *ServerInterfaceWrapper) ListAccessControlEntries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAccessControlEntriesParams
+
+	// ------------- Optional query parameter "categoryId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "categoryId", r.URL.Query(), &params.CategoryId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "categoryId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tableName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tableName", r.URL.Query(), &params.TableName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tableName", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAccessControlEntries(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateAccessControlEntry operation middleware
+func (siw *ServerInterfaceWrapper) CreateAccessControlEntry(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAccessControlEntry(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAccessControlEntry operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAccessControlEntry(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "entryId" -------------
+	var entryId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "entryId", chi.URLParam(r, "entryId"), &entryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entryId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAccessControlEntry(w, r, entryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAccessGroups operation middleware
+func (siw *ServerInterfaceWrapper) ListAccessGroups(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAccessGroups(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateAccessGroup operation middleware
+func (siw *ServerInterfaceWrapper) CreateAccessGroup(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAccessGroup(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAccessGroup operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAccessGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAccessGroup(w, r, groupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAccessGroupMembers operation middleware
+func (siw *ServerInterfaceWrapper) ListAccessGroupMembers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAccessGroupMembers(w, r, groupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveAccessGroupMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveAccessGroupMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveAccessGroupMember(w, r, groupId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddAccessGroupMember operation middleware
+func (siw *ServerInterfaceWrapper) AddAccessGroupMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", chi.URLParam(r, "groupId"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddAccessGroupMember(w, r, groupId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/access-control/entries", wrapper.ListAccessControlEntries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/access-control/entries", wrapper.CreateAccessControlEntry)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/access-control/entries/{entryId}", wrapper.DeleteAccessControlEntry)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/access-control/groups", wrapper.ListAccessGroups)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/access-control/groups", wrapper.CreateAccessGroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/access-control/groups/{groupId}", wrapper.DeleteAccessGroup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/access-control/groups/{groupId}/members", wrapper.ListAccessGroupMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/access-control/groups/{groupId}/members/{userId}", wrapper.RemoveAccessGroupMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/access-control/groups/{groupId}/members/{userId}", wrapper.AddAccessGroupMember)
+	})
+
+	return r
+}
+
+type ListAccessControlEntriesRequestObject struct {
+	Params ListAccessControlEntriesParams
+}
+
+type ListAccessControlEntriesResponseObject interface {
+	VisitListAccessControlEntriesResponse(w http.ResponseWriter) error
+}
+
+type ListAccessControlEntries200JSONResponse AccessControlEntryList
+
+func (response ListAccessControlEntries200JSONResponse) VisitListAccessControlEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAccessControlEntriesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response ListAccessControlEntriesdefaultApplicationProblemPlusJSONResponse) VisitListAccessControlEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAccessControlEntryRequestObject struct {
+	Body *CreateAccessControlEntryJSONRequestBody
+}
+
+type CreateAccessControlEntryResponseObject interface {
+	VisitCreateAccessControlEntryResponse(w http.ResponseWriter) error
+}
+
+type CreateAccessControlEntry201ResponseHeaders struct {
+	Location string
+}
+
+type CreateAccessControlEntry201JSONResponse struct {
+	Body    AccessControlEntry
+	Headers CreateAccessControlEntry201ResponseHeaders
+}
+
+func (response CreateAccessControlEntry201JSONResponse) VisitCreateAccessControlEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAccessControlEntrydefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response CreateAccessControlEntrydefaultApplicationProblemPlusJSONResponse) VisitCreateAccessControlEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteAccessControlEntryRequestObject struct {
+	EntryId externalRef2.UUID `json:"entryId"`
+}
+
+type DeleteAccessControlEntryResponseObject interface {
+	VisitDeleteAccessControlEntryResponse(w http.ResponseWriter) error
+}
+
+type DeleteAccessControlEntry204Response struct {
+}
+
+func (response DeleteAccessControlEntry204Response) VisitDeleteAccessControlEntryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAccessControlEntrydefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response DeleteAccessControlEntrydefaultApplicationProblemPlusJSONResponse) VisitDeleteAccessControlEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListAccessGroupsRequestObject struct {
+}
+
+type ListAccessGroupsResponseObject interface {
+	VisitListAccessGroupsResponse(w http.ResponseWriter) error
+}
+
+type ListAccessGroups200JSONResponse AccessGroupList
+
+func (response ListAccessGroups200JSONResponse) VisitListAccessGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAccessGroupsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response ListAccessGroupsdefaultApplicationProblemPlusJSONResponse) VisitListAccessGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAccessGroupRequestObject struct {
+	Body *CreateAccessGroupJSONRequestBody
+}
+
+type CreateAccessGroupResponseObject interface {
+	VisitCreateAccessGroupResponse(w http.ResponseWriter) error
+}
+
+type CreateAccessGroup201ResponseHeaders struct {
+	Location string
+}
+
+type CreateAccessGroup201JSONResponse struct {
+	Body    AccessGroup
+	Headers CreateAccessGroup201ResponseHeaders
+}
+
+func (response CreateAccessGroup201JSONResponse) VisitCreateAccessGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAccessGroupdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response CreateAccessGroupdefaultApplicationProblemPlusJSONResponse) VisitCreateAccessGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteAccessGroupRequestObject struct {
+	GroupId externalRef2.UUID `json:"groupId"`
+}
+
+type DeleteAccessGroupResponseObject interface {
+	VisitDeleteAccessGroupResponse(w http.ResponseWriter) error
+}
+
+type DeleteAccessGroup204Response struct {
+}
+
+func (response DeleteAccessGroup204Response) VisitDeleteAccessGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAccessGroupdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response DeleteAccessGroupdefaultApplicationProblemPlusJSONResponse) VisitDeleteAccessGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListAccessGroupMembersRequestObject struct {
+	GroupId externalRef2.UUID `json:"groupId"`
+}
+
+type ListAccessGroupMembersResponseObject interface {
+	VisitListAccessGroupMembersResponse(w http.ResponseWriter) error
+}
+
+type ListAccessGroupMembers200JSONResponse AccessGroupMemberList
+
+func (response ListAccessGroupMembers200JSONResponse) VisitListAccessGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAccessGroupMembersdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response ListAccessGroupMembersdefaultApplicationProblemPlusJSONResponse) VisitListAccessGroupMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemoveAccessGroupMemberRequestObject struct {
+	GroupId externalRef2.UUID `json:"groupId"`
+	UserId  externalRef2.UUID `json:"userId"`
+}
+
+type RemoveAccessGroupMemberResponseObject interface {
+	VisitRemoveAccessGroupMemberResponse(w http.ResponseWriter) error
+}
+
+type RemoveAccessGroupMember204Response struct {
+}
+
+func (response RemoveAccessGroupMember204Response) VisitRemoveAccessGroupMemberResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveAccessGroupMemberdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response RemoveAccessGroupMemberdefaultApplicationProblemPlusJSONResponse) VisitRemoveAccessGroupMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AddAccessGroupMemberRequestObject struct {
+	GroupId externalRef2.UUID `json:"groupId"`
+	UserId  externalRef2.UUID `json:"userId"`
+}
+
+type AddAccessGroupMemberResponseObject interface {
+	VisitAddAccessGroupMemberResponse(w http.ResponseWriter) error
+}
+
+type AddAccessGroupMember204Response struct {
+}
+
+func (response AddAccessGroupMember204Response) VisitAddAccessGroupMemberResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddAccessGroupMemberdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response AddAccessGroupMemberdefaultApplicationProblemPlusJSONResponse) VisitAddAccessGroupMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List ACL entries
+	// (GET /access-control/entries)
+	ListAccessControlEntries(ctx context.Context, request ListAccessControlEntriesRequestObject) (ListAccessControlEntriesResponseObject, error)
+	// Create ACL entry
+	// (POST /access-control/entries)
+	CreateAccessControlEntry(ctx context.Context, request CreateAccessControlEntryRequestObject) (CreateAccessControlEntryResponseObject, error)
+	// Delete ACL entry
+	// (DELETE /access-control/entries/{entryId})
+	DeleteAccessControlEntry(ctx context.Context, request DeleteAccessControlEntryRequestObject) (DeleteAccessControlEntryResponseObject, error)
+	// List access groups
+	// (GET /access-control/groups)
+	ListAccessGroups(ctx context.Context, request ListAccessGroupsRequestObject) (ListAccessGroupsResponseObject, error)
+	// Create access group
+	// (POST /access-control/groups)
+	CreateAccessGroup(ctx context.Context, request CreateAccessGroupRequestObject) (CreateAccessGroupResponseObject, error)
+	// Delete access group
+	// (DELETE /access-control/groups/{groupId})
+	DeleteAccessGroup(ctx context.Context, request DeleteAccessGroupRequestObject) (DeleteAccessGroupResponseObject, error)
+	// List group members
+	// (GET /access-control/groups/{groupId}/members)
+	ListAccessGroupMembers(ctx context.Context, request ListAccessGroupMembersRequestObject) (ListAccessGroupMembersResponseObject, error)
+	// Remove group member
+	// (DELETE /access-control/groups/{groupId}/members/{userId})
+	RemoveAccessGroupMember(ctx context.Context, request RemoveAccessGroupMemberRequestObject) (RemoveAccessGroupMemberResponseObject, error)
+	// Add group member
+	// (PUT /access-control/groups/{groupId}/members/{userId})
+	AddAccessGroupMember(ctx context.Context, request AddAccessGroupMemberRequestObject) (AddAccessGroupMemberResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListAccessControlEntries operation middleware
+func (sh *strictHandler) ListAccessControlEntries(w http.ResponseWriter, r *http.Request, params ListAccessControlEntriesParams) {
+	var request ListAccessControlEntriesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAccessControlEntries(ctx, request.(ListAccessControlEntriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAccessControlEntries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAccessControlEntriesResponseObject); ok {
+		if err := validResponse.VisitListAccessControlEntriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAccessControlEntry operation middleware
+func (sh *strictHandler) CreateAccessControlEntry(w http.ResponseWriter, r *http.Request) {
+	var request CreateAccessControlEntryRequestObject
+
+	var body CreateAccessControlEntryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAccessControlEntry(ctx, request.(CreateAccessControlEntryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAccessControlEntry")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAccessControlEntryResponseObject); ok {
+		if err := validResponse.VisitCreateAccessControlEntryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAccessControlEntry operation middleware
+func (sh *strictHandler) DeleteAccessControlEntry(w http.ResponseWriter, r *http.Request, entryId externalRef2.UUID) {
+	var request DeleteAccessControlEntryRequestObject
+
+	request.EntryId = entryId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAccessControlEntry(ctx, request.(DeleteAccessControlEntryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAccessControlEntry")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAccessControlEntryResponseObject); ok {
+		if err := validResponse.VisitDeleteAccessControlEntryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAccessGroups operation middleware
+func (sh *strictHandler) ListAccessGroups(w http.ResponseWriter, r *http.Request) {
+	var request ListAccessGroupsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAccessGroups(ctx, request.(ListAccessGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAccessGroups")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAccessGroupsResponseObject); ok {
+		if err := validResponse.VisitListAccessGroupsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAccessGroup operation middleware
+func (sh *strictHandler) CreateAccessGroup(w http.ResponseWriter, r *http.Request) {
+	var request CreateAccessGroupRequestObject
+
+	var body CreateAccessGroupJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAccessGroup(ctx, request.(CreateAccessGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAccessGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAccessGroupResponseObject); ok {
+		if err := validResponse.VisitCreateAccessGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAccessGroup operation middleware
+func (sh *strictHandler) DeleteAccessGroup(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID) {
+	var request DeleteAccessGroupRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAccessGroup(ctx, request.(DeleteAccessGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAccessGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAccessGroupResponseObject); ok {
+		if err := validResponse.VisitDeleteAccessGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAccessGroupMembers operation middleware
+func (sh *strictHandler) ListAccessGroupMembers(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID) {
+	var request ListAccessGroupMembersRequestObject
+
+	request.GroupId = groupId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAccessGroupMembers(ctx, request.(ListAccessGroupMembersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAccessGroupMembers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAccessGroupMembersResponseObject); ok {
+		if err := validResponse.VisitListAccessGroupMembersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveAccessGroupMember operation middleware
+func (sh *strictHandler) RemoveAccessGroupMember(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID, userId externalRef2.UUID) {
+	var request RemoveAccessGroupMemberRequestObject
+
+	request.GroupId = groupId
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveAccessGroupMember(ctx, request.(RemoveAccessGroupMemberRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveAccessGroupMember")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveAccessGroupMemberResponseObject); ok {
+		if err := validResponse.VisitRemoveAccessGroupMemberResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddAccessGroupMember operation middleware
+func (sh *strictHandler) AddAccessGroupMember(w http.ResponseWriter, r *http.Request, groupId externalRef2.UUID, userId externalRef2.UUID) {
+	var request AddAccessGroupMemberRequestObject
+
+	request.GroupId = groupId
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddAccessGroupMember(ctx, request.(AddAccessGroupMemberRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddAccessGroupMember")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddAccessGroupMemberResponseObject); ok {
+		if err := validResponse.VisitAddAccessGroupMemberResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xa23IbuRH9lS5kH+xkaFKyN+swT4zsOKrIsSJLL1EYFThocrA7A4wBDC1GxX9P4cK5",
+	"82KJ65SdvNgcEtM46D59ugHogcQyy6VAYTQZPxAdJ5hR93ESx6j1mRRGyfStMGplv2WoY8Vzw6UgY/JO",
+	"UWFAzoFCjirjWnMpQAqg4C1BTA0upFqBVEAFoDDcrMDQWYovSERyJXNUhqObcjP4nNknmqYf5mR8+0B+",
+	"UDgnY/KbYQV2GJDarzIp7nLFM274EvXdzc35G7KeRi2sH1uATIKwcPhpnqccNRgZARdxWjAuFsCNBl3M",
+	"BuEFjtoCFkWaWvBkbFSB64jECqlBNjFk/KVAr3mG2tAsJ+uIoDCbhT9muRGpArDPho/sZTXevq24iHlO",
+	"0ydB2Bi5XuW4z8xlY/A6Io4Uf6MZPiX416WRLgPe1sjXG/6eAK8jovBTwRUyMr4to9Rea9OBjWDUKTKN",
+	"iHGuIXL2M8bGLrubZxdcOzY1k4MbzJof9se4bpWsy8mpUnRF2mvzdrdDfKdkkXdxHSsBGrF6IBm9v0Cx",
+	"MAkZ/3hy2o5LCVIbxcXCvr+w8J7CXhGoV5v55PR1RDIuyufOvC0fbkAEa4fF3jn2eEH3cTpKtN9jNkPV",
+	"hUUZO0LEC43q8QFrrScYi0psBy3tyF4P/nqq7y8bUt4Useo3SHGJ6R8BaZz4z6F6oXbqJgVqmOFcKgRu",
+	"4JlCyuCfxWj0MobPihvcPFCWcfHcih+KIrMA7VASETfKuTTjgkw75I/ImaN3V2yu8FOB2nThv72nsUlX",
+	"FpztG6qKbxuEsgJAVmgDM4RcySVnyPb1Cv8vmU8olC12HlzZ+ghcJ4RLiRoTmgF8qt4fQ66djb5lXLbD",
+	"0mTxX7lglr6la2xvOzm7ANcfgKFqgUbXM8rKEwlFqjeTdkWoM/+F/IwqphpBC/oL3rmPl1KbhcKPf78I",
+	"LQ5nKAyfczcz3tMsT63rb0lMFdN39kcXDK/Ed7mSc25HTCOSU2NQ2an+dUsH/57af0aDP9xNf/sDOQx8",
+	"KfQd8OcfP8Dr349OwGzGABdwc33WQnk6Ov1xcDIanLy8Pnk1fjkaj0b/sNjmUmXUkDFh1ODAGjkMkku5",
+	"DpqrP5/Bq5PTU7A/Q3i/NklRcLbTvpylmDE0lKf67tI/vvGP/bP99Hr0E4SBsBkZdbLDft81MIGkyKgY",
+	"WIV2Qcb7PKWC2p9B5xjzOY/BSDAJ1yDjuFAKRezE1haFgLdvRaiUVJvyzq1Bml7218XOu81y1268P+Te",
+	"GmQ0t0DmHFM28CVrSVPOPPwAoCcdudCGihj7/HFzdQ4K5+iXaRJqKuL7Qli65YvcoQ01RU8IrxOEv1xf",
+	"X4IfALFkNQJyYXARWgBu0l7EOpHKRO1A6iLLqFq1kIHxIrzF449xR8tyxXTFyT7B9GsqndOVzrWL1lxu",
+	"OyuwSqOBCgZOCjXY9UehH5HK9yJAXf2wNG4eI3D079aPEZzMBl+H/glCKwKTy3MSkSUqX+PJ8sS6TuYo",
+	"aM7JmLx8MXrxiji5S1yoh37mQewNDFEYFci/wJ525gpNoYQutZ+jjkAGuqcrSK36ILMrOehIBM5omloP",
+	"feYmkYVxAfM+UTINfdGcpwYVzFZAQaGWhXKRxpUfybVBZZ1iU9dllm1QiG11O42al/+cKpqhQZv9t+0l",
+	"fhDpCpRb52aJfuOMzJ70OJ1prcxOzu27nwpUq81maFzv2aJw1PT47v8xONvHT30gq57q8RjrzdXUZpDO",
+	"pdCeR6ejkf3PMgyFo5Q7gIhdpIY/a98OHTbzlrMDl4UtYagICnM0cYIMdOFenxdpGkR7TovU7EAXpON3",
+	"X4byoFLZg/mtUlLBs03NfO7UKMhkIHQ98Yjthxeudejl+dS221KbLdKkgTp1smnptOmAQ81nXCSoXIbP",
+	"Vj0nhk6sTII87G30c2vdm+JikWKTkXDlpVa3094rhf/CH1o5RmOZ/t1037YzI17QUZs/SbY6GhP3bQTX",
+	"zUoSDk9biXHyKybGrqRYQTimIRFJkDL0bdCF9HN3CXNzdbGp0wI/p+X71R6gIR7tmrr+9pLNx7exvj3J",
+	"to62VdPhQzhGXXvPpmiwr7Qu5S8uhapZj5ohb9zEWzKkwctXPY1WSR2Pn32DAuod8CUx3dMqnJcbzk12",
+	"1G27Yms7rarWVsfpTWl4cncw7WGfbzj3tnK4RLXadKDuHZCKofIab4Hv6q3e+Ul+9ZpfnRn36VoN/PdV",
+	"7uth6Rb84PzthX6Leuyuns7qVyibjeOy/0q9DDcHuwl1tFpZj+V3Wi5bS+xna0TuB5tIDywd3Zhw5r7e",
+	"KmTDh3DZtLOKeo3X4brThs/IBZoEldtiup41c7cWOuG5b1hrPfULODhl6uW0Spm9dbTOrG++lB4h3l9c",
+	"Yltz9lTZ6lLyq1XZipzDwK5a3d1ZO9+H4V+ngtZuAXuC7oZs0uO7qqOL+sp21tHvkozDB39dvGcDcqDy",
+	"XWEml9hh1UH6d6NRgXIGGMyVzCql/ga55T3RYNf/gAxG+9GF678eVOXfLRw7HSKSFz0t8IQxXULyF0Uh",
+	"YC9gwtxfvlEBeM+1sZ99EIHb0zEhBzLf2hFE9kgrxlIuF5SLsJnyG/JyIkhkynQ3jyaMPSGJHMQAV86r",
+	"2b7BNJowdowcckYxLhQ3K5dDM6QK1aQwCRnfTi1FNKrlJsMKlZIxGdKcD+09ybSctO3u91TQBdbbRHvY",
+	"csBNTcX6LRcR21cT7qgzN7UKj1YntoALe185DzdO7iKsjjimItzTt1GVW/iD0UzX0/V/BgAigmHRSisA",
+	"AA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/iam.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef1.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/pagination.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef2.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/primitives.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef3.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/problemdetails.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
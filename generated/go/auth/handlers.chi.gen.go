@@ -30,7 +30,10 @@ const (
 
 // AccessTokenResponse defines model for AccessTokenResponse.
 type AccessTokenResponse struct {
-	AccessToken  string             `json:"accessToken"`
+	AccessToken string `json:"accessToken"`
+
+	// ExpiresIn Access token lifetime in seconds
+	ExpiresIn    *int               `json:"expiresIn,omitempty"`
 	RefreshToken *string            `json:"refreshToken,omitempty"`
 	User         *externalRef3.User `json:"user,omitempty"`
 }
@@ -68,6 +71,9 @@ type User struct {
 	Id    externalRef1.UUID       `json:"id"`
 	Roles []externalRef0.UserRole `json:"roles"`
 
+	// Status Approval lifecycle status. Only approved and enabled users can access the API. Transitions: pending -> approved | rejected, rejected -> approved, approved | enabled -> disabled, disabled -> enabled.
+	Status *externalRef3.UserStatus `json:"status,omitempty"`
+
 	// UpdatedAt ISO 8601 timestamp in UTC
 	UpdatedAt externalRef1.Timestamp `json:"updatedAt"`
 }
@@ -607,33 +613,38 @@ func (sh *strictHandler) AuthSignup(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xYeW/juBX/Kg9s/9hFfefYGRcFmmamrYLJsV5nJycCWny2mFCkQlJx1MDfvSAlH5Ll",
-	"iVNsCky7fwSIaPK9H3/v5gsJVZwoidIa0n8hJowwpv7fgzBEY4bqAeUATaKkQbecaJWgthz9Jrrc5D5t",
-	"liDpE2M1lxMyaxCNY40m2rwhNajdD3/UOCZ98of2Ek+7ANN2e8zduds58zIfU66Rkf51Sf9tYy5eje4x",
-	"tE78FzXhcoCPKRq7jh5jysVr2kMVx0reJZrH3PInNHef/bFZgyTUmKnSzIkYKx1TS/rLxUb1thXsufYV",
-	"KXUXGOQEbrzCKwRXVJZ216n7hU9kmrwbYeNUiBMaY60r/MZsrmiru+l54XgMTah5YrmSpE+OueQxFeBc",
-	"Dp44TkGjTbVEBqMMaGojQMkSxV28NCrshBqpRXZg387QkMdoLI0TB+1dWebs7ZLPz4NPPpqVyG/KLcZm",
-	"Szmcxj52B0qgE1IgolrTzGeAhP0GrFW8gTv7z11iwcf8Bo0VU60CqPOTulusuc1BkggeUvcFToVTLtPY",
-	"ZygWc0nyRHcXU0knqIvPFX1L+2yy65pOvwyUMY3GQIIaBn8/hL2dXg9+MDxOBB9zZD86KM80Trzlrr3e",
-	"vxYLrVDFDsMi2OaEbQFqSf0asOCXU/iw3+mCne8BLuF8eFiB0uv09prdTrO7M+zu9nc6/U7nqgTHmaXp",
-	"hGwHyXvpGhpHym631wP3MxTnV5SkKWfflK9GAmOGlnJh7s7yz0/5Z722nz50foJiI8x3VnNFLrDGkyBK",
-	"YyqbGimjI4GAz4mgMnctk2DIxzwEq8BG3IAKw1RrlCGCGoONEAq8dTdCrZXOqzZj3Amk4qwEahHWa2er",
-	"QVsGfZrk0iCmiQMy5ihYU+ATCniigrMcfgGgJsi4NJbKsC6y4HwQgMYx5te0EbXAGUrr3Nv4Oy9oeRMd",
-	"xlKb1phwGCH8czg8g3wDhIqtOCCXFl0EO064rc0FYCKlbaNqSJPGMdVZBRl4uY1NjP8ndFQkLz1d81er",
-	"aH6nBTl1CXGlG1vrD/7nK+B/tVhtW6RmPoLGqsZdXMPCVEy5hFBJq2lo+2B8k9cA4ZrjBhRNof9WqW1B",
-	"ICPU3BqYCDWiAo6+DmGEVKMGg2Gquc3+XAhpexntQgRQjZCkI8HDFlkECDmjIs40dTkRDs4C0iBPqE2O",
-	"8KnraFUJSppw0ic7rU5r17dxNvIu1XZNV67G+5vK+9JK2vVNmgEqIZ8IwLoWF6hk8+vlKy04phmMELSr",
-	"LcIZB5nD6rzYZ6mAFbz50YHklkJj/6ZY5l1cSYvSY6DLqt++N0ouB6jXvKM0lszK/mB1in4hn7k8Cb1O",
-	"Zwvdy+L6QuJUWK6LZqVIPS6BL+clmHIbQUIzoShr+cYI/gLXNz7Ab0gDbko9yw25dYajIsW1wY9gdhSN",
-	"/hHyU34UnP8r6J7wwARysBceBvvBQ3Lx6+HRxxZmR9mo9yzCLNj/mh11w96vWcCnnF2ciFB8tJdf96Ir",
-	"tyY6JohFxA6D/ePh5d7xp4Op/zuc8quLaBrcq+eT+we35n8b/9wyweSkKS7U5d7jke0OenpHdtPTHf7h",
-	"YU9ejn7+yK+SzsVjd9rDA1IdSonxrDWL1ab3k9wo2xmzblD2x9dj0eXpok00qT82TkVeT8c0FfYbRi6y",
-	"+p/e5mhbdTE1YD+7Ug0/zNuZH33Omgc/6V/fNlac6ouauA7P+xPmXalksDq50Ynv+BwF5NaJWkS1Su3m",
-	"sA5k0T0UlS2v7raI8XYpsk0LBnkMGT+nLaneGN5O91qg7a7D+KImE2SgUrtiNZF9P3YrWcpdY06kn0I2",
-	"26cgeLOBPj+HEZUTBFpOszBWGihInJby8Ruyb/Hu8U75t/Kq8nsG/r/IwCcVdwRuTIrsu8+/g3n3tXK3",
-	"b0R13rltDupD33YCzR/BaBiqVNoWnKFkXE6AJolWT1TAVOmHsVBTiGnmVkVWH8r5m+I7RXL5wXKrQO6+",
-	"SXl5wNnmubr2obry3rPaupfZd6eh6Py/e8901oE0KUrBhmpTPv9C8lHD/9q/vp05gajdxOB/TbUgfdKm",
-	"CW+72eF2Ie+FSD/x5XJnt7N/BwAA//9TL86L3BgAAA==",
+	"H4sIAAAAAAAC/+wZa2/buvWvHHD70GKKX2l6W18MWJZ2m4I2yXWd27RJENDiscWGIlWSsqNl+e8DKcmW",
+	"bLlxhnVDce8HAyZ5eN5P6p5EKkmVRGkNGd4TE8WYUP/3MIrQmLG6RTlCkypp0G2nWqWoLUcPRFdAbmnz",
+	"FMmQGKu5nJGHgOBdyjWa0J8yNJHmqeVKkmGJH6y7C4JP0fIEgUswGCnJDAkqdFxanKF2+DRONZp4O8HM",
+	"oHYHf9Q4JUPyh+5Kvm4pXNfBmJtzB/ngcX7NuEZGhpcNea6XDKjJF4ysQ/9Ozbgc4dcMjd3UBiaUi8eo",
+	"RypJlLxJNU+45XM0N2/9tYeApNSYhdLMoZgqnVBLhqvNYF3aNd4L6jUsbQKMCgVuFeERBa+RbEC3kfvA",
+	"ZzJLv5vCppkQJzTBVld4TJsJvXuHcmZjMuwPXgUk4bJav3qyrmu8tOnhvHTLZgi855InVIBzSJhzXIBG",
+	"m2mJDCY50MzGgJKlirvoDNZ0F2mkFtmhfbr+xjxBY2mS+hD9njbg7OmYz8/DN+6uVqKQlFtMzI54OE18",
+	"ZI+UQIek5IhqTXO3NpbazOyeIT4U8C6zpOy/oO81P+KMVBaoabKSPagZuc5Am4e1yb+Zc9NU8Ii6FTgS",
+	"jrjMEp/5WMIlKRLoTUIldRm3WNborSy7zSM2aPptoIxpl+5T1DD62xEc7A8G8MzwJBV8ypE9d6zc0ST1",
+	"Nr/0dP9SbnQilTgelkFcKWwHplaq32As/HAKr172+mArGFd9zsdHa6wMeoODvX5vr78/7r8Y7veGvd7n",
+	"BjvOLHsOyW4sef/e4MYp5UV/MAB3DOX9GpEs4+yb+NVEYMLQUi7MzVmxfFMs26n99Kr3E5SAUEGuZ5kC",
+	"YYsnQZwlVO5ppIxOBALepYLKwrVMihGf8gisAhtzAyqKMq1RRghqCjZGKPltkwi1VtoTp4xxh5CKswZT",
+	"y4SwcXc93JtMn6YFNkho6hiZchRsT+AcBcyp4Kxgv2SgJci4NJbKqC2y4HwUgsYpFmLamFrgDKV17m28",
+	"zEu1PEkdq4zVpDiOEf4xHp9BAQCRYtjaM1luW3MBmFhpG6wb0mRJQnW+xhl4vME2jf8n6ljDvPJ0zR/t",
+	"dQqZlsppS4i1Lm+j7/i9dv5va2dxc4TUqJZJoNgHjZHSDBksuI29hwhqLGh0FnWQLd73fynKpR6eUp03",
+	"lNJWmrWaU+FHoSiPXCR60A6cSpED9efIgEoGKF2oMt84GoioBFpOUzHC4VnYgbGm0vjkaYaQomRczmDv",
+	"Kuv19nGF61+ldpEFy38bUEEdvqJcATFu/Eaw/Lc8KkE7tSajZIQEpELp1FoS9nD+CglIha2l93jwiXiq",
+	"WnToOmamEsolREpaTSM7BONnkACEm90CKGcWv1aZ7UAoY9TcGpgJNaECjj+OYYJUo3ajaKa5zX8ukXQ9",
+	"jm6JAqhGSLOJ4JETssyz5IyKJNfUlVZnCxKQOWpTcDjvO29QKUqacjIk+51e54WfI2zsnaLruv6CjFum",
+	"ythNOUd+SjBQs7ufop1rVLz5nQ68pzlMELRrUYTz/cIgLhn6YheyUm9+siVFIKCxf1Us95lSSYvS80BX",
+	"zWP3SxnIRXg9FnyNqfmhGW5WZ+g3iicGr4RBr7cD7VWPdk+STFiuy563rGBkWB/ni6yS0lwoyjoO1MCf",
+	"4fLKh+YVCeCq0fpekWtnOCoy3HjnIJgfx5O/R/yUH4fn/wz7Jzw0oRwdREfhy/A2vfj16Ph1B/PjfDK4",
+	"E1EevvyYH/ejwa95yBecXZyISLy2nz4exJ/dnuiZMBExOwpfvh9/Onj/5nDhf0cL/vkiXoRf1N3Jl1u3",
+	"58+mv3RMODvZExfq08HXY9sfDfS+7Gen+/zV7YH8NPnlNf+c9i6+9hcDPCTrbybEeK3tlbt73k8Ko+xm",
+	"zLZ3IX99MxZduS+nDZP5a9NMFG3ZlGbCfsPIZXPwp6c52k7NcAuzb7VWGp5VXfFzXxKq4CfDy+ug5lTv",
+	"1Ax46U9YDDeSQf2Zhs784OBUQK4dqmVUq8x+K6zn6rbqFdG4lAETjLlkfqvoGW0j5APgsupc5QxcFiuP",
+	"N3KB6cCoiDoDtGGcrQnBcbsRmi82GX+nZjNkoDJbs7PIfxxLN2zrxKh07cff7RYtFbzdpG/vopjKGQJt",
+	"GgOmSgMFiYuGOZ2J6iYDrSy1CG4omaPOHT8/Q6rROOvJma8AwrXu/mjN4qBrDrWIlVi61RPqQsnQd6oM",
+	"a8+Rv9eG30RtOFlze+DGZMh++MpQBW9dtm9kj6Kn3J48jvx4AbR4H6ZRpDJpC1+ly3oDz4SKqCiHa5u7",
+	"sXrOGernHSj17K4ZN01ouxwFaDlutAd98Wz/nWK++U1gp5DvP4l4c9bf5YtQ67egtafPapjb9BF3G8pZ",
+	"8If3YWcdyNKyOG2pf83796QYl/zp8PL6wSFE7aYef5ppQYakS1PedfPP9RLfPZH+8aPA+3D98O8BABuA",
+	"gqCPHAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
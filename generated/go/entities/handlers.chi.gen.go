@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -29,6 +30,68 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for EntityImportEntityId.
+const (
+	EntityImportEntityIdKey  EntityImportEntityId = "key"
+	EntityImportEntityIdUuid EntityImportEntityId = "uuid"
+)
+
+// Defines values for EntityImportFormat.
+const (
+	EntityImportFormatCsv    EntityImportFormat = "csv"
+	EntityImportFormatNdjson EntityImportFormat = "ndjson"
+)
+
+// Defines values for EntityImportStatus.
+const (
+	EntityImportStatusFailed    EntityImportStatus = "failed"
+	EntityImportStatusQueued    EntityImportStatus = "queued"
+	EntityImportStatusRunning   EntityImportStatus = "running"
+	EntityImportStatusSucceeded EntityImportStatus = "succeeded"
+)
+
+// Defines values for EntityImportOutcome.
+const (
+	Accepted  EntityImportOutcome = "accepted"
+	Rejected  EntityImportOutcome = "rejected"
+	Unchanged EntityImportOutcome = "unchanged"
+	Updated   EntityImportOutcome = "updated"
+)
+
+// Defines values for JobReferenceStatus.
+const (
+	JobReferenceStatusCancelled JobReferenceStatus = "cancelled"
+	JobReferenceStatusFailed    JobReferenceStatus = "failed"
+	JobReferenceStatusQueued    JobReferenceStatus = "queued"
+	JobReferenceStatusRunning   JobReferenceStatus = "running"
+	JobReferenceStatusSucceeded JobReferenceStatus = "succeeded"
+)
+
+// Defines values for ExportDocumentsParamsFormat.
+const (
+	ExportDocumentsParamsFormatCsv    ExportDocumentsParamsFormat = "csv"
+	ExportDocumentsParamsFormatJson   ExportDocumentsParamsFormat = "json"
+	ExportDocumentsParamsFormatNdjson ExportDocumentsParamsFormat = "ndjson"
+)
+
+// Defines values for ExportDocumentsParamsCompression.
+const (
+	Gzip ExportDocumentsParamsCompression = "gzip"
+	None ExportDocumentsParamsCompression = "none"
+)
+
+// Defines values for ImportDocumentsParamsFormat.
+const (
+	Csv    ImportDocumentsParamsFormat = "csv"
+	Ndjson ImportDocumentsParamsFormat = "ndjson"
+)
+
+// Defines values for ImportDocumentsParamsEntityId.
+const (
+	ImportDocumentsParamsEntityIdKey  ImportDocumentsParamsEntityId = "key"
+	ImportDocumentsParamsEntityIdUuid ImportDocumentsParamsEntityId = "uuid"
+)
+
 // CreateEntityDocumentRequest defines model for CreateEntityDocumentRequest.
 type CreateEntityDocumentRequest struct {
 	// EntityId Client-supplied identifier for immutable entity records. Accepts any characters but must be non-empty and at most 128 characters after trimming.
@@ -61,11 +124,107 @@ type EntityDocument struct {
 
 	// SchemaVersion Semantic version string in major.minor.patch format
 	SchemaVersion externalRef2.SemanticVersion `json:"schemaVersion"`
+
+	// Slug Kebab-case slug used in URLs
+	Slug externalRef2.Slug `json:"slug"`
+}
+
+// EntityImport defines model for EntityImport.
+type EntityImport struct {
+	Counts EntityImportCounts `json:"counts"`
+
+	// CreatedAt ISO 8601 timestamp in UTC
+	CreatedAt externalRef2.Timestamp `json:"createdAt"`
+	EntityId  EntityImportEntityId   `json:"entityId"`
+
+	// Error Why a failed import stopped. Rejected lines do not fail an import.
+	Error  *string   `json:"error,omitempty"`
+	Fields *[]string `json:"fields,omitempty"`
+
+	// FinishedAt ISO 8601 timestamp in UTC
+	FinishedAt *externalRef2.Timestamp `json:"finishedAt,omitempty"`
+	Format     EntityImportFormat      `json:"format"`
+
+	// ImportId RFC 4122 UUID string
+	ImportId externalRef2.UUID `json:"importId"`
+
+	// JobId RFC 4122 UUID string
+	JobId    *externalRef2.UUID `json:"jobId,omitempty"`
+	KeyField string             `json:"keyField"`
+
+	// Namespace RFC 4122 UUID string
+	Namespace    *externalRef2.UUID `json:"namespace,omitempty"`
+	SlugTemplate *string            `json:"slugTemplate,omitempty"`
+
+	// StartedAt ISO 8601 timestamp in UTC
+	StartedAt *externalRef2.Timestamp `json:"startedAt,omitempty"`
+	Status    EntityImportStatus      `json:"status"`
+
+	// TableName Lowercase snake_case PostgreSQL table identifier
+	TableName externalRef2.TableName `json:"tableName"`
+}
+
+// EntityImportEntityId defines model for EntityImport.EntityId.
+type EntityImportEntityId string
+
+// EntityImportFormat defines model for EntityImport.Format.
+type EntityImportFormat string
+
+// EntityImportStatus defines model for EntityImport.Status.
+type EntityImportStatus string
+
+// EntityImportCounts defines model for EntityImportCounts.
+type EntityImportCounts struct {
+	// Accepted Lines that created a document.
+	Accepted int64 `json:"accepted"`
+
+	// Rejected Invalid lines; see the import results.
+	Rejected int64 `json:"rejected"`
+
+	// Unchanged Lines matching the active version; nothing was written.
+	Unchanged int64 `json:"unchanged"`
+
+	// Updated Lines that wrote a new version of an existing document.
+	Updated int64 `json:"updated"`
+}
+
+// EntityImportOutcome defines model for EntityImportOutcome.
+type EntityImportOutcome string
+
+// EntityImportResult defines model for EntityImportResult.
+type EntityImportResult struct {
+	// EntityId Client-supplied identifier for immutable entity records. Accepts any characters but must be non-empty and at most 128 characters after trimming.
+	EntityId *externalRef2.EntityIdentifier `json:"entityId,omitempty"`
+
+	// Error Why the line was rejected.
+	Error *string `json:"error,omitempty"`
+
+	// Line 1-based line of the record in the upload.
+	Line    int                 `json:"line"`
+	Outcome EntityImportOutcome `json:"outcome"`
 }
 
-// UpdateEntityDocumentRequest defines model for UpdateEntityDocumentRequest.
+// JobReference A queued background job; see the Jobs API for its progress and result.
+type JobReference struct {
+	// CreatedAt ISO 8601 timestamp in UTC
+	CreatedAt externalRef2.Timestamp `json:"createdAt"`
+
+	// JobId RFC 4122 UUID string
+	JobId  externalRef2.UUID  `json:"jobId"`
+	Kind   string             `json:"kind"`
+	Status JobReferenceStatus `json:"status"`
+}
+
+// JobReferenceStatus defines model for JobReference.Status.
+type JobReferenceStatus string
+
+// UpdateEntityDocumentRequest At least one of payload and slug is required.
 type UpdateEntityDocumentRequest struct {
+	// Payload Replaces the document body; when omitted the current body is kept.
 	Payload *map[string]interface{} `json:"payload,omitempty"`
+
+	// Slug Kebab-case slug used in URLs
+	Slug *externalRef2.Slug `json:"slug,omitempty"`
 }
 
 // ListDocumentsParams defines parameters for ListDocuments.
@@ -80,6 +239,63 @@ type ListDocumentsParams struct {
 	Sort *externalRef1.Sort `form:"sort,omitempty" json:"sort,omitempty"`
 }
 
+// ExportDocumentsParams defines parameters for ExportDocuments.
+type ExportDocumentsParams struct {
+	// Format Output encoding. `ndjson` writes one EntityDocument per line, `json` a single array of them and
+	// `csv` one row per document with the payload flattened into columns by the schema's properties.
+	Format *ExportDocumentsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Fields Payload fields (dotted paths) to keep; CSV columns follow this order.
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Filter Equality filter on a payload field as `path=value`, compared as text. Repeatable.
+	Filter *[]string `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// ChangedSince Only documents whose active version was written at or after this time.
+	ChangedSince *externalRef2.Timestamp           `form:"changedSince,omitempty" json:"changedSince,omitempty"`
+	Compression  *ExportDocumentsParamsCompression `form:"compression,omitempty" json:"compression,omitempty"`
+}
+
+// ExportDocumentsParamsFormat defines parameters for ExportDocuments.
+type ExportDocumentsParamsFormat string
+
+// ExportDocumentsParamsCompression defines parameters for ExportDocuments.
+type ExportDocumentsParamsCompression string
+
+// ImportDocumentsParams defines parameters for ImportDocuments.
+type ImportDocumentsParams struct {
+	// Format Encoding of the upload; `ndjson` holds one payload per line, `csv` one per row.
+	Format *ImportDocumentsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// KeyField Payload field (dotted path) holding each record's unique key.
+	KeyField string `form:"keyField" json:"keyField"`
+
+	// EntityId How document ids are derived from the key: `key` uses the key itself, `uuid` a name-based
+	// (v5) UUID of the key in `namespace`.
+	EntityId *ImportDocumentsParamsEntityId `form:"entityId,omitempty" json:"entityId,omitempty"`
+
+	// Namespace UUID namespace for `entityId=uuid`; defaults to the URL namespace.
+	Namespace *externalRef2.UUID `form:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// SlugTemplate Derives slugs from payload fields, e.g. `{name}-{number}`; defaults to the schema's template, then the key.
+	SlugTemplate *string `form:"slugTemplate,omitempty" json:"slugTemplate,omitempty"`
+
+	// Fields Top-level payload fields to keep; others are dropped and counted. All fields when omitted.
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// ImportDocumentsParamsFormat defines parameters for ImportDocuments.
+type ImportDocumentsParamsFormat string
+
+// ImportDocumentsParamsEntityId defines parameters for ImportDocuments.
+type ImportDocumentsParamsEntityId string
+
+// GetImportResultsParams defines parameters for GetImportResults.
+type GetImportResultsParams struct {
+	// Outcome Only results with this outcome, e.g. `rejected` for the error report.
+	Outcome *EntityImportOutcome `form:"outcome,omitempty" json:"outcome,omitempty"`
+}
+
 // CreateDocumentJSONRequestBody defines body for CreateDocument for application/json ContentType.
 type CreateDocumentJSONRequestBody = CreateEntityDocumentRequest
 
@@ -88,6 +304,9 @@ type UpdateDocumentJSONRequestBody = UpdateEntityDocumentRequest
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get document by slug
+	// (GET /entities/{tableName}/by-slug/{slug})
+	GetDocumentBySlug(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, slug externalRef2.Slug)
 	// List documents
 	// (GET /entities/{tableName}/documents)
 	ListDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ListDocumentsParams)
@@ -103,12 +322,33 @@ type ServerInterface interface {
 	// Update document (partial)
 	// (PATCH /entities/{tableName}/documents/{entityId})
 	UpdateDocument(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, entityId externalRef2.EntityIdentifier)
+	// Export documents
+	// (GET /entities/{tableName}/export)
+	ExportDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ExportDocumentsParams)
+	// Import documents
+	// (POST /entities/{tableName}/imports)
+	ImportDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ImportDocumentsParams)
+	// Get import
+	// (GET /entities/{tableName}/imports/{importId})
+	GetImport(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, importId externalRef2.UUID)
+	// Download import results
+	// (GET /entities/{tableName}/imports/{importId}/results)
+	GetImportResults(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, importId externalRef2.UUID, params GetImportResultsParams)
+	// Revalidate documents
+	// (POST /entities/{tableName}/revalidations)
+	RevalidateDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Get document by slug
+// (GET /entities/{tableName}/by-slug/{slug})
+func (_ Unimplemented) GetDocumentBySlug(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, slug externalRef2.Slug) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List documents
 // (GET /entities/{tableName}/documents)
 func (_ Unimplemented) ListDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ListDocumentsParams) {
@@ -139,6 +379,36 @@ func (_ Unimplemented) UpdateDocument(w http.ResponseWriter, r *http.Request, ta
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Export documents
+// (GET /entities/{tableName}/export)
+func (_ Unimplemented) ExportDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ExportDocumentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import documents
+// (POST /entities/{tableName}/imports)
+func (_ Unimplemented) ImportDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ImportDocumentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get import
+// (GET /entities/{tableName}/imports/{importId})
+func (_ Unimplemented) GetImport(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, importId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download import results
+// (GET /entities/{tableName}/imports/{importId}/results)
+func (_ Unimplemented) GetImportResults(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, importId externalRef2.UUID, params GetImportResultsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revalidate documents
+// (POST /entities/{tableName}/revalidations)
+func (_ Unimplemented) RevalidateDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -148,6 +418,46 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetDocumentBySlug operation middleware
+func (siw *ServerInterfaceWrapper) GetDocumentBySlug(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tableName" -------------
+	var tableName externalRef2.TableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tableName", chi.URLParam(r, "tableName"), &tableName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tableName", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "slug" -------------
+	var slug externalRef2.Slug
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDocumentBySlug(w, r, tableName, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListDocuments operation middleware
 func (siw *ServerInterfaceWrapper) ListDocuments(w http.ResponseWriter, r *http.Request) {
 
@@ -357,110 +667,395 @@ func (siw *ServerInterfaceWrapper) UpdateDocument(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// ExportDocuments operation middleware
+func (siw *ServerInterfaceWrapper) ExportDocuments(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "tableName" -------------
+	var tableName externalRef2.TableName
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "tableName", chi.URLParam(r, "tableName"), &tableName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tableName", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type RequiredParamError struct {
-	ParamName string
-}
+	r = r.WithContext(ctx)
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportDocumentsParams
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "format" -------------
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Optional query parameter "fields" -------------
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Optional query parameter "filter" -------------
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	// ------------- Optional query parameter "changedSince" -------------
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	err = runtime.BindQueryParameter("form", true, false, "changedSince", r.URL.Query(), &params.ChangedSince)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "changedSince", Err: err})
+		return
+	}
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	// ------------- Optional query parameter "compression" -------------
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	err = runtime.BindQueryParameter("form", true, false, "compression", r.URL.Query(), &params.Compression)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "compression", Err: err})
+		return
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportDocuments(w, r, tableName, params)
+	}))
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// ImportDocuments operation middleware
+func (siw *ServerInterfaceWrapper) ImportDocuments(w http.ResponseWriter, r *http.Request) {
 
-	if r == nil {
-		r = chi.NewRouter()
+	var err error
+
+	// ------------- Path parameter "tableName" -------------
+	var tableName externalRef2.TableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tableName", chi.URLParam(r, "tableName"), &tableName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tableName", Err: err})
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportDocumentsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "keyField" -------------
+
+	if paramValue := r.URL.Query().Get("keyField"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "keyField"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "keyField", r.URL.Query(), &params.KeyField)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyField", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "entityId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entityId", r.URL.Query(), &params.EntityId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entityId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", r.URL.Query(), &params.Namespace)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "slugTemplate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "slugTemplate", r.URL.Query(), &params.SlugTemplate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slugTemplate", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportDocuments(w, r, tableName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImport operation middleware
+func (siw *ServerInterfaceWrapper) GetImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tableName" -------------
+	var tableName externalRef2.TableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tableName", chi.URLParam(r, "tableName"), &tableName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tableName", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "importId" -------------
+	var importId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importId", chi.URLParam(r, "importId"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImport(w, r, tableName, importId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImportResults operation middleware
+func (siw *ServerInterfaceWrapper) GetImportResults(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tableName" -------------
+	var tableName externalRef2.TableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tableName", chi.URLParam(r, "tableName"), &tableName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tableName", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "importId" -------------
+	var importId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importId", chi.URLParam(r, "importId"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetImportResultsParams
+
+	// ------------- Optional query parameter "outcome" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outcome", r.URL.Query(), &params.Outcome)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outcome", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportResults(w, r, tableName, importId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevalidateDocuments operation middleware
+func (siw *ServerInterfaceWrapper) RevalidateDocuments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tableName" -------------
+	var tableName externalRef2.TableName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tableName", chi.URLParam(r, "tableName"), &tableName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tableName", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevalidateDocuments(w, r, tableName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
 	}
@@ -470,183 +1065,508 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/entities/{tableName}/documents", wrapper.ListDocuments)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/entities/{tableName}/documents", wrapper.CreateDocument)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/entities/{tableName}/documents/{entityId}", wrapper.DeleteDocument)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/entities/{tableName}/documents/{entityId}", wrapper.GetDocument)
-	})
-	r.Group(func(r chi.Router) {
-		r.Patch(options.BaseURL+"/entities/{tableName}/documents/{entityId}", wrapper.UpdateDocument)
-	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/entities/{tableName}/by-slug/{slug}", wrapper.GetDocumentBySlug)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/entities/{tableName}/documents", wrapper.ListDocuments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/entities/{tableName}/documents", wrapper.CreateDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/entities/{tableName}/documents/{entityId}", wrapper.DeleteDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/entities/{tableName}/documents/{entityId}", wrapper.GetDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/entities/{tableName}/documents/{entityId}", wrapper.UpdateDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/entities/{tableName}/export", wrapper.ExportDocuments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/entities/{tableName}/imports", wrapper.ImportDocuments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/entities/{tableName}/imports/{importId}", wrapper.GetImport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/entities/{tableName}/imports/{importId}/results", wrapper.GetImportResults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/entities/{tableName}/revalidations", wrapper.RevalidateDocuments)
+	})
+
+	return r
+}
+
+type GetDocumentBySlugRequestObject struct {
+	TableName externalRef2.TableName `json:"tableName"`
+	Slug      externalRef2.Slug      `json:"slug"`
+}
+
+type GetDocumentBySlugResponseObject interface {
+	VisitGetDocumentBySlugResponse(w http.ResponseWriter) error
+}
+
+type GetDocumentBySlug200JSONResponse EntityDocument
+
+func (response GetDocumentBySlug200JSONResponse) VisitGetDocumentBySlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDocumentBySlug301ResponseHeaders struct {
+	Location string
+}
+
+type GetDocumentBySlug301Response struct {
+	Headers GetDocumentBySlug301ResponseHeaders
+}
+
+func (response GetDocumentBySlug301Response) VisitGetDocumentBySlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(301)
+	return nil
+}
+
+type GetDocumentBySlugdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response GetDocumentBySlugdefaultApplicationProblemPlusJSONResponse) VisitGetDocumentBySlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListDocumentsRequestObject struct {
+	TableName externalRef2.TableName `json:"tableName"`
+	Params    ListDocumentsParams
+}
+
+type ListDocumentsResponseObject interface {
+	VisitListDocumentsResponse(w http.ResponseWriter) error
+}
+
+type ListDocuments200JSONResponse struct {
+	Items      []EntityDocument `json:"items"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"pageSize"`
+	TotalItems int              `json:"totalItems"`
+	TotalPages int              `json:"totalPages"`
+}
+
+func (response ListDocuments200JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDocumentsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response ListDocumentsdefaultApplicationProblemPlusJSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateDocumentRequestObject struct {
+	TableName externalRef2.TableName `json:"tableName"`
+	Body      *CreateDocumentJSONRequestBody
+}
+
+type CreateDocumentResponseObject interface {
+	VisitCreateDocumentResponse(w http.ResponseWriter) error
+}
+
+type CreateDocument201ResponseHeaders struct {
+	Location string
+}
+
+type CreateDocument201JSONResponse struct {
+	Body    EntityDocument
+	Headers CreateDocument201ResponseHeaders
+}
+
+func (response CreateDocument201JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateDocumentdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response CreateDocumentdefaultApplicationProblemPlusJSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteDocumentRequestObject struct {
+	TableName externalRef2.TableName        `json:"tableName"`
+	EntityId  externalRef2.EntityIdentifier `json:"entityId"`
+}
+
+type DeleteDocumentResponseObject interface {
+	VisitDeleteDocumentResponse(w http.ResponseWriter) error
+}
+
+type DeleteDocument204Response struct {
+}
+
+func (response DeleteDocument204Response) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteDocumentdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response DeleteDocumentdefaultApplicationProblemPlusJSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDocumentRequestObject struct {
+	TableName externalRef2.TableName        `json:"tableName"`
+	EntityId  externalRef2.EntityIdentifier `json:"entityId"`
+}
+
+type GetDocumentResponseObject interface {
+	VisitGetDocumentResponse(w http.ResponseWriter) error
+}
+
+type GetDocument200JSONResponse EntityDocument
+
+func (response GetDocument200JSONResponse) VisitGetDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDocumentdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response GetDocumentdefaultApplicationProblemPlusJSONResponse) VisitGetDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateDocumentRequestObject struct {
+	TableName externalRef2.TableName        `json:"tableName"`
+	EntityId  externalRef2.EntityIdentifier `json:"entityId"`
+	Body      *UpdateDocumentJSONRequestBody
+}
+
+type UpdateDocumentResponseObject interface {
+	VisitUpdateDocumentResponse(w http.ResponseWriter) error
+}
+
+type UpdateDocument200JSONResponse EntityDocument
 
-	return r
+func (response UpdateDocument200JSONResponse) VisitUpdateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDocumentsRequestObject struct {
+type UpdateDocumentdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response UpdateDocumentdefaultApplicationProblemPlusJSONResponse) VisitUpdateDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ExportDocumentsRequestObject struct {
 	TableName externalRef2.TableName `json:"tableName"`
-	Params    ListDocumentsParams
+	Params    ExportDocumentsParams
 }
 
-type ListDocumentsResponseObject interface {
-	VisitListDocumentsResponse(w http.ResponseWriter) error
+type ExportDocumentsResponseObject interface {
+	VisitExportDocumentsResponse(w http.ResponseWriter) error
 }
 
-type ListDocuments200JSONResponse struct {
-	Items      []EntityDocument `json:"items"`
-	Page       int              `json:"page"`
-	PageSize   int              `json:"pageSize"`
-	TotalItems int              `json:"totalItems"`
-	TotalPages int              `json:"totalPages"`
+type ExportDocuments200ResponseHeaders struct {
+	ContentDisposition string
+	XExportSnapshotAt  externalRef2.Timestamp
+	XSchemaId          externalRef2.UUID
+	XSchemaVersion     externalRef2.SemanticVersion
 }
 
-func (response ListDocuments200JSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+type ExportDocuments200ApplicationgzipResponse struct {
+	Body          io.Reader
+	Headers       ExportDocuments200ResponseHeaders
+	ContentLength int64
+}
+
+func (response ExportDocuments200ApplicationgzipResponse) VisitExportDocumentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/gzip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.Header().Set("X-Export-Snapshot-At", fmt.Sprint(response.Headers.XExportSnapshotAt))
+	w.Header().Set("X-Schema-Id", fmt.Sprint(response.Headers.XSchemaId))
+	w.Header().Set("X-Schema-Version", fmt.Sprint(response.Headers.XSchemaVersion))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportDocuments200JSONResponse struct {
+	Body    []EntityDocument
+	Headers ExportDocuments200ResponseHeaders
+}
+
+func (response ExportDocuments200JSONResponse) VisitExportDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.Header().Set("X-Export-Snapshot-At", fmt.Sprint(response.Headers.XExportSnapshotAt))
+	w.Header().Set("X-Schema-Id", fmt.Sprint(response.Headers.XSchemaId))
+	w.Header().Set("X-Schema-Version", fmt.Sprint(response.Headers.XSchemaVersion))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type ListDocumentsdefaultApplicationProblemPlusJSONResponse struct {
+type ExportDocuments200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	Headers       ExportDocuments200ResponseHeaders
+	ContentLength int64
+}
+
+func (response ExportDocuments200ApplicationxNdjsonResponse) VisitExportDocumentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.Header().Set("X-Export-Snapshot-At", fmt.Sprint(response.Headers.XExportSnapshotAt))
+	w.Header().Set("X-Schema-Id", fmt.Sprint(response.Headers.XSchemaId))
+	w.Header().Set("X-Schema-Version", fmt.Sprint(response.Headers.XSchemaVersion))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportDocuments200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       ExportDocuments200ResponseHeaders
+	ContentLength int64
+}
+
+func (response ExportDocuments200TextcsvResponse) VisitExportDocumentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.Header().Set("X-Export-Snapshot-At", fmt.Sprint(response.Headers.XExportSnapshotAt))
+	w.Header().Set("X-Schema-Id", fmt.Sprint(response.Headers.XSchemaId))
+	w.Header().Set("X-Schema-Version", fmt.Sprint(response.Headers.XSchemaVersion))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportDocumentsdefaultApplicationProblemPlusJSONResponse struct {
 	Body       externalRef3.ProblemDetails
 	StatusCode int
 }
 
-func (response ListDocumentsdefaultApplicationProblemPlusJSONResponse) VisitListDocumentsResponse(w http.ResponseWriter) error {
+func (response ExportDocumentsdefaultApplicationProblemPlusJSONResponse) VisitExportDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateDocumentRequestObject struct {
+type ImportDocumentsRequestObject struct {
 	TableName externalRef2.TableName `json:"tableName"`
-	Body      *CreateDocumentJSONRequestBody
+	Params    ImportDocumentsParams
+	Body      io.Reader
 }
 
-type CreateDocumentResponseObject interface {
-	VisitCreateDocumentResponse(w http.ResponseWriter) error
+type ImportDocumentsResponseObject interface {
+	VisitImportDocumentsResponse(w http.ResponseWriter) error
 }
 
-type CreateDocument201ResponseHeaders struct {
+type ImportDocuments202ResponseHeaders struct {
 	Location string
 }
 
-type CreateDocument201JSONResponse struct {
-	Body    EntityDocument
-	Headers CreateDocument201ResponseHeaders
+type ImportDocuments202JSONResponse struct {
+	Body    EntityImport
+	Headers ImportDocuments202ResponseHeaders
 }
 
-func (response CreateDocument201JSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response ImportDocuments202JSONResponse) VisitImportDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(201)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateDocumentdefaultApplicationProblemPlusJSONResponse struct {
+type ImportDocumentsdefaultApplicationProblemPlusJSONResponse struct {
 	Body       externalRef3.ProblemDetails
 	StatusCode int
 }
 
-func (response CreateDocumentdefaultApplicationProblemPlusJSONResponse) VisitCreateDocumentResponse(w http.ResponseWriter) error {
+func (response ImportDocumentsdefaultApplicationProblemPlusJSONResponse) VisitImportDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type DeleteDocumentRequestObject struct {
-	TableName externalRef2.TableName        `json:"tableName"`
-	EntityId  externalRef2.EntityIdentifier `json:"entityId"`
+type GetImportRequestObject struct {
+	TableName externalRef2.TableName `json:"tableName"`
+	ImportId  externalRef2.UUID      `json:"importId"`
 }
 
-type DeleteDocumentResponseObject interface {
-	VisitDeleteDocumentResponse(w http.ResponseWriter) error
+type GetImportResponseObject interface {
+	VisitGetImportResponse(w http.ResponseWriter) error
 }
 
-type DeleteDocument204Response struct {
-}
+type GetImport200JSONResponse EntityImport
 
-func (response DeleteDocument204Response) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+func (response GetImport200JSONResponse) VisitGetImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDocumentdefaultApplicationProblemPlusJSONResponse struct {
+type GetImportdefaultApplicationProblemPlusJSONResponse struct {
 	Body       externalRef3.ProblemDetails
 	StatusCode int
 }
 
-func (response DeleteDocumentdefaultApplicationProblemPlusJSONResponse) VisitDeleteDocumentResponse(w http.ResponseWriter) error {
+func (response GetImportdefaultApplicationProblemPlusJSONResponse) VisitGetImportResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetDocumentRequestObject struct {
-	TableName externalRef2.TableName        `json:"tableName"`
-	EntityId  externalRef2.EntityIdentifier `json:"entityId"`
+type GetImportResultsRequestObject struct {
+	TableName externalRef2.TableName `json:"tableName"`
+	ImportId  externalRef2.UUID      `json:"importId"`
+	Params    GetImportResultsParams
 }
 
-type GetDocumentResponseObject interface {
-	VisitGetDocumentResponse(w http.ResponseWriter) error
+type GetImportResultsResponseObject interface {
+	VisitGetImportResultsResponse(w http.ResponseWriter) error
 }
 
-type GetDocument200JSONResponse EntityDocument
+type GetImportResults200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
 
-func (response GetDocument200JSONResponse) VisitGetDocumentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response GetImportResults200ApplicationxNdjsonResponse) VisitGetImportResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type GetDocumentdefaultApplicationProblemPlusJSONResponse struct {
+type GetImportResultsdefaultApplicationProblemPlusJSONResponse struct {
 	Body       externalRef3.ProblemDetails
 	StatusCode int
 }
 
-func (response GetDocumentdefaultApplicationProblemPlusJSONResponse) VisitGetDocumentResponse(w http.ResponseWriter) error {
+func (response GetImportResultsdefaultApplicationProblemPlusJSONResponse) VisitGetImportResultsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type UpdateDocumentRequestObject struct {
-	TableName externalRef2.TableName        `json:"tableName"`
-	EntityId  externalRef2.EntityIdentifier `json:"entityId"`
-	Body      *UpdateDocumentJSONRequestBody
+type RevalidateDocumentsRequestObject struct {
+	TableName externalRef2.TableName `json:"tableName"`
 }
 
-type UpdateDocumentResponseObject interface {
-	VisitUpdateDocumentResponse(w http.ResponseWriter) error
+type RevalidateDocumentsResponseObject interface {
+	VisitRevalidateDocumentsResponse(w http.ResponseWriter) error
 }
 
-type UpdateDocument200JSONResponse EntityDocument
+type RevalidateDocuments202ResponseHeaders struct {
+	Location string
+}
 
-func (response UpdateDocument200JSONResponse) VisitUpdateDocumentResponse(w http.ResponseWriter) error {
+type RevalidateDocuments202JSONResponse struct {
+	Body    JobReference
+	Headers RevalidateDocuments202ResponseHeaders
+}
+
+func (response RevalidateDocuments202JSONResponse) VisitRevalidateDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(202)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type UpdateDocumentdefaultApplicationProblemPlusJSONResponse struct {
+type RevalidateDocumentsdefaultApplicationProblemPlusJSONResponse struct {
 	Body       externalRef3.ProblemDetails
 	StatusCode int
 }
 
-func (response UpdateDocumentdefaultApplicationProblemPlusJSONResponse) VisitUpdateDocumentResponse(w http.ResponseWriter) error {
+func (response RevalidateDocumentsdefaultApplicationProblemPlusJSONResponse) VisitRevalidateDocumentsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
@@ -655,6 +1575,9 @@ func (response UpdateDocumentdefaultApplicationProblemPlusJSONResponse) VisitUpd
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Get document by slug
+	// (GET /entities/{tableName}/by-slug/{slug})
+	GetDocumentBySlug(ctx context.Context, request GetDocumentBySlugRequestObject) (GetDocumentBySlugResponseObject, error)
 	// List documents
 	// (GET /entities/{tableName}/documents)
 	ListDocuments(ctx context.Context, request ListDocumentsRequestObject) (ListDocumentsResponseObject, error)
@@ -670,6 +1593,21 @@ type StrictServerInterface interface {
 	// Update document (partial)
 	// (PATCH /entities/{tableName}/documents/{entityId})
 	UpdateDocument(ctx context.Context, request UpdateDocumentRequestObject) (UpdateDocumentResponseObject, error)
+	// Export documents
+	// (GET /entities/{tableName}/export)
+	ExportDocuments(ctx context.Context, request ExportDocumentsRequestObject) (ExportDocumentsResponseObject, error)
+	// Import documents
+	// (POST /entities/{tableName}/imports)
+	ImportDocuments(ctx context.Context, request ImportDocumentsRequestObject) (ImportDocumentsResponseObject, error)
+	// Get import
+	// (GET /entities/{tableName}/imports/{importId})
+	GetImport(ctx context.Context, request GetImportRequestObject) (GetImportResponseObject, error)
+	// Download import results
+	// (GET /entities/{tableName}/imports/{importId}/results)
+	GetImportResults(ctx context.Context, request GetImportResultsRequestObject) (GetImportResultsResponseObject, error)
+	// Revalidate documents
+	// (POST /entities/{tableName}/revalidations)
+	RevalidateDocuments(ctx context.Context, request RevalidateDocumentsRequestObject) (RevalidateDocumentsResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -701,6 +1639,33 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// GetDocumentBySlug operation middleware
+func (sh *strictHandler) GetDocumentBySlug(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, slug externalRef2.Slug) {
+	var request GetDocumentBySlugRequestObject
+
+	request.TableName = tableName
+	request.Slug = slug
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDocumentBySlug(ctx, request.(GetDocumentBySlugRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDocumentBySlug")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDocumentBySlugResponseObject); ok {
+		if err := validResponse.VisitGetDocumentBySlugResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListDocuments operation middleware
 func (sh *strictHandler) ListDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ListDocumentsParams) {
 	var request ListDocumentsRequestObject
@@ -849,41 +1814,217 @@ func (sh *strictHandler) UpdateDocument(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// ExportDocuments operation middleware
+func (sh *strictHandler) ExportDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ExportDocumentsParams) {
+	var request ExportDocumentsRequestObject
+
+	request.TableName = tableName
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportDocuments(ctx, request.(ExportDocumentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportDocuments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportDocumentsResponseObject); ok {
+		if err := validResponse.VisitExportDocumentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportDocuments operation middleware
+func (sh *strictHandler) ImportDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, params ImportDocumentsParams) {
+	var request ImportDocumentsRequestObject
+
+	request.TableName = tableName
+	request.Params = params
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportDocuments(ctx, request.(ImportDocumentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportDocuments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportDocumentsResponseObject); ok {
+		if err := validResponse.VisitImportDocumentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetImport operation middleware
+func (sh *strictHandler) GetImport(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, importId externalRef2.UUID) {
+	var request GetImportRequestObject
+
+	request.TableName = tableName
+	request.ImportId = importId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetImport(ctx, request.(GetImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetImport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetImportResponseObject); ok {
+		if err := validResponse.VisitGetImportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetImportResults operation middleware
+func (sh *strictHandler) GetImportResults(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName, importId externalRef2.UUID, params GetImportResultsParams) {
+	var request GetImportResultsRequestObject
+
+	request.TableName = tableName
+	request.ImportId = importId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetImportResults(ctx, request.(GetImportResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetImportResults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetImportResultsResponseObject); ok {
+		if err := validResponse.VisitGetImportResultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevalidateDocuments operation middleware
+func (sh *strictHandler) RevalidateDocuments(w http.ResponseWriter, r *http.Request, tableName externalRef2.TableName) {
+	var request RevalidateDocumentsRequestObject
+
+	request.TableName = tableName
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevalidateDocuments(ctx, request.(RevalidateDocumentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevalidateDocuments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevalidateDocumentsResponseObject); ok {
+		if err := validResponse.VisitRevalidateDocumentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RZ3XLbuBV+FQy6M5t0KYlSst1UvXLjdOuON3H904s6queIPBKRJQAGOHSs9Wimz9Gb",
-	"vmIfoQOApCSSVmRP2p3cJKQIHHz4zs93AN/zRMtCK1Rk+fSeF2BAIqHxb4mWUqubApZCAYnwiO5LijYx",
-	"onC/8SkfD4RK8Q5T5r4zVco5Gh5x4T5+LNGseMQVSORT7i1E3CYZSgimFlDmxKfjiEuhhCylf6ZV4cYL",
-	"RbhEw9fr6AE8F+KXHkxvPQimF0wQSssKNAHdMwl3bBzHz/cA9CZ7QU7iiEu4q1DG8RMwW22oi/dCG2IL",
-	"gXlqI4bD5ZB96wBFg8QgEKZH9O0DgL29bbAVCktGqCVfOxTho3fqa2/vjSJBq2OdlBIVnePHEq1HVRhd",
-	"oCGBfjD6YSepe/7G4IJP+W9Gm5AZVXZH9S6NkILELdqbN9VMZ2EhHBkRL2CVa/DGIE2F2znkZ1sLkimx",
-	"YVHPP2BCnkSDH0thMOXT68bIrDMw4ru76pJ8ImVJMM+RGUy0SZnBwqB1GNWSAfvLxbu3LK2msyIvLZNI",
-	"kALBkEctbhrHPJ6cSyHREsjCgf6yHAdrf0Nj/ZYfa/ICJSgSSW1gHXFhjxL3sYdOlYoECC37lCFlaBhl",
-	"wjJhGWXIwM+qmb4NBh2NldvmWucI1RIXekHHmCNh2l3nVC9FAjlL/QC2yGH5B+Zixa2rwqKN16qFmM10",
-	"madsjiwTaYqKLYyWrEpk5nJIoO2Hc2Cc7oI8MnNBBswqRFGiFXk4kIvUxQmDJQhlaZub4IctEJtQDp+e",
-	"EhVXVyfHGwtfLhJaediEbTvmtrC3QWyojbbSZyvE2qHQl+RXRXp4AXtyyeks2y3kZ83jT0jQt3YQy30K",
-	"EfFtCTtcWSJOmiA/cdq2s0b84NgzWOJnx3aKrVfrLU3cWnbH7mwPZXsqVifXX+cCFQ1sWRS5wJSJZixb",
-	"aMNEU8FD0FXlxQ7ZUZJgQZaBWrEkAwOJa2PYvCQmS0uuECitBigLWjFQKQNiUlti48mr7QmwIFfIjJBS",
-	"qKXLTbwDWeSOu2v++uj8eBDH8TjIwULkaIeQFxl4db5FRdqspq7nGLycuN9S9klQxmwBCTrOUOoPYvCf",
-	"f//rn44zCXenqJaU8el48sr7vHmP2krex2g7TbuNRTVgUxi9NSYUk/BBm6EUSpthAZRkjmIJ1NrzeBgP",
-	"Yx7xyfDF8HsHugAiNM74P96/T797/3649d83/CDcl86Jb30H0y33n9AkYJFZBT/jjX8805aWBi/+esqC",
-	"/zeB0YKbgEntjQ8P4RkvLZqb2lkt/Ncw+GXm/okHv7+Z/fZQ8I1+dzXx4h179bt4zKge45i+unzdQjmJ",
-	"J98PxvFg/OJy/HL6Ip7G8d8dtsoDU+6K3MAZOQySr/odNOd/es1ejicT5j5Xnudbi5SlSPfa1/McZYoE",
-	"Irc3Z+H1OLz2r/bDq/gHVg1k9ch26xQMdg0csayUoAYGIQ1JflfkEGosswUmYiESRjpIvk6S0hhUCbo2",
-	"38lqhbdvR2iMDqeafh2456KupZ251Q9gDKzc+y7od0WwxiQUDohv4gc53mJeq7+DXwHoKZOuKwCVYB8f",
-	"V+cnzOACwzYpA9oEfmizGloeRYcloLLHhZcZsj9fXp6xMIAlOt0KwG1JEZT3IraZNhS1HWlLKV1vtIuM",
-	"ebvRQ4w/hY6W5U2kG9FdqKV2YU8NOV1JW3tvLXSPbJ1fHXuB8s1fpU11T2qZJW3c8RhN1fONfBHznV8g",
-	"Mpxd3C6Ozk54xG/res5vx44RXaCCQvApfzGMhy+9KFPmPTiqa93onuqquh41i7shS/S9kQt3H42ur+Sn",
-	"wtJxMyraOfxf97eLmyGjBy4H1tETZ/r+4kmz/QF4PXO+tIVWNqTzJI7DHYbvw33iu5Yi8XNGH2zQys2p",
-	"GfL83cJvvOgvC83Dvj66dQLtFI9WxAWbPb3TYd36g73oeuZjdTdGXaOWslxYcmm4iQ8/sLrfeJCwKq++",
-	"6xJ30LFin470QH3jiiV7VgvKc09cVUOqyN3aQMQJll5V6yTis3UnnltkZCvrD5Whm5jrUqVOWUDtHs7c",
-	"iVEoLxj1/YtLvM31S5NyfNu14VjxSJJ6mqO1i+tC2570DRc5TaiF1dHSH3W6elTk78O277ZovRvNbsvr",
-	"ThKOvxiUdmp1o6b+xqqzJY94hpBWt5mnOizbLd5X56e1MFUzN9cJBq0uTYL7b9i+vhQKjm322Z9D6+hz",
-	"4jK6r+8B1oFXd27vxmo4z+/E6k6UvOw6pXFmWt0FfH0ch11/huOoX5x/RHqYrvjXSKqFK5FfoRd+xI1W",
-	"sPmKhRPPAYLxKxT7qHfVrau2L7Vo9+p4HQ7GSdaNxXDj9j9Wmn3Xegcpzf8zKQLYjUx8hWkRtrDJjGcF",
-	"GBKQP39ACtxkTEojaOWzY45g0ByVlPHp9cxFj0VzW+dOaXI+5SMoxMidX2aNzXaZ/wkULHH3Ly/hr3Wh",
-	"MXs2h+RnTF3iVg2ZwUJbQdqsnm9SpEG6nq3/GwAA///vRczG1RwAAA==",
+	"H4sIAAAAAAAC/+w8/3LbOHqvgmFvJkmXkmUne7eV56bjS7J32eY2Ptu522nsRhD5SYJNAgwA2tZ6NNPn",
+	"6D99xT5C5/sAkJRIWZLjdDc7/SeRRBD4fv+G76JE5YWSIK2JhndRwTXPwYKmb4nKcyU/FnwqJLfCfQR8",
+	"koJJtCjwt2gY7feETOEWUobPmSzzMegojgQ+/FSCnkdxJHkO0TCiHeLIJDPIudtqwsvMRsP9OMqFFHmZ",
+	"02c7L3C9kBamoKPFIl4Dz6n4uQOmHwkIpiZMWMgNK0A76J7m/JbtDwbP7gGQtuwE8mAQRzm/9VAOBg+A",
+	"2Sht2/CeKm3ZRECWmphBf9pnTxCguJdo4BbSI/tkDcC0XxNYD4WxWshptFgswkNi6kva77W0ws5fqaTM",
+	"QdoT+FSCIagKrQrQVgAtBlr2JsXPv9MwiYbRP+3VIrPn990LWGqRCyuuwXx87d/EHSYCiRFHBZ9nitNm",
+	"PE0FYs6z48aBVpdQUVGNLyGxREQNn0qhIY2GH6pNLloL42gZqzaR3+R5afk4A6YhUTplGgoNBmGUU8bZ",
+	"D6fvfmSpf50VWWlYDpan3PJ+FK/QpmLM7sQ5EzkYy/MCgX5cGrvd/g7aEMq7bnkKOZdWJGGDRRwJc5Tg",
+	"ww5yylQk3IJhNzOwM9DMzoRhwjA7A8bprUDpa7dhP6rYNlYqA+6POFUT+woysJC2z3mrpiLhGUtpAZtk",
+	"fHrIUFbwXOkOrbjmD2JmpsosZWNgM5GmINlEq5x5RWaoQwJMNzhbyukykEd6LKzmeu6kKFHSEjg8EynK",
+	"CeNTLqSxTdo4PvSjDlF2jx4iFe/fv3lV7/CYkmCycvqAjfCtVS2uhH5VYhuYr6LgAaj5Ezd0sCGnq/K0",
+	"3lK8yQtvjFdUW5XeId6HanOPl+6NRfxFzAJIdDAfoitAu1+WoolUsPNxBFor3Vaff8zmjLMJFxmkTBC4",
+	"zFhVFJD22QkgSSBlmZCAasSksrSYcelX96OOw5yvwtPIwXY4nuolrjWfu3ekMLNHIM5E6ZzbJmlkemlI",
+	"RhJz3Ukch8rnKNSlGn/O61cw/x5p1kkp9OWm4Al8hr5n5fQM8iLjFjrPMJbrx5BMY7ktTZP4n0ooAfVR",
+	"l1LiaXFkyiQBSOlXJ3qdXCFv/CMFMjvDVL26alwqVjf3r4SmQqDBkrhpkLz2N1V5kwl5WRmMZUPCkwSK",
+	"bp9G6mZn3DJ/DOOVE+vX0FI0+fsXUTu4RJyd7nZ5ZvI7TqkPmQEgt+OVX4MpM2u2PKWUyYzL6Xokcm6T",
+	"GQZQDc/mvfAhWhN6dsMNu9HCWpDbnluQ37yXdDdaWWCcSbipHL+aoOWCW2EoqtuJpiuCVLGvhqZJjwYH",
+	"NgnIu9ImKoem0jxk81pxmpufED+/dPR+j3dBxqOoEZcD2J1eA1d1JZBjbrwPQv7ZWRU3CknfygI9fr9T",
+	"UFRN223ddWDHKsMJvHrHLq7+oMYnMAENMunA5Ig5W8jGPLmaalXKlF2qca2CP6ixYUfHb9hEaSasYYVW",
+	"Uw3GMC5Tr5lfLtf4XBcmZLrOtTzIJcRRwmUCWbd7WGGOA95D0bDi95vp96Rba/PdFfZZlgE3likniD7S",
+	"JN6gf8XcJoDU5tKD8oYTKDKegMuZqixmrNL5oUtvVC4sugd8npRah8cIyxUUtjt9+LxQvbVfu4xxXH38",
+	"K1jeNj6hVHRffSSOmgWc7esqcWSV5dmbEHhWawdr1x7zKWxc2yo1UK2qURFqHLu078U9JLvHqrbk72Um",
+	"QNqeKYsiExitV2udvajqF860eyNp+uyInAkakTlLZlzzxII2bFxalpfGYhoslexBXtg5STO3LFfGsv2D",
+	"75ov8InFNF6LPBdyiqIFtzwvMqTdh+jl0cmr3mAw2HeiPxEZmD7Pihmn2tQ1SKv0fCgs5L0XB/hbym6E",
+	"nTEKbZFmkKtL0fuf//6v/0Sa5fz2LcipnUXD/YPviOfV9w7nsTlJbZfV/IK6LEC7oVvJ+aXS/VxIpfsF",
+	"hjCsChCbOO/3B/1BFEcH/ef9bxHoglsLGjf/j/Pz9Jvz837jv99F28Ht1XMZ2H+DMR/3Em7AGZsSfaKQ",
+	"7P3JW7MC1TjjyVUvU7Y0PceAZcg+8N7Pg96/XHzz9F+HverLs3/eEr6zZli+Woy5Ae1glPwKPtLHY2Xs",
+	"VMPp394yJ5+14K4AnnCdmo/4kAxFHJUG9McgTB1YXHjoP15sDXzl8dpx8ek79t3vB/vMhjVE37OXK1Ae",
+	"DA6+7e0PevvPz/ZfDJ8PhoPBvyNsVQCJPqWHm2wHEjnQFjQn379kL/YPDhg+9pLZjFIp0b9vfzXOIE/B",
+	"cpGZj8fu6yv3tfu0P3w3+APzC1lYuerG3IZdsc2szLnsaeCpM0K3RcadD2CmgERMRMKscgU5lThnlVQR",
+	"nYc3Wle5MOsd5y5FhmWg3xVuN5bzAgGhskUvg2vIQm0OwfcAdJhxIY3la2K99ydvmA7BoMtIKsF3Dr0i",
+	"y07kqMOp5RPPZsD+cnZ2zNwClqgUOkNiK2zWCbGZKW3jVUaaMs+xcrkMGaN943UUfwg5VnauJV2LaFP4",
+	"53CqiNN2uQvi1kR1uNWT96/IgVJp1vvOEGsZZqzS2LwC7Suye2TEKLByhHTpFmJxdPwmiqPr4G+i631K",
+	"QQqQvBDRMHreH/RfUNBgZ8TBvWDr9u6qYsRibzzvoYnfu8N/F7huCh3x6AnYUsulenoVIpYm5Ny4R5+h",
+	"TzGNIoJzH2OYKA1MWJ+bSZ5jqUGaG9DON/NzqSEVGhLrlLc+44mpIk4641xGhKsmpcFMIvoz2BBV/2l+",
+	"6uqzGkyhpHGKezAYRFRSpXo4fuQY3CS0xR4V7YZ3je7V5hQunOc4vkyw8IxNMPVC1jwf7HcrErnYBlEO",
+	"2VvloGIzlaVmOeB2/EJXHMXRDHjqe6ThnfYZ70/eBoWqWSJTcGlfk673d+8IR99+XEtHr1jf7EbPrRxJ",
+	"B5Vfo7VkT4NHeUa66o2Ik4lGJjMPOFo+JecadCm6WMRLHecPd67BibpT9zebJbzaHrhUakc0u2uHnaea",
+	"IMqPc6DPrBDjboNQWaOGLVjWtLfCVKpmohbluuCpl+yt6eUv4ge+SQnRg96mfvXi4jPNBM+ydxNCvOiO",
+	"E6oPuxiU1WhitbBMe3Yke9uJw9rkeXHRoWSYWWJJzFg0I7V8fH32ACW3gcB2lmCFGLO5oR6wSy/GVFiz",
+	"Ciu9S71UbPAKSRFkFHfp9Ze2JpjAKNOhvm7uohI1dzoY+yeVzh/NQd433LFYLFZRXrSUcP+X8NW+gPcA",
+	"x+rfrL2NBqNKncBvzaU6xlZ4duvQZueydxd6AgtH1wwstGXVdc6XZHVJSl60mVIxM/Vd96+Pxg7rDTSO",
+	"u51zIwz+tQXAX3nwKNJ1jPi1ho6NVvJjHdruxy1cpSyZtWXRdTy+sKe5r62ylaf5v1QKB2ztJr5CtXAo",
+	"1JrxtODaCp4929EVwG0YeeosOJxaDTw3DK5Bz1slB+91aTs3T8cZViEyYImSRhgkIzOSF2ambMyEDMUW",
+	"kTKlU9D9c/kPLSwYhnRyjS3fc5hRQdENJ3FtDeMaaBhJyCQrUxxWorTdxXmhnk+dOX8gVXXPJb6nqXDi",
+	"yuc+qOizIxlOoNoU9iANQzr2bji105IScZxQXUSVlnFmQecUqcspS2alvIqZUecyoT6Ncb0VJJmlaSmJ",
+	"8kBOJAg746aevXKHd1VRXtOTe7K7laJmaYvSMpCJSrFNw0Zu/GlEIxZgqH25rBZU3cqEhJiN3NKKdZTr",
+	"eN7mSNBzOUrM9Yh20eqGXq1kgGpGVMvzvdFJxq0FR2urWKKyMpcGTbet2PXEsDpNcwToGmWuJ3Tak9f1",
+	"hFfnyFcc0ZeuJnIrlwiA0wQbe5oqEkOq2D3DpOIKoDhkL0//XiEzUVmmbnxhm+SYGgZFplKIhhOeGViD",
+	"EZ2xhNH2lWxj51kgS9TG4/WnkmeoXBORoQahNtRcwYNR+kaI1x+veVbCKEatKzgWO7lhFm4tDgAWwKt6",
+	"Z4WT81fdKOFpXwaldzJrFmZvZsqsjhQ1J4mwi6l0ZUCEIQvQXyNdfrLmVMiV/ODBAxVVALB6ksoLDWZp",
+	"oHVFmJWEpii7r9OfRdElwrsVS2iXJX9V1dnHQnICtKUkG/zvY1VTmqfc9mTaPmkbWFFy91Drd3yz7XSD",
+	"w0Gvt5x/vnTE7b0SplBGdKeiR9byZObibZFRHTlmo/NyMHieVE6XvkLP/Rp8lfux7350kLufPvSnP1+M",
+	"7s1f4+innoO8d+q36x11OPNXqNfcAGlFcN4BgMfSgOin3imt7LnJoodu6maMmvutb+qvhADOPnD7WPi1",
+	"Rt+/xoqBF+1dy26/RMlsbbDq5lVbN9M+3P3Clb3V7AI9rmE/vqL2otIUOvjRHApQPR4uwKIoqQ6j/YBj",
+	"PS8Y03fDcxynpCDqXBoAtEssVUMGPJn53Z/gBNh8tckaWB63h2ANm4JdnZeV2fxc+ps0IHQVQnh/GTsU",
+	"/ERxhRYF2f7uQIiX6VZB6Er6uwNIjHPpDStDXi3HKBQpNkKwQ4bzgO6ARMlr0PgkkGxeeCSrcm+ScU0x",
+	"5VljwLmUhiJvn39g0OkfXapxzFwT1EeodapAK54YRoOGfXassqyBCTaQz+XSuGaqbiRhUoDu0fCqH61m",
+	"SibUdJ1xw8Kth67A342jbh34v/YRfzDmbiz2sI7/XedSyZrIjbi/CurxN61u+o8fhu8cfy+F388IfsRv",
+	"WchLKT6VgLK+DuTGJP963d8I2V/UTZ3oCC/lKWhxDanLd5HoVzAfstEVzEfYYjfhNyasgWwSsxHO7WB+",
+	"hbC5yeZz+fT622duzkdN6jckG1U3P0br86JGRamLJe5a0FaXhNo4E0wVEDQlMQrn/ZEwOQy35kxQQ6zA",
+	"V6+sY0m14DM8cQgKWpEN8cRQZ9k4ziwbFX+HdnSHUCx6d+5O8qIDlypBtf7uDNlfGXi0Drul2zY7CdmZ",
+	"Kvz80YodrDJPZWegvfBpuqdF1obupWAZ5CjLwjvNCeFfICe92La4qBILtufj7M+N2s+q6wHIZzSJLqEd",
+	"ub1GzPA53W7ZVIM8eOQapLPmXSC7J6yait+10eVc0G+tq+WpsiFG3RQe7t2FC1/bDVJVft5P0aFu+Rsf",
+	"TscMM4pNuO633PWfwb4JnPjC9eyNsvQ1N3gqcf6qGjuNe4WPdah3cBc7yPiejzE31vBR1oNcq4kv6fso",
+	"FY0npF7OGQ/JS9yoHDfvljWiSCHdHlUZdI2GnHgoN5WyseIXomZfWxYmwB3ceMg0RhSfIGI0sMs0hPvJ",
+	"XY7Ob7J1/NF9Q2y34ttDi1qLuOOWYyDM19hMD6nR8p3T/1f5zSqvoR5M/7XXPv6GAQ22uJbvO/qLzTNI",
+	"rrbqJTb/Ngb98sT4F87lcsPPWwRX8uehDOBKFX32fejTAEHhxm33LtXYHNLUrZPC4OVxGcGICVqzyFGX",
+	"S7h0I3hu8URo4/qGpQ59rGXzdxJ4B82c/ouFnUuXUTuU9KQhSkSRBwegl2r8W4s+a15tjEDxPUhKLeyc",
+	"VHAMXIM+Ku0sGn64QPUwoK+DgpY6i4bRHi/EHl4PuKj2XKXvX7nEP0m19GeH3J+qcmrxFLXKVaq8oKPD",
+	"MwJv2T2rtf91fZvqthfUv6eVv8zE01zIcNMqpyO1/xpdLC4W/zsAXrkhQAVMAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
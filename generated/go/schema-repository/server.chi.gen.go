@@ -576,33 +576,34 @@ func (sh *strictHandler) GetSchemaVersion(w http.ResponseWriter, r *http.Request
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xYW3PbuhH+Kxj0PJzTUDc7aVL1oePaberWTVzZ7kNt1QMRSxEJCDDAUoma4X/vACAp",
-	"XuSL0vT6JJICF9/ufvvtgl9prLNcK1Bo6fwrtXEKGfOXpwYYwpV/8BcwVmi1gE8FWHT/5kbnYFCAXxsz",
-	"hLU223Pu7n4wkNA5/dlkZ3tSGXaPMq3ucyMygWID9v7m5vyMllG19RkkQgkUWjlLjHN/zeRlaz80BUSU",
-	"g42NyMNS+oer9+9IAEu4josMFJKwZCXUmmAKBBQK3I5pRHGbA51TvfoAMfrNZbE+HPqVe6uMKLKVhHcs",
-	"g8NNXDevlmVEDXwqhAFO57fDeLT3qRBH7cgv9/jVSZ9D141aHbBmE5IBMs6QEYvaACdC+dAZyLUVqI0P",
-	"3/dPfuzJxk/wGyIoMrDIstzZEfYkdo+Hrp4rLhxSSz6ngCkY71cwSzYhQERY/zQujAGFckuYt9YKUIs9",
-	"K60lMBW2vdIJnoEEBD7c+0KvRcwk4X4BSSRb/4o4Fjss6gEgqeAcFEmMztz+rJBIYq1skYGx+2H8Z0vI",
-	"v/nPK0CLrAdWI2RMoYhrA//NZX3Oad/f6PCKb9dNi/t9Pj4pDBciiHqXD6daSoi9KuikR1A7lAGBkHUv",
-	"HgtZV5jKBiEzhm0HQQsm9/nxNA2GolctaIrNonH8Fopk7IM240wobcY5wzgliTYZc9GFLyzLpXP1ls7G",
-	"0/GURvRofDx+5WDlDBGMM/63uzv+4u5u3Pr5YVctYacHcFdc7YL9I6zYahQzC8TlnxQ2iPLN4sL2UK0k",
-	"iz+OpMbCjpjMU9ZDdstGf5+Ofrl88eOv56Pm5qefPxPfdbsa+vr2GUzAqNhHuPeXl9ri2sDVny+IZzER",
-	"3KlHIsD0gMfMcHvvpcVxKaKFBXOfG50It2LoxbJCf798NvimSQwbw9V78uYX0xnBeo2P7/VpD+XR9OjV",
-	"aDYdzY6vZy/nx9P5dPpXh61iyJxyhjByRp4HyaveAM3id6fk5ezoiLi/K2bS1iZFIfij9vVKQsYBmZD2",
-	"/jLcnoXb/bu9fjN9TaqFpF7ZL+5gcGjghKRFxtTIAOM+yfAll0wxrxo2h1gkIiaoCabCEh2HzhqDUxTX",
-	"Tyq8+zwCY7SxD7ewltAM3u2KSb/Rvc+DNZKx3AFJBEg+krABSTZMCh7gVwD2iI5QFpmKYV88bhbnxEAC",
-	"wU1MGe6IH6aLJiwHhcMiw2JPCq9TIL+/vr4kYQGJNW8RUCiENRgfE4FyL2KbaoNRP5G2yDJmtj1kxNuN",
-	"Hor4t4SjZ3nHdCOGG/X6QvCpCc6wQZQ+W4keQvsTU2wNdQ8ATlrjj+1Nv1Xv6w7BVTzrGXrR/ElOLs9p",
-	"RDd1/6GbmYuQzkGxXNA5PR5Pxy+pV7XUZ7TqiqPdBpPWQWwNe5rzArAwyhLYgNn2p8eHZveIKPgMFkki",
-	"jEXnhCsnz3Y3t1E3B5xI2enNXguYYRkguHK8HQ7WsSw4EKGqYbk3Kexg2EKiHxyEe+9TAWZLI6p8S6Ei",
-	"mDmvrDTjUHDdj790njBpYTj5lktHC5trZYMyHE2n/mSiFYLy0WN5Lt38L7SafLBhLNht8OxJxU9KnlV7",
-	"D1KNzwlgnAIntohjsDYppKyEqPLkQXBVObw4DOSz5H8P7t86jSM/1n3gJ19hVelXhOjn00+la98Sg9c7",
-	"5tNlGdFc2718XQvrGESYI2GfsUxxoitZlluSMfPREoGEBY3YewzrcnfP5woaxAIs/kbz7XcjxCMfRsqu",
-	"QLlTVjmg5uxfQ82naUmq00KXlRFNgXEIrfZCByDDBN4sLupe0JjpWjdgdWHibun29bv83yuCkO+et49X",
-	"QRk9oumTr/UZsJzURVU/q3JZPqb7RsAGXB01g0SdgSo/wdSwRN4CDuvj36Gaz6Dm/4lgvgU8iChP9dbm",
-	"yNTNLWHrtYE1Q6i7qZslds209ZGhq0bRoeHpfaQpoydP012g9Yekx3B25fp7gB18Cip9TVqICyNw6yO9",
-	"AmbAnBSY0vnt0o0QFsymzkNhJJ3TCcvFxI1vyyaLg68ki5sz0tSZJYk2je9Nu7I7lwckiOiXUe33yOjq",
-	"tMl4JhRdlsvyHwEAAP//ayeE4qMXAAA=",
+	"H4sIAAAAAAAC/9xYW3fbuBH+K3PQfdhtKFl2st2t+tDjxu3WrZu4vvShtuozIoYSEhBgAFCJmsP/3gOA",
+	"pHiRb2l6fZJIgoNvZr5vZsDPLNV5oRUpZ9n8M7PpmnIMf18bQkeX4cZfyFih1QV9KMk6/7QwuiDjBIW1",
+	"KTpaabM95f7qG0MZm7OfHexsH9SG/a1cq7vCiFw4sSF7d319esKqpN76hDKhhBNaeUvIefiP8ryznzMl",
+	"JYyTTY0o4lL2h8u3byCCBa7TMiflIC5ZCrUCtyYg5YTbTlnC3LYgNmd6+Y5SFzaX5er50C/9W1XCHC4l",
+	"vcGcnm/iqn21qhJm6EMpDHE2vxnHo7tPjTjpRn6xx69e+jy6ftSagLWbQE4OOToE67QhDkKF0BkqtBVO",
+	"mxC+r5/8NJCNH7sviKDIyTrMC29H2OPU3x67eqq48EgtfFyTW5MJfkWzsIkBAmHD3bQ0hpSTW8BgrROg",
+	"DnuWWktCFbe91Jk7IUmO+HjvM70SKUrgYQFkEle/As9ij0XdA2QtOCcFmdG53x9L6SDVypY5Gbsfxn9W",
+	"QuHNf74CdMj6TDVSjsqJtDHw3yzrU86G/ibPV3xXNx3uD/n4aGE4E7Go9/nwWktJqb8AnQ0IasdlQDjK",
+	"+38eCllvf1a1CNEY3I6CFk3u8+NxGoyLXr2gFZt1xvNbKMjxnTbTXChtpgW6dA2ZNjn66NInzAvpXb1h",
+	"h9PZdMYSdjR9Of3ewyrQOTLe+N9ub/mL29tp5+ebnVriTvfgrrnaB/tHWuJykqIl8PmH0saifH1xZgeo",
+	"lhLT9xOpXWknKIs1DpDd4OTvs8kvFy++/fV80l589/Mn4rvqqmFY3z6SiRgVvqe78PdcW7cydPnnMwgs",
+	"BsFJOZEJMgPgKRpu7/zDwKWElZbMXWF0JvyKsReLGv3d4sng2yYxbgyXb+HHX8wOwTVrQnyvXg9QHs2O",
+	"vp8cziaHL68OX81fzuaz2V89tpohc8bR0cQbeRqkUPVGaC5+9xpeHR4dgX9cM5N1NilLwR+0r5eSck4O",
+	"hbR35/HyJF7u3+2HH2c/QL0QmpVDcUeDYwPHsC5zVBNDyEOS6VMhUaELuiooFZlIwWlwa2FBp7GzpuQr",
+	"iu8nNd59HpEx2tj7W1in0Ize7ReTYaN7W0RrkGPhgWSCJJ9I2pCEDUrBI/wawJ6iI5R1qFLaF4/ri1Mw",
+	"lFF0063R7Ygfp4s2LM8Kh3Xoyj0pvFoT/P7q6hziAkg17xBQKEcrMiEmwsm9iO1aG5cME2nLPEezHSCD",
+	"YDe5L+JfEo6B5R3TjRhvNOgL0ac2OOMGUYVsZXoM7U+ocEVNDyAOnfHHDqbfuvf1h+A6ns0MfdE+hOPz",
+	"U5awTdN/2ObQR0gXpLAQbM5eTmfTVyxUtXXIaN0VJ7sNDjoHsRXtac4X5EqjLNCGzHY4Pd43uyeg6CNZ",
+	"B5kw1k3hjVYT5LlQkKKUZCxoJbdgqXHZD8vaUizgIV1bSFGBZwmUitdTNKYpWU895YyWIIV1YUDwYg1a",
+	"8lMh81PGsZS9zh8qDRrMyZEX+814bE9lyQmEqkfxvqt256QtZdxV+Pc+lGS2LGEqNCwmopnT2ko7bMXA",
+	"huGazTOUlsZzdbXwpLOFVjbWnaPZzP94d0mF3GBRSH+6EFodvLNx6Nht8OQ5yEcocnbvMa31OSOXromD",
+	"LUPgs1LKuszVntwLrhbbi+eBfFJz2YP7t76CwrdNl/ku6LcuLDUhhvkMM+8qNNzo9U5XXt6fJo38J0bX",
+	"jTkwuJka8iBrU1+yRZWwQtu9AloJ60kH6FUxlBAqDrruE3ILOZr3FoQDtDXj95wL+3Tf8/2ExepF1v1G",
+	"8+1X49ADX2qqfsX0x75qxObDfw2bH2cy1MeXPpETtibkFHv/mY5Axgm8vjhrmlNrpm/dkNWlSftqHzaU",
+	"6n9PNzHfA28fFI5///4mc/C5OZRWB40Om3t1LquHGpERtCGvo3ayaTJQ5yeaGkvkJ3Jjffw7Cu0TqPl/",
+	"UmN/IvcsojzWjtszXD+3gKuVoRU6ahqwH252/bfz1aNfjZLnhmfw1ahKHj3e94E2X7Yewtkv118D7Ojb",
+	"VBU0aSktjXDbEOkloSFzXLo1m98s/NRhyWyaPJRGsjk7wEIc+Hly0WZx9Nnm4voEWp1Z/wWj9b1tV3bn",
+	"8ogED3XZRbWo/jEAQ9ZUMDQYAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
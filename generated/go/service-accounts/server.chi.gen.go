@@ -0,0 +1,1370 @@
+// Package serviceaccounts provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package serviceaccounts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	externalRef0 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/iam"
+	externalRef1 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/pagination"
+	externalRef2 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/primitives"
+	externalRef3 "github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	// CreatedAt ISO 8601 timestamp in UTC
+	CreatedAt externalRef2.Timestamp  `json:"createdAt"`
+	ExpiresAt *externalRef2.Timestamp `json:"expiresAt"`
+
+	// KeyId RFC 4122 UUID string
+	KeyId externalRef2.UUID `json:"keyId"`
+
+	// LastUsedAt Last successful authentication, recorded with one minute granularity.
+	LastUsedAt *externalRef2.Timestamp `json:"lastUsedAt"`
+	Name       string                  `json:"name"`
+
+	// Prefix Public part of the key, shown to tell keys apart.
+	Prefix    string                  `json:"prefix"`
+	RevokedAt *externalRef2.Timestamp `json:"revokedAt"`
+}
+
+// ApiKeyList defines model for ApiKeyList.
+type ApiKeyList struct {
+	Items []ApiKey `json:"items"`
+}
+
+// ApiKeySecret defines model for ApiKeySecret.
+type ApiKeySecret struct {
+	// CreatedAt ISO 8601 timestamp in UTC
+	CreatedAt externalRef2.Timestamp  `json:"createdAt"`
+	ExpiresAt *externalRef2.Timestamp `json:"expiresAt"`
+
+	// KeyId RFC 4122 UUID string
+	KeyId externalRef2.UUID `json:"keyId"`
+
+	// LastUsedAt Last successful authentication, recorded with one minute granularity.
+	LastUsedAt *externalRef2.Timestamp `json:"lastUsedAt"`
+	Name       string                  `json:"name"`
+
+	// Prefix Public part of the key, shown to tell keys apart.
+	Prefix    string                  `json:"prefix"`
+	RevokedAt *externalRef2.Timestamp `json:"revokedAt"`
+
+	// Secret Full API key to send as `Authorization Bearer <secret>`. It cannot be retrieved again.
+	Secret string `json:"secret"`
+}
+
+// CreateApiKeyRequest defines model for CreateApiKeyRequest.
+type CreateApiKeyRequest struct {
+	// ExpiresAt ISO 8601 timestamp in UTC
+	ExpiresAt *externalRef2.Timestamp `json:"expiresAt,omitempty"`
+	Name      string                  `json:"name"`
+}
+
+// CreateServiceAccountRequest defines model for CreateServiceAccountRequest.
+type CreateServiceAccountRequest struct {
+	Description *string                 `json:"description"`
+	Name        string                  `json:"name"`
+	Roles       []externalRef0.UserRole `json:"roles"`
+
+	// Tables Restrict the account to these entity tables. Omit to allow every table.
+	Tables *[]externalRef2.TableName `json:"tables"`
+}
+
+// ServiceAccount defines model for ServiceAccount.
+type ServiceAccount struct {
+	// CreatedAt ISO 8601 timestamp in UTC
+	CreatedAt   externalRef2.Timestamp  `json:"createdAt"`
+	Description *string                 `json:"description"`
+	Name        string                  `json:"name"`
+	Roles       []externalRef0.UserRole `json:"roles"`
+
+	// ServiceAccountId RFC 4122 UUID string
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+
+	// Tables Entity tables the account may access. Null grants every table its roles allow.
+	Tables *[]externalRef2.TableName `json:"tables"`
+
+	// UpdatedAt ISO 8601 timestamp in UTC
+	UpdatedAt externalRef2.Timestamp `json:"updatedAt"`
+}
+
+// ServiceAccountList defines model for ServiceAccountList.
+type ServiceAccountList struct {
+	Items []ServiceAccount `json:"items"`
+}
+
+// UpdateServiceAccountRequest defines model for UpdateServiceAccountRequest.
+type UpdateServiceAccountRequest struct {
+	Description *string                  `json:"description"`
+	Name        *string                  `json:"name,omitempty"`
+	Roles       *[]externalRef0.UserRole `json:"roles,omitempty"`
+
+	// Tables Replace the table scope. An empty array removes the restriction.
+	Tables *[]externalRef2.TableName `json:"tables,omitempty"`
+}
+
+// CreateServiceAccountJSONRequestBody defines body for CreateServiceAccount for application/json ContentType.
+type CreateServiceAccountJSONRequestBody = CreateServiceAccountRequest
+
+// UpdateServiceAccountJSONRequestBody defines body for UpdateServiceAccount for application/json ContentType.
+type UpdateServiceAccountJSONRequestBody = UpdateServiceAccountRequest
+
+// CreateServiceAccountKeyJSONRequestBody defines body for CreateServiceAccountKey for application/json ContentType.
+type CreateServiceAccountKeyJSONRequestBody = CreateApiKeyRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List service accounts
+	// (GET /admin/service-accounts)
+	ListServiceAccounts(w http.ResponseWriter, r *http.Request)
+	// Create service account
+	// (POST /admin/service-accounts)
+	CreateServiceAccount(w http.ResponseWriter, r *http.Request)
+	// Delete service account
+	// (DELETE /admin/service-accounts/{serviceAccountId})
+	DeleteServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID)
+	// Get service account
+	// (GET /admin/service-accounts/{serviceAccountId})
+	GetServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID)
+	// Update service account
+	// (PATCH /admin/service-accounts/{serviceAccountId})
+	UpdateServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID)
+	// List API keys
+	// (GET /admin/service-accounts/{serviceAccountId}/keys)
+	ListServiceAccountKeys(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID)
+	// Create API key
+	// (POST /admin/service-accounts/{serviceAccountId}/keys)
+	CreateServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID)
+	// Revoke API key
+	// (DELETE /admin/service-accounts/{serviceAccountId}/keys/{keyId})
+	RevokeServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID, keyId externalRef2.UUID)
+	// Rotate API key
+	// (POST /admin/service-accounts/{serviceAccountId}/keys/{keyId}/rotate)
+	RotateServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID, keyId externalRef2.UUID)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List service accounts
+// (GET /admin/service-accounts)
+func (_ Unimplemented) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create service account
+// (POST /admin/service-accounts)
+func (_ Unimplemented) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete service account
+// (DELETE /admin/service-accounts/{serviceAccountId})
+func (_ Unimplemented) DeleteServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get service account
+// (GET /admin/service-accounts/{serviceAccountId})
+func (_ Unimplemented) GetServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update service account
+// (PATCH /admin/service-accounts/{serviceAccountId})
+func (_ Unimplemented) UpdateServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List API keys
+// (GET /admin/service-accounts/{serviceAccountId}/keys)
+func (_ Unimplemented) ListServiceAccountKeys(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create API key
+// (POST /admin/service-accounts/{serviceAccountId}/keys)
+func (_ Unimplemented) CreateServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke API key
+// (DELETE /admin/service-accounts/{serviceAccountId}/keys/{keyId})
+func (_ Unimplemented) RevokeServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID, keyId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate API key
+// (POST /admin/service-accounts/{serviceAccountId}/keys/{keyId}/rotate)
+func (_ Unimplemented) RotateServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID, keyId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListServiceAccounts operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListServiceAccounts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateServiceAccount operation middleware
+func (siw *ServerInterfaceWrapper) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateServiceAccount(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteServiceAccount operation middleware
+func (siw *ServerInterfaceWrapper) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "serviceAccountId" -------------
+	var serviceAccountId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceAccountId", chi.URLParam(r, "serviceAccountId"), &serviceAccountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serviceAccountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteServiceAccount(w, r, serviceAccountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetServiceAccount operation middleware
+func (siw *ServerInterfaceWrapper) GetServiceAccount(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "serviceAccountId" -------------
+	var serviceAccountId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceAccountId", chi.URLParam(r, "serviceAccountId"), &serviceAccountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serviceAccountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetServiceAccount(w, r, serviceAccountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateServiceAccount operation middleware
+func (siw *ServerInterfaceWrapper) UpdateServiceAccount(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "serviceAccountId" -------------
+	var serviceAccountId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceAccountId", chi.URLParam(r, "serviceAccountId"), &serviceAccountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serviceAccountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateServiceAccount(w, r, serviceAccountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListServiceAccountKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceAccountKeys(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "serviceAccountId" -------------
+	var serviceAccountId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceAccountId", chi.URLParam(r, "serviceAccountId"), &serviceAccountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serviceAccountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListServiceAccountKeys(w, r, serviceAccountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateServiceAccountKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateServiceAccountKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "serviceAccountId" -------------
+	var serviceAccountId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceAccountId", chi.URLParam(r, "serviceAccountId"), &serviceAccountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serviceAccountId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateServiceAccountKey(w, r, serviceAccountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeServiceAccountKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeServiceAccountKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "serviceAccountId" -------------
+	var serviceAccountId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceAccountId", chi.URLParam(r, "serviceAccountId"), &serviceAccountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serviceAccountId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeServiceAccountKey(w, r, serviceAccountId, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RotateServiceAccountKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateServiceAccountKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "serviceAccountId" -------------
+	var serviceAccountId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceAccountId", chi.URLParam(r, "serviceAccountId"), &serviceAccountId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "serviceAccountId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateServiceAccountKey(w, r, serviceAccountId, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/service-accounts", wrapper.ListServiceAccounts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/service-accounts", wrapper.CreateServiceAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/service-accounts/{serviceAccountId}", wrapper.DeleteServiceAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/service-accounts/{serviceAccountId}", wrapper.GetServiceAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/admin/service-accounts/{serviceAccountId}", wrapper.UpdateServiceAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/service-accounts/{serviceAccountId}/keys", wrapper.ListServiceAccountKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/service-accounts/{serviceAccountId}/keys", wrapper.CreateServiceAccountKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/service-accounts/{serviceAccountId}/keys/{keyId}", wrapper.RevokeServiceAccountKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/service-accounts/{serviceAccountId}/keys/{keyId}/rotate", wrapper.RotateServiceAccountKey)
+	})
+
+	return r
+}
+
+type ListServiceAccountsRequestObject struct {
+}
+
+type ListServiceAccountsResponseObject interface {
+	VisitListServiceAccountsResponse(w http.ResponseWriter) error
+}
+
+type ListServiceAccounts200JSONResponse ServiceAccountList
+
+func (response ListServiceAccounts200JSONResponse) VisitListServiceAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListServiceAccountsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response ListServiceAccountsdefaultApplicationProblemPlusJSONResponse) VisitListServiceAccountsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateServiceAccountRequestObject struct {
+	Body *CreateServiceAccountJSONRequestBody
+}
+
+type CreateServiceAccountResponseObject interface {
+	VisitCreateServiceAccountResponse(w http.ResponseWriter) error
+}
+
+type CreateServiceAccount201ResponseHeaders struct {
+	Location string
+}
+
+type CreateServiceAccount201JSONResponse struct {
+	Body    ServiceAccount
+	Headers CreateServiceAccount201ResponseHeaders
+}
+
+func (response CreateServiceAccount201JSONResponse) VisitCreateServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateServiceAccountdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response CreateServiceAccountdefaultApplicationProblemPlusJSONResponse) VisitCreateServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteServiceAccountRequestObject struct {
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+}
+
+type DeleteServiceAccountResponseObject interface {
+	VisitDeleteServiceAccountResponse(w http.ResponseWriter) error
+}
+
+type DeleteServiceAccount204Response struct {
+}
+
+func (response DeleteServiceAccount204Response) VisitDeleteServiceAccountResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteServiceAccountdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response DeleteServiceAccountdefaultApplicationProblemPlusJSONResponse) VisitDeleteServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetServiceAccountRequestObject struct {
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+}
+
+type GetServiceAccountResponseObject interface {
+	VisitGetServiceAccountResponse(w http.ResponseWriter) error
+}
+
+type GetServiceAccount200JSONResponse ServiceAccount
+
+func (response GetServiceAccount200JSONResponse) VisitGetServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServiceAccountdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response GetServiceAccountdefaultApplicationProblemPlusJSONResponse) VisitGetServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateServiceAccountRequestObject struct {
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+	Body             *UpdateServiceAccountJSONRequestBody
+}
+
+type UpdateServiceAccountResponseObject interface {
+	VisitUpdateServiceAccountResponse(w http.ResponseWriter) error
+}
+
+type UpdateServiceAccount200JSONResponse ServiceAccount
+
+func (response UpdateServiceAccount200JSONResponse) VisitUpdateServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateServiceAccountdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response UpdateServiceAccountdefaultApplicationProblemPlusJSONResponse) VisitUpdateServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListServiceAccountKeysRequestObject struct {
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+}
+
+type ListServiceAccountKeysResponseObject interface {
+	VisitListServiceAccountKeysResponse(w http.ResponseWriter) error
+}
+
+type ListServiceAccountKeys200JSONResponse ApiKeyList
+
+func (response ListServiceAccountKeys200JSONResponse) VisitListServiceAccountKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListServiceAccountKeysdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response ListServiceAccountKeysdefaultApplicationProblemPlusJSONResponse) VisitListServiceAccountKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateServiceAccountKeyRequestObject struct {
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+	Body             *CreateServiceAccountKeyJSONRequestBody
+}
+
+type CreateServiceAccountKeyResponseObject interface {
+	VisitCreateServiceAccountKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateServiceAccountKey201JSONResponse ApiKeySecret
+
+func (response CreateServiceAccountKey201JSONResponse) VisitCreateServiceAccountKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateServiceAccountKeydefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response CreateServiceAccountKeydefaultApplicationProblemPlusJSONResponse) VisitCreateServiceAccountKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeServiceAccountKeyRequestObject struct {
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+	KeyId            externalRef2.UUID `json:"keyId"`
+}
+
+type RevokeServiceAccountKeyResponseObject interface {
+	VisitRevokeServiceAccountKeyResponse(w http.ResponseWriter) error
+}
+
+type RevokeServiceAccountKey204Response struct {
+}
+
+func (response RevokeServiceAccountKey204Response) VisitRevokeServiceAccountKeyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeServiceAccountKeydefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response RevokeServiceAccountKeydefaultApplicationProblemPlusJSONResponse) VisitRevokeServiceAccountKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RotateServiceAccountKeyRequestObject struct {
+	ServiceAccountId externalRef2.UUID `json:"serviceAccountId"`
+	KeyId            externalRef2.UUID `json:"keyId"`
+}
+
+type RotateServiceAccountKeyResponseObject interface {
+	VisitRotateServiceAccountKeyResponse(w http.ResponseWriter) error
+}
+
+type RotateServiceAccountKey201JSONResponse ApiKeySecret
+
+func (response RotateServiceAccountKey201JSONResponse) VisitRotateServiceAccountKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateServiceAccountKeydefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response RotateServiceAccountKeydefaultApplicationProblemPlusJSONResponse) VisitRotateServiceAccountKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List service accounts
+	// (GET /admin/service-accounts)
+	ListServiceAccounts(ctx context.Context, request ListServiceAccountsRequestObject) (ListServiceAccountsResponseObject, error)
+	// Create service account
+	// (POST /admin/service-accounts)
+	CreateServiceAccount(ctx context.Context, request CreateServiceAccountRequestObject) (CreateServiceAccountResponseObject, error)
+	// Delete service account
+	// (DELETE /admin/service-accounts/{serviceAccountId})
+	DeleteServiceAccount(ctx context.Context, request DeleteServiceAccountRequestObject) (DeleteServiceAccountResponseObject, error)
+	// Get service account
+	// (GET /admin/service-accounts/{serviceAccountId})
+	GetServiceAccount(ctx context.Context, request GetServiceAccountRequestObject) (GetServiceAccountResponseObject, error)
+	// Update service account
+	// (PATCH /admin/service-accounts/{serviceAccountId})
+	UpdateServiceAccount(ctx context.Context, request UpdateServiceAccountRequestObject) (UpdateServiceAccountResponseObject, error)
+	// List API keys
+	// (GET /admin/service-accounts/{serviceAccountId}/keys)
+	ListServiceAccountKeys(ctx context.Context, request ListServiceAccountKeysRequestObject) (ListServiceAccountKeysResponseObject, error)
+	// Create API key
+	// (POST /admin/service-accounts/{serviceAccountId}/keys)
+	CreateServiceAccountKey(ctx context.Context, request CreateServiceAccountKeyRequestObject) (CreateServiceAccountKeyResponseObject, error)
+	// Revoke API key
+	// (DELETE /admin/service-accounts/{serviceAccountId}/keys/{keyId})
+	RevokeServiceAccountKey(ctx context.Context, request RevokeServiceAccountKeyRequestObject) (RevokeServiceAccountKeyResponseObject, error)
+	// Rotate API key
+	// (POST /admin/service-accounts/{serviceAccountId}/keys/{keyId}/rotate)
+	RotateServiceAccountKey(ctx context.Context, request RotateServiceAccountKeyRequestObject) (RotateServiceAccountKeyResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListServiceAccounts operation middleware
+func (sh *strictHandler) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	var request ListServiceAccountsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListServiceAccounts(ctx, request.(ListServiceAccountsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListServiceAccounts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListServiceAccountsResponseObject); ok {
+		if err := validResponse.VisitListServiceAccountsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateServiceAccount operation middleware
+func (sh *strictHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var request CreateServiceAccountRequestObject
+
+	var body CreateServiceAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateServiceAccount(ctx, request.(CreateServiceAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateServiceAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateServiceAccountResponseObject); ok {
+		if err := validResponse.VisitCreateServiceAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteServiceAccount operation middleware
+func (sh *strictHandler) DeleteServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	var request DeleteServiceAccountRequestObject
+
+	request.ServiceAccountId = serviceAccountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteServiceAccount(ctx, request.(DeleteServiceAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteServiceAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteServiceAccountResponseObject); ok {
+		if err := validResponse.VisitDeleteServiceAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetServiceAccount operation middleware
+func (sh *strictHandler) GetServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	var request GetServiceAccountRequestObject
+
+	request.ServiceAccountId = serviceAccountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetServiceAccount(ctx, request.(GetServiceAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetServiceAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetServiceAccountResponseObject); ok {
+		if err := validResponse.VisitGetServiceAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateServiceAccount operation middleware
+func (sh *strictHandler) UpdateServiceAccount(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	var request UpdateServiceAccountRequestObject
+
+	request.ServiceAccountId = serviceAccountId
+
+	var body UpdateServiceAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateServiceAccount(ctx, request.(UpdateServiceAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateServiceAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateServiceAccountResponseObject); ok {
+		if err := validResponse.VisitUpdateServiceAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListServiceAccountKeys operation middleware
+func (sh *strictHandler) ListServiceAccountKeys(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	var request ListServiceAccountKeysRequestObject
+
+	request.ServiceAccountId = serviceAccountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListServiceAccountKeys(ctx, request.(ListServiceAccountKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListServiceAccountKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListServiceAccountKeysResponseObject); ok {
+		if err := validResponse.VisitListServiceAccountKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateServiceAccountKey operation middleware
+func (sh *strictHandler) CreateServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID) {
+	var request CreateServiceAccountKeyRequestObject
+
+	request.ServiceAccountId = serviceAccountId
+
+	var body CreateServiceAccountKeyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateServiceAccountKey(ctx, request.(CreateServiceAccountKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateServiceAccountKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateServiceAccountKeyResponseObject); ok {
+		if err := validResponse.VisitCreateServiceAccountKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeServiceAccountKey operation middleware
+func (sh *strictHandler) RevokeServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID, keyId externalRef2.UUID) {
+	var request RevokeServiceAccountKeyRequestObject
+
+	request.ServiceAccountId = serviceAccountId
+	request.KeyId = keyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeServiceAccountKey(ctx, request.(RevokeServiceAccountKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeServiceAccountKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeServiceAccountKeyResponseObject); ok {
+		if err := validResponse.VisitRevokeServiceAccountKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RotateServiceAccountKey operation middleware
+func (sh *strictHandler) RotateServiceAccountKey(w http.ResponseWriter, r *http.Request, serviceAccountId externalRef2.UUID, keyId externalRef2.UUID) {
+	var request RotateServiceAccountKeyRequestObject
+
+	request.ServiceAccountId = serviceAccountId
+	request.KeyId = keyId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateServiceAccountKey(ctx, request.(RotateServiceAccountKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateServiceAccountKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RotateServiceAccountKeyResponseObject); ok {
+		if err := validResponse.VisitRotateServiceAccountKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xa23IbN9J+lS78uUj+HZ5kZ+3wjrGTrCraWKvDzWq1CjTTJBFhgAmAocS4+O5bDWAo",
+	"zoESJUtOyZUbiZxDow9ff90N8CNLdV5ohcpZNv7IbDrHnPuPk0L8jEv6VBhdoHEC/fXUIHeYTRx9+crg",
+	"lI3Z/w1uxQyiDLqUa3VRGJELJxZoL05EjtbxvGCrhOFNIQzaIIdL+WHKxmefIPE8YaqUkl9KZGNnSlwl",
+	"7AqX+9nD9Tw93X9PKkpu3anF7Cl1zNCmRhROaMXG7IBbB7ZMU7R2WkrgpZujciLl9EACBlNtMszgWrg5",
+	"aIWQC1U6hJnhqpTcCLfssw7LFc+RlM75zQGqmZuz8WjvbcJyodbfE+aWBbIxs84INSOLC4NTcUMv1vU8",
+	"LC+lSKHgxoGegpsjXOEyATvX1wqcBodS0iULnB4infCG54VEy8ZnrJD5xavpd+keH12+yV4jO+9Y3OBC",
+	"Xz2ttxt+8Yv8XgqDGWkV4BGdtTY+2YD4rZr68jdMHakZEuNAWNdODuEwr3+4S/sgia3Wi3Bj+JI11Qyy",
+	"tqtyjKnBBzhtvWxTe7sWVI/+j6WUMDncpwBTsC2qDLiFXyelm2sj/vBohe+RGzTwn3I4fJUGWf4z/tqH",
+	"fQcpV0o7uEQw6IzABWbAZ1yoPlubVoGh4YGoWNsFrYSagMJruQRhbYnZhtIzdHM0IY+EsxBE9smL73y4",
+	"g1eO8PcSuyJbo6tPoL1HJWbDHV5GFx6CJcdoFiLFSZrqUrmtFtUcV1Po29FeM3U60vWRFGO0xN1TJHpT",
+	"8Pzi1KI50hJJSC7Ufnh91EyehDlSu20iO0LSInWevnjwjueuOVoEol23hPByHz7kwt/kUuprwAWaeI/Q",
+	"+hDVN4FA7/9Cbltt9W83BUSGCr7rinw95s9XtV8yaJo4sTWffUqjsA1xP2xiqga7nC/pM1rbh1+IXqmg",
+	"O7sJNE9T3vAAws8DvISVRfYEUGkxeMPXSR3Tm0V3U4X7sf4Uhbgu8RMK8qlX/C8C3kbAheQp+kQIELep",
+	"LrAPEwWYF24JXgQYzPUiJoyJpC20eqoEaMW2FcYuo1vWTIpCxkbdpylLGKoyJ4DwLBeKcGzRXORc8Rma",
+	"+LWz871L4da6B/oaTcotglX8Ci/8x0Nt3czg8b8OKvLIqKBNBZpGK55yk9kLuulxGJUsjJ6KWFwK7hwa",
+	"Wuq/Z7z3xzn9Gfa+uzj//6/YbsqvaaCl/P7xB3j79+EIXPUMCAWnJ+8aWu4N977tjYa90auT0evxq+F4",
+	"OPw36TbVJueOjRnlWY+E7KaSZ+o2IH98B69He3tAtyG+v7FIWYrsTvn6UmKeoeNC2ovD8PV9+Nq92pu3",
+	"wzcQH4TqyaRFDHS9A3EwL3OuegZ55oOMN4XkKkDQFpiKqUhDRyMs6DQtjUGVYjWyRX27LEJjtPGL8ywT",
+	"JJDLw25Gbb3bTP+60h+KIA1yXpAiU4Ey60lcoIQFlyIL6kcFOpJRKOu4SrsyEE6P9sHgFIOZbs7dLfAD",
+	"f6zd8iB3WMdd2RHCkznCP05ODiE8AKnONgAolEPKdPKJcJ2cQROzcUkzkLbMc26WDc3Ay022efwx7mhI",
+	"vkW6EfdOYcGmtXPa9W/lozXVbdX+6TkQYhdQdUEWptpAztO5UAipFEjXvraIGRqb+O0GhQa8X43Hif0G",
+	"uMq8KXG283YtN3dO0E95/dsHuKGllaOZ9TIMqU5fobJeVsqNWYZaE5otkn9bnWJMhKm09uNqCG/VisCk",
+	"MmhyuM8StkBjg+GLEcVLF6h4IdiYveoP+6+Z59i5x9fA14pB9Eyv8gzdmnXN4kfoSqOqRrHh0Ao/DhWn",
+	"byZDgxlcLoF6BVKcMto7ktpdRr1TvVmhDDRoC61sSPu94ZD+pVo5DIMFv617g99s6F1C5X1Ym0WLB8zU",
+	"LTxugQRdOsdsY59MRp6Z8lK6O9SLaP/bw9Tcid07FP/BGG3g64rmv/EJFDM7OruVAYQlPvMlrxmIc9qP",
+	"06FxrIeta8xnIVfRuu91tnyykN21o7CqE0TceWygZ/RM6NkBORBHCpawOXKiFFriQIfV26l1enRQJVDY",
+	"RIrvN4PGkg2Nm5S5ennADDHusHI7MlfJNuIafGyOeqvgaYmuo2a999djqW6Er71pVzF6m8mCnI6UqIHx",
+	"dVuBJmaCotkL5JfgggeFMalqTN2ZP6G7z5PDPzGtv5B68BO6Bwar4Ibn6DyRnbUmq/XAV5FYW7igB6n1",
+	"qLZfxl0bM3VKTx7qpcbu2CpMlOm8g3D9bklIflIngY37SWzHtKnvFbybczVDOmcqpD+NCJ0Q7fJHu9fN",
+	"kPKkofDGQayNbeLo2rF5plp61+bQTrX0z0y6uCX3AvMsuP35ytuAKtK9/Xo8MrVbkjMBoVJZZkLNIJ6D",
+	"+kEkHDtloBUdSoSTvjDQKMI9GC8es116+59Jz2cE1cbBaEeU1tPYl9TOV0Z9scQd54+GxtaWxMDUJlcu",
+	"6MOJ15wACsKCVnK5Rift8fk9qQp7bbh2DRp0Sv2cY039yPczjzO1s/vt+bIeYl7sXBENeWLCHXz0v964",
+	"c7g48kS65l4QeY6Z4A7lsg/+JtEtXxOuf8bjWvd00cZokNeN0fvmjCqcca0XGM5g/U7hfKmMl9yv6a35",
+	"HRpWPyh6aiJ+fHoMjHY85MZfIfkctbHJOdTEiapemnAAmqNyYVPDO5rnYfzxz0oxRSdyDPWUCuzja+qR",
+	"j/0OfPX5qtrRhgdefoULDt6twtGLmJb0i02ff+Eggn5Bx8Zn5wQoSqYqO0sj2ZgNeCEGdIRwvha968lK",
+	"PCURZrNHjTnR3vK/6VX50Ys/D6gOsc9X56v/DQBrlsycJiwAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	for rawPath, rawFunc := range externalRef0.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/iam.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef1.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/pagination.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef2.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/primitives.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	for rawPath, rawFunc := range externalRef3.PathToRawSpec(path.Join(path.Dir(pathToFile), "./common/problemdetails.yaml")) {
+		if _, ok := res[rawPath]; ok {
+			// it is not possible to compare functions in golang, so always overwrite the old value
+		}
+		res[rawPath] = rawFunc
+	}
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -29,11 +29,26 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for UserStatus.
+const (
+	Approved UserStatus = "approved"
+	Disabled UserStatus = "disabled"
+	Enabled  UserStatus = "enabled"
+	Pending  UserStatus = "pending"
+	Rejected UserStatus = "rejected"
+)
+
 // CreateUser defines model for CreateUser.
 type CreateUser struct {
 	// Email Email address per RFC 5322 (simplified)
-	Email    externalRef2.Email `json:"email"`
-	FullName string             `json:"fullName"`
+	Email    externalRef2.Email       `json:"email"`
+	FullName string                   `json:"fullName"`
+	Roles    *[]externalRef0.UserRole `json:"roles,omitempty"`
+}
+
+// RejectUser defines model for RejectUser.
+type RejectUser struct {
+	Reason string `json:"reason"`
 }
 
 // UpdateSelf defines model for UpdateSelf.
@@ -43,7 +58,8 @@ type UpdateSelf struct {
 
 // UpdateUser defines model for UpdateUser.
 type UpdateUser struct {
-	FullName *string `json:"fullName,omitempty"`
+	FullName *string                  `json:"fullName,omitempty"`
+	Roles    *[]externalRef0.UserRole `json:"roles,omitempty"`
 }
 
 // User defines model for User.
@@ -56,7 +72,14 @@ type User struct {
 	FullName string             `json:"fullName"`
 
 	// Id RFC 4122 UUID string
-	Id externalRef2.UUID `json:"id"`
+	Id    externalRef2.UUID       `json:"id"`
+	Roles []externalRef0.UserRole `json:"roles"`
+
+	// Status Approval lifecycle status. Only approved and enabled users can access the API. Transitions: pending -> approved | rejected, rejected -> approved, approved | enabled -> disabled, disabled -> enabled.
+	Status UserStatus `json:"status"`
+
+	// StatusReason Reason recorded with the last rejection
+	StatusReason *string `json:"statusReason,omitempty"`
 
 	// UpdatedAt ISO 8601 timestamp in UTC
 	UpdatedAt externalRef2.Timestamp `json:"updatedAt"`
@@ -65,8 +88,17 @@ type User struct {
 // UserFilter defines model for UserFilter.
 type UserFilter struct {
 	Email *string `json:"email,omitempty"`
+
+	// Role Application role
+	Role *externalRef0.UserRole `json:"role,omitempty"`
+
+	// Status Approval lifecycle status. Only approved and enabled users can access the API. Transitions: pending -> approved | rejected, rejected -> approved, approved | enabled -> disabled, disabled -> enabled.
+	Status *UserStatus `json:"status,omitempty"`
 }
 
+// UserStatus Approval lifecycle status. Only approved and enabled users can access the API. Transitions: pending -> approved | rejected, rejected -> approved, approved | enabled -> disabled, disabled -> enabled.
+type UserStatus string
+
 // UsersListParams defines parameters for UsersList.
 type UsersListParams struct {
 	// Page 1-indexed page number
@@ -80,6 +112,12 @@ type UsersListParams struct {
 
 	// Email Filter by user email (contains)
 	Email *string `form:"email,omitempty" json:"email,omitempty"`
+
+	// Status Filter by approval lifecycle status
+	Status *UserStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Role Filter by users holding the role
+	Role *externalRef0.UserRole `form:"role,omitempty" json:"role,omitempty"`
 }
 
 // UsersCreateJSONRequestBody defines body for UsersCreate for application/json ContentType.
@@ -88,6 +126,9 @@ type UsersCreateJSONRequestBody = CreateUser
 // UsersUpdateJSONRequestBody defines body for UsersUpdate for application/json ContentType.
 type UsersUpdateJSONRequestBody = UpdateUser
 
+// UsersRejectJSONRequestBody defines body for UsersReject for application/json ContentType.
+type UsersRejectJSONRequestBody = RejectUser
+
 // UsersUpdateMeJSONRequestBody defines body for UsersUpdateMe for application/json ContentType.
 type UsersUpdateMeJSONRequestBody = UpdateSelf
 
@@ -108,6 +149,18 @@ type ServerInterface interface {
 	// Update user
 	// (PATCH /admin/users/{userId})
 	UsersUpdate(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID)
+	// Approve user
+	// (POST /admin/users/{userId}/approve)
+	UsersApprove(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID)
+	// Disable user
+	// (POST /admin/users/{userId}/disable)
+	UsersDisable(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID)
+	// Enable user
+	// (POST /admin/users/{userId}/enable)
+	UsersEnable(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID)
+	// Reject user
+	// (POST /admin/users/{userId}/reject)
+	UsersReject(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID)
 	// Get the current authenticated user
 	// (GET /users/me)
 	UsersMe(w http.ResponseWriter, r *http.Request)
@@ -150,6 +203,30 @@ func (_ Unimplemented) UsersUpdate(w http.ResponseWriter, r *http.Request, userI
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Approve user
+// (POST /admin/users/{userId}/approve)
+func (_ Unimplemented) UsersApprove(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Disable user
+// (POST /admin/users/{userId}/disable)
+func (_ Unimplemented) UsersDisable(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enable user
+// (POST /admin/users/{userId}/enable)
+func (_ Unimplemented) UsersEnable(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject user
+// (POST /admin/users/{userId}/reject)
+func (_ Unimplemented) UsersReject(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get the current authenticated user
 // (GET /users/me)
 func (_ Unimplemented) UsersMe(w http.ResponseWriter, r *http.Request) {
@@ -217,6 +294,22 @@ func (siw *ServerInterfaceWrapper) UsersList(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", r.URL.Query(), &params.Role)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.UsersList(w, r, params)
 	}))
@@ -341,6 +434,130 @@ func (siw *ServerInterfaceWrapper) UsersUpdate(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// UsersApprove operation middleware
+func (siw *ServerInterfaceWrapper) UsersApprove(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UsersApprove(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UsersDisable operation middleware
+func (siw *ServerInterfaceWrapper) UsersDisable(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UsersDisable(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UsersEnable operation middleware
+func (siw *ServerInterfaceWrapper) UsersEnable(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UsersEnable(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UsersReject operation middleware
+func (siw *ServerInterfaceWrapper) UsersReject(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId externalRef2.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UsersReject(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // UsersMe operation middleware
 func (siw *ServerInterfaceWrapper) UsersMe(w http.ResponseWriter, r *http.Request) {
 
@@ -509,6 +726,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Patch(options.BaseURL+"/admin/users/{userId}", wrapper.UsersUpdate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{userId}/approve", wrapper.UsersApprove)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{userId}/disable", wrapper.UsersDisable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{userId}/enable", wrapper.UsersEnable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{userId}/reject", wrapper.UsersReject)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/me", wrapper.UsersMe)
 	})
@@ -678,6 +907,123 @@ func (response UsersUpdatedefaultApplicationProblemPlusJSONResponse) VisitUsersU
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type UsersApproveRequestObject struct {
+	UserId externalRef2.UUID `json:"userId"`
+}
+
+type UsersApproveResponseObject interface {
+	VisitUsersApproveResponse(w http.ResponseWriter) error
+}
+
+type UsersApprove200JSONResponse User
+
+func (response UsersApprove200JSONResponse) VisitUsersApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UsersApprovedefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response UsersApprovedefaultApplicationProblemPlusJSONResponse) VisitUsersApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UsersDisableRequestObject struct {
+	UserId externalRef2.UUID `json:"userId"`
+}
+
+type UsersDisableResponseObject interface {
+	VisitUsersDisableResponse(w http.ResponseWriter) error
+}
+
+type UsersDisable200JSONResponse User
+
+func (response UsersDisable200JSONResponse) VisitUsersDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UsersDisabledefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response UsersDisabledefaultApplicationProblemPlusJSONResponse) VisitUsersDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UsersEnableRequestObject struct {
+	UserId externalRef2.UUID `json:"userId"`
+}
+
+type UsersEnableResponseObject interface {
+	VisitUsersEnableResponse(w http.ResponseWriter) error
+}
+
+type UsersEnable200JSONResponse User
+
+func (response UsersEnable200JSONResponse) VisitUsersEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UsersEnabledefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response UsersEnabledefaultApplicationProblemPlusJSONResponse) VisitUsersEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UsersRejectRequestObject struct {
+	UserId externalRef2.UUID `json:"userId"`
+	Body   *UsersRejectJSONRequestBody
+}
+
+type UsersRejectResponseObject interface {
+	VisitUsersRejectResponse(w http.ResponseWriter) error
+}
+
+type UsersReject200JSONResponse User
+
+func (response UsersReject200JSONResponse) VisitUsersRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UsersRejectdefaultApplicationProblemPlusJSONResponse struct {
+	Body       externalRef3.ProblemDetails
+	StatusCode int
+}
+
+func (response UsersRejectdefaultApplicationProblemPlusJSONResponse) VisitUsersRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type UsersMeRequestObject struct {
 }
 
@@ -752,6 +1098,18 @@ type StrictServerInterface interface {
 	// Update user
 	// (PATCH /admin/users/{userId})
 	UsersUpdate(ctx context.Context, request UsersUpdateRequestObject) (UsersUpdateResponseObject, error)
+	// Approve user
+	// (POST /admin/users/{userId}/approve)
+	UsersApprove(ctx context.Context, request UsersApproveRequestObject) (UsersApproveResponseObject, error)
+	// Disable user
+	// (POST /admin/users/{userId}/disable)
+	UsersDisable(ctx context.Context, request UsersDisableRequestObject) (UsersDisableResponseObject, error)
+	// Enable user
+	// (POST /admin/users/{userId}/enable)
+	UsersEnable(ctx context.Context, request UsersEnableRequestObject) (UsersEnableResponseObject, error)
+	// Reject user
+	// (POST /admin/users/{userId}/reject)
+	UsersReject(ctx context.Context, request UsersRejectRequestObject) (UsersRejectResponseObject, error)
 	// Get the current authenticated user
 	// (GET /users/me)
 	UsersMe(ctx context.Context, request UsersMeRequestObject) (UsersMeResponseObject, error)
@@ -931,6 +1289,117 @@ func (sh *strictHandler) UsersUpdate(w http.ResponseWriter, r *http.Request, use
 	}
 }
 
+// UsersApprove operation middleware
+func (sh *strictHandler) UsersApprove(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	var request UsersApproveRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UsersApprove(ctx, request.(UsersApproveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UsersApprove")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UsersApproveResponseObject); ok {
+		if err := validResponse.VisitUsersApproveResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UsersDisable operation middleware
+func (sh *strictHandler) UsersDisable(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	var request UsersDisableRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UsersDisable(ctx, request.(UsersDisableRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UsersDisable")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UsersDisableResponseObject); ok {
+		if err := validResponse.VisitUsersDisableResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UsersEnable operation middleware
+func (sh *strictHandler) UsersEnable(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	var request UsersEnableRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UsersEnable(ctx, request.(UsersEnableRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UsersEnable")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UsersEnableResponseObject); ok {
+		if err := validResponse.VisitUsersEnableResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UsersReject operation middleware
+func (sh *strictHandler) UsersReject(w http.ResponseWriter, r *http.Request, userId externalRef2.UUID) {
+	var request UsersRejectRequestObject
+
+	request.UserId = userId
+
+	var body UsersRejectJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UsersReject(ctx, request.(UsersRejectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UsersReject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UsersRejectResponseObject); ok {
+		if err := validResponse.VisitUsersRejectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // UsersMe operation middleware
 func (sh *strictHandler) UsersMe(w http.ResponseWriter, r *http.Request) {
 	var request UsersMeRequestObject
@@ -989,33 +1458,41 @@ func (sh *strictHandler) UsersUpdateMe(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xYf2/bOBL9KgPeAU1xciw76bXwX5dLf5wPTWs0CRbYwChocWSzEEmVpNx4A3/3BUnZ",
-	"li3JcbJtdwPsX5Yscvg48+bNkHckUSJXEqU1ZHBHcqqpQIvavyVKCCU/53TKJbU8PKL7wtAkmufuPzIg",
-	"vQ6XDG+RgfsOshAT1CQi3H38WqBekIhIKpAMiLcQEZPMUNBgKqVFZsmgFxHBJReF8M92kbvxXFqcoibL",
-	"ZdSC55L/1oDpgwcBKgVuURjIUQd0R4LeQi+On+8B6E02guzHERH0tkQZx4/AbJS2dbyXSltIOWbMRIDH",
-	"02N45gBFnUQjtcjO7LMWwN5eFWyJwljN5ZQsHYrw0Qf13Nu7Nqh9wLXKUVuO/hsKyjP38E+NKRmQf3Q3",
-	"9OiWNrqrHWkuuOVzNJ/f+GnLiKRFln3wqOooIqLxa8E1MjK4KVeqzBivvacmXzCxztx1zqjFS8zSOtT9",
-	"S7WYat71Q001GlmH6eHuu+ICjaUid9Z/YAgiwtnDLV9fD1+7uYX34B/d4A4NOCNRnQtRxZvVhcct0XjL",
-	"M7uXzgdEtZ6no/XjBVpat77Swn0CEJGqQh0uHBGxytJs6KRra424deyITvHesTvuL8W4InmVZbfsjve4",
-	"bJeFNW3zfwNlTKMJSvzp7Tm8OOn34chwkWc85cicIOMtFXnm9nFDCoP6P+Ufx4kSDkOqtKCWDNacqVF8",
-	"H/tqwIaXH+HVv+Me2NUY4BKur853oPTj/otOL+70Tq56p4OTeBDHv27BcQztOCOHQfI5VUPjnHLa6/fB",
-	"fYZyfmWRovDp0m5fTTIUDC3lmfk8Cq+vw2vzai9fxS+hHAirkdEOz4PBuoEzmBWCyo5GyugkQ8DbPKMh",
-	"Y8DkmPCUJ2AV2Bk3oJKk0Bplgq4m2xlCibdpR6i1Ci0IZYw7gzQbbYHiq8yozS3/oFrThXvfBv0xD9ZA",
-	"0NwB8RW3k+EcM5jTjLMAvwTQQHoujaUywSZ/XH8agsYUwzbtjFrgDKV19DZ+z2u3PMgdxlJbNITwaobw",
-	"v6urEYQBkChWIWBVILjNGhGbmdI22g2kKYSgerGDDLzdqM3jj3HHjuUN0zWvL7SjXWFPa+fUBWrpo5Wq",
-	"OjRXMwwwJSiXkChpNU3sACgTXHYElXSKDAo/6Bu3M8i4sVxOIwipEEEoSRFQyYDmuVZzmnUZN857XY1u",
-	"faCJW80cw1DOUHNrYJqpCc3g/79cHZN1TMiIZmKhqUtDOBsNSUTmqE0AOu85/6ocJc05GZCT4/j41Au2",
-	"nXk+dD3mrsfq3qfY0Fq+58ZWt6NWOZD6umn8NjaFz4FzqeZfhmzlL2fFL705Idw0NwKbId2WE8QyeuRM",
-	"X6UeNdt3yW7mtm9C6wCThXcQ+LICR44TlEvTdkZYVZ/2nnvsyGpyJU3Qq34chxOVtCh9jGieZzzx4Lpf",
-	"jANzV7FHs+xj6j2cN+ve+mFfK+Zb1Zom7vZg3lJDgT+szWttmJZjn4Lb/h753HIJ5dQl8NYPKs9XrS4q",
-	"peJfdVcd1IvuK40NMN84/YejVY187p1WyuJWRrlEplPfJDhnw4VXD+E2MHbdnzINCRmOYEBB4rfAO42J",
-	"0qwl88JwEqKGxv5XscWDyLTPQ5Xj4HKbGVYXuKzRuPfdVt6sWVdnKA8AJCIzpKxUt/cqLNSg6J/er6pV",
-	"OXPlV6MKneD+8/HTI2DJILfHvQxcRlslonvnfoZsGTyYoW0o3CPUgrpdZAsIY4AGb04WmwKuW8j6Olit",
-	"FQovo65ubVQ0YCG7nIse6tqdo2qD9p429wDl9tgTVKDg5vsJEK1agoZQvUP7F4tT/HPEJVWFfIpBf4e2",
-	"koj3lR5qk1lL5MNt2J8f/O9fzir3fAeVs5/AuHB9FVL16XEuwD+s0oQaE+4e22XnIrQyPzkM5/64bZ9q",
-	"GFzq++6m3AYt7MxV4mTDrU10/I35YRpw8aP6ysrd/d+J+B0TcT8JINcq5f5eZIcM3hgmheZ24bV+glSj",
-	"PivsjAxuxk6PDer5qhIUOiMD0qU57857xH0t7dWueVx3CUqH5cPliT8WlfVjVynqR/A3kuWKS2sgVfp+",
-	"kpd2A7PGy98DAAD//2fGdgTCHAAA",
+	"H4sIAAAAAAAC/+xae3PjthH/KjtoZ+KbQpZk3zUZ9Z9efHepO3c5jx/TmboeD0ysJKQgwACgY/XK797B",
+	"g9SDpCw7Ti5O7y+RIrD4Yfe3Dyz5iWQ6L7RC5SyZfCIFMyxHhybcZTrPtbou2Ewo5kS8RP+Eo82MKPx/",
+	"ZELGA6E43iEH/xxUmd+gIZQI//DHEs2CUKJYjmRCggRKbDbHnEVRU1ZKRyZjSnKhRF7m4dotCj9eKIcz",
+	"NKSqaA+eM/GfDkzfBxCgpyAc5hYKNBHdXs7uYDwavdgCMIjsBHkwoiRndwnlaPQIzFYb18Z7po2DqUDJ",
+	"LQXcn+3DVx4QHWQGmUP+2n3VAzjIWwWbUFhnhJqRqqrqh8GoR0HehUXj7wqjCzROYHiGORPSX/zR4JRM",
+	"yB+GS3oMk4xhvSMjcuHELdrrt2FaRcm0lPL7gKqFghKjZVwlGGTHVQTLrz3WUy3RC8mFOo7Tl+pmxrBF",
+	"ULbBH0thkJPJZdrLCqarZoK++QEz58Wdor/qVoZBZr1pPnmDv0c1c3MyeZVMXt+P6eZGN2AkKV2LXxSc",
+	"OTxDOW0vvkWTVa+o7n18bqO0wXbCbHj+cP6dixytY3nhpf+iHBb84ZIvLo7fPJmq17VLiXXMlfcK8xLO",
+	"4shmzmlD7/VAFP8Hg5k2HDn8JNwc3BxBMuvABIfxI2lbO2XBn8CEGw4kOKFtZ67V2WiArhBoFclVDwHf",
+	"Cem2hsBOV3mk1R5upT6/OWskrVvtdVEYfcskSDHFbJFJhLjoPnxUcgEsPEcOTHFAxW4kcigtGgsZU8Cy",
+	"DK0NZn59crwP54YpK7xsO4ECFRdqBoN/laPRIS5l/TfRATltrlqj6Or4euV6EBc2/EGbq+ZRGrrvra98",
+	"fr0kCQihpBZJKKkXDuPCFEJJLW3F/EtDdhmpS6FSZCFjQ7D8EgbjuVCeZBbNdc4Um6FJt9vWWykBTprL",
+	"D+hYm4R1mbWttqBktfjZvSahxGnH5HEdhJqxo96xJ2yG947dcNtU561UUyvLrsnt8tC++NwyU/gbGOcG",
+	"bSzyTt8dwavDgwPYsyIvpJgK5L7WwzuWFyECXwZT/TX9sZ/p3GOYapMzRyZNrOm1Y1fUagE7PvsI3/x5",
+	"NAZXjwGh4OL8aAPKwejg1WA8GowPz8cvJ4ejyWj0zzU4PpANvJDdIIVs0w7q747g5fjgAPxjSPNXFilL",
+	"wbfK1zcSc46OCWmvT+Ltm3jbvdrX34y+hjQQ6pF0g+dRYIfzwbzMmRoYZNz7MeBdIVn0GLAFZmIqMnAa",
+	"3FxY0FlWGoMqQ1/u+xiW8HbtCI3R8XTDOA8RjsmTNVBNem7N3Uy+66A/FlEa5KzwQEIxP5B4ixJumRQ8",
+	"wk8AOkgvlHVMZV3BCC5Oj8HgFOM23Zw5EByV8/SOcbtRy4PUYXvyyfkc4W/n5ycpi0CmOZK201PihOsM",
+	"n2Dn2ji6aUhb5jkziw1kEOTSPo0/Rh0bkpdMN4LcV7PHPTXKaQeoKlhrqtvQLkJK5TpnQkGmlTMscxMI",
+	"KWMQc0Wdd0NhJYV1Qs0oRFegECsXGvI0S0l9mNLZMOY6YKECs/twrOZohLMwk/qGSfj7P859vkw2ISdM",
+	"5gvDvBv6tE4ouUVjI9DbsdevLlCxQpAJOdwf7b8MAdvNAx+GAfMwYPX3M+w4tb4X1q1uR9c+MA3llQ3b",
+	"WCY+D867Wrg55rW+vBRC15oPl92l0nLIsKc5UdFHzgxZ6lGzwwG8opu6iRUm3CyCgiCkFdjznGBC2b72",
+	"Q519+o/zWxZifVVgz2LNw+Vquxeo2/drYa5lKBu9N6YKqgtDerQbgs76urry7msLrWyM4AejEQntK+VQ",
+	"BdayZTU3/CEde5YrMik/TgPniu5MsNOJzQNqZ4nN00yQ1FHy7HZg6i0hq6sQlNYtchKijQ8xPt5GT67o",
+	"spnVq6IUPP/UVtUuILcWCx0w3xqjDezVVcOLoLSUKNZiDKHEsVkom7yy4UOIp7nfwJWvh7XtCFGx3wUM",
+	"FP4UPTEebXtiURxOotXQum81XzyITNs0tNJ7q9aZ4UyJVYvG4ydbeblmO19BOjkTSubIeIr373VcqCPH",
+	"nb6v83eaWevV6tJkuL0Z+fwImBjk97iVgRVdS5rDT/7nmFdRgxJdRylzgiZnfhdyAXEMsKjNm8WypDE9",
+	"ZH0TpbZSZ4izPpMvw2zEQjY5Rx+q2o22VkfsfdldFaXt8WcYgaKa7ycArYukDlN9h+43ZqfRrxNcprpU",
+	"z9Ho36FbccT7Ug9z2bzH8rE3//mN//TpbOWtw07p7FdgXOz7Rld9fpyL8B+faYapL+rhd1dDsU/sk0zd",
+	"1d3TBgqDt0KXVi6aPu6LSH7reyy46GoSd6ektMD/Z6xjU4cmKMg1DfRnSMOaJI/nYWpY9PPwW6mzf4Mn",
+	"Vf1yQJu19xIwNTq/h2tv0ipfuPZ8uZZs+DO4FlnTT7VTtE6bwKM6hE21AbZ85eQl9VDsrfrCsGfOsGjC",
+	"n0GwmBK3ESx2ZZuU6if+JSgufv0BwkLgIAetwv/39yGi0N9j1bjyzc1vpWr8HbE9kXEntkeex09d+k+u",
+	"H5B8BpschXdY7rlW8v70GBpkaRusdHNUzmNFvmmd8AnYbsfID79Ua3LlY7QvZ7knPMttJwEURk+FxDYZ",
+	"gjDMSiPcIgT+G2QGzevSzcnk8soHZ4vmtk4LpZFkQoasEEP/Zu+qkdc6APoM54vtsHz6esUuk8lmpKDk",
+	"blBzYZA+Yev5BOaq/U7oreKFFsrFkuteh0gYggoesHD97U11Vf1vAKYjNTrRLAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
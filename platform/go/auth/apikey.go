@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
+)
+
+// APIKeyPrefix marks bearer tokens that are service account API keys rather than JWTs. Keys look like
+// `plm_<id>_<secret>`; `plm_<id>` is the public prefix used to look the key up.
+const APIKeyPrefix = "plm_"
+
+const (
+	apiKeyIDBytes     = 6
+	apiKeySecretBytes = 32
+)
+
+// ErrInvalidAPIKey is returned by APIKeyLookup implementations for unknown, revoked or expired keys.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// GeneratedAPIKey is a freshly issued key. Key is shown to the client once; only Hash is stored.
+type GeneratedAPIKey struct {
+	Key    string
+	Prefix string
+	Hash   string
+}
+
+// GenerateAPIKey returns a new random API key with its public prefix and storage hash.
+func GenerateAPIKey() (GeneratedAPIKey, error) {
+	id := make([]byte, apiKeyIDBytes)
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(id); err != nil {
+		return GeneratedAPIKey{}, fmt.Errorf("generate api key id: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return GeneratedAPIKey{}, fmt.Errorf("generate api key secret: %w", err)
+	}
+
+	prefix := APIKeyPrefix + hex.EncodeToString(id)
+	key := prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return GeneratedAPIKey{Key: key, Prefix: prefix, Hash: HashAPIKey(key)}, nil
+}
+
+// IsAPIKey reports whether the bearer token looks like an API key.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// APIKeyPrefixOf returns the public prefix of key, or false when key is malformed.
+func APIKeyPrefixOf(key string) (string, bool) {
+	if !IsAPIKey(key) {
+		return "", false
+	}
+	rest := key[len(APIKeyPrefix):]
+	sep := strings.IndexByte(rest, '_')
+	if sep <= 0 || sep == len(rest)-1 {
+		return "", false
+	}
+	return key[:len(APIKeyPrefix)+sep], true
+}
+
+// HashAPIKey returns the hex SHA-256 of key. Keys carry 256 bits of entropy, so a fast hash is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyMatches compares key against a stored hash in constant time.
+func APIKeyMatches(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(hash)) == 1
+}
+
+// APIKeyLookup resolves an API key into the credentials of its service account. It returns
+// ErrInvalidAPIKey when the key is unknown, revoked or expired.
+type APIKeyLookup func(ctx context.Context, key string) (*UserCredentials, error)
+
+// APIKey authenticates bearer tokens carrying APIKeyPrefix and sets the context credentials. Other
+// tokens pass through untouched to the JWT middleware, which must run after this one.
+func APIKey(lookup APIKeyLookup) func(http.Handler) http.Handler {
+	if lookup == nil {
+		panic("auth.APIKey: lookup func must not be nil")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, found := ExtractJWTToken(r)
+			if !found || !IsAPIKey(token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			creds, err := lookup(r.Context(), token)
+			switch {
+			case errors.Is(err, ErrInvalidAPIKey):
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token", error_description="invalid api key"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			case err != nil:
+				platformhttp.WriteProblem(w, r, http.StatusInternalServerError, platformhttp.ProblemTypeInternal,
+					"Internal server error", "could not verify api key")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUserCredentials(r.Context(), creds)))
+		})
+	}
+}
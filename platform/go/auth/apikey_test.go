@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.True(t, IsAPIKey(key.Key))
+	require.True(t, strings.HasPrefix(key.Key, key.Prefix+"_"))
+
+	prefix, ok := APIKeyPrefixOf(key.Key)
+	require.True(t, ok)
+	require.Equal(t, key.Prefix, prefix)
+	require.True(t, APIKeyMatches(key.Key, key.Hash))
+	require.False(t, APIKeyMatches(key.Key+"x", key.Hash))
+
+	other, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key.Prefix, other.Prefix)
+	require.False(t, APIKeyMatches(other.Key, key.Hash))
+
+	for _, malformed := range []string{"", "plm_", "plm_abc", "plm_abc_", "plm__secret", "eyJhbGciOi.x.y"} {
+		_, ok := APIKeyPrefixOf(malformed)
+		require.False(t, ok, malformed)
+	}
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	t.Parallel()
+
+	lookup := func(ctx context.Context, key string) (*UserCredentials, error) {
+		switch key {
+		case "plm_good_secret":
+			return &UserCredentials{Id: "sa-1", ServiceAccountID: "sa-1", Roles: []Role{RoleUser}}, nil
+		case "plm_broken_secret":
+			return nil, errors.New("db down")
+		default:
+			return nil, ErrInvalidAPIKey
+		}
+	}
+	jwt := JWT(func(ctx context.Context, token string) (map[string]interface{}, error) {
+		return map[string]interface{}{"uid": "user-1"}, nil
+	}, nil)
+
+	testCases := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantID     string
+	}{
+		{name: "api key", header: "Bearer plm_good_secret", wantStatus: http.StatusOK, wantID: "sa-1"},
+		{name: "invalid api key", header: "Bearer plm_bad_secret", wantStatus: http.StatusUnauthorized},
+		{name: "lookup failure", header: "Bearer plm_broken_secret", wantStatus: http.StatusInternalServerError},
+		{name: "jwt passes through", header: "Bearer eyJhbGciOi.x.y", wantStatus: http.StatusOK, wantID: "user-1"},
+		{name: "anonymous", wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *UserCredentials
+			handler := APIKey(lookup)(jwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = UserFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantID == "" {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			require.Equal(t, tc.wantID, got.Id)
+		})
+	}
+}
+
+func TestUserCredentialsCanAccessTable(t *testing.T) {
+	t.Parallel()
+
+	var anonymous *UserCredentials
+	require.True(t, anonymous.CanAccessTable("cards_entities"))
+	require.True(t, (&UserCredentials{}).CanAccessTable("cards_entities"))
+
+	scoped := &UserCredentials{ServiceAccountID: "sa-1", TableScope: []string{"cards_entities"}}
+	require.True(t, scoped.IsServiceAccount())
+	require.True(t, scoped.CanAccessTable("cards_entities"))
+	require.False(t, scoped.CanAccessTable("pricing_entities"))
+}
@@ -26,6 +26,53 @@ type UserCredentials struct {
 	PictureURL    *string
 	IsAdmin       bool
 	TenantID      *string
+	Roles         []Role
+	SessionID     string // `sid` claim of tokens issued by the local identity provider
+	Issuer        string // `iss` claim; together with Id it identifies the caller at their identity provider
+
+	// UserID is the internal users.user_id of the caller once their identity has been resolved
+	// (see domains/users/be/middleware.RequireActiveUser); empty for callers without a user record.
+	UserID string
+
+	// ServiceAccountID is set when the caller authenticated with an API key; Id then holds the same value.
+	ServiceAccountID string
+	// TableScope restricts service accounts to these entity tables; nil means unrestricted.
+	TableScope []string
+}
+
+// IsServiceAccount reports whether the caller is a service account rather than a user.
+func (c *UserCredentials) IsServiceAccount() bool {
+	return c != nil && c.ServiceAccountID != ""
+}
+
+// PrincipalKey identifies the caller across requests: the service account behind an API key, the resolved
+// user or, for callers without a user record, the token issuer and subject. It is empty for nil credentials.
+func (c *UserCredentials) PrincipalKey() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.IsServiceAccount():
+		return "sa:" + c.ServiceAccountID
+	case c.UserID != "":
+		return "user:" + c.UserID
+	case c.Id != "":
+		return "sub:" + c.Issuer + "|" + c.Id
+	default:
+		return ""
+	}
+}
+
+// CanAccessTable reports whether the caller's table scope includes tableName.
+func (c *UserCredentials) CanAccessTable(tableName string) bool {
+	if c == nil || c.TableScope == nil {
+		return true
+	}
+	for _, scoped := range c.TableScope {
+		if scoped == tableName {
+			return true
+		}
+	}
+	return false
 }
 
 func UserFromContext(ctx context.Context) (*UserCredentials, bool) {
@@ -44,6 +91,7 @@ type VerifyFunc func(ctx context.Context, token string) (map[string]interface{},
 type ExtractFunc func(claims map[string]interface{}) (*UserCredentials, error)
 
 // JWT parses the request and sets the context credentials using the provided verify/extract functions.
+// Requests already authenticated by an earlier middleware (see APIKey) pass through untouched.
 func JWT(verify VerifyFunc, extract ExtractFunc) func(http.Handler) http.Handler {
 	if verify == nil {
 		panic("auth.JWT: verify func must not be nil")
@@ -59,6 +107,11 @@ func JWT(verify VerifyFunc, extract ExtractFunc) func(http.Handler) http.Handler
 				return
 			}
 
+			if creds, ok := UserFromContext(r.Context()); ok && creds != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			token, found := ExtractJWTToken(r)
 			if token == "" || !found {
 				next.ServeHTTP(w, r)
@@ -99,6 +152,9 @@ func DefaultCredentialExtractor(claims map[string]interface{}) (*UserCredentials
 		PictureURL:    extractOptionalStringClaim(claims, "picture"),
 		IsAdmin:       extractBoolClaim(claims, "isAdmin"),
 		TenantID:      extractTenantID(claims),
+		Roles:         extractRoles(claims),
+		SessionID:     extractStringClaim(claims, "sid"),
+		Issuer:        extractStringClaim(claims, "iss"),
 	}
 
 	return creds, nil
@@ -194,6 +250,7 @@ func FirebaseTokenVerifier(fbAuth *auth.Client) VerifyFunc {
 		}
 		claims["uid"] = t.UID
 		claims["sub"] = t.Subject
+		claims["iss"] = t.Issuer
 		if tenant := t.Firebase.Tenant; tenant != "" {
 			if firebaseClaim, ok := claims["firebase"].(map[string]interface{}); ok {
 				firebaseClaim["tenant"] = tenant
@@ -214,28 +271,7 @@ func UnsignedTokenVerifier() VerifyFunc {
 	}
 }
 
-// RequireRole is a helper to gate endpoints inside handlers if necessary:
+// RequireRole is a helper to gate endpoints inside handlers if necessary; see RequireRoles.
 func RequireRole(role string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			creds, ok := UserFromContext(r.Context())
-			if !ok || creds == nil {
-				http.Error(w, "forbidden", http.StatusForbidden)
-				return
-			}
-
-			switch role {
-			case "admin":
-				if !creds.IsAdmin {
-					http.Error(w, "forbidden", http.StatusForbidden)
-					return
-				}
-			default:
-				http.Error(w, "forbidden", http.StatusForbidden)
-				return
-			}
-
-			next.ServeHTTP(w, r)
-		})
-	}
+	return RequireRoles(Role(role))
 }
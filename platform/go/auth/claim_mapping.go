@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ClaimMapping names the claims holding each credential field for issuers that do not follow the
+// Firebase layout. Paths use dots for nested objects (e.g. `realm_access.roles`). Empty fields keep the
+// DefaultCredentialExtractor behaviour.
+type ClaimMapping struct {
+	UserID        string
+	Email         string
+	EmailVerified string
+	Name          string
+	Roles         string
+	TenantID      string
+}
+
+var claimMappingFields = map[string]func(*ClaimMapping) *string{
+	"uid":            func(m *ClaimMapping) *string { return &m.UserID },
+	"email":          func(m *ClaimMapping) *string { return &m.Email },
+	"email_verified": func(m *ClaimMapping) *string { return &m.EmailVerified },
+	"name":           func(m *ClaimMapping) *string { return &m.Name },
+	"roles":          func(m *ClaimMapping) *string { return &m.Roles },
+	"tenant":         func(m *ClaimMapping) *string { return &m.TenantID },
+}
+
+// ParseClaimMapping builds a ClaimMapping from field=claim pairs, e.g. {"roles": "realm_access.roles"}.
+// Supported fields are uid, email, email_verified, name, roles and tenant.
+func ParseClaimMapping(pairs map[string]string) (ClaimMapping, error) {
+	var mapping ClaimMapping
+	for field, path := range pairs {
+		target, ok := claimMappingFields[strings.TrimSpace(field)]
+		if !ok {
+			return ClaimMapping{}, fmt.Errorf("unsupported claim mapping field %q (supported: %s)", field, supportedClaimMappingFields())
+		}
+		*target(&mapping) = strings.TrimSpace(path)
+	}
+	return mapping, nil
+}
+
+// ClaimMappingExtractor returns an ExtractFunc applying mapping on top of DefaultCredentialExtractor.
+// Mapped roles are added to those found in the standard claims; role names that are not part of
+// contracts/common/iam.yaml are ignored.
+func ClaimMappingExtractor(mapping ClaimMapping) ExtractFunc {
+	return func(claims map[string]interface{}) (*UserCredentials, error) {
+		creds, err := DefaultCredentialExtractor(claims)
+		if err != nil {
+			return nil, err
+		}
+
+		if mapping.UserID != "" {
+			id, _ := lookupClaim(claims, mapping.UserID).(string)
+			if id == "" {
+				return nil, fmt.Errorf("missing %s claim", mapping.UserID)
+			}
+			creds.Id = id
+		}
+		if mapping.Email != "" {
+			creds.Email, _ = lookupClaim(claims, mapping.Email).(string)
+		}
+		if mapping.EmailVerified != "" {
+			creds.EmailVerified, _ = lookupClaim(claims, mapping.EmailVerified).(bool)
+		}
+		if mapping.Name != "" {
+			if name, ok := lookupClaim(claims, mapping.Name).(string); ok && name != "" {
+				creds.Name = &name
+			} else {
+				creds.Name = nil
+			}
+		}
+		if mapping.TenantID != "" {
+			if tenant, ok := lookupClaim(claims, mapping.TenantID).(string); ok && tenant != "" {
+				creds.TenantID = &tenant
+			} else {
+				creds.TenantID = nil
+			}
+		}
+		if mapping.Roles != "" {
+			creds.Roles = dedupeRoles(append(creds.Roles, parseRoleClaim(lookupClaim(claims, mapping.Roles))...))
+			creds.IsAdmin = creds.IsAdmin || creds.HasRole(RoleAdmin)
+		}
+
+		return creds, nil
+	}
+}
+
+// lookupClaim resolves a dotted path. A top-level claim whose name contains dots wins over nesting.
+func lookupClaim(claims map[string]interface{}, path string) interface{} {
+	if v, ok := claims[path]; ok {
+		return v
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = obj[part]
+	}
+	return current
+}
+
+// parseRoleClaim accepts an array of role names or a space/comma separated string (OAuth `scope` style).
+func parseRoleClaim(raw interface{}) []Role {
+	var values []string
+	switch v := raw.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	case []string:
+		values = v
+	case string:
+		values = strings.FieldsFunc(v, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+
+	roles := make([]Role, 0, len(values))
+	for _, value := range values {
+		if role, ok := ParseRole(value); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func supportedClaimMappingFields() string {
+	fields := make([]string, 0, len(claimMappingFields))
+	for field := range claimMappingFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
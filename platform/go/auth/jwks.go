@@ -0,0 +1,427 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Signing algorithms accepted by JWKSTokenVerifier.
+const (
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+	AlgEdDSA = "EdDSA"
+)
+
+var (
+	// ErrUnknownSigningKey indicates a token whose `kid` is not in the key set, even after a refresh.
+	ErrUnknownSigningKey = errors.New("unknown signing key")
+	// ErrUnsupportedJWK indicates a key type, curve or algorithm the verifier does not handle.
+	ErrUnsupportedJWK = errors.New("unsupported jwk")
+)
+
+// JWK is a public JSON Web Key (RFC 7517) of type RSA, EC (P-256) or OKP (Ed25519).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is the document served at a JWKS endpoint.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicJWK encodes key as a JWK. An empty kid is replaced by the RFC 7638 thumbprint.
+func PublicJWK(kid string, key crypto.PublicKey) (JWK, error) {
+	var jwk JWK
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		jwk = JWK{Kty: "RSA", Alg: AlgRS256, N: b64(k.N.Bytes()), E: b64(big.NewInt(int64(k.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return JWK{}, fmt.Errorf("%w: curve %s", ErrUnsupportedJWK, k.Curve.Params().Name)
+		}
+		jwk = JWK{Kty: "EC", Alg: AlgES256, Crv: "P-256", X: b64(k.X.FillBytes(make([]byte, 32))), Y: b64(k.Y.FillBytes(make([]byte, 32)))}
+	case ed25519.PublicKey:
+		jwk = JWK{Kty: "OKP", Alg: AlgEdDSA, Crv: "Ed25519", X: b64(k)}
+	default:
+		return JWK{}, fmt.Errorf("%w: key type %T", ErrUnsupportedJWK, key)
+	}
+
+	jwk.Use = "sig"
+	jwk.Kid = kid
+	if jwk.Kid == "" {
+		jwk.Kid = jwk.thumbprint()
+	}
+	return jwk, nil
+}
+
+// PublicKey decodes the JWK into an *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := unb64(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode n: %w", err)
+		}
+		e, err := unb64(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode e: %w", err)
+		}
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("%w: malformed rsa key", ErrUnsupportedJWK)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedJWK, k.Crv)
+		}
+		x, err := unb64(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		y, err := unb64(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode y: %w", err)
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("%w: point not on curve", ErrUnsupportedJWK)
+		}
+		return key, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedJWK, k.Crv)
+		}
+		x, err := unb64(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: malformed ed25519 key", ErrUnsupportedJWK)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("%w: kty %q", ErrUnsupportedJWK, k.Kty)
+	}
+}
+
+// thumbprint computes the RFC 7638 SHA-256 thumbprint used as default kid.
+func (k JWK) thumbprint() string {
+	var canonical string
+	switch k.Kty {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, k.E, k.N)
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, k.Crv, k.X, k.Y)
+	default:
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, k.Crv, k.Kty, k.X)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:])
+}
+
+// JWKSConfig configures where a JWKS is loaded from and how often it is refreshed.
+type JWKSConfig struct {
+	// URL of the JWKS endpoint; mutually exclusive with File.
+	URL string
+	// File holding a JWKS document, re-read on refresh so keys can be rotated on disk.
+	File string
+	// RefreshInterval is how long fetched keys are used before the next verification reloads them (default 1h).
+	RefreshInterval time.Duration
+	// MinRefreshInterval throttles refreshes triggered by unknown `kid`s (default 1m).
+	MinRefreshInterval time.Duration
+	HTTPClient         *http.Client
+}
+
+type jwksKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// JWKS caches the public keys of a JWKS document. Keys are refreshed lazily when RefreshInterval has
+// elapsed or a token references an unknown `kid` (key rotation); failed refreshes keep serving the
+// previous keys.
+type JWKS struct {
+	cfg JWKSConfig
+	now func() time.Time
+
+	mu          sync.RWMutex
+	keys        map[string]jwksKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewJWKS validates the configuration and loads the initial key set.
+func NewJWKS(ctx context.Context, cfg JWKSConfig) (*JWKS, error) {
+	if (cfg.URL == "") == (cfg.File == "") {
+		return nil, errors.New("exactly one of jwks url or file is required")
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = time.Hour
+	}
+	if cfg.MinRefreshInterval <= 0 {
+		cfg.MinRefreshInterval = time.Minute
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	set := &JWKS{cfg: cfg, now: time.Now}
+	if err := set.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Refresh reloads the key set from its source.
+func (s *JWKS) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	s.attemptedAt = s.now()
+	s.mu.Unlock()
+
+	raw, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	var doc JWKSet
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]jwksKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			// Skip keys we cannot use (e.g. encryption keys or other curves) instead of failing the set.
+			continue
+		}
+		alg := jwk.Alg
+		if alg == "" {
+			alg = defaultAlgFor(key)
+		}
+		keys[jwk.Kid] = jwksKey{alg: alg, key: key}
+	}
+	if len(keys) == 0 {
+		return errors.New("jwks contains no usable signing keys")
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = s.now()
+	s.mu.Unlock()
+	return nil
+}
+
+// Key returns the public key for kid and alg, refreshing the set when kid is unknown or stale. Tokens
+// without a kid are accepted only when the set holds a single key.
+func (s *JWKS) Key(ctx context.Context, kid, alg string) (crypto.PublicKey, error) {
+	s.mu.RLock()
+	stale := s.now().Sub(s.fetchedAt) > s.cfg.RefreshInterval
+	s.mu.RUnlock()
+	if stale {
+		_ = s.refreshThrottled(ctx)
+	}
+
+	key, ok := s.lookup(kid)
+	if !ok {
+		if err := s.refreshThrottled(ctx); err == nil {
+			key, ok = s.lookup(kid)
+		}
+	}
+	if !ok {
+		return nil, ErrUnknownSigningKey
+	}
+
+	if key.alg != alg || defaultAlgFor(key.key) != alg {
+		return nil, fmt.Errorf("%w: key %q does not support %s", ErrUnsupportedJWK, kid, alg)
+	}
+	return key.key, nil
+}
+
+// Check reports whether the key set is usable, for readiness probes. A stale set is refreshed first; the
+// check fails when the set has not been refreshed for twice the refresh interval.
+func (s *JWKS) Check(ctx context.Context) error {
+	s.mu.RLock()
+	age := s.now().Sub(s.fetchedAt)
+	s.mu.RUnlock()
+	if age > s.cfg.RefreshInterval {
+		if err := s.refreshThrottled(ctx); err == nil {
+			return nil
+		}
+	}
+	if age > 2*s.cfg.RefreshInterval {
+		return fmt.Errorf("jwks last refreshed %s ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+func (s *JWKS) lookup(kid string) (jwksKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" {
+		if len(s.keys) != 1 {
+			return jwksKey{}, false
+		}
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *JWKS) refreshThrottled(ctx context.Context) error {
+	s.mu.RLock()
+	recent := s.now().Sub(s.attemptedAt) < s.cfg.MinRefreshInterval
+	s.mu.RUnlock()
+	if recent {
+		return errors.New("jwks refresh throttled")
+	}
+	return s.Refresh(ctx)
+}
+
+func (s *JWKS) load(ctx context.Context) ([]byte, error) {
+	if s.cfg.File != "" {
+		raw, err := os.ReadFile(s.cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("read jwks file: %w", err)
+		}
+		return raw, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build jwks request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read jwks: %w", err)
+	}
+	return raw, nil
+}
+
+// JWKSVerifierConfig lists the claim checks applied by JWKSTokenVerifier.
+type JWKSVerifierConfig struct {
+	// Issuer must match the `iss` claim.
+	Issuer string
+	// Audience, when set, requires `aud` to contain at least one of the values.
+	Audience []string
+	// Leeway tolerates clock skew on exp/nbf/iat (default 30s).
+	Leeway time.Duration
+}
+
+// JWKSTokenVerifier returns a VerifyFunc validating RS256, ES256 and EdDSA tokens against keys. `exp`
+// is required; `nbf` and `iat` are honoured when present.
+func JWKSTokenVerifier(keys *JWKS, cfg JWKSVerifierConfig) VerifyFunc {
+	if keys == nil {
+		panic("auth.JWKSTokenVerifier: key set must not be nil")
+	}
+	if cfg.Issuer == "" {
+		panic("auth.JWKSTokenVerifier: issuer must not be empty")
+	}
+	if cfg.Leeway <= 0 {
+		cfg.Leeway = 30 * time.Second
+	}
+
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{AlgRS256, AlgES256, AlgEdDSA}), jwt.WithoutClaimsValidation())
+
+	return func(ctx context.Context, token string) (map[string]interface{}, error) {
+		claims := jwt.MapClaims{}
+		if _, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			return keys.Key(ctx, kid, t.Method.Alg())
+		}); err != nil {
+			return nil, fmt.Errorf("parse token: %w", err)
+		}
+
+		now := keys.now()
+		if !claims.VerifyIssuer(cfg.Issuer, true) {
+			return nil, errors.New("unexpected token issuer")
+		}
+		if len(cfg.Audience) > 0 && !verifyAnyAudience(claims, cfg.Audience) {
+			return nil, errors.New("unexpected token audience")
+		}
+		if !claims.VerifyExpiresAt(now.Add(-cfg.Leeway).Unix(), true) {
+			return nil, errors.New("token expired")
+		}
+		if !claims.VerifyNotBefore(now.Add(cfg.Leeway).Unix(), false) {
+			return nil, errors.New("token not valid yet")
+		}
+		if !claims.VerifyIssuedAt(now.Add(cfg.Leeway).Unix(), false) {
+			return nil, errors.New("token issued in the future")
+		}
+
+		return claims, nil
+	}
+}
+
+func verifyAnyAudience(claims jwt.MapClaims, audiences []string) bool {
+	for _, aud := range audiences {
+		if claims.VerifyAudience(aud, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultAlgFor(key crypto.PublicKey) string {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return AlgRS256
+	case *ecdsa.PublicKey:
+		return AlgES256
+	case ed25519.PublicKey:
+		return AlgEdDSA
+	default:
+		return ""
+	}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func unb64(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(value)
+}
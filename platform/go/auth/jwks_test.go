@@ -0,0 +1,274 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type testSigningKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+func newTestSigningKeys(t *testing.T) []testSigningKey {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return []testSigningKey{
+		{kid: "rsa", method: jwt.SigningMethodRS256, key: rsaKey},
+		{kid: "ec", method: jwt.SigningMethodES256, key: ecKey},
+		{kid: "ed", method: jwt.SigningMethodEdDSA, key: edKey},
+	}
+}
+
+func jwksDocument(t *testing.T, keys ...testSigningKey) []byte {
+	t.Helper()
+
+	var set JWKSet
+	for _, key := range keys {
+		jwk, err := PublicJWK(key.kid, key.key.Public())
+		require.NoError(t, err)
+		set.Keys = append(set.Keys, jwk)
+	}
+	raw, err := json.Marshal(set)
+	require.NoError(t, err)
+	return raw
+}
+
+func signTestToken(t *testing.T, key testSigningKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	signed, err := token.SignedString(key.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":   "https://issuer.example.com",
+		"aud":   "palmyra",
+		"sub":   "user-1",
+		"email": "user@example.com",
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Minute).Unix(),
+	}
+}
+
+func TestJWKSTokenVerifierAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestSigningKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksDocument(t, keys...), 0o600))
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{File: path})
+	require.NoError(t, err)
+
+	verify := JWKSTokenVerifier(set, JWKSVerifierConfig{Issuer: "https://issuer.example.com", Audience: []string{"palmyra"}})
+
+	for _, key := range keys {
+		claims, err := verify(context.Background(), signTestToken(t, key, validClaims()))
+		require.NoError(t, err, key.kid)
+		require.Equal(t, "user-1", claims["sub"])
+	}
+}
+
+func TestJWKSTokenVerifierRejectsInvalidClaims(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestSigningKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksDocument(t, keys...), 0o600))
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{File: path})
+	require.NoError(t, err)
+
+	verify := JWKSTokenVerifier(set, JWKSVerifierConfig{Issuer: "https://issuer.example.com", Audience: []string{"palmyra"}, Leeway: time.Second})
+
+	mutate := map[string]func(jwt.MapClaims){
+		"issuer":     func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"audience":   func(c jwt.MapClaims) { c["aud"] = "other" },
+		"expired":    func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"no expiry":  func(c jwt.MapClaims) { delete(c, "exp") },
+		"not before": func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(time.Minute).Unix() },
+	}
+	for name, fn := range mutate {
+		claims := validClaims()
+		fn(claims)
+		_, err := verify(context.Background(), signTestToken(t, keys[1], claims))
+		require.Error(t, err, name)
+	}
+
+	// A key from outside the set, advertised under a known kid.
+	rogue, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = verify(context.Background(), signTestToken(t, testSigningKey{kid: "ec", method: jwt.SigningMethodES256, key: rogue}, validClaims()))
+	require.Error(t, err)
+
+	// The RSA key cannot be used with an HMAC algorithm (alg confusion).
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	hmac.Header["kid"] = "rsa"
+	signed, err := hmac.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = verify(context.Background(), signed)
+	require.Error(t, err)
+
+	_, err = verify(context.Background(), signTestToken(t, testSigningKey{kid: "unknown", method: keys[0].method, key: keys[0].key}, validClaims()))
+	require.ErrorIs(t, err, ErrUnknownSigningKey)
+}
+
+func TestJWKSRefreshesOnKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestSigningKeys(t)
+	var (
+		document atomic.Value
+		fetches  atomic.Int32
+	)
+	document.Store(jwksDocument(t, keys[0]))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(document.Load().([]byte))
+	}))
+	t.Cleanup(server.Close)
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{URL: server.URL, MinRefreshInterval: time.Nanosecond})
+	require.NoError(t, err)
+
+	verify := JWKSTokenVerifier(set, JWKSVerifierConfig{Issuer: "https://issuer.example.com"})
+
+	_, err = verify(context.Background(), signTestToken(t, keys[0], validClaims()))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, fetches.Load(), "known keys are served from cache")
+
+	document.Store(jwksDocument(t, keys[0], keys[2]))
+	_, err = verify(context.Background(), signTestToken(t, keys[2], validClaims()))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, fetches.Load())
+}
+
+func TestJWKSKeyWithoutKid(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestSigningKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksDocument(t, keys[2]), 0o600))
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{File: path})
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, validClaims())
+	signed, err := token.SignedString(keys[2].key)
+	require.NoError(t, err)
+
+	_, err = JWKSTokenVerifier(set, JWKSVerifierConfig{Issuer: "https://issuer.example.com"})(context.Background(), signed)
+	require.NoError(t, err)
+}
+
+func TestPublicJWKRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range newTestSigningKeys(t) {
+		jwk, err := PublicJWK("", key.key.Public())
+		require.NoError(t, err)
+		require.NotEmpty(t, jwk.Kid, "thumbprint kid")
+		require.Equal(t, key.method.Alg(), jwk.Alg)
+
+		decoded, err := jwk.PublicKey()
+		require.NoError(t, err)
+		require.True(t, key.key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(decoded), key.kid)
+	}
+}
+
+func TestClaimMappingExtractor(t *testing.T) {
+	t.Parallel()
+
+	mapping, err := ParseClaimMapping(map[string]string{
+		"uid":    "sub",
+		"email":  "preferred_email",
+		"roles":  "realm_access.roles",
+		"tenant": "org",
+	})
+	require.NoError(t, err)
+
+	creds, err := ClaimMappingExtractor(mapping)(map[string]interface{}{
+		"sub":             "kc-user",
+		"uid":             "ignored",
+		"preferred_email": "kc@example.com",
+		"org":             "acme",
+		"realm_access":    map[string]interface{}{"roles": []interface{}{"admin", "offline_access"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "kc-user", creds.Id)
+	require.Equal(t, "kc@example.com", creds.Email)
+	require.Equal(t, "acme", *creds.TenantID)
+	require.Equal(t, []Role{RoleAdmin}, creds.Roles)
+	require.True(t, creds.IsAdmin)
+
+	_, err = ClaimMappingExtractor(mapping)(map[string]interface{}{"uid": "x"})
+	require.Error(t, err, "mapped user id is required")
+
+	_, err = ParseClaimMapping(map[string]string{"picture": "avatar"})
+	require.Error(t, err)
+}
+
+func TestJWKSCheck(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestSigningKeys(t)
+	var available atomic.Bool
+	available.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(jwksDocument(t, keys[0]))
+	}))
+	t.Cleanup(server.Close)
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{URL: server.URL, RefreshInterval: time.Minute, MinRefreshInterval: time.Nanosecond})
+	require.NoError(t, err)
+
+	now := time.Now()
+	set.now = func() time.Time { return now }
+	require.NoError(t, set.Check(context.Background()))
+
+	available.Store(false)
+	now = now.Add(90 * time.Second)
+	require.NoError(t, set.Check(context.Background()), "a stale set is still usable within twice the refresh interval")
+
+	now = now.Add(time.Minute)
+	require.Error(t, set.Check(context.Background()))
+
+	available.Store(true)
+	now = now.Add(time.Second)
+	require.NoError(t, set.Check(context.Background()), "a successful refresh recovers the check")
+}
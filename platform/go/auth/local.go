@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// minLocalSigningKeyLength is the minimum HS256 secret size (256 bits).
+const minLocalSigningKeyLength = 32
+
+// LocalTokenConfig configures the access tokens issued by the local identity provider.
+type LocalTokenConfig struct {
+	SigningKey []byte
+	Issuer     string
+	AccessTTL  time.Duration
+}
+
+// LocalAccessClaims describes the subject of a locally issued access token.
+type LocalAccessClaims struct {
+	UserID        string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Roles         []string
+	SessionID     string
+}
+
+// LocalTokenSigner issues and parses HS256 access tokens for AUTH_PROVIDER=local. The claims mirror the
+// Firebase layout (uid, email, name, roles) so DefaultCredentialExtractor works unchanged, plus `sid`
+// linking the token to its login session.
+type LocalTokenSigner struct {
+	cfg LocalTokenConfig
+	now func() time.Time
+}
+
+// NewLocalTokenSigner validates the configuration and returns a signer.
+func NewLocalTokenSigner(cfg LocalTokenConfig) (*LocalTokenSigner, error) {
+	if len(cfg.SigningKey) < minLocalSigningKeyLength {
+		return nil, fmt.Errorf("local signing key must be at least %d bytes", minLocalSigningKeyLength)
+	}
+	if cfg.Issuer == "" {
+		return nil, errors.New("local token issuer is required")
+	}
+	if cfg.AccessTTL <= 0 {
+		return nil, errors.New("local access token ttl must be positive")
+	}
+	return &LocalTokenSigner{cfg: cfg, now: time.Now}, nil
+}
+
+// AccessTTL returns the lifetime of issued access tokens.
+func (s *LocalTokenSigner) AccessTTL() time.Duration {
+	return s.cfg.AccessTTL
+}
+
+// IssueAccessToken signs an access token for the subject and returns it with its expiry.
+func (s *LocalTokenSigner) IssueAccessToken(subject LocalAccessClaims) (string, time.Time, error) {
+	now := s.now().UTC()
+	expiresAt := now.Add(s.cfg.AccessTTL)
+
+	claims := jwt.MapClaims{
+		"iss":            s.cfg.Issuer,
+		"sub":            subject.UserID,
+		"uid":            subject.UserID,
+		"email":          subject.Email,
+		"email_verified": subject.EmailVerified,
+		"roles":          subject.Roles,
+		"sid":            subject.SessionID,
+		"jti":            uuid.NewString(),
+		"iat":            now.Unix(),
+		"exp":            expiresAt.Unix(),
+	}
+	if subject.Name != "" {
+		claims["name"] = subject.Name
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.cfg.SigningKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse verifies the signature, issuer and expiry of token and returns its claims.
+func (s *LocalTokenSigner) Parse(token string) (map[string]interface{}, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation())
+
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return s.cfg.SigningKey, nil
+	}); err != nil {
+		return nil, fmt.Errorf("parse token: %w", err)
+	}
+
+	now := s.now().Unix()
+	if !claims.VerifyIssuer(s.cfg.Issuer, true) {
+		return nil, errors.New("unexpected token issuer")
+	}
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("token expired")
+	}
+	if !claims.VerifyNotBefore(now, false) {
+		return nil, errors.New("token not valid yet")
+	}
+
+	return claims, nil
+}
+
+// SessionChecker reports whether the login session behind a locally issued token is still active.
+type SessionChecker func(ctx context.Context, sessionID string) (bool, error)
+
+// LocalTokenVerifier returns a VerifyFunc for tokens issued by signer. Tokens whose session was revoked
+// (logout, refresh-token reuse) are rejected even before they expire.
+func LocalTokenVerifier(signer *LocalTokenSigner, active SessionChecker) VerifyFunc {
+	if signer == nil {
+		panic("auth.LocalTokenVerifier: signer must not be nil")
+	}
+	if active == nil {
+		panic("auth.LocalTokenVerifier: session checker must not be nil")
+	}
+
+	return func(ctx context.Context, token string) (map[string]interface{}, error) {
+		claims, err := signer.Parse(token)
+		if err != nil {
+			return nil, err
+		}
+
+		sessionID := extractStringClaim(claims, "sid")
+		if sessionID == "" {
+			return nil, errors.New("missing session")
+		}
+
+		ok, err := active(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("check session: %w", err)
+		}
+		if !ok {
+			return nil, errors.New("session revoked")
+		}
+
+		return claims, nil
+	}
+}
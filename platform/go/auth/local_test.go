@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testPasswordParams = PasswordParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHashPasswordRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hash, err := HashPassword("correct horse", testPasswordParams)
+	require.NoError(t, err)
+	require.Contains(t, hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+	ok, err := VerifyPassword("correct horse", hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = VerifyPassword("wrong horse", hash)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	other, err := HashPassword("correct horse", testPasswordParams)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other, "salt must be random")
+
+	_, err = VerifyPassword("correct horse", "$2a$10$bcrypt")
+	require.ErrorIs(t, err, ErrInvalidPasswordHash)
+}
+
+func newTestSigner(t *testing.T) *LocalTokenSigner {
+	t.Helper()
+	signer, err := NewLocalTokenSigner(LocalTokenConfig{
+		SigningKey: []byte("0123456789abcdef0123456789abcdef"),
+		Issuer:     "palmyra-test",
+		AccessTTL:  time.Minute,
+	})
+	require.NoError(t, err)
+	return signer
+}
+
+func TestNewLocalTokenSignerValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewLocalTokenSigner(LocalTokenConfig{SigningKey: []byte("short"), Issuer: "x", AccessTTL: time.Minute})
+	require.Error(t, err)
+	_, err = NewLocalTokenSigner(LocalTokenConfig{SigningKey: make([]byte, 32), AccessTTL: time.Minute})
+	require.Error(t, err)
+	_, err = NewLocalTokenSigner(LocalTokenConfig{SigningKey: make([]byte, 32), Issuer: "x"})
+	require.Error(t, err)
+}
+
+func TestLocalTokenRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	token, expiresAt, err := signer.IssueAccessToken(LocalAccessClaims{
+		UserID:    "user-1",
+		Email:     "user@example.com",
+		Name:      "User",
+		Roles:     []string{"user"},
+		SessionID: "session-1",
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+	claims, err := signer.Parse(token)
+	require.NoError(t, err)
+
+	creds, err := DefaultCredentialExtractor(claims)
+	require.NoError(t, err)
+	require.Equal(t, "user-1", creds.Id)
+	require.Equal(t, "user@example.com", creds.Email)
+	require.Equal(t, "session-1", creds.SessionID)
+	require.Equal(t, []Role{RoleUser}, creds.Roles)
+}
+
+func TestLocalTokenParseRejectsInvalidTokens(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	token, _, err := signer.IssueAccessToken(LocalAccessClaims{UserID: "user-1", SessionID: "session-1"})
+	require.NoError(t, err)
+
+	other, err := NewLocalTokenSigner(LocalTokenConfig{
+		SigningKey: []byte("fedcba9876543210fedcba9876543210"),
+		Issuer:     "palmyra-test",
+		AccessTTL:  time.Minute,
+	})
+	require.NoError(t, err)
+	_, err = other.Parse(token)
+	require.Error(t, err, "signature from another key")
+
+	foreignIssuer, err := NewLocalTokenSigner(LocalTokenConfig{
+		SigningKey: []byte("0123456789abcdef0123456789abcdef"),
+		Issuer:     "someone-else",
+		AccessTTL:  time.Minute,
+	})
+	require.NoError(t, err)
+	_, err = foreignIssuer.Parse(token)
+	require.Error(t, err, "issuer mismatch")
+
+	expired := newTestSigner(t)
+	expired.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	stale, _, err := expired.IssueAccessToken(LocalAccessClaims{UserID: "user-1", SessionID: "session-1"})
+	require.NoError(t, err)
+	_, err = signer.Parse(stale)
+	require.Error(t, err, "expired token")
+}
+
+func TestLocalTokenVerifierChecksSession(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	revoked := map[string]bool{"revoked": true}
+	verify := LocalTokenVerifier(signer, func(ctx context.Context, sessionID string) (bool, error) {
+		return !revoked[sessionID], nil
+	})
+
+	active, _, err := signer.IssueAccessToken(LocalAccessClaims{UserID: "user-1", SessionID: "active"})
+	require.NoError(t, err)
+	_, err = verify(context.Background(), active)
+	require.NoError(t, err)
+
+	gone, _, err := signer.IssueAccessToken(LocalAccessClaims{UserID: "user-1", SessionID: "revoked"})
+	require.NoError(t, err)
+	_, err = verify(context.Background(), gone)
+	require.Error(t, err)
+
+	sessionless, _, err := signer.IssueAccessToken(LocalAccessClaims{UserID: "user-1"})
+	require.NoError(t, err)
+	_, err = verify(context.Background(), sessionless)
+	require.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
+)
+
+// Role mirrors contracts/common/iam.yaml#/components/schemas/UserRole.
+type Role string
+
+const (
+	RoleAdmin       Role = "admin"
+	RoleUserManager Role = "user_manager"
+	RoleUser        Role = "user"
+)
+
+// ParseRole converts a raw string into a known Role.
+func ParseRole(raw string) (Role, bool) {
+	switch role := Role(strings.TrimSpace(raw)); role {
+	case RoleAdmin, RoleUserManager, RoleUser:
+		return role, true
+	default:
+		return "", false
+	}
+}
+
+// HasRole reports whether the credentials carry the provided role.
+func (c *UserCredentials) HasRole(role Role) bool {
+	if c == nil {
+		return false
+	}
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyRole reports whether the credentials carry at least one of the provided roles.
+// An empty role list means the caller only needs to be authenticated.
+func (c *UserCredentials) HasAnyRole(roles ...Role) bool {
+	if c == nil {
+		return false
+	}
+	if len(roles) == 0 {
+		return true
+	}
+	for _, role := range roles {
+		if c.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithUserCredentials stores the credentials on the context (used by middleware and tests).
+func WithUserCredentials(ctx context.Context, creds *UserCredentials) context.Context {
+	return context.WithValue(ctx, ctxUserCredentials, creds)
+}
+
+// RoleLookup returns the roles persisted for the authenticated caller (e.g. the users table).
+// Implementations should return an empty slice, not an error, when the caller is unknown.
+type RoleLookup func(ctx context.Context, creds *UserCredentials) ([]Role, error)
+
+// ResolveRoles merges the roles carried by the token with those returned by lookup and always grants
+// the baseline RoleUser to authenticated callers. Anonymous requests and service accounts, whose roles
+// are fixed by the account, pass through untouched.
+func ResolveRoles(lookup RoleLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			creds, ok := UserFromContext(r.Context())
+			if !ok || creds == nil || creds.IsServiceAccount() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			roles := append([]Role{RoleUser}, creds.Roles...)
+			if lookup != nil {
+				stored, err := lookup(r.Context(), creds)
+				if err != nil {
+					platformhttp.WriteProblem(w, r, http.StatusInternalServerError, platformhttp.ProblemTypeInternal,
+						"Internal server error", "could not resolve caller roles")
+					return
+				}
+				roles = append(roles, stored...)
+			}
+
+			resolved := *creds
+			resolved.Roles = dedupeRoles(roles)
+			resolved.IsAdmin = resolved.HasRole(RoleAdmin)
+
+			next.ServeHTTP(w, r.WithContext(WithUserCredentials(r.Context(), &resolved)))
+		})
+	}
+}
+
+// RequireRoles gates handlers so only callers holding at least one of the roles get through.
+func RequireRoles(roles ...Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			creds, ok := UserFromContext(r.Context())
+			if !ok || creds == nil {
+				platformhttp.WriteProblem(w, r, http.StatusUnauthorized, platformhttp.ProblemTypeUnauthorized,
+					"Unauthorized", "authentication is required")
+				return
+			}
+
+			if !creds.HasAnyRole(roles...) {
+				platformhttp.WriteProblem(w, r, http.StatusForbidden, platformhttp.ProblemTypeForbidden,
+					"Forbidden", fmt.Sprintf("requires one of roles: %s", joinRoles(roles)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// extractRoles reads the `roles` claim (contracts/common/iam.yaml#/components/schemas/Claims), the
+// Identity Platform `tenantRoles` claim and the legacy `isAdmin` flag. Unknown roles are ignored.
+func extractRoles(claims map[string]interface{}) []Role {
+	var roles []Role
+	for _, key := range []string{"roles", "tenantRoles"} {
+		raw, ok := claims[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, v := range raw {
+			if s, ok := v.(string); ok {
+				if role, known := ParseRole(s); known {
+					roles = append(roles, role)
+				}
+			}
+		}
+	}
+	if extractBoolClaim(claims, "isAdmin") {
+		roles = append(roles, RoleAdmin)
+	}
+	return dedupeRoles(roles)
+}
+
+func dedupeRoles(roles []Role) []Role {
+	seen := make(map[Role]struct{}, len(roles))
+	result := make([]Role, 0, len(roles))
+	for _, role := range roles {
+		if _, ok := seen[role]; ok {
+			continue
+		}
+		seen[role] = struct{}{}
+		result = append(result, role)
+	}
+	return result
+}
+
+func joinRoles(roles []Role) string {
+	parts := make([]string, 0, len(roles))
+	for _, role := range roles {
+		parts = append(parts, string(role))
+	}
+	return strings.Join(parts, ", ")
+}
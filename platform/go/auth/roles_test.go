@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRoles(t *testing.T) {
+	testCases := []struct {
+		name   string
+		claims map[string]interface{}
+		want   []Role
+	}{
+		{
+			name:   "roles claim",
+			claims: map[string]interface{}{"roles": []interface{}{"user_manager", "user"}},
+			want:   []Role{RoleUserManager, RoleUser},
+		},
+		{
+			name: "tenant roles and legacy admin flag",
+			claims: map[string]interface{}{
+				"tenantRoles": []interface{}{"user"},
+				"isAdmin":     true,
+			},
+			want: []Role{RoleUser, RoleAdmin},
+		},
+		{
+			name:   "unknown roles are ignored",
+			claims: map[string]interface{}{"roles": []interface{}{"superuser", "admin", "admin"}},
+			want:   []Role{RoleAdmin},
+		},
+		{
+			name:   "no roles",
+			claims: map[string]interface{}{},
+			want:   []Role{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, extractRoles(tc.claims))
+		})
+	}
+}
+
+func TestResolveRoles(t *testing.T) {
+	lookup := func(ctx context.Context, creds *UserCredentials) ([]Role, error) {
+		if creds.Id == "broken" {
+			return nil, errors.New("boom")
+		}
+		return []Role{RoleUserManager}, nil
+	}
+
+	t.Run("merges token and stored roles", func(t *testing.T) {
+		var got *UserCredentials
+		handler := ResolveRoles(lookup)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = UserFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(WithUserCredentials(req.Context(), &UserCredentials{Id: "user-1", Roles: []Role{RoleAdmin}}))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.NotNil(t, got)
+		require.Equal(t, []Role{RoleUser, RoleAdmin, RoleUserManager}, got.Roles)
+		require.True(t, got.IsAdmin)
+	})
+
+	t.Run("service accounts keep their roles", func(t *testing.T) {
+		var got *UserCredentials
+		handler := ResolveRoles(lookup)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = UserFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(WithUserCredentials(req.Context(), &UserCredentials{Id: "broken", ServiceAccountID: "broken", Roles: []Role{RoleUserManager}}))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.NotNil(t, got)
+		require.Equal(t, []Role{RoleUserManager}, got.Roles)
+	})
+
+	t.Run("lookup failure returns 500", func(t *testing.T) {
+		handler := ResolveRoles(lookup)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not be called")
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(WithUserCredentials(req.Context(), &UserCredentials{Id: "broken"}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	})
+}
+
+func TestRequireRoles(t *testing.T) {
+	testCases := []struct {
+		name       string
+		creds      *UserCredentials
+		wantStatus int
+	}{
+		{name: "anonymous", wantStatus: http.StatusUnauthorized},
+		{name: "missing role", creds: &UserCredentials{Roles: []Role{RoleUser}}, wantStatus: http.StatusForbidden},
+		{name: "allowed", creds: &UserCredentials{Roles: []Role{RoleUserManager}}, wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := RequireRoles(RoleAdmin, RoleUserManager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.creds != nil {
+				req = req.WithContext(WithUserCredentials(req.Context(), tc.creds))
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code)
+		})
+	}
+}
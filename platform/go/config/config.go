@@ -0,0 +1,161 @@
+// Package config loads service configuration from defaults, an optional YAML file and environment
+// variables, and validates it before anything starts.
+//
+// Configuration structs use three tags per field: `env` (with the caarlos0/env options such as
+// envDefault and envSeparator), `yaml` for the file, and `secret` for values that must not be printed.
+// Sections shared by the API and the tools (Server, Database, Log, CORS, Auth) live in this package;
+// apps compose them with their own settings.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable holding the path of the YAML configuration file.
+const FileEnv = "CONFIG_FILE"
+
+// Validator is implemented by configuration sections that check their own values.
+type Validator interface {
+	Validate() error
+}
+
+// Option customises Decode.
+type Option func(*options)
+
+type options struct {
+	ignoreUnknownKeys bool
+}
+
+// IgnoreUnknownKeys accepts YAML keys without a matching field, so a tool can read the sections it needs
+// from a file written for the API.
+func IgnoreUnknownKeys() Option {
+	return func(o *options) {
+		o.ignoreUnknownKeys = true
+	}
+}
+
+// Load decodes cfg (a pointer to a struct) with Decode and validates it with Validate.
+func Load(cfg any, path string, opts ...Option) error {
+	if err := Decode(cfg, path, opts...); err != nil {
+		return err
+	}
+	return Validate(cfg)
+}
+
+// Decode fills cfg from, in increasing precedence: envDefault tags, the YAML file at path (skipped when
+// path is empty) and environment variables. Unknown YAML keys are rejected so typos do not go unnoticed,
+// unless IgnoreUnknownKeys is given.
+func Decode(cfg any, path string, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if value := reflect.ValueOf(cfg); value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: %T is not a pointer to a struct", cfg)
+	}
+
+	if err := env.ParseWithOptions(cfg, env.Options{Environment: map[string]string{}}); err != nil {
+		return fmt.Errorf("config: defaults: %w", err)
+	}
+
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+		defer file.Close()
+
+		decoder := yaml.NewDecoder(file)
+		decoder.KnownFields(!o.ignoreUnknownKeys)
+		// An empty file decodes to io.EOF and leaves the defaults in place.
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("config: %s: %w", path, err)
+		}
+	}
+
+	// A tag name no field uses keeps envDefault from overwriting values taken from the file.
+	if err := env.ParseWithOptions(cfg, env.Options{DefaultValueTagName: "-"}); err != nil {
+		return fmt.Errorf("config: environment: %w", err)
+	}
+	return nil
+}
+
+// Validate runs Validate on cfg and on every nested section implementing Validator, and reports all
+// failures at once, each prefixed with the YAML path of its section.
+func Validate(cfg any) error {
+	if errs := validate(reflect.ValueOf(cfg), ""); len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func validate(value reflect.Value, path string) []error {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs []error
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		errs = append(errs, validate(value.Field(i), joinPath(path, yamlName(field)))...)
+	}
+
+	if value.CanAddr() {
+		if validator, ok := value.Addr().Interface().(Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, prefixErrors(path, err)...)
+			}
+		}
+	}
+	return errs
+}
+
+// prefixErrors splits joined errors so each line carries the section path.
+func prefixErrors(path string, err error) []error {
+	var parts []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		parts = joined.Unwrap()
+	} else {
+		parts = []error{err}
+	}
+	if path == "" {
+		return parts
+	}
+	for i, part := range parts {
+		parts[i] = fmt.Errorf("%s: %w", path, part)
+	}
+	return parts
+}
+
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
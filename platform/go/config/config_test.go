@@ -0,0 +1,167 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Server   Server   `yaml:"server"`
+	Database Database `yaml:"database"`
+	Log      Log      `yaml:"log"`
+	CORS     CORS     `yaml:"cors"`
+	Auth     Auth     `yaml:"auth"`
+
+	Feature bool `env:"TEST_FEATURE" envDefault:"true" yaml:"feature"`
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+server:
+  port: "8080"
+  request_timeout: 20s
+database:
+  url: postgres://palmyra:file-secret@db:5432/palmyra
+  max_conns: 20
+log:
+  level: debug
+cors:
+  allowed_origins: [https://admin.example.com]
+feature: false
+`)
+	t.Setenv("PORT", "9090")
+	t.Setenv("DB_MIN_CONNS", "2")
+	t.Setenv("AUTH_PROVIDER", "dev")
+
+	var cfg testConfig
+	require.NoError(t, Load(&cfg, path))
+
+	require.Equal(t, "9090", cfg.Server.Port, "environment overrides the file")
+	require.Equal(t, 20*time.Second, cfg.Server.RequestTimeout, "the file overrides defaults")
+	require.Equal(t, 60*time.Second, cfg.Server.WriteTimeout, "defaults fill the rest")
+	require.EqualValues(t, 20, cfg.Database.MaxConns)
+	require.EqualValues(t, 2, cfg.Database.MinConns)
+	require.Equal(t, "debug", cfg.Log.Level)
+	require.Equal(t, []string{"https://admin.example.com"}, cfg.CORS.AllowedOrigins)
+	require.False(t, cfg.Feature, "file values are not reset to envDefault")
+
+	pool := cfg.Database.PoolConfig()
+	require.Equal(t, cfg.Database.URL, pool.ConnString)
+	require.EqualValues(t, 20, pool.MaxConns)
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/palmyra")
+	t.Setenv("AUTH_PROVIDER", "dev")
+
+	var cfg testConfig
+	require.NoError(t, Load(&cfg, ""))
+	require.Equal(t, "3000", cfg.Server.Port)
+	require.Equal(t, []string{"*"}, cfg.CORS.AllowedOrigins)
+	require.True(t, cfg.Feature)
+}
+
+func TestDecodeRejectsUnknownKeys(t *testing.T) {
+	path := writeFile(t, "database:\n  urll: postgres://localhost/palmyra\n")
+
+	var cfg testConfig
+	err := Decode(&cfg, path)
+	require.ErrorContains(t, err, "urll")
+
+	var partial struct {
+		Log Log `yaml:"log"`
+	}
+	require.NoError(t, Decode(&partial, path, IgnoreUnknownKeys()))
+	require.NoError(t, Decode(&partial, writeFile(t, "")), "empty files keep the defaults")
+	require.Equal(t, "info", partial.Log.Level)
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig{
+		Server:   Server{Port: "http", RequestTimeout: time.Minute, ReadTimeout: time.Second, WriteTimeout: time.Second, IdleTimeout: time.Second, ShutdownTimeout: time.Second},
+		Database: Database{MaxConns: 2, MinConns: 5},
+		Log:      Log{Level: "loud"},
+		CORS:     CORS{AllowedOrigins: []string{"*", "https://ok.example.com", "admin.example.com", "https://x.example.com/path"}},
+		Auth:     Auth{Provider: "jwks", JWKSClaims: map[string]string{"roles": ""}},
+	}
+
+	err := Validate(&cfg)
+	require.Error(t, err)
+	for _, want := range []string{
+		`server: port "http"`,
+		"server: write_timeout must not be shorter than request_timeout",
+		"database: url is required",
+		"database: min_conns (5) must not exceed max_conns (2)",
+		"log: level:",
+		`cors: allowed_origins: "admin.example.com"`,
+		`cors: allowed_origins: "https://x.example.com/path"`,
+		"auth: exactly one of jwks_url and jwks_file is required",
+		"auth: jwks_issuer is required",
+		"auth: jwks_refresh_interval must be positive",
+	} {
+		require.ErrorContains(t, err, want)
+	}
+	require.NotContains(t, err.Error(), "ok.example.com")
+}
+
+func TestAuthValidateLocal(t *testing.T) {
+	t.Parallel()
+
+	auth := Auth{Provider: "local", LocalSigningKey: "short", Issuer: "palmyra-api", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}
+	require.ErrorContains(t, auth.Validate(), "local_signing_key")
+
+	auth.LocalSigningKey = "0123456789abcdef0123456789abcdef"
+	require.NoError(t, auth.Validate())
+
+	require.ErrorContains(t, Auth{Provider: "saml"}.Validate(), `provider "saml"`)
+}
+
+func TestJobsValidate(t *testing.T) {
+	t.Parallel()
+
+	jobs := Jobs{Workers: 2, PollInterval: time.Second, HeartbeatInterval: time.Minute, StaleAfter: time.Minute}
+	require.ErrorContains(t, jobs.Validate(), "stale_after must exceed heartbeat_interval")
+
+	jobs.HeartbeatInterval = 10 * time.Second
+	require.NoError(t, jobs.Validate())
+
+	jobs.Workers = -1
+	require.ErrorContains(t, jobs.Validate(), "workers must not be negative")
+}
+
+func TestRedacted(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig{
+		Server:   Server{Port: "3000", RequestTimeout: 15 * time.Second},
+		Database: Database{URL: "postgres://palmyra:s3cret@db:5432/palmyra?sslmode=disable", MaxConns: 10},
+		Auth:     Auth{Provider: "local", LocalSigningKey: "0123456789abcdef0123456789abcdef"},
+	}
+
+	out := Redacted(&cfg)
+	require.Equal(t, "15s", out["server"].(map[string]any)["request_timeout"])
+
+	database := out["database"].(map[string]any)
+	require.Equal(t, "postgres://palmyra:xxxxx@db:5432/palmyra?sslmode=disable", database["url"])
+	require.EqualValues(t, 10, database["max_conns"])
+
+	auth := out["auth"].(map[string]any)
+	require.Equal(t, "[redacted]", auth["local_signing_key"])
+	require.Equal(t, "local", auth["provider"])
+
+	require.Equal(t, "[redacted]", redactURL("host=db user=palmyra password=s3cret"))
+	require.Equal(t, "postgres://db/palmyra?password=xxxxx", redactURL("postgres://db/palmyra?password=s3cret"))
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
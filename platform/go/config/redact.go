@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+const redacted = "[redacted]"
+
+// Redacted returns cfg as nested maps keyed by YAML names, safe to log or print. Fields tagged
+// `secret:"true"` are replaced when set; `secret:"url"` keeps the URL but hides its password.
+func Redacted(cfg any) map[string]any {
+	value := reflect.ValueOf(cfg)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	return redactStruct(value)
+}
+
+func redactStruct(value reflect.Value) map[string]any {
+	out := map[string]any{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Tag.Get("yaml") == "-" {
+			continue
+		}
+		out[yamlName(field)] = redactValue(value.Field(i), field.Tag.Get("secret"))
+	}
+	return out
+}
+
+func redactValue(value reflect.Value, secret string) any {
+	switch secret {
+	case "true":
+		if value.IsZero() {
+			return ""
+		}
+		return redacted
+	case "url":
+		return redactURL(value.String())
+	}
+
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return value.Interface().(time.Duration).String()
+	}
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	if value.Kind() == reflect.Struct {
+		return redactStruct(value)
+	}
+	return value.Interface()
+}
+
+// redactURL hides the password of URL-style connection strings; anything else is redacted entirely.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return redacted
+	}
+	if _, ok := parsed.User.Password(); ok {
+		parsed.User = url.UserPassword(parsed.User.Username(), "xxxxx")
+	}
+	query := parsed.Query()
+	if query.Has("password") {
+		query.Set("password", "xxxxx")
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/jobs"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// Server configures the HTTP server and its shutdown.
+type Server struct {
+	Port               string        `env:"PORT" envDefault:"3000" yaml:"port"`
+	RequestTimeout     time.Duration `env:"REQUEST_TIMEOUT" envDefault:"15s" yaml:"request_timeout"`
+	BulkTimeout        time.Duration `env:"BULK_TIMEOUT" envDefault:"30m" yaml:"bulk_timeout"`             // replaces request_timeout for entity exports and import uploads
+	MaxImportSize      int64         `env:"MAX_IMPORT_SIZE" envDefault:"268435456" yaml:"max_import_size"` // bytes; larger import uploads are refused with 413
+	ReadTimeout        time.Duration `env:"HTTP_READ_TIMEOUT" envDefault:"30s" yaml:"read_timeout"`
+	WriteTimeout       time.Duration `env:"HTTP_WRITE_TIMEOUT" envDefault:"60s" yaml:"write_timeout"`
+	IdleTimeout        time.Duration `env:"HTTP_IDLE_TIMEOUT" envDefault:"2m" yaml:"idle_timeout"`
+	ShutdownTimeout    time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s" yaml:"shutdown_timeout"`
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s" yaml:"shutdown_drain_delay"` // /readyz fails this long before shutdown
+}
+
+// Validate implements Validator.
+func (s Server) Validate() error {
+	var errs []error
+	if port, err := strconv.Atoi(s.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("port %q must be a number between 1 and 65535", s.Port))
+	}
+	errs = append(errs,
+		positive("request_timeout", s.RequestTimeout),
+		positive("bulk_timeout", s.BulkTimeout),
+		positive("read_timeout", s.ReadTimeout),
+		positive("write_timeout", s.WriteTimeout),
+		positive("idle_timeout", s.IdleTimeout),
+		positive("shutdown_timeout", s.ShutdownTimeout),
+	)
+	if s.MaxImportSize <= 0 {
+		errs = append(errs, errors.New("max_import_size must be positive"))
+	}
+	if s.WriteTimeout > 0 && s.WriteTimeout < s.RequestTimeout {
+		errs = append(errs, errors.New("write_timeout must not be shorter than request_timeout"))
+	}
+	if s.ShutdownDrainDelay < 0 {
+		errs = append(errs, errors.New("shutdown_drain_delay must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Database configures the Postgres connection pool. Zero values keep the pgx defaults.
+type Database struct {
+	URL                 string        `env:"DATABASE_URL" yaml:"url" secret:"url"`
+	MaxConns            int32         `env:"DB_MAX_CONNS" yaml:"max_conns"`
+	MinConns            int32         `env:"DB_MIN_CONNS" yaml:"min_conns"`
+	MaxConnLifetime     time.Duration `env:"DB_MAX_CONN_LIFETIME" yaml:"max_conn_lifetime"`
+	MaxConnIdleTime     time.Duration `env:"DB_MAX_CONN_IDLE_TIME" yaml:"max_conn_idle_time"`
+	HealthCheckInterval time.Duration `env:"DB_HEALTH_CHECK_INTERVAL" yaml:"health_check_interval"`
+}
+
+// Validate implements Validator.
+func (d Database) Validate() error {
+	var errs []error
+	if d.URL == "" {
+		errs = append(errs, errors.New("url is required (DATABASE_URL)"))
+	} else if _, err := pgxpool.ParseConfig(d.URL); err != nil {
+		// pgconn redacts the password in parse errors.
+		errs = append(errs, fmt.Errorf("url: %w", err))
+	}
+	if d.MaxConns < 0 || d.MinConns < 0 {
+		errs = append(errs, errors.New("max_conns and min_conns must not be negative"))
+	}
+	if d.MaxConns > 0 && d.MinConns > d.MaxConns {
+		errs = append(errs, fmt.Errorf("min_conns (%d) must not exceed max_conns (%d)", d.MinConns, d.MaxConns))
+	}
+	if d.MaxConnLifetime < 0 || d.MaxConnIdleTime < 0 || d.HealthCheckInterval < 0 {
+		errs = append(errs, errors.New("connection lifetimes and health_check_interval must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// PoolConfig returns the persistence pool settings.
+func (d Database) PoolConfig() persistence.PoolConfig {
+	return persistence.PoolConfig{
+		ConnString:          d.URL,
+		MaxConns:            d.MaxConns,
+		MinConns:            d.MinConns,
+		MaxConnLifetime:     d.MaxConnLifetime,
+		MaxConnIdleTime:     d.MaxConnIdleTime,
+		HealthCheckInterval: d.HealthCheckInterval,
+	}
+}
+
+// Log configures the structured logger.
+type Log struct {
+	Level string `env:"LOG_LEVEL" envDefault:"info" yaml:"level"` // debug | info | warn | error
+}
+
+// Validate implements Validator.
+func (l Log) Validate() error {
+	if _, err := zapcore.ParseLevel(strings.ToLower(l.Level)); err != nil {
+		return fmt.Errorf("level: %w", err)
+	}
+	return nil
+}
+
+// Logger builds the logger for component.
+func (l Log) Logger(component string) (*zap.Logger, error) {
+	return platformlogging.NewLogger(platformlogging.Config{Component: component, Level: l.Level})
+}
+
+// CORS configures the origins browsers may call the API from.
+type CORS struct {
+	AllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS" envSeparator:"," envDefault:"*" yaml:"allowed_origins"` // "*" allows any origin
+}
+
+// Validate implements Validator.
+func (c CORS) Validate() error {
+	var errs []error
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+			(parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" {
+			errs = append(errs, fmt.Errorf("allowed_origins: %q must be \"*\" or scheme://host[:port]", origin))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Auth selects and configures the identity provider.
+type Auth struct {
+	Provider string `env:"AUTH_PROVIDER" envDefault:"firebase" yaml:"provider"` // firebase | local | jwks | dev
+
+	// Local identity provider (provider local).
+	LocalSigningKey string        `env:"AUTH_LOCAL_SIGNING_KEY" yaml:"local_signing_key" secret:"true"` // HS256 secret, at least 32 bytes
+	Issuer          string        `env:"AUTH_ISSUER" envDefault:"palmyra-api" yaml:"issuer"`
+	AccessTokenTTL  time.Duration `env:"AUTH_ACCESS_TOKEN_TTL" envDefault:"15m" yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `env:"AUTH_REFRESH_TOKEN_TTL" envDefault:"720h" yaml:"refresh_token_ttl"`
+
+	// External issuer verified against its JWKS (provider jwks).
+	JWKSURL             string            `env:"AUTH_JWKS_URL" yaml:"jwks_url"`
+	JWKSFile            string            `env:"AUTH_JWKS_FILE" yaml:"jwks_file"`
+	JWKSIssuer          string            `env:"AUTH_JWKS_ISSUER" yaml:"jwks_issuer"`
+	JWKSAudience        []string          `env:"AUTH_JWKS_AUDIENCE" envSeparator:"," yaml:"jwks_audience"`
+	JWKSRefreshInterval time.Duration     `env:"AUTH_JWKS_REFRESH_INTERVAL" envDefault:"1h" yaml:"jwks_refresh_interval"`
+	JWKSClaims          map[string]string `env:"AUTH_JWKS_CLAIMS" envKeyValSeparator:"=" yaml:"jwks_claims"` // e.g. roles=realm_access.roles,uid=sub
+}
+
+// Validate implements Validator; only the settings of the selected provider are checked.
+func (a Auth) Validate() error {
+	var errs []error
+	switch a.Provider {
+	case "firebase", "dev":
+	case "local":
+		if len(a.LocalSigningKey) < 32 {
+			errs = append(errs, errors.New("local_signing_key must be at least 32 bytes (AUTH_LOCAL_SIGNING_KEY)"))
+		}
+		if a.Issuer == "" {
+			errs = append(errs, errors.New("issuer is required"))
+		}
+		errs = append(errs, positive("access_token_ttl", a.AccessTokenTTL), positive("refresh_token_ttl", a.RefreshTokenTTL))
+	case "jwks":
+		if (a.JWKSURL == "") == (a.JWKSFile == "") {
+			errs = append(errs, errors.New("exactly one of jwks_url and jwks_file is required"))
+		}
+		if a.JWKSIssuer == "" {
+			errs = append(errs, errors.New("jwks_issuer is required (AUTH_JWKS_ISSUER)"))
+		}
+		if _, err := platformauth.ParseClaimMapping(a.JWKSClaims); err != nil {
+			errs = append(errs, fmt.Errorf("jwks_claims: %w", err))
+		}
+		errs = append(errs, positive("jwks_refresh_interval", a.JWKSRefreshInterval))
+	default:
+		errs = append(errs, fmt.Errorf("provider %q must be one of firebase, local, jwks, dev", a.Provider))
+	}
+	return errors.Join(errs...)
+}
+
+// Jobs configures the background job workers.
+type Jobs struct {
+	Workers           int           `env:"JOB_WORKERS" envDefault:"2" yaml:"workers"` // jobs run at once per process; 0 leaves them to apps/worker
+	PollInterval      time.Duration `env:"JOB_POLL_INTERVAL" envDefault:"1s" yaml:"poll_interval"`
+	HeartbeatInterval time.Duration `env:"JOB_HEARTBEAT_INTERVAL" envDefault:"10s" yaml:"heartbeat_interval"`
+	StaleAfter        time.Duration `env:"JOB_STALE_AFTER" envDefault:"1m" yaml:"stale_after"` // running jobs without a heartbeat this long are requeued
+}
+
+// Validate implements Validator.
+func (j Jobs) Validate() error {
+	var errs []error
+	if j.Workers < 0 {
+		errs = append(errs, errors.New("workers must not be negative"))
+	}
+	errs = append(errs,
+		positive("poll_interval", j.PollInterval),
+		positive("heartbeat_interval", j.HeartbeatInterval),
+		positive("stale_after", j.StaleAfter),
+	)
+	if j.StaleAfter > 0 && j.StaleAfter <= j.HeartbeatInterval {
+		errs = append(errs, errors.New("stale_after must exceed heartbeat_interval"))
+	}
+	return errors.Join(errs...)
+}
+
+// WorkerConfig returns the job worker settings.
+func (j Jobs) WorkerConfig() jobs.Config {
+	return jobs.Config{
+		Concurrency:       j.Workers,
+		PollInterval:      j.PollInterval,
+		HeartbeatInterval: j.HeartbeatInterval,
+		StaleAfter:        j.StaleAfter,
+	}
+}
+
+func positive(name string, d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("%s must be positive", name)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Ping checks that Postgres answers within the check timeout.
+func Ping(pool *pgxpool.Pool) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+// PoolSaturation fails when at least threshold (0..1) of the pool's connections are checked out and
+// callers had to wait for a connection since the previous run.
+func PoolSaturation(pool *pgxpool.Pool, threshold float64) func(context.Context) error {
+	var lastEmptyAcquires atomic.Int64
+	return func(ctx context.Context) error {
+		stat := pool.Stat()
+		waited := stat.EmptyAcquireCount() > lastEmptyAcquires.Swap(stat.EmptyAcquireCount())
+
+		used := float64(stat.AcquiredConns()) / float64(stat.MaxConns())
+		if used >= threshold && waited {
+			return fmt.Errorf("pool saturated: %d of %d connections in use", stat.AcquiredConns(), stat.MaxConns())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Status of a check or of the whole report.
+type Status string
+
+// Statuses, worst last.
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// defaultCheckTimeout bounds checks that do not set their own timeout.
+const defaultCheckTimeout = 2 * time.Second
+
+// Check probes one dependency. A failing critical check makes the service unready; a failing
+// non-critical check is reported as a warning only.
+type Check struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration // defaults to 2s
+	Run      func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	Status    Status  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Critical  bool    `json:"critical"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the readiness document served by Handler.
+type Report struct {
+	Status   Status                 `json:"status"`
+	Draining bool                   `json:"draining,omitempty"`
+	Checks   map[string]CheckResult `json:"checks"`
+}
+
+// Ready reports whether the service should receive traffic.
+func (r Report) Ready() bool {
+	return r.Status != StatusFail
+}
+
+// Checker runs the registered checks and tracks whether the service is draining.
+type Checker struct {
+	logger   *zap.Logger
+	draining atomic.Bool
+
+	mu     sync.RWMutex
+	checks []Check
+}
+
+// New returns a Checker without checks.
+func New(logger *zap.Logger) *Checker {
+	if logger == nil {
+		panic("logger is required")
+	}
+	return &Checker{logger: logger}
+}
+
+// Register adds checks; names must be unique.
+func (c *Checker) Register(checks ...Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, check := range checks {
+		if check.Name == "" || check.Run == nil {
+			panic("health check requires a name and a run function")
+		}
+		for _, existing := range c.checks {
+			if existing.Name == check.Name {
+				panic("duplicate health check " + check.Name)
+			}
+		}
+		c.checks = append(c.checks, check)
+	}
+}
+
+// Drain marks the service as shutting down; readiness fails from now on so load balancers stop
+// routing new requests while in-flight ones complete.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Run executes every check concurrently, each bounded by its timeout.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]Check(nil), c.checks...)
+	c.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusPass, Checks: make(map[string]CheckResult, len(checks))}
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		report.Status = worst(report.Status, results[i].Status)
+	}
+	if c.draining.Load() {
+		report.Draining = true
+		report.Status = StatusFail
+	}
+	return report
+}
+
+// Handler serves the readiness report as JSON: 200 while ready, 503 when a critical check fails or the
+// service is draining.
+func (c *Checker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Run(r.Context())
+
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+			c.logger.Warn("readiness check failed", zap.Any("checks", failing(report)), zap.Bool("draining", report.Draining))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := check.Run(ctx)
+	result := CheckResult{
+		Status:    StatusPass,
+		LatencyMs: float64(time.Since(started).Microseconds()) / 1000,
+		Critical:  check.Critical,
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.Status = StatusWarn
+		if check.Critical {
+			result.Status = StatusFail
+		}
+	}
+	return result
+}
+
+func worst(a, b Status) Status {
+	rank := map[Status]int{StatusPass: 0, StatusWarn: 1, StatusFail: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// failing lists the names of checks that did not pass, for logging.
+func failing(report Report) []string {
+	var names []string
+	for name, result := range report.Checks {
+		if result.Status != StatusPass {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCheckerRun(t *testing.T) {
+	t.Parallel()
+
+	checker := New(zaptest.NewLogger(t))
+	checker.Register(
+		Check{Name: "db", Critical: true, Run: func(context.Context) error { return nil }},
+		Check{Name: "cache", Run: func(context.Context) error { return errors.New("cold") }},
+	)
+
+	report := checker.Run(context.Background())
+	require.Equal(t, StatusWarn, report.Status, "non-critical failures only warn")
+	require.True(t, report.Ready())
+	require.Equal(t, StatusPass, report.Checks["db"].Status)
+	require.Equal(t, StatusWarn, report.Checks["cache"].Status)
+	require.Equal(t, "cold", report.Checks["cache"].Error)
+
+	checker.Register(Check{Name: "keys", Critical: true, Run: func(context.Context) error { return errors.New("missing") }})
+	report = checker.Run(context.Background())
+	require.Equal(t, StatusFail, report.Status)
+	require.False(t, report.Ready())
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	t.Parallel()
+
+	checker := New(zaptest.NewLogger(t))
+	checker.Register(Check{Name: "slow", Critical: true, Timeout: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	report := checker.Run(context.Background())
+	require.Equal(t, StatusFail, report.Checks["slow"].Status)
+	require.Contains(t, report.Checks["slow"].Error, context.DeadlineExceeded.Error())
+}
+
+func TestCheckerRegisterRejectsDuplicates(t *testing.T) {
+	t.Parallel()
+
+	checker := New(zaptest.NewLogger(t))
+	checker.Register(Check{Name: "db", Run: func(context.Context) error { return nil }})
+	require.Panics(t, func() {
+		checker.Register(Check{Name: "db", Run: func(context.Context) error { return nil }})
+	})
+	require.Panics(t, func() {
+		checker.Register(Check{Name: "nil"})
+	})
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	checker := New(zaptest.NewLogger(t))
+	checker.Register(Check{Name: "db", Critical: true, Run: func(context.Context) error { return nil }})
+
+	serve := func() (*httptest.ResponseRecorder, Report) {
+		recorder := httptest.NewRecorder()
+		checker.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var report Report
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&report))
+		return recorder, report
+	}
+
+	recorder, report := serve()
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+	require.Equal(t, StatusPass, report.Status)
+	require.Contains(t, report.Checks, "db")
+
+	checker.Drain()
+	recorder, report = serve()
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code, "draining fails readiness")
+	require.True(t, report.Draining)
+	require.Equal(t, StatusFail, report.Status)
+	require.Equal(t, StatusPass, report.Checks["db"].Status, "checks still run while draining")
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/generated/go/common/problemdetails"
+)
+
+// Problem type URIs shared by middleware that answers before a domain handler runs.
+const (
+	ProblemTypeValidation      = "https://palmyra.pro/problems/validation-error"
+	ProblemTypeUnauthorized    = "https://palmyra.pro/problems/unauthorized"
+	ProblemTypeForbidden       = "https://palmyra.pro/problems/forbidden"
+	ProblemTypeNotFound        = "https://palmyra.pro/problems/not-found"
+	ProblemTypeConflict        = "https://palmyra.pro/problems/conflict"
+	ProblemTypeInternal        = "https://palmyra.pro/problems/internal-error"
+	ProblemTypeRateLimited     = "https://palmyra.pro/problems/rate-limited"
+	ProblemTypePayloadTooLarge = "https://palmyra.pro/problems/payload-too-large"
+)
+
+// NewProblem builds a ProblemDetails body for the request.
+func NewProblem(r *nethttp.Request, status int, problemType, title, detail string) problemdetails.ProblemDetails {
+	problem := problemdetails.ProblemDetails{
+		Title:  title,
+		Status: status,
+	}
+	if problemType != "" {
+		problem.Type = &problemType
+	}
+	if detail != "" {
+		problem.Detail = &detail
+	}
+	if r != nil && r.URL != nil {
+		instance := r.URL.Path
+		problem.Instance = &instance
+	}
+	return problem
+}
+
+// WriteProblem renders an RFC 7807 application/problem+json response.
+func WriteProblem(w nethttp.ResponseWriter, r *nethttp.Request, status int, problemType, title, detail string) {
+	WriteProblemDetails(w, NewProblem(r, status, problemType, title, detail))
+}
+
+// WriteProblemDetails renders a prepared ProblemDetails body using its status code.
+func WriteProblemDetails(w nethttp.ResponseWriter, problem problemdetails.ProblemDetails) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
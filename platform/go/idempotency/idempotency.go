@@ -0,0 +1,269 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+const (
+	// HeaderKey is the request header carrying the client-chosen key.
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed marks responses replayed from a stored record.
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// DefaultMaxBodySize caps the bodies buffered for fingerprinting unless Config.MaxBodySize is set.
+	DefaultMaxBodySize int64 = 10 << 20
+
+	// maxKeyLength mirrors the idempotency_keys.idempotency_key check constraint.
+	maxKeyLength = 255
+	// sweepInterval bounds how often a replica deletes the expired records of a tenant.
+	sweepInterval = 10 * time.Minute
+)
+
+// replayedHeaders are the response headers stored with a record and replayed to retries.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag", "Last-Modified"}
+
+// Store persists idempotency records; persistence.IdempotencyStore implements it.
+type Store interface {
+	ReserveIdempotencyKey(ctx context.Context, params persistence.ReserveIdempotencyKeyParams) (persistence.IdempotencyRecord, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord, response persistence.IdempotentResponse) error
+	ReleaseIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// Config controls how long records are kept and held.
+type Config struct {
+	// TTL is how long a key and its response are kept; retries after that run the request again.
+	TTL time.Duration
+	// LockTimeout is how long an unfinished request holds its key before a retry may take it over.
+	// It should exceed the request timeout.
+	LockTimeout time.Duration
+	// MaxBodySize caps the body of a keyed request, which is read into memory to fingerprint it; larger
+	// bodies answer 413. Defaults to DefaultMaxBodySize.
+	MaxBodySize int64
+	// Skip exempts requests, e.g. streamed uploads too large to buffer: their key is ignored and they
+	// pass through untouched.
+	Skip func(*http.Request) bool
+}
+
+// Keeper replays the responses of POST requests retried with the same Idempotency-Key.
+type Keeper struct {
+	store  Store
+	cfg    Config
+	logger *zap.Logger
+
+	mu         sync.Mutex
+	lastSweeps map[string]time.Time
+}
+
+// New builds a Keeper.
+func New(store Store, cfg Config, logger *zap.Logger) *Keeper {
+	if store == nil {
+		panic("idempotency store is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = DefaultMaxBodySize
+	}
+	return &Keeper{store: store, cfg: cfg, logger: logger, lastSweeps: map[string]time.Time{}}
+}
+
+// Middleware makes POST requests carrying an Idempotency-Key header safe to retry. Keys are scoped to
+// the tenant and caller. The first request runs and its response is stored; a retry with the same
+// method, path, query and body gets the stored response with Idempotent-Replayed: true. Reusing a key
+// for a different request answers 422, and a retry arriving while the first request is still running
+// answers 409 with Retry-After. 5xx responses are not stored, so those requests can be retried.
+//
+// It must run after authentication and tenant resolution; anonymous requests and those matched by
+// Config.Skip pass through untouched. Bodies over Config.MaxBodySize answer 413. Store failures are
+// logged and the request is let through.
+func (k *Keeper) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+			if r.Method != http.MethodPost || key == "" || (k.cfg.Skip != nil && k.cfg.Skip(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			creds, _ := platformauth.UserFromContext(r.Context())
+			tenantID, hasTenant := tenant.FromContext(r.Context())
+			scope := creds.PrincipalKey()
+			if scope == "" || !hasTenant {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				platformhttp.WriteProblem(w, r, http.StatusBadRequest, platformhttp.ProblemTypeValidation,
+					"Invalid Idempotency-Key", "Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, k.cfg.MaxBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				platformhttp.WriteProblem(w, r, http.StatusRequestEntityTooLarge, platformhttp.ProblemTypePayloadTooLarge,
+					"Request body too large", fmt.Sprintf("requests with an Idempotency-Key may carry at most %d bytes", tooLarge.Limit))
+				return
+			}
+			if err != nil {
+				platformhttp.WriteProblem(w, r, http.StatusBadRequest, platformhttp.ProblemTypeValidation,
+					"Invalid request body", "request body could not be read")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			logger := platformlogging.FromRequest(r, k.logger).With(zap.String("idempotency_key", key))
+			fingerprint := requestFingerprint(r, body)
+
+			record, reserved, err := k.store.ReserveIdempotencyKey(r.Context(), persistence.ReserveIdempotencyKeyParams{
+				Scope:       scope,
+				Key:         key,
+				Fingerprint: fingerprint,
+				TTL:         k.cfg.TTL,
+				LockTimeout: k.cfg.LockTimeout,
+			})
+			if err != nil {
+				logger.Warn("idempotency store failed; request processed without a key", zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !reserved {
+				replay(w, r, record, fingerprint)
+				return
+			}
+
+			k.sweep(r.Context(), tenantID, logger)
+
+			// The outcome is stored even when the client has gone or the request timed out.
+			storeCtx := context.WithoutCancel(r.Context())
+			release := func() {
+				if err := k.store.ReleaseIdempotencyKey(storeCtx, record); err != nil {
+					logger.Warn("release idempotency key", zap.Error(err))
+				}
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				if rec := recover(); rec != nil {
+					release()
+					panic(rec)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status >= http.StatusInternalServerError {
+				release()
+				return
+			}
+
+			response := persistence.IdempotentResponse{
+				Status:  recorder.status,
+				Headers: map[string]string{},
+				Body:    recorder.body.Bytes(),
+			}
+			for _, name := range replayedHeaders {
+				if value := w.Header().Get(name); value != "" {
+					response.Headers[name] = value
+				}
+			}
+			if err := k.store.CompleteIdempotencyKey(storeCtx, record, response); err != nil {
+				logger.Warn("store idempotent response", zap.Error(err))
+			}
+		})
+	}
+}
+
+// sweep deletes the tenant's expired records at most once per sweepInterval per replica. Failures are
+// logged; the next sweep retries.
+func (k *Keeper) sweep(ctx context.Context, tenantID string, logger *zap.Logger) {
+	k.mu.Lock()
+	if time.Since(k.lastSweeps[tenantID]) < sweepInterval {
+		k.mu.Unlock()
+		return
+	}
+	k.lastSweeps[tenantID] = time.Now()
+	k.mu.Unlock()
+
+	if _, err := k.store.DeleteExpiredIdempotencyKeys(ctx); err != nil {
+		logger.Warn("delete expired idempotency keys", zap.Error(err))
+	}
+}
+
+// replay answers a request whose key is already held or completed.
+func replay(w http.ResponseWriter, r *http.Request, record persistence.IdempotencyRecord, fingerprint string) {
+	switch {
+	case record.Fingerprint != fingerprint:
+		platformhttp.WriteProblem(w, r, http.StatusUnprocessableEntity, platformhttp.ProblemTypeValidation,
+			"Idempotency-Key reused", "Idempotency-Key was already used for a different request")
+	case record.Response == nil:
+		w.Header().Set("Retry-After", "1")
+		platformhttp.WriteProblem(w, r, http.StatusConflict, platformhttp.ProblemTypeConflict,
+			"Request in progress", "a request with this Idempotency-Key is still being processed")
+	default:
+		header := w.Header()
+		for name, value := range record.Response.Headers {
+			header.Set(name, value)
+		}
+		header.Set(HeaderReplayed, "true")
+		header.Set("Content-Length", strconv.Itoa(len(record.Response.Body)))
+		w.WriteHeader(record.Response.Status)
+		_, _ = w.Write(record.Response.Body)
+	}
+}
+
+// requestFingerprint hashes what makes two requests the same: method, path, query and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+	_, _ = hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder passes the response through while keeping its status and a copy of its body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,262 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+func TestMiddlewareReplaysCompletedRequests(t *testing.T) {
+	t.Parallel()
+
+	store := newMemoryStore()
+	calls := 0
+	handler := newTestHandler(t, store, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/v1/users/u-1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"u-1"}`))
+	})
+
+	first := serve(handler, http.MethodPost, "/api/v1/users", `{"email":"a@example.com"}`, "key-1", alice)
+	require.Equal(t, http.StatusCreated, first.Code)
+	require.Empty(t, first.Header().Get(HeaderReplayed))
+
+	retry := serve(handler, http.MethodPost, "/api/v1/users", `{"email":"a@example.com"}`, "key-1", alice)
+	require.Equal(t, http.StatusCreated, retry.Code)
+	require.Equal(t, "true", retry.Header().Get(HeaderReplayed))
+	require.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+	require.Equal(t, "/api/v1/users/u-1", retry.Header().Get("Location"))
+	require.JSONEq(t, `{"id":"u-1"}`, retry.Body.String())
+	require.Equal(t, 1, calls)
+
+	other := serve(handler, http.MethodPost, "/api/v1/users", `{"email":"a@example.com"}`, "key-1", bob)
+	require.Equal(t, http.StatusCreated, other.Code)
+	require.Empty(t, other.Header().Get(HeaderReplayed), "keys are scoped to the caller")
+	require.Equal(t, 2, calls)
+}
+
+func TestMiddlewareRejectsKeyReuseWithDifferentRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, newMemoryStore(), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	require.Equal(t, http.StatusCreated, serve(handler, http.MethodPost, "/api/v1/users", `{"a":1}`, "key-1", alice).Code)
+
+	reused := serve(handler, http.MethodPost, "/api/v1/users", `{"a":2}`, "key-1", alice)
+	require.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	require.Equal(t, platformhttp.ProblemTypeValidation, problemType(t, reused))
+
+	otherPath := serve(handler, http.MethodPost, "/api/v1/entities", `{"a":1}`, "key-1", alice)
+	require.Equal(t, http.StatusUnprocessableEntity, otherPath.Code)
+}
+
+func TestMiddlewareRejectsConcurrentDuplicates(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	handler := newTestHandler(t, newMemoryStore(), func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-finish
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- serve(handler, http.MethodPost, "/api/v1/users", `{}`, "key-1", alice)
+	}()
+	<-started
+
+	duplicate := serve(handler, http.MethodPost, "/api/v1/users", `{}`, "key-1", alice)
+	require.Equal(t, http.StatusConflict, duplicate.Code)
+	require.Equal(t, "1", duplicate.Header().Get("Retry-After"))
+	require.Equal(t, platformhttp.ProblemTypeConflict, problemType(t, duplicate))
+
+	close(finish)
+	require.Equal(t, http.StatusCreated, (<-done).Code)
+}
+
+func TestMiddlewareReleasesKeyOnServerError(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusServiceUnavailable
+	handler := newTestHandler(t, newMemoryStore(), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	})
+
+	require.Equal(t, http.StatusServiceUnavailable, serve(handler, http.MethodPost, "/api/v1/users", `{}`, "key-1", alice).Code)
+
+	status = http.StatusCreated
+	retry := serve(handler, http.MethodPost, "/api/v1/users", `{}`, "key-1", alice)
+	require.Equal(t, http.StatusCreated, retry.Code)
+	require.Empty(t, retry.Header().Get(HeaderReplayed), "failed requests run again")
+}
+
+func TestMiddlewarePassesThrough(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	handler := newTestHandler(t, newMemoryStore(), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	serve(handler, http.MethodPost, "/api/v1/users", `{}`, "", alice)
+	serve(handler, http.MethodPost, "/api/v1/users", `{}`, "", alice)
+	serve(handler, http.MethodPatch, "/api/v1/users/u-1", `{}`, "key-1", alice)
+	serve(handler, http.MethodPatch, "/api/v1/users/u-1", `{}`, "key-1", alice)
+	serve(handler, http.MethodPost, "/api/v1/auth/login", `{}`, "key-1", nil)
+	serve(handler, http.MethodPost, "/api/v1/auth/login", `{}`, "key-1", nil)
+	require.Equal(t, 6, calls)
+
+	tooLong := serve(handler, http.MethodPost, "/api/v1/users", `{}`, strings.Repeat("k", maxKeyLength+1), alice)
+	require.Equal(t, http.StatusBadRequest, tooLong.Code)
+	require.Equal(t, 6, calls)
+}
+
+func TestMiddlewareRefusesOversizedBodies(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	keeper := New(newMemoryStore(), Config{
+		TTL:         time.Hour,
+		LockTimeout: time.Minute,
+		MaxBodySize: 8,
+		Skip:        func(r *http.Request) bool { return strings.HasSuffix(r.URL.Path, "/imports") },
+	}, zaptest.NewLogger(t))
+	handler := keeper.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	oversized := serve(handler, http.MethodPost, "/api/v1/users", `{"email":"a@example.com"}`, "key-1", alice)
+	require.Equal(t, http.StatusRequestEntityTooLarge, oversized.Code)
+	require.Equal(t, platformhttp.ProblemTypePayloadTooLarge, problemType(t, oversized))
+	require.Zero(t, calls)
+
+	require.Equal(t, http.StatusAccepted, serve(handler, http.MethodPost, "/api/v1/users", `{}`, "key-1", alice).Code)
+	require.Equal(t, 1, calls)
+
+	upload := serve(handler, http.MethodPost, "/api/v1/entities/cards/imports", strings.Repeat("x", 64), "key-2", alice)
+	require.Equal(t, http.StatusAccepted, upload.Code, "skipped requests are not buffered")
+	require.Equal(t, 2, calls)
+}
+
+func TestMiddlewareFailsOpen(t *testing.T) {
+	t.Parallel()
+
+	store := newMemoryStore()
+	store.reserveErr = errors.New("database down")
+	calls := 0
+	handler := newTestHandler(t, store, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	require.Equal(t, http.StatusCreated, serve(handler, http.MethodPost, "/api/v1/users", `{}`, "key-1", alice).Code)
+	require.Equal(t, 1, calls)
+}
+
+var (
+	alice = &platformauth.UserCredentials{Id: "uid-a", UserID: "user-a"}
+	bob   = &platformauth.UserCredentials{Id: "uid-b", UserID: "user-b"}
+)
+
+func newTestHandler(t *testing.T, store Store, fn http.HandlerFunc) http.Handler {
+	t.Helper()
+	keeper := New(store, Config{TTL: time.Hour, LockTimeout: time.Minute}, zaptest.NewLogger(t))
+	return keeper.Middleware()(fn)
+}
+
+func serve(handler http.Handler, method, path, body, key string, creds *platformauth.UserCredentials) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+	ctx := tenant.WithID(req.Context(), "acme")
+	if creds != nil {
+		ctx = platformauth.WithUserCredentials(ctx, creds)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req.WithContext(ctx))
+	return recorder
+}
+
+func problemType(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+	var problem map[string]any
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&problem))
+	return problem["type"].(string)
+}
+
+// memoryStore mimics persistence.IdempotencyStore without expiry or lock timeouts.
+type memoryStore struct {
+	mu         sync.Mutex
+	records    map[string]persistence.IdempotencyRecord
+	reserveErr error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]persistence.IdempotencyRecord{}}
+}
+
+func (s *memoryStore) ReserveIdempotencyKey(ctx context.Context, params persistence.ReserveIdempotencyKeyParams) (persistence.IdempotencyRecord, bool, error) {
+	if s.reserveErr != nil {
+		return persistence.IdempotencyRecord{}, false, s.reserveErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params.Scope + "|" + params.Key
+	if record, ok := s.records[id]; ok {
+		return record, false, nil
+	}
+	record := persistence.IdempotencyRecord{
+		Scope:       params.Scope,
+		Key:         params.Key,
+		Fingerprint: params.Fingerprint,
+		Status:      persistence.IdempotencyStatusInProgress,
+	}
+	s.records[id] = record
+	return record, true, nil
+}
+
+func (s *memoryStore) CompleteIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord, response persistence.IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.Status = persistence.IdempotencyStatusCompleted
+	record.Response = &response
+	s.records[record.Scope+"|"+record.Key] = record
+	return nil
+}
+
+func (s *memoryStore) ReleaseIdempotencyKey(ctx context.Context, record persistence.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, record.Scope+"|"+record.Key)
+	return nil
+}
+
+func (s *memoryStore) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,174 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// Kind names a type of job and the payload its handler receives.
+type Kind[P any] struct {
+	// Name identifies the kind in the jobs table, e.g. "entities.revalidate".
+	Name string
+	// MaxAttempts is how many times the job runs before it fails; defaults to 1.
+	MaxAttempts int
+	// Timeout bounds a single attempt; zero means no limit.
+	Timeout time.Duration
+}
+
+// Handler runs one attempt of a job. Its context carries the job's tenant and is cancelled when the job is
+// cancelled, times out or the worker shuts down. The result is stored as JSON on success.
+type Handler[P any] func(ctx context.Context, run *Run, payload P) (any, error)
+
+type handler struct {
+	timeout time.Duration
+	run     func(ctx context.Context, run *Run, payload json.RawMessage) (any, error)
+}
+
+// Registry maps job kinds to their handlers. Workers only claim jobs of registered kinds.
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]handler
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: map[string]handler{}}
+}
+
+// Handle registers fn for kind. It panics when the kind is already registered.
+func Handle[P any](registry *Registry, kind Kind[P], fn Handler[P]) {
+	if kind.Name == "" {
+		panic("job kind name is required")
+	}
+	if fn == nil {
+		panic("job handler is required")
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, exists := registry.handlers[kind.Name]; exists {
+		panic(fmt.Sprintf("job kind %q registered twice", kind.Name))
+	}
+	registry.handlers[kind.Name] = handler{
+		timeout: kind.Timeout,
+		run: func(ctx context.Context, run *Run, raw json.RawMessage) (any, error) {
+			var payload P
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return nil, Permanent(fmt.Errorf("decode %s payload: %w", kind.Name, err))
+			}
+			return fn(ctx, run, payload)
+		},
+	}
+}
+
+// Kinds returns the registered kind names, sorted.
+func (r *Registry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kinds := make([]string, 0, len(r.handlers))
+	for name := range r.handlers {
+		kinds = append(kinds, name)
+	}
+	slices.Sort(kinds)
+	return kinds
+}
+
+func (r *Registry) lookup(kind string) (handler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	h, ok := r.handlers[kind]
+	return h, ok
+}
+
+// Enqueuer stores new jobs; persistence.JobStore implements it.
+type Enqueuer interface {
+	EnqueueJob(ctx context.Context, params persistence.EnqueueJobParams) (persistence.Job, error)
+}
+
+// Enqueue queues a job of kind for the tenant of ctx, recorded as created by the authenticated caller.
+func Enqueue[P any](ctx context.Context, store Enqueuer, kind Kind[P], payload P) (persistence.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return persistence.Job{}, fmt.Errorf("encode %s payload: %w", kind.Name, err)
+	}
+
+	params := persistence.EnqueueJobParams{
+		JobID:       uuid.New(),
+		Kind:        kind.Name,
+		Payload:     raw,
+		MaxAttempts: kind.MaxAttempts,
+	}
+	if creds, ok := platformauth.UserFromContext(ctx); ok {
+		if key := creds.PrincipalKey(); key != "" {
+			params.CreatedBy = &key
+		}
+	}
+	return store.EnqueueJob(ctx, params)
+}
+
+// Run is the attempt of a job being executed.
+type Run struct {
+	Job persistence.Job
+
+	mu       sync.Mutex
+	progress *float64
+	message  *string
+}
+
+// SetProgress reports how far the job is, as a percentage clamped to 0-100, with an optional message.
+// The worker stores it with the next heartbeat.
+func (r *Run) SetProgress(percent float64, message string) {
+	percent = max(0, min(100, percent))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.progress = &percent
+	if message != "" {
+		r.message = &message
+	}
+}
+
+// takeProgress returns the progress reported since the last call.
+func (r *Run) takeProgress() (*float64, *string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	progress, message := r.progress, r.message
+	r.progress, r.message = nil, nil
+	return progress, message
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying: the job fails even if it has attempts left.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
@@ -0,0 +1,360 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+type echoPayload struct {
+	Value string `json:"value"`
+}
+
+var echoKind = Kind[echoPayload]{Name: "test.echo", MaxAttempts: 2}
+
+func TestEnqueueRecordsCaller(t *testing.T) {
+	store := newFakeStore()
+	ctx := platformauth.WithUserCredentials(tenant.WithID(context.Background(), "tenant-a"), &platformauth.UserCredentials{UserID: "user-1"})
+
+	job, err := Enqueue(ctx, store, echoKind, echoPayload{Value: "hi"})
+	require.NoError(t, err)
+	require.Equal(t, "test.echo", job.Kind)
+	require.Equal(t, 2, job.MaxAttempts)
+	require.Equal(t, "user:user-1", *job.CreatedBy)
+	require.JSONEq(t, `{"value":"hi"}`, string(job.Payload))
+}
+
+func TestHandleRejectsDuplicateKinds(t *testing.T) {
+	registry := NewRegistry()
+	Handle(registry, echoKind, func(context.Context, *Run, echoPayload) (any, error) { return nil, nil })
+	require.Panics(t, func() {
+		Handle(registry, echoKind, func(context.Context, *Run, echoPayload) (any, error) { return nil, nil })
+	})
+	require.Equal(t, []string{"test.echo"}, registry.Kinds())
+}
+
+func TestWorkerRunsJob(t *testing.T) {
+	store := newFakeStore()
+	registry := NewRegistry()
+	Handle(registry, echoKind, func(ctx context.Context, run *Run, payload echoPayload) (any, error) {
+		tenantID, err := tenant.Require(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-a", tenantID)
+		run.SetProgress(150, "done")
+		return map[string]string{"echo": payload.Value}, nil
+	})
+	job := store.add("tenant-a", echoKind.Name, `{"value":"hi"}`, 1)
+
+	finished := runUntilSettled(t, store, registry, job.JobID)
+	require.Equal(t, persistence.JobSucceeded, finished.Status)
+	require.JSONEq(t, `{"echo":"hi"}`, string(finished.Result))
+}
+
+func TestWorkerRetriesThenFails(t *testing.T) {
+	store := newFakeStore()
+	registry := NewRegistry()
+	Handle(registry, echoKind, func(context.Context, *Run, echoPayload) (any, error) {
+		return nil, errors.New("boom")
+	})
+	job := store.add("tenant-a", echoKind.Name, `{}`, 2)
+
+	retried := runUntilSettled(t, store, registry, job.JobID)
+	require.Equal(t, persistence.JobQueued, retried.Status)
+	require.Equal(t, "boom", *retried.Error)
+	require.Greater(t, retried.retryDelay, time.Duration(0))
+
+	failed := runUntilSettled(t, store, registry, job.JobID)
+	require.Equal(t, persistence.JobFailed, failed.Status)
+	require.Equal(t, 2, failed.Attempts)
+}
+
+func TestWorkerDoesNotRetryPermanentErrors(t *testing.T) {
+	store := newFakeStore()
+	registry := NewRegistry()
+	Handle(registry, echoKind, func(context.Context, *Run, echoPayload) (any, error) {
+		panic("unreachable: the payload does not decode")
+	})
+	job := store.add("tenant-a", echoKind.Name, `[]`, 3)
+
+	failed := runUntilSettled(t, store, registry, job.JobID)
+	require.Equal(t, persistence.JobFailed, failed.Status)
+	require.Contains(t, *failed.Error, "decode test.echo payload")
+}
+
+func TestWorkerRecoversPanics(t *testing.T) {
+	store := newFakeStore()
+	registry := NewRegistry()
+	Handle(registry, echoKind, func(context.Context, *Run, echoPayload) (any, error) {
+		panic("kaboom")
+	})
+	job := store.add("tenant-a", echoKind.Name, `{}`, 1)
+
+	failed := runUntilSettled(t, store, registry, job.JobID)
+	require.Equal(t, persistence.JobFailed, failed.Status)
+	require.Contains(t, *failed.Error, "kaboom")
+}
+
+func TestWorkerCancelsOnRequest(t *testing.T) {
+	store := newFakeStore()
+	registry := NewRegistry()
+	Handle(registry, echoKind, func(ctx context.Context, run *Run, _ echoPayload) (any, error) {
+		run.SetProgress(10, "started")
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	job := store.add("tenant-a", echoKind.Name, `{}`, 3)
+	store.onHeartbeat = func(j *fakeJob) {
+		require.Equal(t, 10.0, j.Progress)
+		j.CancelRequested = true
+	}
+
+	cancelled := runUntilSettled(t, store, registry, job.JobID)
+	require.Equal(t, persistence.JobCancelled, cancelled.Status)
+}
+
+func TestWorkerReleasesJobsOnShutdown(t *testing.T) {
+	store := newFakeStore()
+	registry := NewRegistry()
+	started := make(chan struct{})
+	Handle(registry, echoKind, func(ctx context.Context, _ *Run, _ echoPayload) (any, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	job := store.add("tenant-a", echoKind.Name, `{}`, 1)
+
+	worker := NewWorker(store, registry, Config{PollInterval: 10 * time.Millisecond}, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- worker.Run(ctx) }()
+
+	<-started
+	cancel()
+	require.NoError(t, <-done)
+
+	released := store.get(job.JobID)
+	require.Equal(t, persistence.JobQueued, released.Status)
+	require.Equal(t, 0, released.Attempts, "a released attempt does not count")
+}
+
+func TestWorkerFailsTimedOutJobs(t *testing.T) {
+	store := newFakeStore()
+	registry := NewRegistry()
+	slowKind := Kind[echoPayload]{Name: "test.slow", Timeout: 20 * time.Millisecond}
+	Handle(registry, slowKind, func(ctx context.Context, _ *Run, _ echoPayload) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	job := store.add("tenant-a", slowKind.Name, `{}`, 1)
+
+	failed := runUntilSettled(t, store, registry, job.JobID)
+	require.Equal(t, persistence.JobFailed, failed.Status)
+	require.Contains(t, *failed.Error, "job timed out")
+}
+
+func TestBackoff(t *testing.T) {
+	worker := NewWorker(newFakeStore(), NewRegistry(), Config{RetryBackoff: time.Second, MaxRetryBackoff: 5 * time.Second}, zap.NewNop())
+
+	require.InDelta(t, time.Second, worker.backoff(1), float64(time.Second/5))
+	require.InDelta(t, 4*time.Second, worker.backoff(3), float64(4*time.Second/5))
+	require.InDelta(t, 5*time.Second, worker.backoff(10), float64(time.Second))
+}
+
+// runUntilSettled runs a worker until the job leaves the running state and returns it.
+func runUntilSettled(t *testing.T, store *fakeStore, registry *Registry, jobID uuid.UUID) fakeJob {
+	t.Helper()
+
+	settled := make(chan struct{}, 1)
+	store.setOnSettle(func() {
+		select {
+		case settled <- struct{}{}:
+		default:
+		}
+	})
+	worker := NewWorker(store, registry, Config{PollInterval: 10 * time.Millisecond, HeartbeatInterval: 10 * time.Millisecond}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- worker.Run(ctx) }()
+
+	select {
+	case <-settled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not settled")
+	}
+	cancel()
+	require.NoError(t, <-done)
+	return store.get(jobID)
+}
+
+type fakeJob struct {
+	persistence.Job
+	retryDelay time.Duration
+}
+
+type fakeStore struct {
+	mu          sync.Mutex
+	jobs        map[uuid.UUID]*fakeJob
+	onHeartbeat func(*fakeJob)
+	onSettle    func()
+	paused      bool // set once a job settles so the test can inspect it before another claim
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{jobs: map[uuid.UUID]*fakeJob{}}
+}
+
+func (s *fakeStore) add(tenantID, kind, payload string, maxAttempts int) persistence.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := persistence.Job{JobID: uuid.New(), TenantID: tenantID, Kind: kind, Payload: json.RawMessage(payload), Status: persistence.JobQueued, MaxAttempts: maxAttempts}
+	s.jobs[job.JobID] = &fakeJob{Job: job}
+	return job
+}
+
+func (s *fakeStore) get(jobID uuid.UUID) fakeJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.jobs[jobID]
+}
+
+func (s *fakeStore) setOnSettle(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onSettle = fn
+	s.paused = false
+}
+
+// claimed returns the job if workerID holds it.
+func (s *fakeStore) claimed(jobID uuid.UUID, workerID string) (*fakeJob, error) {
+	job, ok := s.jobs[jobID]
+	if !ok || job.Status != persistence.JobRunning || job.WorkerID == nil || *job.WorkerID != workerID {
+		return nil, persistence.ErrJobNotClaimed
+	}
+	return job, nil
+}
+
+func (s *fakeStore) settled(job *fakeJob) {
+	job.WorkerID = nil
+	s.paused = true
+	if s.onSettle != nil {
+		s.onSettle()
+	}
+}
+
+func (s *fakeStore) EnqueueJob(ctx context.Context, params persistence.EnqueueJobParams) (persistence.Job, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return persistence.Job{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := persistence.Job{JobID: params.JobID, TenantID: tenantID, Kind: params.Kind, Payload: params.Payload, Status: persistence.JobQueued, MaxAttempts: max(params.MaxAttempts, 1), CreatedBy: params.CreatedBy}
+	s.jobs[job.JobID] = &fakeJob{Job: job}
+	return job, nil
+}
+
+func (s *fakeStore) ClaimJob(_ context.Context, workerID string, kinds []string) (persistence.Job, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.paused {
+		return persistence.Job{}, false, nil
+	}
+	for _, job := range s.jobs {
+		if job.Status != persistence.JobQueued {
+			continue
+		}
+		for _, kind := range kinds {
+			if job.Kind == kind {
+				job.Status = persistence.JobRunning
+				job.Attempts++
+				job.WorkerID = &workerID
+				return job.Job, true, nil
+			}
+		}
+	}
+	return persistence.Job{}, false, nil
+}
+
+func (s *fakeStore) HeartbeatJob(_ context.Context, heartbeat persistence.JobHeartbeat) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.claimed(heartbeat.JobID, heartbeat.WorkerID)
+	if err != nil {
+		return false, err
+	}
+	if heartbeat.Progress != nil {
+		job.Progress = *heartbeat.Progress
+	}
+	if heartbeat.ProgressMessage != nil {
+		job.ProgressMessage = heartbeat.ProgressMessage
+	}
+	if s.onHeartbeat != nil {
+		s.onHeartbeat(job)
+	}
+	return job.CancelRequested, nil
+}
+
+func (s *fakeStore) FinishJob(_ context.Context, params persistence.FinishJobParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.claimed(params.JobID, params.WorkerID)
+	if err != nil {
+		return err
+	}
+	job.Status = params.Status
+	job.Result = params.Result
+	job.Error = params.Error
+	s.settled(job)
+	return nil
+}
+
+func (s *fakeStore) RetryJob(_ context.Context, jobID uuid.UUID, workerID string, delay time.Duration, cause string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.claimed(jobID, workerID)
+	if err != nil {
+		return err
+	}
+	job.Status = persistence.JobQueued
+	job.Error = &cause
+	job.retryDelay = delay
+	s.settled(job)
+	return nil
+}
+
+func (s *fakeStore) ReleaseJob(_ context.Context, jobID uuid.UUID, workerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.claimed(jobID, workerID)
+	if err != nil {
+		return err
+	}
+	job.Status = persistence.JobQueued
+	job.Attempts--
+	s.settled(job)
+	return nil
+}
+
+func (s *fakeStore) RequeueStaleJobs(context.Context, time.Duration) (int64, error) {
+	return 0, nil
+}
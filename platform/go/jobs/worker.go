@@ -0,0 +1,355 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+const (
+	// progressInterval bounds how often reported progress is written between heartbeats.
+	progressInterval = time.Second
+	// settleTimeout bounds the store write that records the outcome of an attempt.
+	settleTimeout = 10 * time.Second
+)
+
+var (
+	errCancelRequested = errors.New("job cancelled")
+	errShutdown        = errors.New("worker shutting down")
+	errClaimLost       = errors.New("job claim lost")
+	errTimeout         = errors.New("job timed out")
+)
+
+// Store claims and settles jobs; persistence.JobStore implements it.
+type Store interface {
+	ClaimJob(ctx context.Context, workerID string, kinds []string) (persistence.Job, bool, error)
+	HeartbeatJob(ctx context.Context, heartbeat persistence.JobHeartbeat) (bool, error)
+	FinishJob(ctx context.Context, params persistence.FinishJobParams) error
+	RetryJob(ctx context.Context, jobID uuid.UUID, workerID string, delay time.Duration, cause string) error
+	ReleaseJob(ctx context.Context, jobID uuid.UUID, workerID string) error
+	RequeueStaleJobs(ctx context.Context, staleAfter time.Duration) (int64, error)
+}
+
+// Config controls how a worker polls for and runs jobs. Zero values get the defaults noted on each field.
+type Config struct {
+	// WorkerID identifies the worker on claimed jobs; defaults to the hostname and a random suffix.
+	WorkerID string
+	// Concurrency is how many jobs run at once; defaults to 1.
+	Concurrency int
+	// PollInterval is how long an idle worker waits before looking for due jobs again; defaults to 1s.
+	PollInterval time.Duration
+	// HeartbeatInterval is how often a running job's claim is renewed; defaults to 10s.
+	HeartbeatInterval time.Duration
+	// StaleAfter is how long a running job may go without a heartbeat before it is requeued; defaults to
+	// 1m and must exceed HeartbeatInterval.
+	StaleAfter time.Duration
+	// RetryBackoff is the delay before the first retry, doubled for every further attempt; defaults to 10s.
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the retry delay; defaults to 10m.
+	MaxRetryBackoff time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.WorkerID == "" {
+		host, _ := os.Hostname()
+		if host == "" {
+			host = "worker"
+		}
+		c.WorkerID = host + "-" + uuid.NewString()[:8]
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 1
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.HeartbeatInterval <= 0 {
+		c.HeartbeatInterval = 10 * time.Second
+	}
+	if c.StaleAfter <= 0 {
+		c.StaleAfter = time.Minute
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = 10 * time.Second
+	}
+	if c.MaxRetryBackoff <= 0 {
+		c.MaxRetryBackoff = 10 * time.Minute
+	}
+	return c
+}
+
+// Worker claims queued jobs of the registered kinds and runs their handlers.
+type Worker struct {
+	store    Store
+	registry *Registry
+	cfg      Config
+	logger   *zap.Logger
+}
+
+// NewWorker builds a worker.
+func NewWorker(store Store, registry *Registry, cfg Config, logger *zap.Logger) *Worker {
+	if store == nil {
+		panic("job store is required")
+	}
+	if registry == nil {
+		panic("job registry is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+
+	cfg = cfg.withDefaults()
+	return &Worker{store: store, registry: registry, cfg: cfg, logger: logger.With(zap.String("worker_id", cfg.WorkerID))}
+}
+
+// Run processes jobs until ctx is cancelled. On cancellation running handlers are cancelled too and their
+// jobs are released back to the queue; Run returns once they have stopped.
+func (w *Worker) Run(ctx context.Context) error {
+	kinds := w.registry.Kinds()
+	if len(kinds) == 0 {
+		return errors.New("no job kinds registered")
+	}
+	w.logger.Info("job worker started", zap.Strings("kinds", kinds), zap.Int("concurrency", w.cfg.Concurrency))
+
+	var wg sync.WaitGroup
+	for range w.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.poll(ctx, kinds)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.reap(ctx)
+	}()
+	wg.Wait()
+
+	w.logger.Info("job worker stopped")
+	return nil
+}
+
+func (w *Worker) poll(ctx context.Context, kinds []string) {
+	for ctx.Err() == nil {
+		job, ok, err := w.store.ClaimJob(ctx, w.cfg.WorkerID, kinds)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Error("claim job", zap.Error(err))
+		}
+		if ok {
+			w.execute(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(w.cfg.PollInterval):
+		}
+	}
+}
+
+// reap requeues the jobs of workers that stopped heartbeating.
+func (w *Worker) reap(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.StaleAfter / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		requeued, err := w.store.RequeueStaleJobs(ctx, w.cfg.StaleAfter)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error("requeue stale jobs", zap.Error(err))
+			}
+			continue
+		}
+		if requeued > 0 {
+			w.logger.Warn("recovered jobs of unresponsive workers", zap.Int64("jobs", requeued))
+		}
+	}
+}
+
+func (w *Worker) execute(ctx context.Context, job persistence.Job) {
+	logger := w.logger.With(
+		zap.String("job_id", job.JobID.String()),
+		zap.String("kind", job.Kind),
+		zap.String("tenant_id", job.TenantID),
+		zap.Int("attempt", job.Attempts),
+	)
+
+	h, ok := w.registry.lookup(job.Kind)
+	if !ok {
+		w.settle(ctx, logger, job, nil, nil, Permanent(fmt.Errorf("no handler registered for job kind %q", job.Kind)))
+		return
+	}
+
+	// The handler outlives ctx long enough to observe the cancellation and return.
+	runCtx, cancel := context.WithCancelCause(tenant.WithID(context.WithoutCancel(ctx), job.TenantID))
+	defer cancel(nil)
+	handlerCtx := runCtx
+	if h.timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		handlerCtx, cancelTimeout = context.WithTimeoutCause(runCtx, h.timeout, errTimeout)
+		defer cancelTimeout()
+	}
+
+	run := &Run{Job: job}
+	stop := make(chan struct{})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		w.watch(ctx, logger, run, cancel, stop)
+	}()
+
+	logger.Info("job started")
+	started := time.Now()
+	result, err := call(handlerCtx, logger, h, run)
+	close(stop)
+	<-watched
+
+	var cause error
+	if handlerCtx.Err() != nil {
+		cause = context.Cause(handlerCtx)
+	}
+	logger = logger.With(zap.Duration("duration", time.Since(started)))
+	w.settle(ctx, logger, job, cause, result, err)
+}
+
+// call runs the handler, turning a panic into an error.
+func call(ctx context.Context, logger *zap.Logger, h handler, run *Run) (result any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logger.Error("job handler panicked", zap.Any("panic", p), zap.Stack("stack"))
+			err = fmt.Errorf("job handler panicked: %v", p)
+		}
+	}()
+	return h.run(ctx, run, run.Job.Payload)
+}
+
+// watch heartbeats the job while its handler runs, writes reported progress, and cancels the handler when
+// the job is cancelled, its claim is lost or the worker shuts down.
+func (w *Worker) watch(ctx context.Context, logger *zap.Logger, run *Run, cancel context.CancelCauseFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(min(progressInterval, w.cfg.HeartbeatInterval))
+	defer ticker.Stop()
+	lastBeat := time.Now()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			cancel(errShutdown)
+			return
+		case <-ticker.C:
+		}
+
+		progress, message := run.takeProgress()
+		if progress == nil && message == nil && time.Since(lastBeat) < w.cfg.HeartbeatInterval {
+			continue
+		}
+
+		beatCtx, cancelBeat := context.WithTimeout(ctx, w.cfg.HeartbeatInterval)
+		cancelRequested, err := w.store.HeartbeatJob(beatCtx, persistence.JobHeartbeat{
+			JobID:           run.Job.JobID,
+			WorkerID:        w.cfg.WorkerID,
+			Progress:        progress,
+			ProgressMessage: message,
+		})
+		cancelBeat()
+		switch {
+		case errors.Is(err, persistence.ErrJobNotClaimed):
+			cancel(errClaimLost)
+			return
+		case err != nil:
+			if ctx.Err() == nil {
+				logger.Warn("job heartbeat failed", zap.Error(err))
+			}
+			continue
+		}
+
+		lastBeat = time.Now()
+		if cancelRequested {
+			cancel(errCancelRequested)
+			return
+		}
+	}
+}
+
+// settle records the outcome of an attempt: success, cancellation, release on shutdown, a retry with
+// backoff, or failure once the job is out of attempts or the error is permanent.
+func (w *Worker) settle(ctx context.Context, logger *zap.Logger, job persistence.Job, cause error, result any, err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), settleTimeout)
+	defer cancel()
+
+	finish := func(status persistence.JobStatus, result json.RawMessage, failure error) error {
+		params := persistence.FinishJobParams{JobID: job.JobID, WorkerID: w.cfg.WorkerID, Status: status, Result: result}
+		if failure != nil {
+			message := failure.Error()
+			params.Error = &message
+		}
+		return w.store.FinishJob(ctx, params)
+	}
+
+	var storeErr error
+	switch {
+	case errors.Is(cause, errClaimLost):
+		logger.Warn("job claim lost; the job was requeued while it ran", zap.Error(err))
+		return
+	case err == nil:
+		raw, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			storeErr = finish(persistence.JobFailed, nil, fmt.Errorf("encode job result: %w", marshalErr))
+			break
+		}
+		logger.Info("job succeeded")
+		storeErr = finish(persistence.JobSucceeded, raw, nil)
+	case errors.Is(cause, errShutdown):
+		logger.Info("job released on shutdown")
+		storeErr = w.store.ReleaseJob(ctx, job.JobID, w.cfg.WorkerID)
+	case errors.Is(cause, errCancelRequested):
+		logger.Info("job cancelled")
+		storeErr = finish(persistence.JobCancelled, nil, nil)
+	default:
+		if errors.Is(cause, errTimeout) {
+			err = fmt.Errorf("%w: %w", errTimeout, err)
+		}
+		if IsPermanent(err) || job.Attempts >= job.MaxAttempts {
+			logger.Error("job failed", zap.Error(err))
+			storeErr = finish(persistence.JobFailed, nil, err)
+			break
+		}
+		delay := w.backoff(job.Attempts)
+		logger.Warn("job attempt failed; retrying", zap.Error(err), zap.Duration("retry_in", delay))
+		storeErr = w.store.RetryJob(ctx, job.JobID, w.cfg.WorkerID, delay, err.Error())
+	}
+
+	if storeErr != nil {
+		logger.Error("record job outcome", zap.Error(storeErr))
+	}
+}
+
+// backoff returns the delay before the next attempt: RetryBackoff doubled per previous attempt, capped at
+// MaxRetryBackoff, with up to 20% jitter so failed jobs do not retry in lockstep.
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.cfg.RetryBackoff
+	for i := 1; i < attempts && delay < w.cfg.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, w.cfg.MaxRetryBackoff)
+	return delay + rand.N(delay/5+1)
+}
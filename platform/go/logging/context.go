@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -31,7 +32,8 @@ func FromRequest(r *http.Request, fallback *zap.Logger) *zap.Logger {
 }
 
 // RequestLogger returns an HTTP middleware that enriches the base logger with request scoped fields,
-// stores it on the context, and emits a completion log once the handler finishes.
+// stores it on the context, and emits a completion log once the handler finishes. When the request
+// carries an OpenTelemetry span (tracing.Middleware must run first), its trace and span IDs are logged.
 func RequestLogger(base *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -42,6 +44,12 @@ func RequestLogger(base *zap.Logger) func(http.Handler) http.Handler {
 			if requestID != "" {
 				logger = logger.With(zap.String("request_id", requestID))
 			}
+			if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.IsValid() {
+				logger = logger.With(
+					zap.String("trace_id", spanContext.TraceID().String()),
+					zap.String("span_id", spanContext.SpanID().String()),
+				)
+			}
 
 			logger = logger.With(
 				zap.String("http_method", r.Method),
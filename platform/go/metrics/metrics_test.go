@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+func TestHTTPMiddlewareLabelsRouteTemplates(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	httpMetrics := NewHTTP(registry)
+
+	api := chi.NewRouter()
+	api.Get("/entities/{tableName}/documents/{entityId}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	root := chi.NewRouter()
+	root.Use(httpMetrics.Middleware())
+	root.Mount("/api/v1", api)
+
+	for _, path := range []string{"/api/v1/entities/cards/documents/a", "/api/v1/entities/sets/documents/b", "/nowhere"} {
+		root.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Equal(t, 2.0, testutil.ToFloat64(httpMetrics.requests.WithLabelValues(http.MethodGet, "/api/v1/entities/{tableName}/documents/{entityId}", "404")))
+	require.Equal(t, 1.0, testutil.ToFloat64(httpMetrics.requests.WithLabelValues(http.MethodGet, unmatchedRoute, "404")))
+	require.Equal(t, 0.0, testutil.ToFloat64(httpMetrics.inFlight))
+}
+
+func TestSchemaValidatorMetrics(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	validatorMetrics := NewSchemaValidator(registry)
+	validator := persistence.NewSchemaValidator(persistence.WithSchemaValidatorObserver(validatorMetrics))
+
+	schema := persistence.SchemaRecord{
+		SchemaID:         uuid.New(),
+		SchemaDefinition: persistence.SchemaDefinition(`{"type":"object","required":["name"]}`),
+	}
+
+	require.NoError(t, validator.Validate(context.Background(), schema, []byte(`{"name":"Pikachu"}`)))
+	require.Error(t, validator.Validate(context.Background(), schema, []byte(`{}`)))
+
+	require.Equal(t, 1.0, testutil.ToFloat64(validatorMetrics.cacheLookups.WithLabelValues("miss")))
+	require.Equal(t, 1.0, testutil.ToFloat64(validatorMetrics.cacheLookups.WithLabelValues("hit")))
+	require.Equal(t, 1.0, testutil.ToFloat64(validatorMetrics.validations.WithLabelValues("valid")))
+	require.Equal(t, 1.0, testutil.ToFloat64(validatorMetrics.validations.WithLabelValues("invalid")))
+	require.Equal(t, 1, testutil.CollectAndCount(validatorMetrics.compiles))
+}
+
+func TestEntityWritesOutcomes(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	writes := NewEntityWrites(registry)
+
+	writes.ObserveEntityWrite("cards", persistence.EntityWriteCreate, nil)
+	writes.ObserveEntityWrite("cards", persistence.EntityWriteCreate, fmt.Errorf("insert: %w", persistence.ErrEntityAlreadyExists))
+	writes.ObserveEntityWrite("cards", persistence.EntityWriteDelete, persistence.ErrEntityNotFound)
+	writes.ObserveEntityWrite("cards", persistence.EntityWriteUpdate, errors.New("connection reset"))
+
+	expected := `
+# HELP palmyra_entities_writes_total Entity writes by table, operation (create, update, delete) and outcome.
+# TYPE palmyra_entities_writes_total counter
+palmyra_entities_writes_total{operation="create",outcome="conflict",table="cards"} 1
+palmyra_entities_writes_total{operation="create",outcome="ok",table="cards"} 1
+palmyra_entities_writes_total{operation="delete",outcome="not_found",table="cards"} 1
+palmyra_entities_writes_total{operation="update",outcome="error",table="cards"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// SchemaValidator implements persistence.SchemaValidatorObserver.
+type SchemaValidator struct {
+	cacheLookups *prometheus.CounterVec
+	compiles     *prometheus.HistogramVec
+	validations  *prometheus.CounterVec
+}
+
+var _ persistence.SchemaValidatorObserver = (*SchemaValidator)(nil)
+
+// NewSchemaValidator registers the schema validator metrics with registerer.
+func NewSchemaValidator(registerer prometheus.Registerer) *SchemaValidator {
+	m := &SchemaValidator{
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "schema_validator",
+			Name:      "cache_lookups_total",
+			Help:      "Compiled schema cache lookups by result (hit or miss).",
+		}, []string{"result"}),
+		compiles: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "schema_validator",
+			Name:      "compile_duration_seconds",
+			Help:      "Time spent compiling JSON Schemas by outcome.",
+			Buckets:   prometheus.ExponentialBuckets(.0005, 4, 8),
+		}, []string{"outcome"}),
+		validations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "schema_validator",
+			Name:      "validations_total",
+			Help:      "Payload validations by outcome (valid, invalid or error).",
+		}, []string{"outcome"}),
+	}
+	registerer.MustRegister(m.cacheLookups, m.compiles, m.validations)
+	return m
+}
+
+// ObserveSchemaCache implements persistence.SchemaValidatorObserver.
+func (m *SchemaValidator) ObserveSchemaCache(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(result).Inc()
+}
+
+// ObserveSchemaCompile implements persistence.SchemaValidatorObserver.
+func (m *SchemaValidator) ObserveSchemaCompile(duration time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	m.compiles.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+// ObserveSchemaValidation implements persistence.SchemaValidatorObserver.
+func (m *SchemaValidator) ObserveSchemaValidation(err error) {
+	m.validations.WithLabelValues(validationOutcome(err)).Inc()
+}
+
+// EntityWrites implements persistence.EntityWriteObserver.
+type EntityWrites struct {
+	writes *prometheus.CounterVec
+}
+
+var _ persistence.EntityWriteObserver = (*EntityWrites)(nil)
+
+// NewEntityWrites registers the entity write counter with registerer.
+func NewEntityWrites(registerer prometheus.Registerer) *EntityWrites {
+	m := &EntityWrites{
+		writes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "entities",
+			Name:      "writes_total",
+			Help:      "Entity writes by table, operation (create, update, delete) and outcome.",
+		}, []string{"table", "operation", "outcome"}),
+	}
+	registerer.MustRegister(m.writes)
+	return m
+}
+
+// ObserveEntityWrite implements persistence.EntityWriteObserver.
+func (m *EntityWrites) ObserveEntityWrite(tableName, operation string, err error) {
+	m.writes.WithLabelValues(tableName, operation, entityWriteOutcome(err)).Inc()
+}
+
+// entityWriteOutcome buckets write errors into a small, fixed set of label values.
+func entityWriteOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, persistence.ErrEntityAlreadyExists):
+		return "conflict"
+	case errors.Is(err, persistence.ErrEntityNotFound):
+		return "not_found"
+	case validationOutcome(err) == "invalid":
+		return "invalid"
+	default:
+		return "error"
+	}
+}
+
+func validationOutcome(err error) string {
+	var validationErr *jsonschema.ValidationError
+	switch {
+	case err == nil:
+		return "valid"
+	case errors.As(err, &validationErr):
+		return "invalid"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports pgxpool.Stat() for one pool, labelled by pool name.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	constructingConns *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquires          *prometheus.Desc
+	emptyAcquires     *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+}
+
+// NewPoolCollector describes the pool's statistics; register it with a prometheus.Registerer.
+func NewPoolCollector(pool *pgxpool.Pool, name string) *PoolCollector {
+	if pool == nil {
+		panic("pool is required")
+	}
+
+	labels := prometheus.Labels{"pool": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(Namespace, "db_pool", metric), help, nil, labels)
+	}
+
+	return &PoolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_connections", "Connections currently checked out of the pool."),
+		idleConns:         desc("idle_connections", "Idle connections in the pool."),
+		constructingConns: desc("constructing_connections", "Connections being established."),
+		totalConns:        desc("total_connections", "Connections in the pool, acquired, idle or being established."),
+		maxConns:          desc("max_connections", "Maximum size of the pool."),
+		acquires:          desc("acquires_total", "Successful connection acquisitions."),
+		emptyAcquires:     desc("empty_acquires_total", "Acquisitions that had to wait because the pool had no idle connection."),
+		canceledAcquires:  desc("canceled_acquires_total", "Acquisitions canceled by their context while waiting."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent waiting for connections."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquires
+	ch <- c.emptyAcquires
+	ch <- c.canceledAcquires
+	ch <- c.acquireDuration
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -1,14 +1,34 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+)
 
+// DefaultCORS allows requests from any origin.
 func DefaultCORS() func(http.Handler) http.Handler {
-	// Keep it simple; tighten for prod
+	return CORS([]string{"*"})
+}
+
+// CORS sets the CORS headers for requests from allowedOrigins ("*" allows any origin) and answers
+// preflight requests. Requests from other origins get no CORS headers, so browsers block them.
+func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
+	anyOrigin := slices.Contains(allowedOrigins, "*")
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PATCH,DELETE,OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Authorization,Content-Type,Idempotency-Key")
+			header := w.Header()
+			if !anyOrigin {
+				header.Add("Vary", "Origin")
+			}
+			if origin := r.Header.Get("Origin"); anyOrigin || (origin != "" && slices.Contains(allowedOrigins, origin)) {
+				if anyOrigin {
+					origin = "*"
+				}
+				header.Set("Access-Control-Allow-Origin", origin)
+				header.Set("Access-Control-Allow-Methods", "GET,POST,PATCH,DELETE,OPTIONS")
+				header.Set("Access-Control-Allow-Headers", "Authorization,Content-Type,Idempotency-Key")
+				header.Set("Access-Control-Expose-Headers", "Idempotent-Replayed,Content-Disposition,X-Schema-Id,X-Schema-Version,X-Export-Snapshot-At")
+			}
 			if r.Method == http.MethodOptions {
 				w.WriteHeader(http.StatusNoContent)
 				return
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	handler := CORS([]string{"https://admin.example.com"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/users", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	allowed := serve(http.MethodGet, "https://admin.example.com")
+	require.Equal(t, http.StatusOK, allowed.Code)
+	require.Equal(t, "https://admin.example.com", allowed.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "Origin", allowed.Header().Get("Vary"))
+
+	preflight := serve(http.MethodOptions, "https://admin.example.com")
+	require.Equal(t, http.StatusNoContent, preflight.Code)
+	require.Contains(t, preflight.Header().Get("Access-Control-Allow-Headers"), "Idempotency-Key")
+
+	other := serve(http.MethodGet, "https://evil.example.com")
+	require.Equal(t, http.StatusOK, other.Code)
+	require.Empty(t, other.Header().Get("Access-Control-Allow-Origin"))
+
+	anyOrigin := httptest.NewRecorder()
+	DefaultCORS()(http.NotFoundHandler()).ServeHTTP(anyOrigin, httptest.NewRequest(http.MethodOptions, "/", nil))
+	require.Equal(t, http.StatusNoContent, anyOrigin.Code)
+	require.Equal(t, "*", anyOrigin.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -2,19 +2,32 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
 )
 
+// RequiredRolesExtension is the vendor extension listing the roles allowed to call an operation.
+// It may be set on the operation itself or on any of its tags; operation-level values win.
+const RequiredRolesExtension = "x-required-roles"
+
+// ErrForbidden marks authentication failures caused by missing roles so the validator answers 403 instead of 401.
+var ErrForbidden = errors.New("forbidden")
+
 // ValidateAuthenticationViaSwagger OpenAPI request validation against the embedded spec (with permissive auth func for public endpoints)
 // Provide AuthenticationFunc to satisfy operations that declare security in OpenAPI.
 func ValidateAuthenticationViaSwagger(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
 	// Enforce presence of Bearer token for endpoints that require bearerAuth.
 	// For operations that allow anonymous (security: [{}] or no security), the validator will not require bearerAuth.
 	if input != nil && input.SecuritySchemeName == "bearerAuth" {
-		// TODO: extract role from `r.Context().Value(ctxUserCredentials)` injected in JWT middleware.
 		r := input.RequestValidationInput.Request
 		if r == nil {
 			return fmt.Errorf("no request in validation input")
@@ -24,7 +37,99 @@ func ValidateAuthenticationViaSwagger(ctx context.Context, input *openapi3filter
 			return fmt.Errorf("missing or invalid Authorization header")
 		}
 
-		// TODO: Validate if the user has one of the required roles.
+		creds, ok := platformauth.UserFromContext(r.Context())
+		if !ok || creds == nil {
+			return fmt.Errorf("missing credentials")
+		}
+
+		required := RequiredRoles(input.RequestValidationInput.Route)
+		if !creds.HasAnyRole(required...) {
+			return fmt.Errorf("%w: requires one of roles %v", ErrForbidden, required)
+		}
 	}
 	return nil
 }
+
+// RequiredRoles returns the roles declared through x-required-roles for the matched route.
+// Operation-level declarations take precedence over the union of tag-level declarations.
+func RequiredRoles(route *routers.Route) []platformauth.Role {
+	if route == nil || route.Operation == nil {
+		return nil
+	}
+
+	if roles, ok := rolesFromExtensions(route.Operation.Extensions); ok {
+		return roles
+	}
+
+	if route.Spec == nil {
+		return nil
+	}
+
+	var roles []platformauth.Role
+	for _, tagName := range route.Operation.Tags {
+		tag := route.Spec.Tags.Get(tagName)
+		if tag == nil {
+			continue
+		}
+		if tagRoles, ok := rolesFromExtensions(tag.Extensions); ok {
+			roles = append(roles, tagRoles...)
+		}
+	}
+	return roles
+}
+
+func rolesFromExtensions(extensions map[string]any) ([]platformauth.Role, bool) {
+	raw, ok := extensions[RequiredRolesExtension]
+	if !ok {
+		return nil, false
+	}
+
+	var values []string
+	switch v := raw.(type) {
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	case []string:
+		values = v
+	case string:
+		values = []string{v}
+	}
+
+	roles := make([]platformauth.Role, 0, len(values))
+	for _, value := range values {
+		// Unknown roles are kept so a typo in a contract fails closed instead of opening the endpoint.
+		role, known := platformauth.ParseRole(value)
+		if !known {
+			role = platformauth.Role(value)
+		}
+		roles = append(roles, role)
+	}
+	return roles, true
+}
+
+// SpecValidationErrorHandler renders validator failures as ProblemDetails: missing roles become 403,
+// other authentication failures 401, unknown routes 404 and request/schema violations 400.
+func SpecValidationErrorHandler(_ context.Context, err error, w http.ResponseWriter, r *http.Request, opts oapimiddleware.ErrorHandlerOpts) {
+	var secErr *openapi3filter.SecurityRequirementsError
+	switch {
+	case errors.As(err, &secErr) && errors.Is(err, ErrForbidden):
+		platformhttp.WriteProblem(w, r, http.StatusForbidden, platformhttp.ProblemTypeForbidden,
+			"Forbidden", "the caller lacks a role required by this operation")
+	case errors.As(err, &secErr):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+		platformhttp.WriteProblem(w, r, http.StatusUnauthorized, platformhttp.ProblemTypeUnauthorized,
+			"Unauthorized", "a valid bearer token is required")
+	case opts.StatusCode == http.StatusNotFound:
+		platformhttp.WriteProblem(w, r, http.StatusNotFound, platformhttp.ProblemTypeNotFound,
+			"Resource not found", "no operation matches the request")
+	case opts.StatusCode >= http.StatusInternalServerError:
+		platformhttp.WriteProblem(w, r, opts.StatusCode, platformhttp.ProblemTypeInternal,
+			"Internal server error", "an unexpected error occurred")
+	default:
+		platformhttp.WriteProblem(w, r, http.StatusBadRequest, platformhttp.ProblemTypeValidation,
+			"Validation failed", err.Error())
+	}
+}
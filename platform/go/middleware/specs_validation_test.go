@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
+	"github.com/stretchr/testify/require"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+)
+
+const rolesSpec = `
+openapi: 3.0.3
+info:
+  title: roles
+  version: 1.0.0
+security:
+  - bearerAuth: []
+tags:
+  - name: Managed
+    x-required-roles: [admin, user_manager]
+paths:
+  /managed:
+    get:
+      tags: [Managed]
+      responses:
+        "200":
+          description: ok
+  /admin-only:
+    get:
+      tags: [Managed]
+      x-required-roles: [admin]
+      responses:
+        "200":
+          description: ok
+  /open:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+`
+
+func TestSpecValidatorEnforcesRequiredRoles(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(rolesSpec))
+	require.NoError(t, err)
+
+	validator := oapimiddleware.OapiRequestValidatorWithOptions(spec, &oapimiddleware.Options{
+		Options: openapi3filter.Options{
+			AuthenticationFunc: ValidateAuthenticationViaSwagger,
+		},
+		ErrorHandlerWithOpts: SpecValidationErrorHandler,
+	})
+	handler := validator(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		name       string
+		path       string
+		roles      []platformauth.Role
+		anonymous  bool
+		wantStatus int
+	}{
+		{name: "tag roles allow manager", path: "/managed", roles: []platformauth.Role{platformauth.RoleUserManager}, wantStatus: http.StatusOK},
+		{name: "tag roles reject plain user", path: "/managed", roles: []platformauth.Role{platformauth.RoleUser}, wantStatus: http.StatusForbidden},
+		{name: "operation roles override tag", path: "/admin-only", roles: []platformauth.Role{platformauth.RoleUserManager}, wantStatus: http.StatusForbidden},
+		{name: "operation roles allow admin", path: "/admin-only", roles: []platformauth.Role{platformauth.RoleAdmin}, wantStatus: http.StatusOK},
+		{name: "no roles declared", path: "/open", roles: []platformauth.Role{platformauth.RoleUser}, wantStatus: http.StatusOK},
+		{name: "missing token", path: "/managed", anonymous: true, wantStatus: http.StatusUnauthorized},
+		{name: "unknown route", path: "/missing", roles: []platformauth.Role{platformauth.RoleAdmin}, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if !tc.anonymous {
+				req.Header.Set("Authorization", "Bearer token")
+				req = req.WithContext(platformauth.WithUserCredentials(context.Background(), &platformauth.UserCredentials{
+					Id:    "user-1",
+					Roles: tc.roles,
+				}))
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus != http.StatusOK {
+				require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestRequiredRolesKeepsUnknownRoles(t *testing.T) {
+	roles, ok := rolesFromExtensions(map[string]any{RequiredRolesExtension: []any{"admin", "auditor"}})
+	require.True(t, ok)
+	require.Equal(t, []platformauth.Role{platformauth.RoleAdmin, platformauth.Role("auditor")}, roles)
+
+	_, ok = rolesFromExtensions(map[string]any{})
+	require.False(t, ok)
+}
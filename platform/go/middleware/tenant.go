@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+// ResolveTenant stores the caller's tenant on the request context so every store can scope its queries.
+// The tenant comes from the credentials injected by the JWT middleware; defaultTenant is used when the
+// token carries no tenant claim (single-tenant deployments). Leave it empty to require the claim.
+// Anonymous requests pass through untouched so the spec validator can reject them with 401.
+func ResolveTenant(defaultTenant string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			creds, ok := platformauth.UserFromContext(r.Context())
+			if !ok || creds == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			candidate := defaultTenant
+			if creds.TenantID != nil && *creds.TenantID != "" {
+				candidate = *creds.TenantID
+			}
+
+			tenantID, err := tenant.Normalize(candidate)
+			if err != nil {
+				http.Error(w, "forbidden: tenant could not be resolved", http.StatusForbidden)
+				return
+			}
+
+			ctx := tenant.WithID(r.Context(), tenantID)
+			if logger, ok := platformlogging.FromContext(ctx); ok {
+				ctx = platformlogging.WithLogger(ctx, logger.With(zap.String("tenant_id", tenantID)))
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
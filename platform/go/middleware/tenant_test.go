@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+func TestResolveTenant(t *testing.T) {
+	testCases := []struct {
+		name          string
+		claims        map[string]interface{}
+		defaultTenant string
+		wantStatus    int
+		wantTenant    string
+	}{
+		{
+			name:       "tenant claim wins",
+			claims:     map[string]interface{}{"sub": "user-1", "firebase": map[string]interface{}{"tenant": "tenant-a"}},
+			wantStatus: http.StatusOK,
+			wantTenant: "tenant-a",
+		},
+		{
+			name:          "falls back to default tenant",
+			claims:        map[string]interface{}{"sub": "user-1"},
+			defaultTenant: "default",
+			wantStatus:    http.StatusOK,
+			wantTenant:    "default",
+		},
+		{
+			name:       "rejects tokens without tenant",
+			claims:     map[string]interface{}{"sub": "user-1"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "anonymous requests pass through",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotTenant string
+			handler := platformauth.JWT(platformauth.UnsignedTokenVerifier(), nil)(
+				ResolveTenant(tc.defaultTenant)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotTenant, _ = tenant.FromContext(r.Context())
+					w.WriteHeader(http.StatusOK)
+				})),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/entities", nil).WithContext(context.Background())
+			if tc.claims != nil {
+				req.Header.Set("Authorization", "Bearer "+unsignedToken(t, tc.claims))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code)
+			require.Equal(t, tc.wantTenant, gotTenant)
+		})
+	}
+}
+
+func unsignedToken(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+}
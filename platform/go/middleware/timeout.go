@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+)
+
+// LongRequest gives the requests selected by Match a longer timeout than the default, e.g. streaming exports
+// and bulk uploads.
+type LongRequest struct {
+	Match   func(*http.Request) bool
+	Timeout time.Duration
+}
+
+// Timeout cancels each request's context after timeout, or after the Timeout of the first matching
+// LongRequest. Long requests also get their connection read and write deadlines extended, since the
+// server's timeouts would otherwise cut their uploads or responses off first.
+func Timeout(timeout time.Duration, long ...LongRequest) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		standard := chimw.Timeout(timeout)(next)
+		extended := make([]http.Handler, len(long))
+		for i, request := range long {
+			extended[i] = chimw.Timeout(request.Timeout)(next)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i, request := range long {
+				if request.Match(r) {
+					// Writers that cannot extend the deadlines keep the server's.
+					deadline := time.Now().Add(request.Timeout)
+					controller := http.NewResponseController(w)
+					_ = controller.SetReadDeadline(deadline)
+					_ = controller.SetWriteDeadline(deadline)
+					extended[i].ServeHTTP(w, r)
+					return
+				}
+			}
+			standard.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutLongRequests(t *testing.T) {
+	t.Parallel()
+
+	var remaining time.Duration
+	handler := Timeout(time.Second, LongRequest{
+		Match:   func(r *http.Request) bool { return strings.HasSuffix(r.URL.Path, "/export") },
+		Timeout: time.Hour,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		require.True(t, ok)
+		remaining = time.Until(deadline)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/entities/cards/documents", nil))
+	require.LessOrEqual(t, remaining, time.Second)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/entities/cards/export", nil))
+	require.Greater(t, remaining, time.Minute)
+}
@@ -0,0 +1,461 @@
+// Package migrate applies the versioned SQL migrations in database/migrations and records them, with
+// their checksums, in the schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NoneApplied is the Baseline version of databases that contain none of the migrations, e.g. those
+// provisioned from the schema snapshot older than the first migration. It records an empty history, so
+// Up then applies every migration.
+const NoneApplied = "0"
+
+// lockKey is the pg_advisory_lock key serialising migration runners ("palmyra.migrate" as an int64).
+const lockKey int64 = 0x70616c6d6d696772
+
+const trackingTableDDL = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		baseline BOOLEAN NOT NULL DEFAULT FALSE,
+		execution_ms BIGINT NOT NULL DEFAULT 0,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`
+
+var (
+	// ErrPending indicates migrations that have not been applied yet.
+	ErrPending = errors.New("pending migrations")
+	// ErrModified indicates applied migrations whose file changed afterwards.
+	ErrModified = errors.New("applied migrations were modified")
+	// ErrNoHistory indicates a database with tables but no migration history.
+	ErrNoHistory = errors.New("database has no migration history")
+	// ErrIrreversible indicates a rollback of a migration without a down script.
+	ErrIrreversible = errors.New("migration has no down script")
+)
+
+// Config describes the migrations to manage.
+type Config struct {
+	// Migrations are the change sets, usually Load(database.Migrations()).
+	Migrations []Migration
+	// Schema is the snapshot applied to empty databases, after which every migration is recorded as a
+	// baseline. Without it, empty databases are migrated from the first migration.
+	Schema fs.FS
+}
+
+// Migrator applies migrations to one database.
+type Migrator struct {
+	pool *pgxpool.Pool
+	cfg  Config
+}
+
+// New returns a Migrator for the pool's database.
+func New(pool *pgxpool.Pool, cfg Config) (*Migrator, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+	return &Migrator{pool: pool, cfg: cfg}, nil
+}
+
+// Applied is a row of schema_migrations.
+type Applied struct {
+	Version     string
+	Name        string
+	Checksum    string
+	Baseline    bool
+	ExecutionMs int64
+	AppliedAt   time.Time
+}
+
+// State is a migration and, once applied, its record.
+type State struct {
+	Migration
+	Applied  *Applied
+	Modified bool // applied with a different checksum
+}
+
+// Status compares the migrations with the database.
+type Status struct {
+	Migrations []State
+	// Unknown are applied versions without a migration file, e.g. from a newer release.
+	Unknown []Applied
+}
+
+// Pending returns the migrations not applied yet, oldest first.
+func (s Status) Pending() []Migration {
+	var pending []Migration
+	for _, state := range s.Migrations {
+		if state.Applied == nil {
+			pending = append(pending, state.Migration)
+		}
+	}
+	return pending
+}
+
+// Modified returns the applied migrations whose checksum changed.
+func (s Status) Modified() []Migration {
+	var modified []Migration
+	for _, state := range s.Migrations {
+		if state.Modified {
+			modified = append(modified, state.Migration)
+		}
+	}
+	return modified
+}
+
+// Status reads the migration history. A database without the tracking table has no history.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	var exists bool
+	if err := m.pool.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return Status{}, fmt.Errorf("check migration history: %w", err)
+	}
+	var applied map[string]Applied
+	if exists {
+		var err error
+		if applied, err = appliedMigrations(ctx, m.pool); err != nil {
+			return Status{}, err
+		}
+	}
+	return m.status(applied), nil
+}
+
+// Verify fails with ErrPending or ErrModified unless every migration is applied unchanged.
+func (m *Migrator) Verify(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if modified := status.Modified(); len(modified) > 0 {
+		return fmt.Errorf("%w: %s", ErrModified, names(modified))
+	}
+	if pending := status.Pending(); len(pending) > 0 {
+		return fmt.Errorf("%w: %s", ErrPending, names(pending))
+	}
+	return nil
+}
+
+// Up applies the pending migrations in version order, each in its own transaction, and returns them. An
+// empty database gets the schema snapshot instead, with every migration recorded as a baseline. A
+// database with tables but no history fails with ErrNoHistory; record what it already contains with
+// Baseline first (NoneApplied when that is nothing). Modified migrations fail with ErrModified before
+// anything runs.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *pgx.Conn, applied map[string]Applied) error {
+		status := m.status(applied)
+		if modified := status.Modified(); len(modified) > 0 {
+			return fmt.Errorf("%w: %s", ErrModified, names(modified))
+		}
+		pending := status.Pending()
+		if len(pending) == 0 {
+			return nil
+		}
+
+		if len(applied) == 0 {
+			empty, err := isEmpty(ctx, conn)
+			if err != nil {
+				return err
+			}
+			switch {
+			case empty && m.cfg.Schema != nil:
+				if err := m.applySchema(ctx, conn); err != nil {
+					return err
+				}
+				done = pending
+				return nil
+			case !empty:
+				return fmt.Errorf("%w: record the migrations it already contains with baseline (version %s for none)", ErrNoHistory, NoneApplied)
+			}
+		}
+
+		for _, migration := range pending {
+			if err := apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the latest steps applied migrations, newest first, and returns them. It fails with
+// ErrIrreversible, before anything runs, when one of them has no down script.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *pgx.Conn, applied map[string]Applied) error {
+		known := map[string]Migration{}
+		for _, migration := range m.cfg.Migrations {
+			known[migration.Version] = migration
+		}
+
+		versions := make([]string, 0, len(applied))
+		for version := range applied {
+			if version != NoneApplied {
+				versions = append(versions, version)
+			}
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+		if steps < len(versions) {
+			versions = versions[:steps]
+		}
+
+		rollback := make([]Migration, 0, len(versions))
+		for _, version := range versions {
+			migration, ok := known[version]
+			if !ok {
+				return fmt.Errorf("migration %s_%s is not in this release", version, applied[version].Name)
+			}
+			if !migration.Reversible() {
+				return fmt.Errorf("%w: %s", ErrIrreversible, migration)
+			}
+			rollback = append(rollback, migration)
+		}
+
+		for _, migration := range rollback {
+			if err := revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline records the migrations up to and including version as applied without running them, for
+// databases whose schema already contains them (e.g. provisioned from the snapshot before migrations
+// were tracked). It returns the migrations recorded. NoneApplied records that the database contains
+// none of them; it is refused once migrations were recorded.
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *pgx.Conn, applied map[string]Applied) error {
+		if version == NoneApplied {
+			for recorded := range applied {
+				if recorded != NoneApplied {
+					return fmt.Errorf("database already has migration history up to %s", latest(applied))
+				}
+			}
+			_, err := conn.Exec(ctx, `
+				INSERT INTO schema_migrations (version, name, checksum, baseline)
+				VALUES ($1, 'none_applied', '', TRUE)
+				ON CONFLICT (version) DO NOTHING
+			`, NoneApplied)
+			if err != nil {
+				return fmt.Errorf("record empty baseline: %w", err)
+			}
+			return nil
+		}
+
+		found := false
+		for _, migration := range m.cfg.Migrations {
+			found = found || migration.Version == version
+		}
+		if !found {
+			return fmt.Errorf("unknown migration version %q", version)
+		}
+
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			for _, migration := range m.cfg.Migrations {
+				if migration.Version > version {
+					break
+				}
+				if _, ok := applied[migration.Version]; ok {
+					continue
+				}
+				if err := record(ctx, tx, migration, true, 0); err != nil {
+					return err
+				}
+				done = append(done, migration)
+			}
+			return nil
+		})
+	})
+	return done, err
+}
+
+// locked runs fn on a dedicated connection holding the migration advisory lock, so concurrent
+// runners (e.g. several replicas starting at once) apply each migration once.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgx.Conn, applied map[string]Applied) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Unlock even when ctx is done; a failed unlock closes the connection, which releases the lock.
+		if _, err := conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockKey); err != nil {
+			_ = conn.Hijack().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, trackingTableDDL); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn.Conn(), applied)
+}
+
+func (m *Migrator) status(applied map[string]Applied) Status {
+	var status Status
+	known := map[string]struct{}{}
+	for _, migration := range m.cfg.Migrations {
+		known[migration.Version] = struct{}{}
+		state := State{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			state.Applied = &record
+			state.Modified = record.Checksum != migration.Checksum
+		}
+		status.Migrations = append(status.Migrations, state)
+	}
+	for version, record := range applied {
+		if _, ok := known[version]; !ok && version != NoneApplied {
+			status.Unknown = append(status.Unknown, record)
+		}
+	}
+	sort.Slice(status.Unknown, func(i, j int) bool {
+		return status.Unknown[i].Version < status.Unknown[j].Version
+	})
+	return status
+}
+
+// applySchema provisions an empty database from the snapshot and records every migration as a baseline.
+func (m *Migrator) applySchema(ctx context.Context, conn *pgx.Conn) error {
+	entries, err := fs.ReadDir(m.cfg.Schema, ".")
+	if err != nil {
+		return fmt.Errorf("read schema: %w", err)
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+				continue
+			}
+			content, err := fs.ReadFile(m.cfg.Schema, entry.Name())
+			if err != nil {
+				return fmt.Errorf("read schema %s: %w", entry.Name(), err)
+			}
+			if _, err := tx.Exec(ctx, string(content)); err != nil {
+				return fmt.Errorf("apply schema %s: %w", entry.Name(), err)
+			}
+		}
+		for _, migration := range m.cfg.Migrations {
+			if err := record(ctx, tx, migration, true, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+func apply(ctx context.Context, conn *pgx.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		started := time.Now()
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return fmt.Errorf("apply migration %s: %w", migration, err)
+		}
+		return record(ctx, tx, migration, false, time.Since(started).Milliseconds())
+	})
+}
+
+func revert(ctx context.Context, conn *pgx.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return fmt.Errorf("revert migration %s: %w", migration, err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+			return fmt.Errorf("delete migration record %s: %w", migration, err)
+		}
+		return nil
+	})
+}
+
+func record(ctx context.Context, db execer, migration Migration, baseline bool, executionMs int64) error {
+	if _, err := db.Exec(ctx, `
+		INSERT INTO schema_migrations (version, name, checksum, baseline, execution_ms)
+		VALUES ($1, $2, $3, $4, $5)
+	`, migration.Version, migration.Name, migration.Checksum, baseline, executionMs); err != nil {
+		return fmt.Errorf("record migration %s: %w", migration, err)
+	}
+	return nil
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func appliedMigrations(ctx context.Context, db querier) (map[string]Applied, error) {
+	rows, err := db.Query(ctx, `
+		SELECT version, name, checksum, baseline, execution_ms, applied_at
+		FROM schema_migrations
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[string]Applied{}
+	for rows.Next() {
+		var record Applied
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.Baseline, &record.ExecutionMs, &record.AppliedAt); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+		applied[record.Version] = record
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// isEmpty reports whether the current schema holds no tables besides schema_migrations.
+func isEmpty(ctx context.Context, conn *pgx.Conn) (bool, error) {
+	var tables int
+	if err := conn.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM pg_tables
+		WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'
+	`).Scan(&tables); err != nil {
+		return false, fmt.Errorf("count tables: %w", err)
+	}
+	return tables == 0, nil
+}
+
+// latest returns the newest applied version.
+func latest(applied map[string]Applied) string {
+	var version string
+	for recorded := range applied {
+		version = max(version, recorded)
+	}
+	return version
+}
+
+func names(migrations []Migration) string {
+	parts := make([]string, len(migrations))
+	for i, migration := range migrations {
+		parts[i] = migration.String()
+	}
+	return strings.Join(parts, ", ")
+}
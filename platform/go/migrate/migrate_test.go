@@ -0,0 +1,245 @@
+package migrate
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/database"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+func TestMigratorIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping migrate integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := persistence.NewPool(ctx, persistence.PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		persistence.ClosePool(pool)
+	})
+
+	t.Run("provisions empty databases from the schema snapshot", func(t *testing.T) {
+		fresh := newDatabase(ctx, t, pool, connString, "fresh")
+
+		migrations, err := Load(database.Migrations())
+		require.NoError(t, err)
+		migrator, err := New(fresh, Config{Migrations: migrations, Schema: database.Schema()})
+		require.NoError(t, err)
+
+		require.ErrorIs(t, migrator.Verify(ctx), ErrPending)
+
+		done, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		require.Len(t, done, len(migrations))
+		require.NoError(t, migrator.Verify(ctx))
+
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		for _, state := range status.Migrations {
+			require.NotNil(t, state.Applied)
+			require.True(t, state.Applied.Baseline)
+		}
+
+		done, err = migrator.Up(ctx)
+		require.NoError(t, err)
+		require.Empty(t, done)
+	})
+
+	t.Run("applies, verifies and reverts migrations", func(t *testing.T) {
+		db := newDatabase(ctx, t, pool, connString, "lifecycle")
+
+		files := fstest.MapFS{
+			"20261018T090000_widgets.sql":      {Data: []byte("CREATE TABLE widgets (id TEXT PRIMARY KEY);")},
+			"20261018T090000_widgets.down.sql": {Data: []byte("DROP TABLE widgets;")},
+			"20261018T100000_gadgets.sql":      {Data: []byte("CREATE TABLE gadgets (id TEXT PRIMARY KEY); INSERT INTO gadgets VALUES ('g');")},
+		}
+		migrations, err := Load(files)
+		require.NoError(t, err)
+		migrator, err := New(db, Config{Migrations: migrations[:1]})
+		require.NoError(t, err)
+
+		done, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+
+		migrator, err = New(db, Config{Migrations: migrations})
+		require.NoError(t, err)
+		require.ErrorIs(t, migrator.Verify(ctx), ErrPending)
+
+		// Concurrent runners apply each migration once.
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			applied []Migration
+		)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				done, err := migrator.Up(ctx)
+				require.NoError(t, err)
+				mu.Lock()
+				applied = append(applied, done...)
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		require.Len(t, applied, 1)
+		require.Equal(t, "gadgets", applied[0].Name)
+		require.NoError(t, migrator.Verify(ctx))
+
+		_, err = migrator.Down(ctx, 1)
+		require.ErrorIs(t, err, ErrIrreversible)
+
+		changed := fstest.MapFS{}
+		for name, file := range files {
+			changed[name] = file
+		}
+		changed["20261018T090000_widgets.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE widgets (id BIGINT PRIMARY KEY);")}
+		modified, err := Load(changed)
+		require.NoError(t, err)
+		modifiedMigrator, err := New(db, Config{Migrations: modified})
+		require.NoError(t, err)
+		require.ErrorIs(t, modifiedMigrator.Verify(ctx), ErrModified)
+		_, err = modifiedMigrator.Up(ctx)
+		require.ErrorIs(t, err, ErrModified)
+
+		migrator, err = New(db, Config{Migrations: migrations[:1]})
+		require.NoError(t, err)
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, status.Unknown, 1, "gadgets is unknown to the older release")
+
+		_, err = db.Exec(ctx, `DELETE FROM schema_migrations WHERE version = '20261018T100000'`)
+		require.NoError(t, err)
+		done, err = migrator.Down(ctx, 5)
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+
+		var exists bool
+		require.NoError(t, db.QueryRow(ctx, `SELECT to_regclass('widgets') IS NOT NULL`).Scan(&exists))
+		require.False(t, exists)
+	})
+
+	t.Run("requires a baseline for untracked databases", func(t *testing.T) {
+		legacy := newDatabase(ctx, t, pool, connString, "legacy")
+		_, err := legacy.Exec(ctx, `CREATE TABLE widgets (id TEXT PRIMARY KEY)`)
+		require.NoError(t, err)
+
+		migrations, err := Load(fstest.MapFS{
+			"20261018T090000_widgets.sql": {Data: []byte("CREATE TABLE widgets (id TEXT PRIMARY KEY);")},
+			"20261018T100000_gadgets.sql": {Data: []byte("CREATE TABLE gadgets (id TEXT PRIMARY KEY);")},
+		})
+		require.NoError(t, err)
+		migrator, err := New(legacy, Config{Migrations: migrations})
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		require.ErrorIs(t, err, ErrNoHistory)
+
+		_, err = migrator.Baseline(ctx, "20261018T999999")
+		require.Error(t, err)
+
+		done, err := migrator.Baseline(ctx, "20261018T090000")
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+
+		done, err = migrator.Up(ctx)
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+		require.Equal(t, "gadgets", done[0].Name)
+		require.NoError(t, migrator.Verify(ctx))
+
+		_, err = migrator.Baseline(ctx, NoneApplied)
+		require.Error(t, err, "an empty baseline cannot replace recorded history")
+	})
+
+	t.Run("baselines databases containing no migration", func(t *testing.T) {
+		legacy := newDatabase(ctx, t, pool, connString, "premigrations")
+		_, err := legacy.Exec(ctx, `CREATE TABLE users (id TEXT PRIMARY KEY)`)
+		require.NoError(t, err)
+
+		files := fstest.MapFS{
+			"20261018T090000_widgets.sql":      {Data: []byte("CREATE TABLE widgets (id TEXT PRIMARY KEY);")},
+			"20261018T090000_widgets.down.sql": {Data: []byte("DROP TABLE widgets;")},
+			"20261018T100000_gadgets.sql":      {Data: []byte("CREATE TABLE gadgets (id TEXT PRIMARY KEY);")},
+			"20261018T100000_gadgets.down.sql": {Data: []byte("DROP TABLE gadgets;")},
+		}
+		migrations, err := Load(files)
+		require.NoError(t, err)
+		migrator, err := New(legacy, Config{Migrations: migrations})
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		require.ErrorIs(t, err, ErrNoHistory)
+
+		done, err := migrator.Baseline(ctx, NoneApplied)
+		require.NoError(t, err)
+		require.Empty(t, done)
+
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		require.Empty(t, status.Unknown)
+		require.Len(t, status.Pending(), 2)
+
+		done, err = migrator.Up(ctx)
+		require.NoError(t, err)
+		require.Len(t, done, 2)
+		require.NoError(t, migrator.Verify(ctx))
+
+		// Rolling everything back leaves the empty baseline, so up still runs.
+		done, err = migrator.Down(ctx, 5)
+		require.NoError(t, err)
+		require.Len(t, done, 2)
+		done, err = migrator.Up(ctx)
+		require.NoError(t, err)
+		require.Len(t, done, 2)
+	})
+}
+
+// newDatabase creates an empty database on the test server and returns a pool connected to it.
+func newDatabase(ctx context.Context, t *testing.T, admin *pgxpool.Pool, connString, name string) *pgxpool.Pool {
+	t.Helper()
+
+	_, err := admin.Exec(ctx, `CREATE DATABASE `+name)
+	require.NoError(t, err)
+
+	config, err := pgxpool.ParseConfig(connString)
+	require.NoError(t, err)
+	config.ConnConfig.Database = name
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+)
+
+// Migration is one versioned change set.
+type Migration struct {
+	Version  string // timestamp prefix, e.g. 20261018T090000
+	Name     string
+	Up       string
+	Down     string // empty when the migration cannot be rolled back
+	Checksum string // hex SHA-256 of the up file, as written by sha256sum
+}
+
+// Reversible reports whether the migration has a down script.
+func (m Migration) Reversible() bool {
+	return m.Down != ""
+}
+
+func (m Migration) String() string {
+	return m.Version + "_" + m.Name
+}
+
+// fileName matches <version>_<name>.sql and its optional <version>_<name>.down.sql rollback.
+var fileName = regexp.MustCompile(`^(\d{8}T\d{6})_([a-z0-9_]+?)(\.down)?\.sql$`)
+
+// Load reads the migrations at the root of fsys, ordered by version. Files that do not follow the
+// naming scheme are rejected so typos do not silently skip a migration.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := map[string]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %q: name must be <YYYYMMDDTHHMMSS>_<name>.sql or .down.sql", entry.Name())
+		}
+		version, name, down := match[1], match[2], match[3] != ""
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migration %s: conflicting names %q and %q", version, migration.Name, name)
+		}
+		if down {
+			migration.Down = string(content)
+			continue
+		}
+		sum := sha256.Sum256(content)
+		migration.Up = string(content)
+		migration.Checksum = hex.EncodeToString(sum[:])
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("migration %s has a down script but no up script", migration)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/database"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := Load(fstest.MapFS{
+		"20261018T100000_second.sql":     {Data: []byte("CREATE TABLE b ();")},
+		"20261018T090000_first.sql":      {Data: []byte("CREATE TABLE a ();")},
+		"20261018T090000_first.down.sql": {Data: []byte("DROP TABLE a;")},
+		"fixtures/20261018T110000_x.sql": {Data: []byte("SELECT 1;")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	require.Equal(t, "20261018T090000", migrations[0].Version)
+	require.Equal(t, "first", migrations[0].Name)
+	require.Equal(t, "DROP TABLE a;", migrations[0].Down)
+	require.True(t, migrations[0].Reversible())
+	require.Equal(t, "20261018T090000_first", migrations[0].String())
+	// sha256sum of the file, so shell scripts can record the same checksum.
+	require.Equal(t, "902f5c947b00294d6b0cdb41666553a31c51672ed6e65af6209e622790dce7b1", migrations[0].Checksum)
+
+	require.Equal(t, "second", migrations[1].Name)
+	require.False(t, migrations[1].Reversible())
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]fstest.MapFS{
+		"bad name":   {"001_core.sql": {Data: []byte("SELECT 1;")}},
+		"down only":  {"20261018T090000_first.down.sql": {Data: []byte("SELECT 1;")}},
+		"name clash": {"20261018T090000_a.sql": {}, "20261018T090000_b.sql": {}},
+		"upper case": {"20261018T090000_Add.sql": {}},
+		"not sql":    {"20261018T090000_first.txt": {}},
+	}
+	for name, fsys := range testCases {
+		_, err := Load(fsys)
+		require.Error(t, err, name)
+	}
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := Load(database.Migrations())
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+}
@@ -0,0 +1,672 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+// AccessPermission is the level granted by an ACL entry. Higher levels include the lower ones.
+type AccessPermission string
+
+const (
+	AccessPermissionRead  AccessPermission = "read"
+	AccessPermissionWrite AccessPermission = "write"
+	AccessPermissionAdmin AccessPermission = "admin"
+)
+
+// ParseAccessPermission converts a raw string into a known AccessPermission.
+func ParseAccessPermission(raw string) (AccessPermission, bool) {
+	switch permission := AccessPermission(strings.TrimSpace(raw)); permission {
+	case AccessPermissionRead, AccessPermissionWrite, AccessPermissionAdmin:
+		return permission, true
+	default:
+		return "", false
+	}
+}
+
+func (p AccessPermission) rank() int {
+	switch p {
+	case AccessPermissionRead:
+		return 1
+	case AccessPermissionWrite:
+		return 2
+	case AccessPermissionAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Includes reports whether p is at least as strong as required.
+func (p AccessPermission) Includes(required AccessPermission) bool {
+	return required.rank() > 0 && p.rank() >= required.rank()
+}
+
+func accessPermissionFromRank(rank int) AccessPermission {
+	switch rank {
+	case 1:
+		return AccessPermissionRead
+	case 2:
+		return AccessPermissionWrite
+	case 3:
+		return AccessPermissionAdmin
+	default:
+		return ""
+	}
+}
+
+// AccessPrincipalType identifies whether an ACL entry targets a user or a group.
+type AccessPrincipalType string
+
+const (
+	AccessPrincipalUser  AccessPrincipalType = "user"
+	AccessPrincipalGroup AccessPrincipalType = "group"
+)
+
+// AccessControlEntry grants a permission on a schema category (inherited by its sub-categories and their
+// tables) or on a single entity table.
+type AccessControlEntry struct {
+	EntryID       uuid.UUID           `db:"entry_id" json:"entryId"`
+	TenantID      string              `db:"tenant_id" json:"tenantId"`
+	PrincipalType AccessPrincipalType `db:"-" json:"principalType"`
+	PrincipalID   uuid.UUID           `db:"-" json:"principalId"`
+	CategoryID    *uuid.UUID          `db:"category_id" json:"categoryId,omitempty"`
+	TableName     *string             `db:"table_name" json:"tableName,omitempty"`
+	Permission    AccessPermission    `db:"permission" json:"permission"`
+	CreatedAt     time.Time           `db:"created_at" json:"createdAt"`
+}
+
+// AccessGroup bundles users so ACL entries can target all of them at once.
+type AccessGroup struct {
+	GroupID     uuid.UUID `db:"group_id" json:"groupId"`
+	TenantID    string    `db:"tenant_id" json:"tenantId"`
+	Name        string    `db:"name" json:"name"`
+	Description *string   `db:"description" json:"description,omitempty"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+// AccessGroupMember links a user to an access group.
+type AccessGroupMember struct {
+	GroupID   uuid.UUID `db:"group_id" json:"groupId"`
+	UserID    uuid.UUID `db:"user_id" json:"userId"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// AccessSubject identifies the caller whose access is being resolved by the user ID resolved from the
+// caller's identity (UserCredentials.UserID). Subjects without one only get what unrestricted resources
+// allow.
+type AccessSubject struct {
+	UserID string
+}
+
+// AccessDecision describes the access a subject has on a resource.
+// A resource without any applicable ACL entry is unrestricted and open to every authenticated caller.
+type AccessDecision struct {
+	Restricted bool
+	// Permission is the strongest permission granted to the subject; empty when none applies.
+	Permission AccessPermission
+}
+
+// Allows reports whether the subject may perform an operation needing the required permission.
+func (d AccessDecision) Allows(required AccessPermission) bool {
+	return !d.Restricted || d.Permission.Includes(required)
+}
+
+// Grants reports whether an ACL entry explicitly grants the subject the required permission.
+// Unlike Allows it is false on unrestricted resources.
+func (d AccessDecision) Grants(required AccessPermission) bool {
+	return d.Permission.Includes(required)
+}
+
+var (
+	ErrAccessControlEntryNotFound = errors.New("access control entry not found")
+	ErrAccessGroupNotFound        = errors.New("access group not found")
+	// ErrAccessControlConflict indicates the principal already holds a grant on the resource or the group name is taken.
+	ErrAccessControlConflict = errors.New("access control conflict")
+	// ErrAccessReferenceNotFound indicates the referenced user, group or category does not exist.
+	ErrAccessReferenceNotFound = errors.New("access control reference not found")
+)
+
+// AccessControlStore persists ACL entries and access groups.
+type AccessControlStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewAccessControlStore(ctx context.Context, pool *pgxpool.Pool) (*AccessControlStore, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+
+	return &AccessControlStore{pool: pool}, nil
+}
+
+type CreateAccessControlEntryParams struct {
+	EntryID       uuid.UUID
+	PrincipalType AccessPrincipalType
+	PrincipalID   uuid.UUID
+	CategoryID    *uuid.UUID
+	TableName     *string
+	Permission    AccessPermission
+}
+
+func (s *AccessControlStore) CreateAccessControlEntry(ctx context.Context, params CreateAccessControlEntryParams) (AccessControlEntry, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return AccessControlEntry{}, err
+	}
+	if params.EntryID == uuid.Nil {
+		return AccessControlEntry{}, errors.New("entry id is required")
+	}
+	if params.PrincipalID == uuid.Nil {
+		return AccessControlEntry{}, errors.New("principal id is required")
+	}
+	if (params.CategoryID == nil) == (params.TableName == nil) {
+		return AccessControlEntry{}, errors.New("exactly one of category id or table name is required")
+	}
+	if _, ok := ParseAccessPermission(string(params.Permission)); !ok {
+		return AccessControlEntry{}, fmt.Errorf("unsupported permission %q", params.Permission)
+	}
+
+	var userID, groupID *uuid.UUID
+	switch params.PrincipalType {
+	case AccessPrincipalUser:
+		userID = &params.PrincipalID
+	case AccessPrincipalGroup:
+		groupID = &params.PrincipalID
+	default:
+		return AccessControlEntry{}, fmt.Errorf("unsupported principal type %q", params.PrincipalType)
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		INSERT INTO access_control_entries (
+			entry_id, tenant_id, user_id, group_id, category_id, table_name, permission, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, NOW()
+		)
+		RETURNING entry_id, tenant_id, user_id, group_id, category_id, table_name, permission, created_at
+	`, params.EntryID, tenantID, userID, groupID, params.CategoryID, params.TableName, string(params.Permission))
+
+	entry, err := scanAccessControlEntry(row)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return AccessControlEntry{}, ErrAccessControlConflict
+		case isForeignKeyViolation(err):
+			return AccessControlEntry{}, ErrAccessReferenceNotFound
+		}
+		return AccessControlEntry{}, fmt.Errorf("insert access control entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *AccessControlStore) GetAccessControlEntry(ctx context.Context, entryID uuid.UUID) (AccessControlEntry, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return AccessControlEntry{}, err
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		SELECT entry_id, tenant_id, user_id, group_id, category_id, table_name, permission, created_at
+		FROM access_control_entries
+		WHERE tenant_id = $1 AND entry_id = $2
+	`, tenantID, entryID)
+
+	entry, err := scanAccessControlEntry(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return AccessControlEntry{}, ErrAccessControlEntryNotFound
+		}
+		return AccessControlEntry{}, fmt.Errorf("get access control entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+type ListAccessControlEntriesParams struct {
+	CategoryID *uuid.UUID
+	TableName  *string
+}
+
+func (s *AccessControlStore) ListAccessControlEntries(ctx context.Context, params ListAccessControlEntriesParams) ([]AccessControlEntry, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT entry_id, tenant_id, user_id, group_id, category_id, table_name, permission, created_at
+		FROM access_control_entries
+		WHERE tenant_id = $1
+		  AND ($2::uuid IS NULL OR category_id = $2)
+		  AND ($3::text IS NULL OR table_name = $3)
+		ORDER BY created_at ASC, entry_id ASC
+	`, tenantID, params.CategoryID, params.TableName)
+	if err != nil {
+		return nil, fmt.Errorf("list access control entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]AccessControlEntry, 0)
+	for rows.Next() {
+		entry, scanErr := scanAccessControlEntry(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate access control entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *AccessControlStore) DeleteAccessControlEntry(ctx context.Context, entryID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.pool.Exec(ctx, `
+		DELETE FROM access_control_entries
+		WHERE tenant_id = $1 AND entry_id = $2
+	`, tenantID, entryID)
+	if err != nil {
+		return fmt.Errorf("delete access control entry: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAccessControlEntryNotFound
+	}
+
+	return nil
+}
+
+type CreateAccessGroupParams struct {
+	GroupID     uuid.UUID
+	Name        string
+	Description *string
+}
+
+func (s *AccessControlStore) CreateAccessGroup(ctx context.Context, params CreateAccessGroupParams) (AccessGroup, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return AccessGroup{}, err
+	}
+	if params.GroupID == uuid.Nil {
+		return AccessGroup{}, errors.New("group id is required")
+	}
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return AccessGroup{}, errors.New("group name is required")
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		INSERT INTO access_groups (group_id, tenant_id, name, description, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING group_id, tenant_id, name, description, created_at
+	`, params.GroupID, tenantID, name, params.Description)
+
+	group, err := scanAccessGroup(row)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return AccessGroup{}, ErrAccessControlConflict
+		}
+		return AccessGroup{}, fmt.Errorf("insert access group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (s *AccessControlStore) GetAccessGroup(ctx context.Context, groupID uuid.UUID) (AccessGroup, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return AccessGroup{}, err
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		SELECT group_id, tenant_id, name, description, created_at
+		FROM access_groups
+		WHERE tenant_id = $1 AND group_id = $2
+	`, tenantID, groupID)
+
+	group, err := scanAccessGroup(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return AccessGroup{}, ErrAccessGroupNotFound
+		}
+		return AccessGroup{}, fmt.Errorf("get access group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (s *AccessControlStore) ListAccessGroups(ctx context.Context) ([]AccessGroup, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT group_id, tenant_id, name, description, created_at
+		FROM access_groups
+		WHERE tenant_id = $1
+		ORDER BY LOWER(name) ASC
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list access groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make([]AccessGroup, 0)
+	for rows.Next() {
+		group, scanErr := scanAccessGroup(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		groups = append(groups, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate access groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// DeleteAccessGroup removes the group; memberships and ACL entries targeting it cascade.
+func (s *AccessControlStore) DeleteAccessGroup(ctx context.Context, groupID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.pool.Exec(ctx, `
+		DELETE FROM access_groups
+		WHERE tenant_id = $1 AND group_id = $2
+	`, tenantID, groupID)
+	if err != nil {
+		return fmt.Errorf("delete access group: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAccessGroupNotFound
+	}
+
+	return nil
+}
+
+// AddAccessGroupMember adds the user to the group; adding an existing member is a no-op.
+func (s *AccessControlStore) AddAccessGroupMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.pool.Exec(ctx, `
+		INSERT INTO access_group_members (tenant_id, group_id, user_id, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant_id, group_id, user_id) DO NOTHING
+	`, tenantID, groupID, userID); err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrAccessReferenceNotFound
+		}
+		return fmt.Errorf("add access group member: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AccessControlStore) RemoveAccessGroupMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.pool.Exec(ctx, `
+		DELETE FROM access_group_members
+		WHERE tenant_id = $1 AND group_id = $2 AND user_id = $3
+	`, tenantID, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("remove access group member: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAccessReferenceNotFound
+	}
+
+	return nil
+}
+
+func (s *AccessControlStore) ListAccessGroupMembers(ctx context.Context, groupID uuid.UUID) ([]AccessGroupMember, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT group_id, user_id, created_at
+		FROM access_group_members
+		WHERE tenant_id = $1 AND group_id = $2
+		ORDER BY created_at ASC, user_id ASC
+	`, tenantID, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("list access group members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]AccessGroupMember, 0)
+	for rows.Next() {
+		var member AccessGroupMember
+		if scanErr := rows.Scan(&member.GroupID, &member.UserID, &member.CreatedAt); scanErr != nil {
+			return nil, scanErr
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate access group members: %w", err)
+	}
+
+	return members, nil
+}
+
+// accessSubjectCTE resolves the caller ($2 user id) and the groups they belong to in tenant $1.
+const accessSubjectCTE = `
+	subject_users AS (
+		SELECT user_id
+		FROM users
+		WHERE user_id::text = $2
+	),
+	subject_groups AS (
+		SELECT m.group_id
+		FROM access_group_members m
+		JOIN subject_users u ON u.user_id = m.user_id
+		WHERE m.tenant_id = $1
+	)`
+
+// accessRankExpr ranks an applicable entry for the subject: 0 when it targets someone else.
+const accessRankExpr = `
+	CASE
+		WHEN e.user_id IN (SELECT user_id FROM subject_users) OR e.group_id IN (SELECT group_id FROM subject_groups)
+		THEN CASE e.permission WHEN 'admin' THEN 3 WHEN 'write' THEN 2 WHEN 'read' THEN 1 ELSE 0 END
+		ELSE 0
+	END`
+
+// maxCategoryDepth bounds the ancestor walk in case a category tree contains a cycle.
+const maxCategoryDepth = 64
+
+// ResolveTableAccess computes the access decision for each table. Entries on the table itself and on the
+// category of its schema (and every ancestor category) apply; the strongest grant held by the subject,
+// directly or through a group, wins. Tables without applicable entries are unrestricted.
+func (s *AccessControlStore) ResolveTableAccess(ctx context.Context, subject AccessSubject, tableNames []string) (map[string]AccessDecision, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := make(map[string]AccessDecision, len(tableNames))
+	for _, tableName := range tableNames {
+		decisions[tableName] = AccessDecision{}
+	}
+	if len(tableNames) == 0 {
+		return decisions, nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		WITH RECURSIVE`+accessSubjectCTE+`,
+		targets AS (
+			SELECT t.table_name, (
+				SELECT sr.category_id
+				FROM schema_repository sr
+				WHERE sr.tenant_id = $1 AND sr.table_name = t.table_name AND NOT sr.is_soft_deleted
+				ORDER BY sr.is_active DESC, sr.created_at DESC
+				LIMIT 1
+			) AS category_id
+			FROM unnest($3::text[]) AS t(table_name)
+		),
+		ancestors AS (
+			SELECT targets.table_name, c.category_id, c.parent_category_id, 1 AS depth
+			FROM targets
+			JOIN schema_categories c ON c.tenant_id = $1 AND c.category_id = targets.category_id
+			UNION ALL
+			SELECT a.table_name, c.category_id, c.parent_category_id, a.depth + 1
+			FROM ancestors a
+			JOIN schema_categories c ON c.tenant_id = $1 AND c.category_id = a.parent_category_id
+			WHERE a.depth < $4
+		),
+		applicable AS (
+			SELECT targets.table_name, `+accessRankExpr+` AS rank
+			FROM targets
+			JOIN access_control_entries e ON e.tenant_id = $1 AND e.table_name = targets.table_name
+			UNION ALL
+			SELECT a.table_name, `+accessRankExpr+` AS rank
+			FROM ancestors a
+			JOIN access_control_entries e ON e.tenant_id = $1 AND e.category_id = a.category_id
+		)
+		SELECT table_name, MAX(rank)
+		FROM applicable
+		GROUP BY table_name
+	`, tenantID, subject.UserID, tableNames, maxCategoryDepth)
+	if err != nil {
+		return nil, fmt.Errorf("resolve table access: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			tableName string
+			rank      int
+		)
+		if scanErr := rows.Scan(&tableName, &rank); scanErr != nil {
+			return nil, scanErr
+		}
+		decisions[tableName] = AccessDecision{Restricted: true, Permission: accessPermissionFromRank(rank)}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate table access: %w", err)
+	}
+
+	return decisions, nil
+}
+
+// ResolveCategoryAccess computes the access decision for a category from the entries on it and its ancestors.
+func (s *AccessControlStore) ResolveCategoryAccess(ctx context.Context, subject AccessSubject, categoryID uuid.UUID) (AccessDecision, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return AccessDecision{}, err
+	}
+
+	var (
+		entries int
+		rank    int
+	)
+	if err = s.pool.QueryRow(ctx, `
+		WITH RECURSIVE`+accessSubjectCTE+`,
+		ancestors AS (
+			SELECT c.category_id, c.parent_category_id, 1 AS depth
+			FROM schema_categories c
+			WHERE c.tenant_id = $1 AND c.category_id = $3
+			UNION ALL
+			SELECT c.category_id, c.parent_category_id, a.depth + 1
+			FROM ancestors a
+			JOIN schema_categories c ON c.tenant_id = $1 AND c.category_id = a.parent_category_id
+			WHERE a.depth < $4
+		)
+		SELECT COUNT(e.entry_id), COALESCE(MAX(`+accessRankExpr+`), 0)
+		FROM ancestors a
+		JOIN access_control_entries e ON e.tenant_id = $1 AND e.category_id = a.category_id
+	`, tenantID, subject.UserID, categoryID, maxCategoryDepth).Scan(&entries, &rank); err != nil {
+		return AccessDecision{}, fmt.Errorf("resolve category access: %w", err)
+	}
+
+	return AccessDecision{Restricted: entries > 0, Permission: accessPermissionFromRank(rank)}, nil
+}
+
+func scanAccessControlEntry(scanner rowScanner) (AccessControlEntry, error) {
+	var (
+		entry      AccessControlEntry
+		userID     pgtype.UUID
+		groupID    pgtype.UUID
+		categoryID pgtype.UUID
+		tableName  pgtype.Text
+		permission string
+	)
+
+	if err := scanner.Scan(&entry.EntryID, &entry.TenantID, &userID, &groupID, &categoryID, &tableName, &permission, &entry.CreatedAt); err != nil {
+		return AccessControlEntry{}, err
+	}
+
+	switch {
+	case userID.Valid:
+		entry.PrincipalType = AccessPrincipalUser
+		entry.PrincipalID = uuid.UUID(userID.Bytes)
+	case groupID.Valid:
+		entry.PrincipalType = AccessPrincipalGroup
+		entry.PrincipalID = uuid.UUID(groupID.Bytes)
+	}
+
+	if categoryID.Valid {
+		id := uuid.UUID(categoryID.Bytes)
+		entry.CategoryID = &id
+	}
+
+	if tableName.Valid {
+		name := tableName.String
+		entry.TableName = &name
+	}
+
+	entry.Permission = AccessPermission(permission)
+	return entry, nil
+}
+
+func scanAccessGroup(scanner rowScanner) (AccessGroup, error) {
+	var (
+		group       AccessGroup
+		description pgtype.Text
+	)
+
+	if err := scanner.Scan(&group.GroupID, &group.TenantID, &group.Name, &description, &group.CreatedAt); err != nil {
+		return AccessGroup{}, err
+	}
+
+	if description.Valid {
+		desc := description.String
+		group.Description = &desc
+	}
+
+	return group, nil
+}
@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+func TestAccessControlStoreIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping persistence integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := NewPool(ctx, PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ClosePool(pool)
+	})
+
+	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
+
+	ctx = tenant.WithID(ctx, "tenant-a")
+
+	categoryStore, err := NewSchemaCategoryStore(ctx, pool)
+	require.NoError(t, err)
+	schemaStore, err := NewSchemaRepositoryStore(ctx, pool)
+	require.NoError(t, err)
+	userStore, err := NewUserStore(ctx, pool)
+	require.NoError(t, err)
+	store, err := NewAccessControlStore(ctx, pool)
+	require.NoError(t, err)
+
+	rootID := uuid.New()
+	childID := uuid.New()
+	_, err = categoryStore.CreateSchemaCategory(ctx, CreateSchemaCategoryParams{CategoryID: rootID, Name: "internal", Slug: "internal"})
+	require.NoError(t, err)
+	_, err = categoryStore.CreateSchemaCategory(ctx, CreateSchemaCategoryParams{CategoryID: childID, ParentCategoryID: &rootID, Name: "pricing", Slug: "pricing"})
+	require.NoError(t, err)
+
+	_, err = schemaStore.CreateOrUpdateSchema(ctx, CreateSchemaParams{
+		SchemaID:   uuid.New(),
+		Version:    SemanticVersion{Major: 1},
+		Definition: SchemaDefinition(`{"type":"object"}`),
+		TableName:  "price_entities",
+		Slug:       "prices",
+		CategoryID: childID,
+		Activate:   true,
+	})
+	require.NoError(t, err)
+
+	reader, err := userStore.CreateUser(ctx, CreateUserParams{UserID: uuid.New(), Email: "reader@example.com", FullName: "Reader"})
+	require.NoError(t, err)
+	writer, err := userStore.CreateUser(ctx, CreateUserParams{UserID: uuid.New(), Email: "writer@example.com", FullName: "Writer"})
+	require.NoError(t, err)
+	outsider := AccessSubject{UserID: uuid.NewString()}
+
+	decisions, err := store.ResolveTableAccess(ctx, outsider, []string{"price_entities"})
+	require.NoError(t, err)
+	require.False(t, decisions["price_entities"].Restricted)
+
+	// Category grants are inherited by sub-categories and their tables.
+	_, err = store.CreateAccessControlEntry(ctx, CreateAccessControlEntryParams{
+		EntryID:       uuid.New(),
+		PrincipalType: AccessPrincipalUser,
+		PrincipalID:   reader.UserID,
+		CategoryID:    &rootID,
+		Permission:    AccessPermissionRead,
+	})
+	require.NoError(t, err)
+
+	group, err := store.CreateAccessGroup(ctx, CreateAccessGroupParams{GroupID: uuid.New(), Name: "Pricing team"})
+	require.NoError(t, err)
+	require.NoError(t, store.AddAccessGroupMember(ctx, group.GroupID, writer.UserID))
+	require.NoError(t, store.AddAccessGroupMember(ctx, group.GroupID, writer.UserID))
+
+	tableName := "price_entities"
+	groupEntry, err := store.CreateAccessControlEntry(ctx, CreateAccessControlEntryParams{
+		EntryID:       uuid.New(),
+		PrincipalType: AccessPrincipalGroup,
+		PrincipalID:   group.GroupID,
+		TableName:     &tableName,
+		Permission:    AccessPermissionWrite,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccessPrincipalGroup, groupEntry.PrincipalType)
+
+	_, err = store.CreateAccessControlEntry(ctx, CreateAccessControlEntryParams{
+		EntryID:       uuid.New(),
+		PrincipalType: AccessPrincipalGroup,
+		PrincipalID:   group.GroupID,
+		TableName:     &tableName,
+		Permission:    AccessPermissionRead,
+	})
+	require.ErrorIs(t, err, ErrAccessControlConflict)
+
+	_, err = store.CreateAccessControlEntry(ctx, CreateAccessControlEntryParams{
+		EntryID:       uuid.New(),
+		PrincipalType: AccessPrincipalUser,
+		PrincipalID:   uuid.New(),
+		TableName:     &tableName,
+		Permission:    AccessPermissionRead,
+	})
+	require.ErrorIs(t, err, ErrAccessReferenceNotFound)
+
+	decisions, err = store.ResolveTableAccess(ctx, outsider, []string{"price_entities", "open_entities"})
+	require.NoError(t, err)
+	require.True(t, decisions["price_entities"].Restricted)
+	require.False(t, decisions["price_entities"].Allows(AccessPermissionRead))
+	require.True(t, decisions["open_entities"].Allows(AccessPermissionWrite))
+
+	decisions, err = store.ResolveTableAccess(ctx, AccessSubject{UserID: reader.UserID.String()}, []string{"price_entities"})
+	require.NoError(t, err)
+	require.Equal(t, AccessPermissionRead, decisions["price_entities"].Permission)
+	require.False(t, decisions["price_entities"].Allows(AccessPermissionWrite))
+
+	decisions, err = store.ResolveTableAccess(ctx, AccessSubject{UserID: writer.UserID.String()}, []string{"price_entities"})
+	require.NoError(t, err)
+	require.Equal(t, AccessPermissionWrite, decisions["price_entities"].Permission)
+
+	categoryDecision, err := store.ResolveCategoryAccess(ctx, AccessSubject{UserID: reader.UserID.String()}, childID)
+	require.NoError(t, err)
+	require.True(t, categoryDecision.Restricted)
+	require.True(t, categoryDecision.Grants(AccessPermissionRead))
+	require.False(t, categoryDecision.Grants(AccessPermissionAdmin))
+
+	entries, err := store.ListAccessControlEntries(ctx, ListAccessControlEntriesParams{TableName: &tableName})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// Entries and groups never leak across tenants.
+	otherCtx := tenant.WithID(ctx, "tenant-b")
+	entries, err = store.ListAccessControlEntries(otherCtx, ListAccessControlEntriesParams{})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	_, err = store.GetAccessGroup(otherCtx, group.GroupID)
+	require.ErrorIs(t, err, ErrAccessGroupNotFound)
+
+	// Deleting the group cascades to its memberships and entries.
+	require.NoError(t, store.DeleteAccessGroup(ctx, group.GroupID))
+	_, err = store.GetAccessControlEntry(ctx, groupEntry.EntryID)
+	require.ErrorIs(t, err, ErrAccessControlEntryNotFound)
+
+	decisions, err = store.ResolveTableAccess(ctx, AccessSubject{UserID: writer.UserID.String()}, []string{"price_entities"})
+	require.NoError(t, err)
+	require.True(t, decisions["price_entities"].Restricted)
+	require.False(t, decisions["price_entities"].Allows(AccessPermissionRead))
+}
@@ -0,0 +1,273 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuthSession is a login session of the local identity provider. Every refresh token belongs to one
+// session; revoking the session invalidates its access and refresh tokens.
+type AuthSession struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+var (
+	// ErrAuthCredentialNotFound indicates the email has no local password credential.
+	ErrAuthCredentialNotFound = errors.New("auth credential not found")
+	// ErrRefreshTokenNotFound indicates an unknown refresh token.
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	// ErrRefreshTokenReused indicates a rotated refresh token was presented again; its session is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrAuthSessionInactive indicates the session was revoked or has expired.
+	ErrAuthSessionInactive = errors.New("auth session inactive")
+)
+
+// AuthStore persists local credentials, sessions and refresh tokens. Users are global, so these
+// tables are not tenant-scoped.
+type AuthStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuthStore returns a store backed by the provided pool.
+func NewAuthStore(ctx context.Context, pool *pgxpool.Pool) (*AuthStore, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+
+	return &AuthStore{pool: pool}, nil
+}
+
+// CreateUserWithPassword inserts the user and its password credential in one transaction.
+func (s *AuthStore) CreateUserWithPassword(ctx context.Context, params CreateUserParams, passwordHash string) (User, error) {
+	if passwordHash == "" {
+		return User{}, errors.New("password hash is required")
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return User{}, fmt.Errorf("begin signup tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	user, err := insertUser(ctx, tx, params)
+	if err != nil {
+		return User{}, err
+	}
+
+	if _, err = tx.Exec(ctx, `
+        INSERT INTO auth_credentials (user_id, password_hash)
+        VALUES ($1, $2)
+    `, user.UserID, passwordHash); err != nil {
+		return User{}, fmt.Errorf("insert credential: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return User{}, fmt.Errorf("commit signup tx: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetPasswordCredential returns the user matching email (case-insensitive) with its password hash.
+func (s *AuthStore) GetPasswordCredential(ctx context.Context, email string) (User, string, error) {
+	row := s.pool.QueryRow(ctx, fmt.Sprintf(`
+        SELECT %s, c.password_hash
+        FROM %s u
+        JOIN auth_credentials c ON c.user_id = u.user_id
+        WHERE LOWER(u.email) = LOWER($1)
+    `, qualifiedUserColumns("u"), UsersTable), strings.TrimSpace(email))
+
+	var (
+		user User
+		hash string
+	)
+	if err := row.Scan(
+		&user.UserID,
+		&user.Email,
+		&user.FullName,
+		&user.Roles,
+		&user.Status,
+		&user.StatusReason,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&hash,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, "", ErrAuthCredentialNotFound
+		}
+		return User{}, "", err
+	}
+
+	return user, hash, nil
+}
+
+// CreateAuthSessionParams captures a new login session and its first refresh token.
+type CreateAuthSessionParams struct {
+	SessionID        uuid.UUID
+	UserID           uuid.UUID
+	ExpiresAt        time.Time
+	RefreshTokenHash string
+}
+
+// CreateSession opens a login session and stores its first refresh token.
+func (s *AuthStore) CreateSession(ctx context.Context, params CreateAuthSessionParams) (AuthSession, error) {
+	if params.SessionID == uuid.Nil || params.UserID == uuid.Nil {
+		return AuthSession{}, errors.New("session and user ids are required")
+	}
+	if params.RefreshTokenHash == "" {
+		return AuthSession{}, errors.New("refresh token hash is required")
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return AuthSession{}, fmt.Errorf("begin session tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	session, err := scanAuthSession(tx.QueryRow(ctx, `
+        INSERT INTO auth_sessions (session_id, user_id, expires_at)
+        VALUES ($1, $2, $3)
+        RETURNING session_id, user_id, created_at, expires_at, revoked_at
+    `, params.SessionID, params.UserID, params.ExpiresAt))
+	if err != nil {
+		return AuthSession{}, fmt.Errorf("insert session: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, `
+        INSERT INTO auth_refresh_tokens (token_hash, session_id)
+        VALUES ($1, $2)
+    `, params.RefreshTokenHash, session.SessionID); err != nil {
+		return AuthSession{}, fmt.Errorf("insert refresh token: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return AuthSession{}, fmt.Errorf("commit session tx: %w", err)
+	}
+
+	return session, nil
+}
+
+// RotateRefreshToken consumes the refresh token identified by tokenHash and stores newTokenHash in its
+// place. Presenting an already consumed token revokes the whole session and returns ErrRefreshTokenReused,
+// so a stolen token can be used at most once before the legitimate client notices.
+func (s *AuthStore) RotateRefreshToken(ctx context.Context, tokenHash, newTokenHash string) (AuthSession, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return AuthSession{}, fmt.Errorf("begin refresh tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var (
+		session AuthSession
+		usedAt  *time.Time
+	)
+	err = tx.QueryRow(ctx, `
+        SELECT s.session_id, s.user_id, s.created_at, s.expires_at, s.revoked_at, t.used_at
+        FROM auth_refresh_tokens t
+        JOIN auth_sessions s ON s.session_id = t.session_id
+        WHERE t.token_hash = $1
+        FOR UPDATE OF t, s
+    `, tokenHash).Scan(&session.SessionID, &session.UserID, &session.CreatedAt, &session.ExpiresAt, &session.RevokedAt, &usedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return AuthSession{}, ErrRefreshTokenNotFound
+		}
+		return AuthSession{}, fmt.Errorf("load refresh token: %w", err)
+	}
+
+	if usedAt != nil {
+		if _, err = tx.Exec(ctx, `
+            UPDATE auth_sessions SET revoked_at = NOW()
+            WHERE session_id = $1 AND revoked_at IS NULL
+        `, session.SessionID); err != nil {
+			return AuthSession{}, fmt.Errorf("revoke reused session: %w", err)
+		}
+		if err = tx.Commit(ctx); err != nil {
+			return AuthSession{}, fmt.Errorf("commit refresh tx: %w", err)
+		}
+		return AuthSession{}, ErrRefreshTokenReused
+	}
+
+	if session.RevokedAt != nil || !session.ExpiresAt.After(time.Now()) {
+		return AuthSession{}, ErrAuthSessionInactive
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE auth_refresh_tokens SET used_at = NOW() WHERE token_hash = $1`, tokenHash); err != nil {
+		return AuthSession{}, fmt.Errorf("consume refresh token: %w", err)
+	}
+	if _, err = tx.Exec(ctx, `
+        INSERT INTO auth_refresh_tokens (token_hash, session_id)
+        VALUES ($1, $2)
+    `, newTokenHash, session.SessionID); err != nil {
+		return AuthSession{}, fmt.Errorf("insert refresh token: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return AuthSession{}, fmt.Errorf("commit refresh tx: %w", err)
+	}
+
+	return session, nil
+}
+
+// RevokeSession marks the session revoked. Revoking an already revoked session is a no-op;
+// unknown sessions return ErrAuthSessionInactive.
+func (s *AuthStore) RevokeSession(ctx context.Context, sessionID, userID uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `
+        UPDATE auth_sessions SET revoked_at = COALESCE(revoked_at, NOW())
+        WHERE session_id = $1 AND user_id = $2
+    `, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAuthSessionInactive
+	}
+	return nil
+}
+
+// IsSessionActive reports whether the session exists, is not revoked and has not expired.
+func (s *AuthStore) IsSessionActive(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	var active bool
+	if err := s.pool.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM auth_sessions
+            WHERE session_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+        )
+    `, sessionID).Scan(&active); err != nil {
+		return false, fmt.Errorf("check session: %w", err)
+	}
+	return active, nil
+}
+
+func scanAuthSession(row pgx.Row) (AuthSession, error) {
+	var session AuthSession
+	if err := row.Scan(&session.SessionID, &session.UserID, &session.CreatedAt, &session.ExpiresAt, &session.RevokedAt); err != nil {
+		return AuthSession{}, err
+	}
+	return session, nil
+}
+
+func qualifiedUserColumns(alias string) string {
+	columns := strings.Split(userColumns, ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
@@ -0,0 +1,110 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestAuthStoreIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping persistence integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := NewPool(ctx, PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ClosePool(pool)
+	})
+
+	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
+
+	store, err := NewAuthStore(ctx, pool)
+	require.NoError(t, err)
+
+	user, err := store.CreateUserWithPassword(ctx, CreateUserParams{UserID: uuid.New(), Email: "signup@example.com", FullName: "Signup"}, "hash-1")
+	require.NoError(t, err)
+	require.Equal(t, DefaultUserStatus, user.Status)
+
+	_, err = store.CreateUserWithPassword(ctx, CreateUserParams{UserID: uuid.New(), Email: "SIGNUP@example.com", FullName: "Again"}, "hash-2")
+	require.ErrorIs(t, err, ErrUserConflict)
+
+	found, hash, err := store.GetPasswordCredential(ctx, "Signup@Example.com")
+	require.NoError(t, err)
+	require.Equal(t, user.UserID, found.UserID)
+	require.Equal(t, "hash-1", hash)
+
+	_, _, err = store.GetPasswordCredential(ctx, "missing@example.com")
+	require.ErrorIs(t, err, ErrAuthCredentialNotFound)
+
+	session, err := store.CreateSession(ctx, CreateAuthSessionParams{
+		SessionID:        uuid.New(),
+		UserID:           user.UserID,
+		ExpiresAt:        time.Now().Add(time.Hour),
+		RefreshTokenHash: "refresh-1",
+	})
+	require.NoError(t, err)
+
+	active, err := store.IsSessionActive(ctx, session.SessionID)
+	require.NoError(t, err)
+	require.True(t, active)
+
+	rotated, err := store.RotateRefreshToken(ctx, "refresh-1", "refresh-2")
+	require.NoError(t, err)
+	require.Equal(t, session.SessionID, rotated.SessionID)
+
+	_, err = store.RotateRefreshToken(ctx, "unknown", "refresh-x")
+	require.ErrorIs(t, err, ErrRefreshTokenNotFound)
+
+	// Replaying a consumed token revokes the session, so the current token stops working too.
+	_, err = store.RotateRefreshToken(ctx, "refresh-1", "refresh-3")
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	active, err = store.IsSessionActive(ctx, session.SessionID)
+	require.NoError(t, err)
+	require.False(t, active)
+
+	_, err = store.RotateRefreshToken(ctx, "refresh-2", "refresh-4")
+	require.ErrorIs(t, err, ErrAuthSessionInactive)
+
+	second, err := store.CreateSession(ctx, CreateAuthSessionParams{
+		SessionID:        uuid.New(),
+		UserID:           user.UserID,
+		ExpiresAt:        time.Now().Add(time.Hour),
+		RefreshTokenHash: "refresh-5",
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, store.RevokeSession(ctx, second.SessionID, uuid.New()), ErrAuthSessionInactive)
+	require.NoError(t, store.RevokeSession(ctx, second.SessionID, user.UserID))
+	require.NoError(t, store.RevokeSession(ctx, second.SessionID, user.UserID))
+
+	_, err = store.RotateRefreshToken(ctx, "refresh-5", "refresh-6")
+	require.ErrorIs(t, err, ErrAuthSessionInactive)
+}
@@ -2,11 +2,17 @@ package persistence
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
-const uniqueViolationCode = "23505"
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+	duplicateObjectCode     = "42710"
+	deadlockDetectedCode    = "40P01"
+)
 
 func isUniqueViolation(err error) bool {
 	var pgErr *pgconn.PgError
@@ -15,3 +21,38 @@ func isUniqueViolation(err error) bool {
 	}
 	return false
 }
+
+// isSlugViolation reports a clash with the unique index on the active slugs of an entity table.
+func isSlugViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == uniqueViolationCode && strings.HasSuffix(pgErr.ConstraintName, "_slug_active_idx")
+	}
+	return false
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == foreignKeyViolationCode
+	}
+	return false
+}
+
+// isDuplicateObject reports concurrent creation of the same policy/index by another connection.
+func isDuplicateObject(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == duplicateObjectCode
+	}
+	return false
+}
+
+// isDeadlock reports a transaction Postgres aborted to break a lock cycle; it can be retried.
+func isDeadlock(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == deadlockDetectedCode
+	}
+	return false
+}
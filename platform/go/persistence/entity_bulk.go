@@ -0,0 +1,520 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// bulkConflictAttempts bounds how often a batch is retried after losing a race with a concurrent writer,
+// e.g. another batch creating the same entity first.
+const bulkConflictAttempts = 3
+
+// bulkStagingTable is the per-transaction table the new versions of a batch are copied into.
+const bulkStagingTable = "entity_bulk_staging"
+
+// BulkEntity is one document written by BulkUpsertEntities.
+type BulkEntity struct {
+	EntityID string  // generated when empty
+	Slug     *string // see CreateOrUpdateEntityParams.Slug
+	Payload  SchemaDefinition
+}
+
+// BulkEntityResult reports what BulkUpsertEntities did with the entity at the same index.
+type BulkEntityResult struct {
+	EntityID string        // normalized; empty when the identifier was invalid
+	Outcome  UpsertOutcome // empty when Err is set
+	Err      error         // why the entity was not written, e.g. a schema validation error or ErrSlugTaken
+}
+
+// bulkItem is a BulkEntity checked and prepared outside the write transaction.
+type bulkItem struct {
+	entityID string
+	slug     string // the normalized explicit slug
+	base     string // the slug rendered from the template, when no slug was given
+	payload  []byte
+	err      error
+}
+
+// bulkRow is a new entity version copied into the staging table.
+type bulkRow struct {
+	entityID    string
+	version     SemanticVersion
+	slug        string
+	payload     []byte
+	active      bool
+	replaces    *string // the active version this row supersedes
+	retiredSlug *string // the slug the entity leaves with this row
+}
+
+// BulkUpsertEntities is UpsertEntity for many entities at once. Payloads are validated against the active
+// schema in parallel; the batch is then written in one transaction by copying the new versions into a
+// staging table and deactivating, inserting and recording slug history with one statement each.
+// Entities are applied in order, so an identifier repeated in the batch gets a version per occurrence.
+// Problems with single entities are reported in their result; the error is reserved for failures of the
+// whole batch, which then writes nothing.
+func (r *EntityRepository) BulkUpsertEntities(ctx context.Context, entities []BulkEntity) ([]BulkEntityResult, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, nil
+	}
+
+	schema, err := r.resolveSchema(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	template, err := r.slugTemplate(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	items := r.prepareBulk(ctx, schema, template, entities)
+	for attempt := 1; ; attempt++ {
+		results, existing, err := r.writeBulk(ctx, schema, template, items)
+		if err == nil {
+			for i, result := range results {
+				if result.Outcome != UpsertUnchanged {
+					r.observeWrite(bulkOperation(result, existing[i]), result.Err)
+				}
+			}
+			return results, nil
+		}
+		if attempt < bulkConflictAttempts && (isUniqueViolation(err) || isDeadlock(err)) && ctx.Err() == nil {
+			continue
+		}
+		for i := range items {
+			r.observeWrite(bulkOperation(BulkEntityResult{}, existing[i]), err)
+		}
+		return nil, err
+	}
+}
+
+// ValidateEntities runs the checks of BulkUpsertEntities that need no database access, such as validation
+// against the active schema and slug derivation, and writes nothing. Results carry no Outcome; problems
+// that depend on stored entities, like slug collisions, are not found.
+func (r *EntityRepository) ValidateEntities(ctx context.Context, entities []BulkEntity) ([]BulkEntityResult, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, nil
+	}
+
+	schema, err := r.resolveSchema(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	template, err := r.slugTemplate(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	items := r.prepareBulk(ctx, schema, template, entities)
+	results := make([]BulkEntityResult, len(items))
+	for i, item := range items {
+		results[i] = BulkEntityResult{EntityID: item.entityID, Err: item.err}
+	}
+	return results, nil
+}
+
+// prepareBulk normalizes and validates entities, spreading validation over the available CPUs.
+func (r *EntityRepository) prepareBulk(ctx context.Context, schema SchemaRecord, template *SlugTemplate, entities []BulkEntity) []bulkItem {
+	items := make([]bulkItem, len(entities))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(entities)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				items[i] = r.prepareBulkItem(ctx, schema, template, entities[i])
+			}
+		}()
+	}
+	for i := range entities {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return items
+}
+
+func (r *EntityRepository) prepareBulkItem(ctx context.Context, schema SchemaRecord, template *SlugTemplate, entity BulkEntity) bulkItem {
+	item := bulkItem{payload: entity.Payload}
+	var err error
+	if strings.TrimSpace(entity.EntityID) == "" {
+		item.entityID = uuid.NewString()
+	} else if item.entityID, err = NormalizeEntityIdentifier(entity.EntityID); err != nil {
+		item.err = err
+		return item
+	}
+
+	if len(entity.Payload) == 0 {
+		item.err = errors.New("payload is required")
+		return item
+	}
+	if err := r.validator.Validate(ctx, schema, entity.Payload); err != nil {
+		item.err = err
+		return item
+	}
+
+	switch {
+	case entity.Slug != nil:
+		item.slug, item.err = NormalizeSlug(*entity.Slug)
+	case template != nil:
+		item.base, item.err = template.RenderJSON(entity.Payload)
+	}
+	return item
+}
+
+// writeBulk writes the prepared items in one transaction. existing reports, per item, whether the entity
+// had an active version before the batch; it is filled in as far as the transaction got.
+func (r *EntityRepository) writeBulk(ctx context.Context, schema SchemaRecord, template *SlugTemplate, items []bulkItem) (results []BulkEntityResult, existing []bool, err error) {
+	existing = make([]bool, len(items))
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, existing, fmt.Errorf("begin bulk tx: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.entityID != "" {
+			ids = append(ids, item.entityID)
+		}
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	// Lock the active versions in a stable order so batches sharing entities queue rather than deadlock.
+	activeSelect := fmt.Sprintf(`
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		FROM %s
+		WHERE tenant_id = $1 AND entity_id = ANY($2) AND is_active = TRUE AND is_soft_deleted = FALSE
+		ORDER BY entity_id
+		FOR UPDATE
+	`, r.tableIdent)
+	rows, err := tx.Query(ctx, activeSelect, r.tenantID, ids)
+	if err != nil {
+		return nil, existing, fmt.Errorf("fetch active entities: %w", err)
+	}
+	active, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (EntityRecord, error) {
+		return scanEntityRecord(row)
+	})
+	if err != nil {
+		return nil, existing, fmt.Errorf("fetch active entities: %w", err)
+	}
+	latest := make(map[string]*EntityRecord, len(active))
+	for i := range active {
+		latest[active[i].EntityID] = &active[i]
+	}
+	for i, item := range items {
+		_, existing[i] = latest[item.entityID]
+	}
+
+	slugs, err := r.newBulkSlugs(ctx, tx, items)
+	if err != nil {
+		return nil, existing, err
+	}
+
+	results = make([]BulkEntityResult, len(items))
+	var staged []bulkRow
+	lastRow := make(map[string]int) // entity ID -> index of its newest staged row
+	superseded := make(map[string]bool)
+	for i, item := range items {
+		results[i].EntityID = item.entityID
+		if item.err != nil {
+			results[i].Err = item.err
+			continue
+		}
+
+		current, exists := latest[item.entityID]
+		row := bulkRow{entityID: item.entityID, payload: item.payload, active: true}
+		if !exists {
+			switch {
+			case item.slug != "":
+				if !slugs.free(item.slug, item.entityID) {
+					results[i].Err = ErrSlugTaken
+					continue
+				}
+				row.slug = item.slug
+			case item.base != "":
+				if row.slug, err = slugs.allocate(ctx, item.entityID, item.base); err != nil {
+					return nil, existing, err
+				}
+			default:
+				results[i].Err = errors.New("slug is required")
+				continue
+			}
+			row.version = SemanticVersion{Major: 1, Minor: 0, Patch: 0}
+			superseded[item.entityID] = true // later occurrences in the batch replace staged rows only
+			results[i].Outcome = UpsertCreated
+		} else {
+			row.slug = current.Slug
+			switch {
+			case item.slug != "":
+				if !slugs.free(item.slug, item.entityID) {
+					results[i].Err = ErrSlugTaken
+					continue
+				}
+				row.slug = item.slug
+			case item.base != "" && template.OnUpdate && !hasSlugBase(current.Slug, item.base):
+				if row.slug, err = slugs.allocate(ctx, item.entityID, item.base); err != nil {
+					return nil, existing, err
+				}
+			}
+			if row.slug == current.Slug && current.SchemaVersion == schema.SchemaVersion && jsonEqual(current.Payload, item.payload) {
+				results[i].Outcome = UpsertUnchanged
+				continue
+			}
+			row.version = current.EntityVersion.NextPatch()
+			if !superseded[item.entityID] {
+				replaces := current.EntityVersion.String()
+				row.replaces = &replaces
+				superseded[item.entityID] = true
+			}
+			if row.slug != current.Slug {
+				retired := current.Slug
+				row.retiredSlug = &retired
+				slugs.release(current.Slug, item.entityID)
+			}
+			results[i].Outcome = UpsertUpdated
+		}
+
+		slugs.take(row.slug, item.entityID)
+		if previous, ok := lastRow[item.entityID]; ok {
+			staged[previous].active = false
+		}
+		lastRow[item.entityID] = len(staged)
+		staged = append(staged, row)
+
+		latest[item.entityID] = &EntityRecord{
+			EntityID:      item.entityID,
+			EntityVersion: row.version,
+			SchemaVersion: schema.SchemaVersion,
+			Slug:          row.slug,
+			Payload:       item.payload,
+		}
+	}
+
+	if len(staged) > 0 {
+		if err := r.flushBulkRows(ctx, tx, schema, staged); err != nil {
+			return nil, existing, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, existing, fmt.Errorf("commit bulk tx: %w", err)
+	}
+	return results, existing, nil
+}
+
+// flushBulkRows copies rows into the staging table and applies them to the entity table.
+func (r *EntityRepository) flushBulkRows(ctx context.Context, tx pgx.Tx, schema SchemaRecord, rows []bulkRow) error {
+	if _, err := tx.Exec(ctx, `
+		CREATE TEMP TABLE `+bulkStagingTable+` (
+			ord INTEGER NOT NULL,
+			entity_id TEXT NOT NULL,
+			entity_version TEXT NOT NULL,
+			slug TEXT NOT NULL,
+			payload JSONB NOT NULL,
+			is_active BOOLEAN NOT NULL,
+			replaces TEXT,
+			retired_slug TEXT
+		) ON COMMIT DROP
+	`); err != nil {
+		return fmt.Errorf("create staging table: %w", err)
+	}
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{bulkStagingTable},
+		[]string{"ord", "entity_id", "entity_version", "slug", "payload", "is_active", "replaces", "retired_slug"},
+		pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+			row := rows[i]
+			return []any{i, row.entityID, row.version.String(), row.slug, row.payload, row.active, row.replaces, row.retiredSlug}, nil
+		}),
+	); err != nil {
+		return fmt.Errorf("copy entity versions: %w", err)
+	}
+
+	deactivateStmt := fmt.Sprintf(`
+		UPDATE %s AS e
+		SET is_active = FALSE
+		FROM `+bulkStagingTable+` AS s
+		WHERE e.tenant_id = $1 AND e.entity_id = s.entity_id AND e.entity_version = s.replaces
+	`, r.tableIdent)
+	if _, err := tx.Exec(ctx, deactivateStmt, r.tenantID); err != nil {
+		return fmt.Errorf("deactivate entity versions: %w", err)
+	}
+
+	insertStmt := fmt.Sprintf(`
+		INSERT INTO %s (
+			tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, is_active, is_soft_deleted, created_at
+		)
+		SELECT $1, entity_id, entity_version, $2, $3, slug, payload, is_active, FALSE, NOW()
+		FROM `+bulkStagingTable+`
+		ORDER BY ord
+	`, r.tableIdent)
+	if _, err := tx.Exec(ctx, insertStmt, r.tenantID, schema.SchemaID, schema.VersionString()); err != nil {
+		return fmt.Errorf("insert entity versions: %w", err)
+	}
+
+	// Same bookkeeping as retireSlug, for every renamed entity of the batch.
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO entity_slug_history (tenant_id, table_name, slug, entity_id, retired_at)
+		SELECT DISTINCT ON (retired_slug) $1, $2, retired_slug, entity_id, NOW()
+		FROM `+bulkStagingTable+`
+		WHERE retired_slug IS NOT NULL
+		ORDER BY retired_slug, ord DESC
+		ON CONFLICT (tenant_id, table_name, slug) DO UPDATE SET entity_id = EXCLUDED.entity_id, retired_at = EXCLUDED.retired_at
+	`, r.tenantID, r.tableName); err != nil {
+		return fmt.Errorf("record slug history: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM entity_slug_history AS h
+		USING `+bulkStagingTable+` AS s
+		WHERE h.tenant_id = $1 AND h.table_name = $2 AND h.slug = s.slug AND s.is_active AND s.retired_slug IS NOT NULL
+	`, r.tenantID, r.tableName); err != nil {
+		return fmt.Errorf("record slug history: %w", err)
+	}
+	return nil
+}
+
+// bulkSlugs tracks which active slugs are in use while a batch is resolved, so slugs are allocated for
+// the whole batch with a few queries instead of one per entity.
+type bulkSlugs struct {
+	repo   *EntityRepository
+	tx     pgx.Tx
+	owners map[string]string // slug -> entity ID
+	probed map[string]int    // base -> highest suffix looked up
+}
+
+// newBulkSlugs locks the slug bases of items, like allocateSlug does, and loads the active slugs the
+// items may collide with.
+func (r *EntityRepository) newBulkSlugs(ctx context.Context, tx pgx.Tx, items []bulkItem) (*bulkSlugs, error) {
+	slugs := &bulkSlugs{repo: r, tx: tx, owners: make(map[string]string), probed: make(map[string]int)}
+
+	var bases, explicit []string
+	for _, item := range items {
+		switch {
+		case item.err != nil:
+		case item.base != "":
+			bases = append(bases, item.base)
+		case item.slug != "":
+			explicit = append(explicit, item.slug)
+		}
+	}
+	slices.Sort(bases)
+	bases = slices.Compact(bases)
+
+	if len(bases) > 0 {
+		keys := make([]string, len(bases))
+		for i, base := range bases {
+			keys[i] = r.tenantID + "/" + r.tableName + "/" + base
+		}
+		if _, err := tx.Exec(ctx, `
+			SELECT pg_advisory_xact_lock(hashtextextended(key, 0))
+			FROM (SELECT unnest($1::text[]) AS key ORDER BY 1) AS keys
+		`, keys); err != nil {
+			return nil, fmt.Errorf("lock slugs: %w", err)
+		}
+	}
+
+	if err := slugs.probe(ctx, bases, 1, explicit); err != nil {
+		return nil, err
+	}
+	return slugs, nil
+}
+
+// probe loads which of the candidates from..from+slugProbeBatch-1 of each base, and which of exact, are
+// used by active entities.
+func (s *bulkSlugs) probe(ctx context.Context, bases []string, from int, exact []string) error {
+	candidates := slices.Clone(exact)
+	for _, base := range bases {
+		for n := from; n < from+slugProbeBatch; n++ {
+			candidates = append(candidates, suffixedSlug(base, n))
+		}
+		s.probed[base] = from + slugProbeBatch - 1
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT slug, entity_id FROM %s
+		WHERE tenant_id = $1 AND is_active AND NOT is_soft_deleted AND slug = ANY($2)
+	`, s.repo.tableIdent)
+	rows, err := s.tx.Query(ctx, query, s.repo.tenantID, candidates)
+	if err != nil {
+		return fmt.Errorf("check slug collisions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var slug, entityID string
+		if err := rows.Scan(&slug, &entityID); err != nil {
+			return fmt.Errorf("check slug collisions: %w", err)
+		}
+		if _, ok := s.owners[slug]; !ok {
+			s.owners[slug] = entityID
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("check slug collisions: %w", err)
+	}
+	return nil
+}
+
+// allocate returns base, or base with the lowest free numeric suffix, among the entities other than entityID.
+func (s *bulkSlugs) allocate(ctx context.Context, entityID, base string) (string, error) {
+	for n := 1; ; n++ {
+		if n > s.probed[base] {
+			if err := s.probe(ctx, []string{base}, n, nil); err != nil {
+				return "", err
+			}
+		}
+		if candidate := suffixedSlug(base, n); s.free(candidate, entityID) {
+			return candidate, nil
+		}
+	}
+}
+
+func (s *bulkSlugs) free(slug, entityID string) bool {
+	owner, ok := s.owners[slug]
+	return !ok || owner == entityID
+}
+
+func (s *bulkSlugs) take(slug, entityID string) {
+	s.owners[slug] = entityID
+}
+
+func (s *bulkSlugs) release(slug, entityID string) {
+	if s.owners[slug] == entityID {
+		delete(s.owners, slug)
+	}
+}
+
+func suffixedSlug(base string, n int) string {
+	if n == 1 {
+		return base
+	}
+	return base + "-" + strconv.Itoa(n)
+}
+
+// bulkOperation names the write reported to the EntityWriteObserver for an entity of a batch.
+func bulkOperation(result BulkEntityResult, existed bool) string {
+	if result.Outcome == UpsertCreated || (result.Outcome == "" && !existed) {
+		return EntityWriteCreate
+	}
+	return EntityWriteUpdate
+}
@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+func TestEntityRepositoryBulkUpsertIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping entity bulk upsert integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := NewPool(ctx, PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ClosePool(pool)
+	})
+
+	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
+
+	ctx = tenant.WithID(ctx, "tenant-a")
+
+	schemaStore, err := NewSchemaRepositoryStore(ctx, pool)
+	require.NoError(t, err)
+	categoryStore, err := NewSchemaCategoryStore(ctx, pool)
+	require.NoError(t, err)
+
+	categoryID := uuid.New()
+	_, err = categoryStore.CreateSchemaCategory(ctx, CreateSchemaCategoryParams{CategoryID: categoryID, Name: "cards", Slug: "cards"})
+	require.NoError(t, err)
+
+	schemaID := uuid.New()
+	_, err = schemaStore.CreateOrUpdateSchema(ctx, CreateSchemaParams{
+		SchemaID: schemaID,
+		Version:  SemanticVersion{Major: 1},
+		Definition: SchemaDefinition(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {"name": {"type": "string"}, "set": {"type": "string"}},
+			"required": ["name", "set"],
+			"x-slug-template": {"template": "{name}-{set}", "onUpdate": true}
+		}`),
+		TableName:  "bulk_cards",
+		Slug:       "bulk-cards",
+		CategoryID: categoryID,
+		Activate:   true,
+	})
+	require.NoError(t, err)
+
+	repo, err := NewEntityRepository(ctx, pool, schemaStore, NewSchemaValidator(), EntityRepositoryConfig{SchemaID: schemaID})
+	require.NoError(t, err)
+
+	existing, err := repo.CreateEntity(ctx, CreateEntityParams{EntityID: "lotus", Payload: SchemaDefinition(`{"name":"Black Lotus","set":"lea"}`)})
+	require.NoError(t, err)
+	require.Equal(t, "black-lotus-lea", existing.Slug)
+
+	results, err := repo.BulkUpsertEntities(ctx, []BulkEntity{
+		{EntityID: "lotus", Payload: SchemaDefinition(`{"name":"Black Lotus","set":"lea"}`)},
+		{EntityID: "lotus-2", Payload: SchemaDefinition(`{"name":"Black Lotus","set":"lea"}`)},
+		{EntityID: "walk", Payload: SchemaDefinition(`{"name":"Time Walk","set":"lea"}`)},
+		{EntityID: "invalid", Payload: SchemaDefinition(`{"name":"No Set"}`)},
+		{EntityID: "walk", Payload: SchemaDefinition(`{"name":"Time Walk","set":"leb"}`)},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+	require.Equal(t, UpsertUnchanged, results[0].Outcome)
+	require.Equal(t, UpsertCreated, results[1].Outcome)
+	require.Equal(t, UpsertCreated, results[2].Outcome)
+	require.Error(t, results[3].Err)
+	require.Empty(t, results[3].Outcome)
+	require.Equal(t, UpsertUpdated, results[4].Outcome)
+
+	collision, err := repo.GetEntityByID(ctx, "lotus-2")
+	require.NoError(t, err)
+	require.Equal(t, "black-lotus-lea-2", collision.Slug, "slug collisions get the next free suffix")
+
+	walk, err := repo.GetEntityByID(ctx, "walk")
+	require.NoError(t, err)
+	require.Equal(t, SemanticVersion{Major: 1, Patch: 1}, walk.EntityVersion, "a repeated ID gets a version per occurrence")
+	require.Equal(t, "time-walk-leb", walk.Slug)
+	require.JSONEq(t, `{"name":"Time Walk","set":"leb"}`, string(walk.Payload))
+
+	first, err := repo.GetEntityVersion(ctx, "walk", SemanticVersion{Major: 1})
+	require.NoError(t, err)
+	require.False(t, first.IsActive)
+
+	renamed, current, err := repo.GetEntityBySlug(ctx, "time-walk-lea")
+	require.NoError(t, err)
+	require.False(t, current, "the slug left by the update redirects")
+	require.Equal(t, "walk", renamed.EntityID)
+
+	// Updating an existing entity supersedes its active version.
+	results, err = repo.BulkUpsertEntities(ctx, []BulkEntity{
+		{EntityID: "lotus", Payload: SchemaDefinition(`{"name":"Black Lotus","set":"leb"}`)},
+		{EntityID: "explicit", Slug: ptr("time-walk-leb"), Payload: SchemaDefinition(`{"name":"Other","set":"x"}`)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, UpsertUpdated, results[0].Outcome)
+	require.ErrorIs(t, results[1].Err, ErrSlugTaken)
+
+	lotus, err := repo.GetEntityByID(ctx, "lotus")
+	require.NoError(t, err)
+	require.Equal(t, SemanticVersion{Major: 1, Patch: 1}, lotus.EntityVersion)
+	require.Equal(t, "black-lotus-leb", lotus.Slug)
+
+	active, err := repo.CountEntities(ctx, ListEntitiesParams{OnlyActive: true})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, active)
+
+	// Reconciling seed runs list the active entities and soft-delete the ones their input lacked.
+	ids, err := repo.ActiveEntityIDs(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []string{"lotus", "lotus-2", "walk"}, ids)
+
+	ids, err = repo.ActiveEntityIDs(ctx, existing.CreatedAt)
+	require.NoError(t, err)
+	require.Empty(t, ids, "entities written since the cutoff are left out")
+
+	deleted, err := repo.SoftDeleteEntities(ctx, []string{"lotus-2", "walk", "missing"}, existing.CreatedAt)
+	require.NoError(t, err)
+	require.Zero(t, deleted, "entities written since the cutoff are not deleted")
+
+	deleted, err = repo.SoftDeleteEntities(ctx, []string{"lotus-2", "walk", "missing"}, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, deleted)
+
+	_, err = repo.GetEntityByID(ctx, "walk")
+	require.ErrorIs(t, err, ErrEntityNotFound)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
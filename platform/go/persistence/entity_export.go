@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var payloadPathPattern = regexp.MustCompile(`^[A-Za-z0-9_$-]+(?:\.[A-Za-z0-9_$-]+)*$`)
+
+// IsPayloadPath reports whether path is a dotted payload field path such as "name" or "images.small".
+func IsPayloadPath(path string) bool {
+	return payloadPathPattern.MatchString(path)
+}
+
+const defaultExportBatchSize = 500
+
+// ExportEntitiesParams filters the active entities included in an export.
+type ExportEntitiesParams struct {
+	// ChangedSince keeps entities whose active version was written at or after this time.
+	ChangedSince *time.Time
+	// Filters keeps entities whose payload field (dotted path) equals the value, compared as text.
+	Filters   map[string]string
+	BatchSize int // rows fetched per round trip; defaults to 500
+}
+
+// EntityExport reads the active entities of a table from a single consistent snapshot. Records come in
+// entity_id order, a batch at a time, through a server-side cursor, so exports of any size use bounded
+// memory. Close must be called to release the connection.
+type EntityExport struct {
+	tx    pgx.Tx
+	batch string
+	done  bool
+
+	// Schema is the schema version active when the export started.
+	Schema SchemaRecord
+	// SnapshotAt is the database time of the snapshot; later writes are not included.
+	SnapshotAt time.Time
+}
+
+// BeginExport opens a read-only repeatable-read transaction and declares a cursor over the active entities
+// matching params.
+func (r *EntityRepository) BeginExport(ctx context.Context, params ExportEntitiesParams) (_ *EntityExport, err error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return nil, err
+	}
+
+	conditions := []string{"tenant_id = $1", "is_active = TRUE", "is_soft_deleted = FALSE"}
+	args := []any{r.tenantID}
+	if params.ChangedSince != nil {
+		args = append(args, *params.ChangedSince)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	for path, value := range params.Filters {
+		if !IsPayloadPath(path) {
+			return nil, fmt.Errorf("invalid filter field %q", path)
+		}
+		args = append(args, strings.Split(path, "."), value)
+		conditions = append(conditions, fmt.Sprintf("payload #>> $%d::text[] = $%d", len(args)-1, len(args)))
+	}
+
+	batchSize := params.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultExportBatchSize
+	}
+
+	schema, err := r.resolveSchema(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin export tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+		}
+	}()
+
+	export := &EntityExport{
+		tx:     tx,
+		batch:  fmt.Sprintf("FETCH FORWARD %d FROM entity_export", batchSize),
+		Schema: schema,
+	}
+	// The first statement fixes the snapshot, so its NOW() is the snapshot time.
+	if err := tx.QueryRow(ctx, `SELECT NOW()`).Scan(&export.SnapshotAt); err != nil {
+		return nil, fmt.Errorf("read export snapshot: %w", err)
+	}
+
+	declare := fmt.Sprintf(`
+		DECLARE entity_export NO SCROLL CURSOR FOR
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		FROM %s
+		WHERE %s
+		ORDER BY entity_id
+	`, r.tableIdent, strings.Join(conditions, " AND "))
+	if _, err := tx.Exec(ctx, declare, args...); err != nil {
+		return nil, fmt.Errorf("declare export cursor: %w", err)
+	}
+
+	return export, nil
+}
+
+// Next returns the next batch of records; an empty batch means the export is complete.
+func (e *EntityExport) Next(ctx context.Context) ([]EntityRecord, error) {
+	if e.done {
+		return nil, nil
+	}
+
+	rows, err := e.tx.Query(ctx, e.batch)
+	if err != nil {
+		return nil, fmt.Errorf("fetch export batch: %w", err)
+	}
+	records, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (EntityRecord, error) {
+		return scanEntityRecord(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch export batch: %w", err)
+	}
+	if len(records) == 0 {
+		e.done = true
+	}
+	return records, nil
+}
+
+// Close ends the snapshot transaction.
+func (e *EntityExport) Close(ctx context.Context) error {
+	if err := e.tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		return fmt.Errorf("close export: %w", err)
+	}
+	return nil
+}
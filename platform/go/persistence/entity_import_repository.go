@@ -0,0 +1,470 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+// EntityImportStatus is the lifecycle state of an entity import.
+type EntityImportStatus string
+
+// Entity import statuses.
+const (
+	EntityImportQueued    EntityImportStatus = "queued"
+	EntityImportRunning   EntityImportStatus = "running"
+	EntityImportSucceeded EntityImportStatus = "succeeded"
+	EntityImportFailed    EntityImportStatus = "failed"
+)
+
+// ErrEntityImportNotFound indicates the import does not exist for the tenant.
+var ErrEntityImportNotFound = errors.New("entity import not found")
+
+// entityImportChunkSize is how much of an upload each entity_import_uploads row holds.
+const entityImportChunkSize = 1 << 20
+
+// EntityImport is a row of entity_imports.
+type EntityImport struct {
+	ImportID   uuid.UUID
+	TenantID   string
+	TableName  string
+	Format     string
+	Options    json.RawMessage // the per-request import options, as given by the caller
+	JobID      *uuid.UUID      // the job running the import, once queued
+	Status     EntityImportStatus
+	Accepted   int64
+	Updated    int64
+	Unchanged  int64
+	Rejected   int64
+	Error      *string // why a failed import stopped
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// EntityImportResult is the outcome of one input line (accepted, updated, unchanged or rejected).
+type EntityImportResult struct {
+	Line     int
+	EntityID string
+	Outcome  string
+	Error    string
+}
+
+// CreateEntityImportParams describes a new, queued import.
+type CreateEntityImportParams struct {
+	ImportID  uuid.UUID
+	TableName string
+	Format    string
+	Options   json.RawMessage
+	// Upload is stored with the import, so any process can run it, until the import finishes.
+	Upload io.Reader
+}
+
+const entityImportColumns = `import_id, tenant_id, table_name, format, options, job_id, status, accepted_count, updated_count, unchanged_count, rejected_count, error, created_at, started_at, finished_at`
+
+// EntityImportStore persists entity imports and their per-line results.
+type EntityImportStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewEntityImportStore returns a store backed by the shared pool.
+func NewEntityImportStore(ctx context.Context, pool *pgxpool.Pool) (*EntityImportStore, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+
+	return &EntityImportStore{pool: pool}, nil
+}
+
+func (s *EntityImportStore) CreateEntityImport(ctx context.Context, params CreateEntityImportParams) (EntityImport, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return EntityImport{}, err
+	}
+	if params.ImportID == uuid.Nil {
+		return EntityImport{}, errors.New("import id is required")
+	}
+	options := params.Options
+	if len(options) == 0 {
+		options = json.RawMessage(`{}`)
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return EntityImport{}, fmt.Errorf("begin entity import tx: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO entity_imports (import_id, tenant_id, table_name, format, options, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING `+entityImportColumns,
+		params.ImportID, tenantID, params.TableName, params.Format, []byte(options), string(EntityImportQueued))
+
+	record, err := scanEntityImport(row)
+	if err != nil {
+		return EntityImport{}, fmt.Errorf("insert entity import: %w", err)
+	}
+
+	if params.Upload != nil {
+		chunk := make([]byte, entityImportChunkSize)
+		for seq := 0; ; seq++ {
+			n, readErr := io.ReadFull(params.Upload, chunk)
+			if n > 0 {
+				if _, err := tx.Exec(ctx, `
+					INSERT INTO entity_import_uploads (import_id, tenant_id, seq, data)
+					VALUES ($1, $2, $3, $4)
+				`, params.ImportID, tenantID, seq, chunk[:n]); err != nil {
+					return EntityImport{}, fmt.Errorf("store import upload: %w", err)
+				}
+			}
+			if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+				break
+			}
+			if readErr != nil {
+				return EntityImport{}, fmt.Errorf("read import upload: %w", readErr)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return EntityImport{}, fmt.Errorf("commit entity import: %w", err)
+	}
+	return record, nil
+}
+
+// SetEntityImportJob records the job queued to run the import. The job may already have started, so
+// the import's status is not checked.
+func (s *EntityImportStore) SetEntityImportJob(ctx context.Context, importID, jobID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE entity_imports SET job_id = $3 WHERE tenant_id = $1 AND import_id = $2
+	`, tenantID, importID, jobID)
+	if err != nil {
+		return fmt.Errorf("update entity import: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEntityImportNotFound
+	}
+	return nil
+}
+
+// OpenEntityImportUpload reads the upload stored with the import back, one chunk at a time. It is empty
+// once the import has finished.
+func (s *EntityImportStore) OpenEntityImportUpload(ctx context.Context, importID uuid.UUID) (io.Reader, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &entityImportUpload{ctx: ctx, pool: s.pool, tenantID: tenantID, importID: importID}, nil
+}
+
+type entityImportUpload struct {
+	ctx      context.Context
+	pool     *pgxpool.Pool
+	tenantID string
+	importID uuid.UUID
+
+	seq   int
+	chunk []byte
+	done  bool
+}
+
+func (u *entityImportUpload) Read(p []byte) (int, error) {
+	for len(u.chunk) == 0 {
+		if u.done {
+			return 0, io.EOF
+		}
+		err := u.pool.QueryRow(u.ctx, `
+			SELECT data
+			FROM entity_import_uploads
+			WHERE tenant_id = $1 AND import_id = $2 AND seq = $3
+		`, u.tenantID, u.importID, u.seq).Scan(&u.chunk)
+		if errors.Is(err, pgx.ErrNoRows) {
+			u.done = true
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read import upload: %w", err)
+		}
+		u.seq++
+	}
+	n := copy(p, u.chunk)
+	u.chunk = u.chunk[n:]
+	return n, nil
+}
+
+// GetEntityImport returns the import. An unfinished import whose job failed or was cancelled, e.g.
+// because its workers kept dying, is reported as failed with the job's error.
+func (s *EntityImportStore) GetEntityImport(ctx context.Context, importID uuid.UUID) (EntityImport, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return EntityImport{}, err
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		SELECT i.import_id, i.tenant_id, i.table_name, i.format, i.options, i.job_id,
+			CASE WHEN orphaned THEN 'failed' ELSE i.status END,
+			i.accepted_count, i.updated_count, i.unchanged_count, i.rejected_count,
+			CASE WHEN orphaned THEN COALESCE(j.error, 'import job ' || j.status) ELSE i.error END,
+			i.created_at, i.started_at,
+			CASE WHEN orphaned THEN j.finished_at ELSE i.finished_at END
+		FROM entity_imports i
+		LEFT JOIN jobs j ON j.job_id = i.job_id
+		CROSS JOIN LATERAL (
+			SELECT i.status IN ('queued', 'running') AND j.status IN ('failed', 'cancelled') AS orphaned
+		) o
+		WHERE i.tenant_id = $1 AND i.import_id = $2
+	`, tenantID, importID)
+
+	record, err := scanEntityImport(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return EntityImport{}, ErrEntityImportNotFound
+		}
+		return EntityImport{}, fmt.Errorf("get entity import: %w", err)
+	}
+	return record, nil
+}
+
+// StartEntityImport moves a queued import to running.
+func (s *EntityImportStore) StartEntityImport(ctx context.Context, importID uuid.UUID) error {
+	return s.setStatus(ctx, importID, `status = 'running', started_at = NOW()`, EntityImportQueued)
+}
+
+// ResetEntityImport moves a running import whose attempt was interrupted back to queued, dropping the
+// results it recorded so the next attempt can start over.
+func (s *EntityImportStore) ResetEntityImport(ctx context.Context, importID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin import reset tx: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	if err := setEntityImportStatus(ctx, tx, tenantID, importID, `
+		status = 'queued', started_at = NULL,
+		accepted_count = 0, updated_count = 0, unchanged_count = 0, rejected_count = 0`, EntityImportRunning); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM entity_import_results WHERE tenant_id = $1 AND import_id = $2`, tenantID, importID); err != nil {
+		return fmt.Errorf("delete import results: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit import reset: %w", err)
+	}
+	return nil
+}
+
+// FinishEntityImport records the final status of a queued or running import, cause explaining a failure,
+// and drops its upload.
+func (s *EntityImportStore) FinishEntityImport(ctx context.Context, importID uuid.UUID, status EntityImportStatus, cause error) error {
+	if status != EntityImportSucceeded && status != EntityImportFailed {
+		return fmt.Errorf("unsupported final import status %q", status)
+	}
+	var message *string
+	if cause != nil {
+		text := cause.Error()
+		message = &text
+	}
+	// Only a running import can succeed; one that failed to queue fails without running.
+	from := EntityImportRunning
+	if status == EntityImportFailed {
+		from = ""
+	}
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin import finish tx: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	if err := setEntityImportStatus(ctx, tx, tenantID, importID, `status = $4, error = $5, finished_at = NOW()`, from, string(status), message); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM entity_import_uploads WHERE tenant_id = $1 AND import_id = $2`, tenantID, importID); err != nil {
+		return fmt.Errorf("delete import upload: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit import finish: %w", err)
+	}
+	return nil
+}
+
+func (s *EntityImportStore) setStatus(ctx context.Context, importID uuid.UUID, assignments string, from EntityImportStatus, args ...any) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	return setEntityImportStatus(ctx, s.pool, tenantID, importID, assignments, from, args...)
+}
+
+// importExecer is satisfied by both the pool and transactions.
+type importExecer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// setEntityImportStatus applies assignments to the import if its status is from; an empty from matches
+// any unfinished import.
+func setEntityImportStatus(ctx context.Context, db importExecer, tenantID string, importID uuid.UUID, assignments string, from EntityImportStatus, args ...any) error {
+	tag, err := db.Exec(ctx, `
+		UPDATE entity_imports
+		SET `+assignments+`
+		WHERE tenant_id = $1 AND import_id = $2 AND (status = $3 OR ($3 = '' AND status IN ('queued', 'running')))
+	`, append([]any{tenantID, importID, string(from)}, args...)...)
+	if err != nil {
+		return fmt.Errorf("update entity import: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		if from == "" {
+			return fmt.Errorf("%w (or already finished)", ErrEntityImportNotFound)
+		}
+		return fmt.Errorf("%w (or not %s)", ErrEntityImportNotFound, from)
+	}
+	return nil
+}
+
+// RecordEntityImportResults stores a batch of line results and adds them to the import's counts, in one
+// transaction so the counts always match the stored results.
+func (s *EntityImportStore) RecordEntityImportResults(ctx context.Context, importID uuid.UUID, results []EntityImportResult) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int64, 4)
+	rows := make([][]any, 0, len(results))
+	for _, result := range results {
+		counts[result.Outcome]++
+		rows = append(rows, []any{importID, tenantID, result.Line, nullableString(result.EntityID), result.Outcome, nullableString(result.Error)})
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("begin import results tx: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"entity_import_results"},
+		[]string{"import_id", "tenant_id", "line", "entity_id", "outcome", "error"},
+		pgx.CopyFromRows(rows),
+	); err != nil {
+		return fmt.Errorf("copy import results: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE entity_imports
+		SET accepted_count = accepted_count + $3,
+			updated_count = updated_count + $4,
+			unchanged_count = unchanged_count + $5,
+			rejected_count = rejected_count + $6
+		WHERE tenant_id = $1 AND import_id = $2
+	`, tenantID, importID, counts["accepted"], counts["updated"], counts["unchanged"], counts["rejected"]); err != nil {
+		return fmt.Errorf("update import counts: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit import results: %w", err)
+	}
+	return nil
+}
+
+// EachEntityImportResult calls fn for the import's results in line order, optionally only those with the
+// given outcome. Results are streamed, so fn must not use the store.
+func (s *EntityImportStore) EachEntityImportResult(ctx context.Context, importID uuid.UUID, outcome string, fn func(EntityImportResult) error) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT line, COALESCE(entity_id, ''), outcome, COALESCE(error, '')
+		FROM entity_import_results
+		WHERE tenant_id = $1 AND import_id = $2 AND ($3 = '' OR outcome = $3)
+		ORDER BY line
+	`, tenantID, importID, outcome)
+	if err != nil {
+		return fmt.Errorf("query import results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result EntityImportResult
+		if err := rows.Scan(&result.Line, &result.EntityID, &result.Outcome, &result.Error); err != nil {
+			return fmt.Errorf("scan import result: %w", err)
+		}
+		if err := fn(result); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("query import results: %w", err)
+	}
+	return nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func scanEntityImport(scanner rowScanner) (EntityImport, error) {
+	var (
+		record  EntityImport
+		options []byte
+		status  string
+	)
+	if err := scanner.Scan(
+		&record.ImportID,
+		&record.TenantID,
+		&record.TableName,
+		&record.Format,
+		&options,
+		&record.JobID,
+		&status,
+		&record.Accepted,
+		&record.Updated,
+		&record.Unchanged,
+		&record.Rejected,
+		&record.Error,
+		&record.CreatedAt,
+		&record.StartedAt,
+		&record.FinishedAt,
+	); err != nil {
+		return EntityImport{}, err
+	}
+	record.Options = options
+	record.Status = EntityImportStatus(status)
+	return record, nil
+}
@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ActiveEntityIDs returns the IDs of the active entities whose current version was written before the
+// given time, in ID order. Reconciling seed runs compare them with the IDs their input mentioned; the
+// cutoff keeps entities written while the run lasted, e.g. through the API, out of the comparison.
+func (r *EntityRepository) ActiveEntityIDs(ctx context.Context, before time.Time) ([]string, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT entity_id
+		FROM %s
+		WHERE tenant_id = $1 AND is_active AND NOT is_soft_deleted AND created_at < $2
+		ORDER BY entity_id
+	`, r.tableIdent)
+
+	rows, err := r.pool.Query(ctx, query, r.tenantID, before)
+	if err != nil {
+		return nil, fmt.Errorf("list active entity ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan entity id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list active entity ids: %w", err)
+	}
+	return ids, nil
+}
+
+// SoftDeleteEntities is SoftDeleteEntity for many entities in one statement, so either all of them are
+// deleted or none is. Unknown and already deleted IDs are skipped, as are entities whose active version
+// was written at or after before: like ActiveEntityIDs, the cutoff spares entities written since a
+// reconciling run started, including between its listing and this delete. The number of entities
+// deleted is returned.
+func (r *EntityRepository) SoftDeleteEntities(ctx context.Context, entityIDs []string, before time.Time) (int64, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return 0, err
+	}
+	if len(entityIDs) == 0 {
+		return 0, nil
+	}
+
+	normalized := make([]string, len(entityIDs))
+	for i, entityID := range entityIDs {
+		id, err := NormalizeEntityIdentifier(entityID)
+		if err != nil {
+			return 0, err
+		}
+		normalized[i] = id
+	}
+
+	stmt := fmt.Sprintf(`
+		WITH deleted AS (
+			UPDATE %s AS e
+			SET is_soft_deleted = TRUE,
+			    is_active = FALSE
+			WHERE e.tenant_id = $1 AND e.entity_id = ANY($2) AND e.is_soft_deleted = FALSE
+			  AND NOT EXISTS (
+				SELECT 1 FROM %s recent
+				WHERE recent.tenant_id = $1 AND recent.entity_id = e.entity_id
+				  AND recent.is_active AND recent.created_at >= $3
+			  )
+			RETURNING e.entity_id
+		)
+		SELECT COUNT(DISTINCT entity_id) FROM deleted
+	`, r.tableIdent, r.tableIdent)
+
+	var deleted int64
+	if err := r.pool.QueryRow(ctx, stmt, r.tenantID, normalized, before).Scan(&deleted); err != nil {
+		r.observeWrite(EntityWriteDelete, err)
+		return 0, fmt.Errorf("soft delete entities: %w", err)
+	}
+	for range deleted {
+		r.observeWrite(EntityWriteDelete, nil)
+	}
+	return deleted, nil
+}
@@ -5,12 +5,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
 )
 
 // ErrEntityNotFound indicates the requested entity (or version) does not exist.
@@ -19,6 +25,12 @@ var ErrEntityNotFound = errors.New("entity not found")
 // ErrEntityAlreadyExists indicates an entity is being created with an identifier that already exists.
 var ErrEntityAlreadyExists = errors.New("entity already exists")
 
+// ErrSlugTaken indicates an explicit slug is already used by another active entity of the same table.
+var ErrSlugTaken = errors.New("slug already in use")
+
+// ErrTenantMismatch indicates an entity repository bound to one tenant was used with another tenant's context.
+var ErrTenantMismatch = errors.New("tenant mismatch")
+
 // SchemaResolver exposes the subset of schema store operations needed by the entity repository.
 type SchemaResolver interface {
 	GetActiveSchema(ctx context.Context, schemaID uuid.UUID) (SchemaRecord, error)
@@ -30,24 +42,46 @@ type PayloadValidator interface {
 	Validate(ctx context.Context, schema SchemaRecord, payload []byte) error
 }
 
+// Entity write operations reported to an EntityWriteObserver.
+const (
+	EntityWriteCreate = "create"
+	EntityWriteUpdate = "update"
+	EntityWriteDelete = "delete"
+)
+
+// EntityWriteObserver is told about every entity write and its outcome (err is nil on success).
+type EntityWriteObserver interface {
+	ObserveEntityWrite(tableName, operation string, err error)
+}
+
 // EntityRepositoryConfig provides the wiring required to manage a specific entity table.
 type EntityRepositoryConfig struct {
-	SchemaID uuid.UUID
+	SchemaID      uuid.UUID
+	WriteObserver EntityWriteObserver // optional
+	SlugTemplate  string              // optional; replaces the schema's SlugTemplateExtension
 }
 
 // EntityRepository persists immutable entity documents with schema validation and versioning.
 // tableName holds the raw schema-owned table (e.g. cards_entities) while tableIdent caches the quoted/sanitized identifier generated via pgx.Identifier to embed safely in SQL strings.
+// tenantID is captured from the constructor context; every query is filtered by it.
 type EntityRepository struct {
 	pool       *pgxpool.Pool
 	schemas    SchemaResolver
 	validator  PayloadValidator
+	tenantID   string
 	tableName  string
 	schemaID   uuid.UUID
 	tableIdent string
+	observer   EntityWriteObserver
+
+	slugOverride   *SlugTemplate
+	activeTemplate *SlugTemplate
+	slugTemplates  sync.Map // schema version -> *SlugTemplate (nil when the version declares none)
 }
 
 // EntityRecord mirrors the entity table shape, capturing every immutable version of a document.
 type EntityRecord struct {
+	TenantID      string          `json:"tenantId"`
 	EntityID      string          `json:"entityId"`
 	EntityVersion SemanticVersion `json:"entityVersion"`
 	SchemaID      uuid.UUID       `json:"schemaId"`
@@ -60,6 +94,7 @@ type EntityRecord struct {
 }
 
 // CreateEntityParams defines the payload required to persist a brand-new entity.
+// Slug may be empty when the schema declares a slug template; the slug is then derived from the payload.
 type CreateEntityParams struct {
 	EntityID      string
 	SchemaVersion *SemanticVersion
@@ -73,17 +108,32 @@ type UpdateEntityParams struct {
 	SchemaVersion *SemanticVersion
 	Slug          *string
 	Payload       SchemaDefinition
+	// SkipUnchanged writes no version when the payload, slug and schema version equal the active version's;
+	// the active version is returned instead.
+	SkipUnchanged bool
 }
 
 // CreateOrUpdateEntityParams unifies the payload for upserting immutable entity records.
-// Slug is optional when updating an existing entity, but required when inserting a new one.
+// Slug is optional when updating an existing entity, but required when inserting a new one unless the schema
+// declares a slug template.
 type CreateOrUpdateEntityParams struct {
 	EntityID      string
 	SchemaVersion *SemanticVersion
 	Slug          *string
 	Payload       SchemaDefinition
+	SkipUnchanged bool // see UpdateEntityParams.SkipUnchanged
 }
 
+// UpsertOutcome reports what UpsertEntity did.
+type UpsertOutcome string
+
+// Upsert outcomes.
+const (
+	UpsertCreated   UpsertOutcome = "created"
+	UpsertUpdated   UpsertOutcome = "updated"
+	UpsertUnchanged UpsertOutcome = "unchanged"
+)
+
 // ListEntitiesParams defines filters when listing entities.
 type ListEntitiesParams struct {
 	OnlyActive     bool
@@ -109,6 +159,11 @@ func NewEntityRepository(ctx context.Context, pool *pgxpool.Pool, schemaStore Sc
 		return nil, errors.New("schema id is required")
 	}
 
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	activeSchema, err := schemaStore.GetActiveSchema(ctx, cfg.SchemaID)
 	if err != nil {
 		return nil, fmt.Errorf("resolve active schema: %w", err)
@@ -121,9 +176,22 @@ func NewEntityRepository(ctx context.Context, pool *pgxpool.Pool, schemaStore Sc
 		pool:       pool,
 		schemas:    schemaStore,
 		validator:  validator,
+		tenantID:   tenantID,
 		tableName:  activeSchema.TableName,
 		schemaID:   cfg.SchemaID,
 		tableIdent: pgx.Identifier{activeSchema.TableName}.Sanitize(),
+		observer:   cfg.WriteObserver,
+	}
+
+	if cfg.SlugTemplate != "" {
+		template, err := ParseSlugTemplate(cfg.SlugTemplate)
+		if err != nil {
+			return nil, err
+		}
+		repo.slugOverride = &template
+	}
+	if repo.activeTemplate, err = repo.slugTemplate(activeSchema); err != nil {
+		return nil, err
 	}
 
 	if err := repo.ensureEntityTable(ctx); err != nil {
@@ -133,8 +201,32 @@ func NewEntityRepository(ctx context.Context, pool *pgxpool.Pool, schemaStore Sc
 	return repo, nil
 }
 
+// ActiveSchema returns the schema version new entities are validated against.
+func (r *EntityRepository) ActiveSchema(ctx context.Context) (SchemaRecord, error) {
+	return r.resolveSchema(ctx, nil)
+}
+
+// SlugTemplate returns the slug template applying to new entities of the active schema; ok is false when
+// slugs must be supplied by the caller.
+func (r *EntityRepository) SlugTemplate() (template SlugTemplate, ok bool) {
+	if r.activeTemplate == nil {
+		return SlugTemplate{}, false
+	}
+	return *r.activeTemplate, true
+}
+
 // CreateEntity persists a new entity (version 1.0.0) after schema validation.
 func (r *EntityRepository) CreateEntity(ctx context.Context, params CreateEntityParams) (EntityRecord, error) {
+	record, err := r.createEntity(ctx, params)
+	r.observeWrite(EntityWriteCreate, err)
+	return record, err
+}
+
+func (r *EntityRepository) createEntity(ctx context.Context, params CreateEntityParams) (EntityRecord, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return EntityRecord{}, err
+	}
+
 	entityID := strings.TrimSpace(params.EntityID)
 	var err error
 	if entityID == "" {
@@ -150,9 +242,12 @@ func (r *EntityRepository) CreateEntity(ctx context.Context, params CreateEntity
 		return EntityRecord{}, errors.New("payload is required")
 	}
 
-	slug, err := NormalizeSlug(params.Slug)
-	if err != nil {
-		return EntityRecord{}, err
+	deriveSlug := strings.TrimSpace(params.Slug) == ""
+	var slug string
+	if !deriveSlug {
+		if slug, err = NormalizeSlug(params.Slug); err != nil {
+			return EntityRecord{}, err
+		}
 	}
 
 	schemaRecord, err := r.resolveSchema(ctx, params.SchemaVersion)
@@ -164,40 +259,62 @@ func (r *EntityRepository) CreateEntity(ctx context.Context, params CreateEntity
 		return EntityRecord{}, err
 	}
 
+	if deriveSlug {
+		template, err := r.slugTemplate(schemaRecord)
+		if err != nil {
+			return EntityRecord{}, err
+		}
+		if template == nil {
+			return EntityRecord{}, errors.New("slug is required")
+		}
+		if slug, err = template.RenderJSON(params.Payload); err != nil {
+			return EntityRecord{}, err
+		}
+	}
+
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return EntityRecord{}, fmt.Errorf("begin entity tx: %w", err)
 	}
 	defer tx.Rollback(ctx) // nolint:errcheck
 
-	existsQuery := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE entity_id = $1)`, r.tableIdent)
+	existsQuery := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE tenant_id = $1 AND entity_id = $2)`, r.tableIdent)
 	var exists bool
-	if err := tx.QueryRow(ctx, existsQuery, entityID).Scan(&exists); err != nil {
+	if err := tx.QueryRow(ctx, existsQuery, r.tenantID, entityID).Scan(&exists); err != nil {
 		return EntityRecord{}, fmt.Errorf("check entity existence: %w", err)
 	}
 	if exists {
 		return EntityRecord{}, ErrEntityAlreadyExists
 	}
 
+	if deriveSlug {
+		if slug, err = r.allocateSlug(ctx, tx, entityID, slug); err != nil {
+			return EntityRecord{}, err
+		}
+	}
+
 	version := SemanticVersion{Major: 1, Minor: 0, Patch: 0}
 	insertStmt := fmt.Sprintf(`
 		INSERT INTO %s (
-			entity_id, entity_version, schema_id, schema_version, slug, payload, is_active, is_soft_deleted, created_at
+			tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, is_active, is_soft_deleted, created_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, TRUE, FALSE, NOW()
+			$1, $2, $3, $4, $5, $6, $7, TRUE, FALSE, NOW()
 		)`, r.tableIdent)
 
-	if _, err := tx.Exec(ctx, insertStmt, entityID, version.String(), schemaRecord.SchemaID, schemaRecord.VersionString(), slug, []byte(params.Payload)); err != nil {
+	if _, err := tx.Exec(ctx, insertStmt, r.tenantID, entityID, version.String(), schemaRecord.SchemaID, schemaRecord.VersionString(), slug, []byte(params.Payload)); err != nil {
+		if isSlugViolation(err) {
+			return EntityRecord{}, ErrSlugTaken
+		}
 		return EntityRecord{}, fmt.Errorf("insert entity: %w", err)
 	}
 
 	selectStmt := fmt.Sprintf(`
-		SELECT entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
 		FROM %s
-		WHERE entity_id = $1 AND entity_version = $2
+		WHERE tenant_id = $1 AND entity_id = $2 AND entity_version = $3
 	`, r.tableIdent)
 
-	row := tx.QueryRow(ctx, selectStmt, entityID, version.String())
+	row := tx.QueryRow(ctx, selectStmt, r.tenantID, entityID, version.String())
 	record, err := scanEntityRecord(row)
 	if err != nil {
 		return EntityRecord{}, fmt.Errorf("fetch entity: %w", err)
@@ -212,42 +329,58 @@ func (r *EntityRepository) CreateEntity(ctx context.Context, params CreateEntity
 
 // UpdateEntity creates a new immutable version of an existing entity, bumping the patch segment.
 func (r *EntityRepository) UpdateEntity(ctx context.Context, params UpdateEntityParams) (EntityRecord, error) {
+	record, _, err := r.updateEntity(ctx, params)
+	return record, err
+}
+
+// updateEntity reports whether a new version was written; it is false only for skipped unchanged updates.
+func (r *EntityRepository) updateEntity(ctx context.Context, params UpdateEntityParams) (record EntityRecord, written bool, err error) {
+	defer func() {
+		if written || err != nil {
+			r.observeWrite(EntityWriteUpdate, err)
+		}
+	}()
+
+	if err := r.checkTenant(ctx); err != nil {
+		return EntityRecord{}, false, err
+	}
+
 	entityID, err := NormalizeEntityIdentifier(params.EntityID)
 	if err != nil {
-		return EntityRecord{}, err
+		return EntityRecord{}, false, err
 	}
 	if len(params.Payload) == 0 {
-		return EntityRecord{}, errors.New("payload is required")
+		return EntityRecord{}, false, errors.New("payload is required")
 	}
 
 	schemaRecord, err := r.resolveSchema(ctx, params.SchemaVersion)
 	if err != nil {
-		return EntityRecord{}, err
+		return EntityRecord{}, false, err
 	}
 
 	if err := r.validator.Validate(ctx, schemaRecord, params.Payload); err != nil {
-		return EntityRecord{}, err
+		return EntityRecord{}, false, err
 	}
 
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return EntityRecord{}, fmt.Errorf("begin update tx: %w", err)
+		return EntityRecord{}, false, fmt.Errorf("begin update tx: %w", err)
 	}
 	defer tx.Rollback(ctx) // nolint:errcheck
 
 	activeSelect := fmt.Sprintf(`
-		SELECT entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
 		FROM %s
-		WHERE entity_id = $1 AND is_active = TRUE AND is_soft_deleted = FALSE
+		WHERE tenant_id = $1 AND entity_id = $2 AND is_active = TRUE AND is_soft_deleted = FALSE
 		FOR UPDATE
 	`, r.tableIdent)
-	currentRow := tx.QueryRow(ctx, activeSelect, entityID)
+	currentRow := tx.QueryRow(ctx, activeSelect, r.tenantID, entityID)
 	currentRecord, err := scanEntityRecord(currentRow)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return EntityRecord{}, ErrEntityNotFound
+			return EntityRecord{}, false, ErrEntityNotFound
 		}
-		return EntityRecord{}, fmt.Errorf("fetch active entity: %w", err)
+		return EntityRecord{}, false, fmt.Errorf("fetch active entity: %w", err)
 	}
 
 	nextVersion := currentRecord.EntityVersion.NextPatch()
@@ -255,110 +388,155 @@ func (r *EntityRepository) UpdateEntity(ctx context.Context, params UpdateEntity
 	if params.Slug != nil {
 		normalizedSlug, normErr := NormalizeSlug(*params.Slug)
 		if normErr != nil {
-			return EntityRecord{}, normErr
+			return EntityRecord{}, false, normErr
 		}
 		nextSlug = normalizedSlug
+	} else {
+		template, err := r.slugTemplate(schemaRecord)
+		if err != nil {
+			return EntityRecord{}, false, err
+		}
+		if template != nil && template.OnUpdate {
+			base, err := template.RenderJSON(params.Payload)
+			if err != nil {
+				return EntityRecord{}, false, err
+			}
+			if !hasSlugBase(currentRecord.Slug, base) {
+				if nextSlug, err = r.allocateSlug(ctx, tx, entityID, base); err != nil {
+					return EntityRecord{}, false, err
+				}
+			}
+		}
+	}
+
+	if params.SkipUnchanged && nextSlug == currentRecord.Slug && currentRecord.SchemaVersion == schemaRecord.SchemaVersion &&
+		jsonEqual(currentRecord.Payload, params.Payload) {
+		return currentRecord, false, nil
 	}
 
 	deactivateStmt := fmt.Sprintf(`
 		UPDATE %s
 		SET is_active = FALSE
-		WHERE entity_id = $1 AND entity_version = $2
+		WHERE tenant_id = $1 AND entity_id = $2 AND entity_version = $3
 	`, r.tableIdent)
-	if _, err := tx.Exec(ctx, deactivateStmt, entityID, currentRecord.EntityVersion.String()); err != nil {
-		return EntityRecord{}, fmt.Errorf("deactivate entity version: %w", err)
+	if _, err := tx.Exec(ctx, deactivateStmt, r.tenantID, entityID, currentRecord.EntityVersion.String()); err != nil {
+		return EntityRecord{}, false, fmt.Errorf("deactivate entity version: %w", err)
 	}
 
 	insertStmt := fmt.Sprintf(`
 		INSERT INTO %s (
-			entity_id, entity_version, schema_id, schema_version, slug, payload, is_active, is_soft_deleted, created_at
+			tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, is_active, is_soft_deleted, created_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, TRUE, FALSE, NOW()
+			$1, $2, $3, $4, $5, $6, $7, TRUE, FALSE, NOW()
 		)
 	`, r.tableIdent)
-	if _, err := tx.Exec(ctx, insertStmt, entityID, nextVersion.String(), schemaRecord.SchemaID, schemaRecord.VersionString(), nextSlug, []byte(params.Payload)); err != nil {
-		return EntityRecord{}, fmt.Errorf("insert entity version: %w", err)
+	if _, err := tx.Exec(ctx, insertStmt, r.tenantID, entityID, nextVersion.String(), schemaRecord.SchemaID, schemaRecord.VersionString(), nextSlug, []byte(params.Payload)); err != nil {
+		if isSlugViolation(err) {
+			return EntityRecord{}, false, ErrSlugTaken
+		}
+		return EntityRecord{}, false, fmt.Errorf("insert entity version: %w", err)
+	}
+
+	if nextSlug != currentRecord.Slug {
+		if err := r.retireSlug(ctx, tx, entityID, currentRecord.Slug, nextSlug); err != nil {
+			return EntityRecord{}, false, err
+		}
 	}
 
 	selectStmt := fmt.Sprintf(`
-		SELECT entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
 		FROM %s
-		WHERE entity_id = $1 AND entity_version = $2
+		WHERE tenant_id = $1 AND entity_id = $2 AND entity_version = $3
 	`, r.tableIdent)
-	row := tx.QueryRow(ctx, selectStmt, entityID, nextVersion.String())
-	record, err := scanEntityRecord(row)
+	row := tx.QueryRow(ctx, selectStmt, r.tenantID, entityID, nextVersion.String())
+	record, err = scanEntityRecord(row)
 	if err != nil {
-		return EntityRecord{}, fmt.Errorf("fetch new entity version: %w", err)
+		return EntityRecord{}, false, fmt.Errorf("fetch new entity version: %w", err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return EntityRecord{}, fmt.Errorf("commit update tx: %w", err)
+		return EntityRecord{}, false, fmt.Errorf("commit update tx: %w", err)
 	}
 
-	return record, nil
+	return record, true, nil
 }
 
 // CreateOrUpdateEntity attempts to update an existing entity version; if it does not exist it falls back to creation.
-// When inserting a new entity (no ID or unknown ID), Slug must be provided; otherwise it is optional and defaults to the current slug.
+// When inserting a new entity (no ID or unknown ID), Slug must be provided unless the schema declares a slug template;
+// otherwise it is optional and defaults to the current slug.
 func (r *EntityRepository) CreateOrUpdateEntity(ctx context.Context, params CreateOrUpdateEntityParams) (EntityRecord, error) {
+	record, _, err := r.upsertEntity(ctx, params)
+	return record, err
+}
+
+// UpsertEntity is CreateOrUpdateEntity with SkipUnchanged set, reporting whether the entity was created,
+// updated or left unchanged.
+func (r *EntityRepository) UpsertEntity(ctx context.Context, params CreateOrUpdateEntityParams) (EntityRecord, UpsertOutcome, error) {
+	params.SkipUnchanged = true
+	return r.upsertEntity(ctx, params)
+}
+
+func (r *EntityRepository) upsertEntity(ctx context.Context, params CreateOrUpdateEntityParams) (EntityRecord, UpsertOutcome, error) {
 	if len(params.Payload) == 0 {
-		return EntityRecord{}, errors.New("payload is required")
+		return EntityRecord{}, "", errors.New("payload is required")
 	}
 
-	if strings.TrimSpace(params.EntityID) == "" {
-		if params.Slug == nil {
-			return EntityRecord{}, errors.New("slug is required for new entities")
-		}
-		return r.CreateEntity(ctx, CreateEntityParams{
+	var slug string
+	if params.Slug != nil {
+		slug = *params.Slug
+	}
+	create := func() (EntityRecord, UpsertOutcome, error) {
+		record, err := r.CreateEntity(ctx, CreateEntityParams{
+			EntityID:      params.EntityID,
 			SchemaVersion: params.SchemaVersion,
-			Slug:          *params.Slug,
+			Slug:          slug,
 			Payload:       params.Payload,
 		})
+		return record, UpsertCreated, err
 	}
 
-	updateParams := UpdateEntityParams{
-		EntityID:      params.EntityID,
-		SchemaVersion: params.SchemaVersion,
-		Payload:       params.Payload,
-	}
-	if params.Slug != nil {
-		updateParams.Slug = params.Slug
-	}
-
-	record, err := r.UpdateEntity(ctx, updateParams)
-	if err == nil {
-		return record, nil
-	}
-	if !errors.Is(err, ErrEntityNotFound) {
-		return EntityRecord{}, err
-	}
-	if params.Slug == nil {
-		return EntityRecord{}, errors.New("slug is required when creating a new entity")
+	if strings.TrimSpace(params.EntityID) == "" {
+		return create()
 	}
 
-	return r.CreateEntity(ctx, CreateEntityParams{
+	record, written, err := r.updateEntity(ctx, UpdateEntityParams{
 		EntityID:      params.EntityID,
 		SchemaVersion: params.SchemaVersion,
-		Slug:          *params.Slug,
+		Slug:          params.Slug,
 		Payload:       params.Payload,
+		SkipUnchanged: params.SkipUnchanged,
 	})
+	switch {
+	case err == nil && written:
+		return record, UpsertUpdated, nil
+	case err == nil:
+		return record, UpsertUnchanged, nil
+	case errors.Is(err, ErrEntityNotFound):
+		return create()
+	default:
+		return EntityRecord{}, "", err
+	}
 }
 
 // GetEntityByID fetches the latest active entity version.
 
 func (r *EntityRepository) GetEntityByID(ctx context.Context, entityID string) (EntityRecord, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return EntityRecord{}, err
+	}
+
 	normalized, err := NormalizeEntityIdentifier(entityID)
 	if err != nil {
 		return EntityRecord{}, err
 	}
 
 	query := fmt.Sprintf(`
-		SELECT entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
 		FROM %s
-		WHERE entity_id = $1 AND is_active = TRUE AND is_soft_deleted = FALSE
+		WHERE tenant_id = $1 AND entity_id = $2 AND is_active = TRUE AND is_soft_deleted = FALSE
 	`, r.tableIdent)
 
-	row := r.pool.QueryRow(ctx, query, normalized)
+	row := r.pool.QueryRow(ctx, query, r.tenantID, normalized)
 	record, err := scanEntityRecord(row)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -370,20 +548,70 @@ func (r *EntityRepository) GetEntityByID(ctx context.Context, entityID string) (
 	return record, nil
 }
 
+// GetEntityBySlug fetches the active entity version using slug. When no active entity uses slug but an
+// entity used it before being renamed, that entity is returned with current set to false so callers can
+// redirect to its current slug.
+func (r *EntityRepository) GetEntityBySlug(ctx context.Context, slug string) (record EntityRecord, current bool, err error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return EntityRecord{}, false, err
+	}
+
+	normalized, err := NormalizeSlug(slug)
+	if err != nil {
+		return EntityRecord{}, false, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		FROM %s
+		WHERE tenant_id = $1 AND slug = $2 AND is_active = TRUE AND is_soft_deleted = FALSE
+	`, r.tableIdent)
+
+	record, err = scanEntityRecord(r.pool.QueryRow(ctx, query, r.tenantID, normalized))
+	if err == nil {
+		return record, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return EntityRecord{}, false, err
+	}
+
+	var entityID string
+	err = r.pool.QueryRow(ctx, `
+		SELECT entity_id FROM entity_slug_history
+		WHERE tenant_id = $1 AND table_name = $2 AND slug = $3
+	`, r.tenantID, r.tableName, normalized).Scan(&entityID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return EntityRecord{}, false, ErrEntityNotFound
+		}
+		return EntityRecord{}, false, fmt.Errorf("lookup slug history: %w", err)
+	}
+
+	record, err = r.GetEntityByID(ctx, entityID)
+	if err != nil {
+		return EntityRecord{}, false, err
+	}
+	return record, false, nil
+}
+
 // GetEntityVersion fetches a specific entity version.
 func (r *EntityRepository) GetEntityVersion(ctx context.Context, entityID string, version SemanticVersion) (EntityRecord, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return EntityRecord{}, err
+	}
+
 	normalized, err := NormalizeEntityIdentifier(entityID)
 	if err != nil {
 		return EntityRecord{}, err
 	}
 
 	query := fmt.Sprintf(`
-		SELECT entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
 		FROM %s
-		WHERE entity_id = $1 AND entity_version = $2
+		WHERE tenant_id = $1 AND entity_id = $2 AND entity_version = $3
 	`, r.tableIdent)
 
-	row := r.pool.QueryRow(ctx, query, normalized, version.String())
+	row := r.pool.QueryRow(ctx, query, r.tenantID, normalized, version.String())
 	record, err := scanEntityRecord(row)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -397,6 +625,10 @@ func (r *EntityRepository) GetEntityVersion(ctx context.Context, entityID string
 
 // ListEntities returns entities ordered by creation time.
 func (r *EntityRepository) ListEntities(ctx context.Context, params ListEntitiesParams) ([]EntityRecord, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return nil, err
+	}
+
 	limit := params.Limit
 	if limit <= 0 || limit > 200 {
 		limit = 50
@@ -412,15 +644,16 @@ func (r *EntityRepository) ListEntities(ctx context.Context, params ListEntities
 	}
 
 	query := fmt.Sprintf(`
-		SELECT entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
+		SELECT tenant_id, entity_id, entity_version, schema_id, schema_version, slug, payload, created_at, is_soft_deleted, is_active
 		FROM %s
-		WHERE ($1::bool = FALSE OR is_active = TRUE)
-		  AND ($2::bool = TRUE OR is_soft_deleted = FALSE)
+		WHERE tenant_id = $1
+		  AND ($2::bool = FALSE OR is_active = TRUE)
+		  AND ($3::bool = TRUE OR is_soft_deleted = FALSE)
 		ORDER BY %s %s
-		LIMIT $3 OFFSET $4
+		LIMIT $4 OFFSET $5
 	`, r.tableIdent, sortField, sortOrder)
 
-	rows, err := r.pool.Query(ctx, query, params.OnlyActive, params.IncludeDeleted, limit, offset)
+	rows, err := r.pool.Query(ctx, query, r.tenantID, params.OnlyActive, params.IncludeDeleted, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("list entities: %w", err)
 	}
@@ -444,15 +677,20 @@ func (r *EntityRepository) ListEntities(ctx context.Context, params ListEntities
 
 // CountEntities returns the total number of entities matching the provided filters.
 func (r *EntityRepository) CountEntities(ctx context.Context, params ListEntitiesParams) (int64, error) {
+	if err := r.checkTenant(ctx); err != nil {
+		return 0, err
+	}
+
 	query := fmt.Sprintf(`
 		SELECT COUNT(*)
 		FROM %s
-		WHERE ($1::bool = FALSE OR is_active = TRUE)
-		  AND ($2::bool = TRUE OR is_soft_deleted = FALSE)
+		WHERE tenant_id = $1
+		  AND ($2::bool = FALSE OR is_active = TRUE)
+		  AND ($3::bool = TRUE OR is_soft_deleted = FALSE)
 	`, r.tableIdent)
 
 	var total int64
-	if err := r.pool.QueryRow(ctx, query, params.OnlyActive, params.IncludeDeleted).Scan(&total); err != nil {
+	if err := r.pool.QueryRow(ctx, query, r.tenantID, params.OnlyActive, params.IncludeDeleted).Scan(&total); err != nil {
 		return 0, fmt.Errorf("count entities: %w", err)
 	}
 
@@ -485,6 +723,16 @@ func sanitizeEntitySort(field, order string) (string, string, error) {
 // SoftDeleteEntity marks all versions of the entity as deleted and non-active.
 // deletedAt is ignored because entity versions are immutable and only track creation time.
 func (r *EntityRepository) SoftDeleteEntity(ctx context.Context, entityID string, _ time.Time) error {
+	err := r.softDeleteEntity(ctx, entityID)
+	r.observeWrite(EntityWriteDelete, err)
+	return err
+}
+
+func (r *EntityRepository) softDeleteEntity(ctx context.Context, entityID string) error {
+	if err := r.checkTenant(ctx); err != nil {
+		return err
+	}
+
 	normalized, err := NormalizeEntityIdentifier(entityID)
 	if err != nil {
 		return err
@@ -494,10 +742,10 @@ func (r *EntityRepository) SoftDeleteEntity(ctx context.Context, entityID string
 		UPDATE %s
 		SET is_soft_deleted = TRUE,
 		    is_active = FALSE
-		WHERE entity_id = $1 AND is_soft_deleted = FALSE
+		WHERE tenant_id = $1 AND entity_id = $2 AND is_soft_deleted = FALSE
 	`, r.tableIdent)
 
-	tag, err := r.pool.Exec(ctx, stmt, normalized)
+	tag, err := r.pool.Exec(ctx, stmt, r.tenantID, normalized)
 	if err != nil {
 		return fmt.Errorf("soft delete entity: %w", err)
 	}
@@ -509,6 +757,24 @@ func (r *EntityRepository) SoftDeleteEntity(ctx context.Context, entityID string
 	return nil
 }
 
+func (r *EntityRepository) observeWrite(operation string, err error) {
+	if r.observer != nil {
+		r.observer.ObserveEntityWrite(r.tableName, operation, err)
+	}
+}
+
+// checkTenant guarantees the caller's tenant matches the tenant the repository was built for.
+func (r *EntityRepository) checkTenant(ctx context.Context) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	if tenantID != r.tenantID {
+		return ErrTenantMismatch
+	}
+	return nil
+}
+
 func (r *EntityRepository) resolveSchema(ctx context.Context, version *SemanticVersion) (SchemaRecord, error) {
 	if version == nil {
 		schema, err := r.schemas.GetActiveSchema(ctx, r.schemaID)
@@ -530,9 +796,92 @@ func (r *EntityRepository) resolveSchema(ctx context.Context, version *SemanticV
 	return schema, nil
 }
 
+// retireSlug records the slug an entity is leaving so lookups by it redirect to the entity, and drops the
+// history entry of the slug it now uses, which resolves directly again.
+func (r *EntityRepository) retireSlug(ctx context.Context, tx pgx.Tx, entityID, oldSlug, newSlug string) error {
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO entity_slug_history (tenant_id, table_name, slug, entity_id, retired_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (tenant_id, table_name, slug) DO UPDATE SET entity_id = EXCLUDED.entity_id, retired_at = EXCLUDED.retired_at
+	`, r.tenantID, r.tableName, oldSlug, entityID); err != nil {
+		return fmt.Errorf("record slug history: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM entity_slug_history WHERE tenant_id = $1 AND table_name = $2 AND slug = $3
+	`, r.tenantID, r.tableName, newSlug); err != nil {
+		return fmt.Errorf("record slug history: %w", err)
+	}
+	return nil
+}
+
+// slugTemplate returns the slug template for entities of schema, or nil when the schema declares none.
+func (r *EntityRepository) slugTemplate(schema SchemaRecord) (*SlugTemplate, error) {
+	if r.slugOverride != nil {
+		return r.slugOverride, nil
+	}
+	key := schema.VersionString()
+	if cached, ok := r.slugTemplates.Load(key); ok {
+		return cached.(*SlugTemplate), nil
+	}
+	template, ok, err := SchemaSlugTemplate(schema.SchemaDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("schema %s version %s: %w", schema.SchemaID, key, err)
+	}
+	var result *SlugTemplate
+	if ok {
+		result = &template
+	}
+	r.slugTemplates.Store(key, result)
+	return result, nil
+}
+
+// slugProbeBatch is the number of suffixed candidates checked per query while resolving a slug collision.
+const slugProbeBatch = 16
+
+// allocateSlug returns base, or base with the lowest free numeric suffix ("base-2"), among the active
+// entities other than entityID. An advisory lock serialises allocations of the same base until tx ends,
+// so concurrent writers cannot pick the same suffix.
+func (r *EntityRepository) allocateSlug(ctx context.Context, tx pgx.Tx, entityID, base string) (string, error) {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, r.tenantID+"/"+r.tableName+"/"+base); err != nil {
+		return "", fmt.Errorf("lock slug: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT slug FROM %s
+		WHERE tenant_id = $1 AND entity_id <> $2 AND is_active AND NOT is_soft_deleted AND slug = ANY($3)
+	`, r.tableIdent)
+	for next := 1; ; next += slugProbeBatch {
+		candidates := make([]string, 0, slugProbeBatch)
+		for n := next; n < next+slugProbeBatch; n++ {
+			if n == 1 {
+				candidates = append(candidates, base)
+			} else {
+				candidates = append(candidates, base+"-"+strconv.Itoa(n))
+			}
+		}
+
+		rows, err := tx.Query(ctx, query, r.tenantID, entityID, candidates)
+		if err != nil {
+			return "", fmt.Errorf("check slug collisions: %w", err)
+		}
+		taken, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return "", fmt.Errorf("check slug collisions: %w", err)
+		}
+		for _, candidate := range candidates {
+			if !slices.Contains(taken, candidate) {
+				return candidate, nil
+			}
+		}
+	}
+}
+
+// ensureEntityTable creates the tenant-scoped entity table, its indexes and the row-level security policy.
+// Entity tables are shared across tenants; the tenant_id column is part of every key and index.
 func (r *EntityRepository) ensureEntityTable(ctx context.Context) error {
 	tableDDL := fmt.Sprintf(`
 CREATE TABLE IF NOT EXISTS %s (
+	tenant_id TEXT NOT NULL,
 	entity_id TEXT NOT NULL CHECK (char_length(entity_id) >= 1 AND char_length(entity_id) <= 128),
 	entity_version TEXT NOT NULL CHECK (entity_version ~ '^\d+\.\d+\.\d+$'),
 	schema_id UUID NOT NULL,
@@ -542,22 +891,22 @@ CREATE TABLE IF NOT EXISTS %s (
 	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 	is_active BOOLEAN NOT NULL DEFAULT TRUE,
 	is_soft_deleted BOOLEAN NOT NULL DEFAULT FALSE,
-	PRIMARY KEY (entity_id, entity_version),
-	FOREIGN KEY (schema_id, schema_version) REFERENCES schema_repository(schema_id, schema_version)
+	PRIMARY KEY (tenant_id, entity_id, entity_version),
+	FOREIGN KEY (tenant_id, schema_id, schema_version) REFERENCES schema_repository(tenant_id, schema_id, schema_version)
 );`, r.tableIdent)
 
 	activeIndex := fmt.Sprintf(`
-CREATE UNIQUE INDEX IF NOT EXISTS %s_active_idx ON %s (entity_id)
+CREATE UNIQUE INDEX IF NOT EXISTS %s_active_idx ON %s (tenant_id, entity_id)
 WHERE is_active AND NOT is_soft_deleted;
 `, r.tableName, r.tableIdent)
 
 	slugIndex := fmt.Sprintf(`
-CREATE UNIQUE INDEX IF NOT EXISTS %s_slug_active_idx ON %s (slug)
+CREATE UNIQUE INDEX IF NOT EXISTS %s_slug_active_idx ON %s (tenant_id, slug)
 WHERE is_active AND NOT is_soft_deleted;
 `, r.tableName, r.tableIdent)
 
 	schemaIndex := fmt.Sprintf(`
-CREATE INDEX IF NOT EXISTS %s_schema_idx ON %s (schema_id, schema_version);
+CREATE INDEX IF NOT EXISTS %s_schema_idx ON %s (tenant_id, schema_id, schema_version);
 `, r.tableName, r.tableIdent)
 
 	statements := []string{tableDDL, activeIndex, slugIndex, schemaIndex}
@@ -567,11 +916,60 @@ CREATE INDEX IF NOT EXISTS %s_schema_idx ON %s (schema_id, schema_version);
 		}
 	}
 
+	return r.ensureTenantPolicy(ctx)
+}
+
+// ensureTenantPolicy enables row-level security and installs the tenant isolation policy once.
+// The existence check keeps the ALTER TABLE locks off the request path; CREATE POLICY has no IF NOT EXISTS form.
+func (r *EntityRepository) ensureTenantPolicy(ctx context.Context) error {
+	policyName := r.tableName + "_tenant_isolation"
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_policies
+			WHERE schemaname = current_schema() AND tablename = $1 AND policyname = $2
+		)
+	`, r.tableName, policyName).Scan(&exists); err != nil {
+		return fmt.Errorf("check tenant policy for %s: %w", r.tableName, err)
+	}
+	if exists {
+		return nil
+	}
+
+	statements := []string{
+		fmt.Sprintf(`ALTER TABLE %s ENABLE ROW LEVEL SECURITY`, r.tableIdent),
+		fmt.Sprintf(`ALTER TABLE %s FORCE ROW LEVEL SECURITY`, r.tableIdent),
+		fmt.Sprintf(`
+CREATE POLICY %s ON %s
+	USING (tenant_id = current_setting('app.tenant_id', true))
+	WITH CHECK (tenant_id = current_setting('app.tenant_id', true))
+`, pgx.Identifier{policyName}.Sanitize(), r.tableIdent),
+	}
+	for _, stmt := range statements {
+		if _, err := r.pool.Exec(ctx, stmt); err != nil {
+			if isDuplicateObject(err) {
+				return nil
+			}
+			return fmt.Errorf("ensure tenant policy for %s: %w", r.tableName, err)
+		}
+	}
+
 	return nil
 }
 
+// jsonEqual reports whether two JSON documents hold the same value, ignoring formatting and key order.
+func jsonEqual(a, b []byte) bool {
+	var left, right any
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
+
 func scanEntityRecord(scanner rowScanner) (EntityRecord, error) {
 	var (
+		tenantID      string
 		entityID      string
 		entityVersion string
 		schemaID      uuid.UUID
@@ -583,7 +981,7 @@ func scanEntityRecord(scanner rowScanner) (EntityRecord, error) {
 		isActive      bool
 	)
 
-	if err := scanner.Scan(&entityID, &entityVersion, &schemaID, &schemaVersion, &slug, &payload, &createdAt, &isSoftDeleted, &isActive); err != nil {
+	if err := scanner.Scan(&tenantID, &entityID, &entityVersion, &schemaID, &schemaVersion, &slug, &payload, &createdAt, &isSoftDeleted, &isActive); err != nil {
 		return EntityRecord{}, err
 	}
 
@@ -598,6 +996,7 @@ func scanEntityRecord(scanner rowScanner) (EntityRecord, error) {
 	}
 
 	return EntityRecord{
+		TenantID:      tenantID,
 		EntityID:      entityID,
 		EntityVersion: ev,
 		SchemaID:      schemaID,
@@ -2,6 +2,8 @@ package persistence
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +12,8 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
 )
 
 func TestEntityRepositoryIntegration(t *testing.T) {
@@ -45,6 +49,8 @@ func TestEntityRepositoryIntegration(t *testing.T) {
 
 	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
 
+	ctx = tenant.WithID(ctx, "tenant-a")
+
 	schemaStore, err := NewSchemaRepositoryStore(ctx, pool)
 	require.NoError(t, err)
 
@@ -114,6 +120,10 @@ func TestEntityRepositoryIntegration(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, created.EntityVersion, fetched.EntityVersion)
 	require.Equal(t, "black-lotus", fetched.Slug)
+	require.Equal(t, "tenant-a", fetched.TenantID)
+
+	_, err = entityRepo.GetEntityByID(tenant.WithID(ctx, "tenant-b"), created.EntityID)
+	require.ErrorIs(t, err, ErrTenantMismatch)
 
 	updatePayload := SchemaDefinition([]byte(`{"name":"Black Lotus","rarity":"mythic"}`))
 	updated, err := entityRepo.UpdateEntity(ctx, UpdateEntityParams{
@@ -237,6 +247,207 @@ func TestEntityRepositoryIntegration(t *testing.T) {
 		Payload: SchemaDefinition([]byte(`{"rarity":"rare"}`)),
 	})
 	require.Error(t, err)
+
+	// Schemas declaring x-slug-template derive slugs from the payload and suffix collisions.
+	templatedSchemaID := uuid.New()
+	_, err = schemaStore.CreateOrUpdateSchema(ctx, CreateSchemaParams{
+		SchemaID: templatedSchemaID,
+		Version:  version,
+		Definition: SchemaDefinition([]byte(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"x-slug-template": {"template": "{name}-{set}", "onUpdate": true},
+			"type": "object",
+			"properties": {
+				"name": { "type": "string" },
+				"set": { "type": "string" }
+			}
+		}`)),
+		TableName:  "printings_entities",
+		Slug:       "printings-schema",
+		CategoryID: categoryID,
+		Activate:   true,
+	})
+	require.NoError(t, err)
+
+	templatedRepo, err := NewEntityRepository(ctx, pool, schemaStore, validator, EntityRepositoryConfig{
+		SchemaID: templatedSchemaID,
+	})
+	require.NoError(t, err)
+	_, ok := templatedRepo.SlugTemplate()
+	require.True(t, ok)
+
+	first, err := templatedRepo.CreateEntity(ctx, CreateEntityParams{
+		Payload: SchemaDefinition([]byte(`{"name":"Æther Vial","set":"DST"}`)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "aether-vial-dst", first.Slug)
+
+	second, err := templatedRepo.CreateOrUpdateEntity(ctx, CreateOrUpdateEntityParams{
+		EntityID: "aether-vial-reprint",
+		Payload:  SchemaDefinition([]byte(`{"name":"Aether Vial","set":"dst"}`)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "aether-vial-dst-2", second.Slug)
+
+	// Unchanged rendered slugs keep their suffix; changed ones are re-derived.
+	second, err = templatedRepo.UpdateEntity(ctx, UpdateEntityParams{
+		EntityID: second.EntityID,
+		Payload:  SchemaDefinition([]byte(`{"name":"Aether Vial","set":"DST"}`)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "aether-vial-dst-2", second.Slug)
+
+	second, err = templatedRepo.UpdateEntity(ctx, UpdateEntityParams{
+		EntityID: second.EntityID,
+		Payload:  SchemaDefinition([]byte(`{"name":"Aether Vial","set":"MMA"}`)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "aether-vial-mma", second.Slug)
+
+	// Previous slugs resolve to the entity, flagged as not current; taken slugs are rejected.
+	found, current, err := templatedRepo.GetEntityBySlug(ctx, "aether-vial-mma")
+	require.NoError(t, err)
+	require.True(t, current)
+	require.Equal(t, second.EntityID, found.EntityID)
+
+	found, current, err = templatedRepo.GetEntityBySlug(ctx, "aether-vial-dst-2")
+	require.NoError(t, err)
+	require.False(t, current)
+	require.Equal(t, "aether-vial-mma", found.Slug)
+
+	taken := "aether-vial-dst"
+	_, err = templatedRepo.UpdateEntity(ctx, UpdateEntityParams{
+		EntityID: second.EntityID,
+		Slug:     &taken,
+		Payload:  SchemaDefinition([]byte(`{"name":"Aether Vial","set":"MMA"}`)),
+	})
+	require.ErrorIs(t, err, ErrSlugTaken)
+
+	_, _, err = templatedRepo.GetEntityBySlug(ctx, "mox-pearl")
+	require.ErrorIs(t, err, ErrEntityNotFound)
+
+	// Exports read a snapshot: writes after BeginExport are not included.
+	export, err := templatedRepo.BeginExport(ctx, ExportEntitiesParams{BatchSize: 1})
+	require.NoError(t, err)
+	_, err = templatedRepo.CreateEntity(ctx, CreateEntityParams{
+		Payload: SchemaDefinition([]byte(`{"name":"Mox Pearl","set":"LEA"}`)),
+	})
+	require.NoError(t, err)
+
+	var exported []string
+	for {
+		batch, err := export.Next(ctx)
+		require.NoError(t, err)
+		if len(batch) == 0 {
+			break
+		}
+		require.Len(t, batch, 1)
+		exported = append(exported, batch[0].Slug)
+	}
+	require.NoError(t, export.Close(ctx))
+	require.ElementsMatch(t, []string{"aether-vial-dst", "aether-vial-mma"}, exported)
+	require.Equal(t, templatedSchemaID, export.Schema.SchemaID)
+
+	filtered, err := templatedRepo.BeginExport(ctx, ExportEntitiesParams{Filters: map[string]string{"set": "LEA"}})
+	require.NoError(t, err)
+	batch, err := filtered.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	require.Equal(t, "mox-pearl-lea", batch[0].Slug)
+	require.NoError(t, filtered.Close(ctx))
+
+	// Upserts skip writes that would not change the active version.
+	upsertParams := CreateOrUpdateEntityParams{
+		EntityID: "sol-ring",
+		Payload:  SchemaDefinition([]byte(`{"name":"Sol Ring","set":"LEA"}`)),
+	}
+	upserted, outcome, err := templatedRepo.UpsertEntity(ctx, upsertParams)
+	require.NoError(t, err)
+	require.Equal(t, UpsertCreated, outcome)
+
+	unchanged, outcome, err := templatedRepo.UpsertEntity(ctx, upsertParams)
+	require.NoError(t, err)
+	require.Equal(t, UpsertUnchanged, outcome)
+	require.Equal(t, upserted.EntityVersion, unchanged.EntityVersion)
+
+	upsertParams.Payload = SchemaDefinition([]byte(`{"set":"LEB","name":"Sol Ring"}`))
+	changed, outcome, err := templatedRepo.UpsertEntity(ctx, upsertParams)
+	require.NoError(t, err)
+	require.Equal(t, UpsertUpdated, outcome)
+	require.NotEqual(t, upserted.EntityVersion, changed.EntityVersion)
+
+	// Imports keep their line results and counts together.
+	importStore, err := NewEntityImportStore(ctx, pool)
+	require.NoError(t, err)
+	importID := uuid.New()
+	upload := strings.Repeat("{\"set\":\"LEA\",\"name\":\"Sol Ring\"}\n", entityImportChunkSize/20)
+	queued, err := importStore.CreateEntityImport(ctx, CreateEntityImportParams{
+		ImportID:  importID,
+		TableName: "printings_entities",
+		Format:    "ndjson",
+		Upload:    strings.NewReader(upload),
+	})
+	require.NoError(t, err)
+	require.Equal(t, EntityImportQueued, queued.Status)
+	require.JSONEq(t, `{}`, string(queued.Options))
+
+	jobID := uuid.New()
+	require.NoError(t, importStore.SetEntityImportJob(ctx, importID, jobID))
+	reader, err := importStore.OpenEntityImportUpload(ctx, importID)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, upload, string(stored), "uploads spanning several chunks read back whole")
+
+	require.ErrorIs(t, importStore.FinishEntityImport(ctx, importID, EntityImportSucceeded, nil), ErrEntityImportNotFound)
+	require.NoError(t, importStore.StartEntityImport(ctx, importID))
+	require.NoError(t, importStore.RecordEntityImportResults(ctx, importID, []EntityImportResult{{Line: 1, Outcome: "accepted"}}))
+
+	// An interrupted attempt is reset so the next one starts over.
+	require.NoError(t, importStore.ResetEntityImport(ctx, importID))
+	reset, err := importStore.GetEntityImport(ctx, importID)
+	require.NoError(t, err)
+	require.Equal(t, EntityImportQueued, reset.Status)
+	require.Zero(t, reset.Accepted)
+	require.Equal(t, &jobID, reset.JobID)
+	require.NoError(t, importStore.StartEntityImport(ctx, importID))
+	require.NoError(t, importStore.RecordEntityImportResults(ctx, importID, []EntityImportResult{
+		{Line: 2, Outcome: "rejected", Error: "decode payload: unexpected end of JSON input"},
+		{Line: 1, EntityID: "sol-ring", Outcome: "unchanged"},
+	}))
+	require.NoError(t, importStore.FinishEntityImport(ctx, importID, EntityImportSucceeded, nil))
+
+	finished, err := importStore.GetEntityImport(ctx, importID)
+	require.NoError(t, err)
+	require.Equal(t, EntityImportSucceeded, finished.Status)
+	require.Equal(t, int64(1), finished.Unchanged)
+	require.Equal(t, int64(1), finished.Rejected)
+	require.NotNil(t, finished.StartedAt)
+	require.NotNil(t, finished.FinishedAt)
+
+	reader, err = importStore.OpenEntityImportUpload(ctx, importID)
+	require.NoError(t, err)
+	stored, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Empty(t, stored, "finished imports drop their upload")
+
+	var lines []int
+	require.NoError(t, importStore.EachEntityImportResult(ctx, importID, "", func(result EntityImportResult) error {
+		lines = append(lines, result.Line)
+		return nil
+	}))
+	require.Equal(t, []int{1, 2}, lines)
+
+	var rejected []EntityImportResult
+	require.NoError(t, importStore.EachEntityImportResult(ctx, importID, "rejected", func(result EntityImportResult) error {
+		rejected = append(rejected, result)
+		return nil
+	}))
+	require.Len(t, rejected, 1)
+	require.Empty(t, rejected[0].EntityID)
+
+	_, err = importStore.GetEntityImport(tenant.WithID(ctx, "tenant-b"), importID)
+	require.ErrorIs(t, err, ErrEntityImportNotFound)
 }
 
 func TestSanitizeEntitySort(t *testing.T) {
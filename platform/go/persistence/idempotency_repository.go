@@ -0,0 +1,206 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+// Idempotency record statuses.
+const (
+	IdempotencyStatusInProgress = "in_progress"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+// IdempotencyRecord is a row of idempotency_keys.
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	Fingerprint string
+	Status      string
+	Response    *IdempotentResponse // set once Status is IdempotencyStatusCompleted
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// IdempotentResponse is the stored response replayed to retries.
+type IdempotentResponse struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
+
+// ReserveIdempotencyKeyParams describes the request claiming an idempotency key.
+type ReserveIdempotencyKeyParams struct {
+	Scope       string // caller identity the key belongs to
+	Key         string
+	Fingerprint string
+	TTL         time.Duration // how long the record (and its response) is kept
+	LockTimeout time.Duration // how long an in-progress request may hold the key
+}
+
+// ErrIdempotencyKeyNotReserved indicates the key is no longer held by the request completing or releasing it.
+var ErrIdempotencyKeyNotReserved = errors.New("idempotency key not reserved")
+
+const idempotencyColumns = `scope, idempotency_key, fingerprint, status, response_status, response_headers, response_body, created_at, expires_at`
+
+// maxReserveAttempts bounds retries when a competing request releases the key between our insert and read.
+const maxReserveAttempts = 3
+
+// IdempotencyStore persists Idempotency-Key records per tenant.
+type IdempotencyStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdempotencyStore returns a store backed by the shared pool.
+func NewIdempotencyStore(ctx context.Context, pool *pgxpool.Pool) (*IdempotencyStore, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+
+	return &IdempotencyStore{pool: pool}, nil
+}
+
+// ReserveIdempotencyKey claims the key for a new request. It returns the reserved record and true when the
+// caller should process the request, or the existing record and false when the key is already held or
+// completed. Expired records, and in-progress records with the same fingerprint whose lock ran out (the
+// original request died), are taken over.
+func (s *IdempotencyStore) ReserveIdempotencyKey(ctx context.Context, params ReserveIdempotencyKeyParams) (IdempotencyRecord, bool, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		record, err := scanIdempotencyRecord(s.pool.QueryRow(ctx, `
+			INSERT INTO idempotency_keys AS k (tenant_id, scope, idempotency_key, fingerprint, status, locked_until, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, 'in_progress', NOW() + make_interval(secs => $6), NOW(), NOW() + make_interval(secs => $5))
+			ON CONFLICT (tenant_id, scope, idempotency_key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint,
+			    status = 'in_progress',
+			    response_status = NULL,
+			    response_headers = NULL,
+			    response_body = NULL,
+			    locked_until = EXCLUDED.locked_until,
+			    created_at = NOW(),
+			    expires_at = EXCLUDED.expires_at
+			WHERE k.expires_at <= NOW()
+			   OR (k.status = 'in_progress' AND k.locked_until <= NOW() AND k.fingerprint = EXCLUDED.fingerprint)
+			RETURNING `+idempotencyColumns,
+			tenantID, params.Scope, params.Key, params.Fingerprint, params.TTL.Seconds(), params.LockTimeout.Seconds()))
+		if err == nil {
+			return record, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return IdempotencyRecord{}, false, fmt.Errorf("reserve idempotency key: %w", err)
+		}
+
+		record, err = scanIdempotencyRecord(s.pool.QueryRow(ctx, `
+			SELECT `+idempotencyColumns+`
+			FROM idempotency_keys
+			WHERE tenant_id = $1 AND scope = $2 AND idempotency_key = $3
+		`, tenantID, params.Scope, params.Key))
+		if err == nil {
+			return record, false, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return IdempotencyRecord{}, false, fmt.Errorf("get idempotency key: %w", err)
+		}
+		// Released in the meantime; try to reserve it again.
+	}
+
+	return IdempotencyRecord{}, false, fmt.Errorf("reserve idempotency key: %w", ErrIdempotencyKeyNotReserved)
+}
+
+// CompleteIdempotencyKey stores the response of the request holding the key.
+func (s *IdempotencyStore) CompleteIdempotencyKey(ctx context.Context, record IdempotencyRecord, response IdempotentResponse) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET status = 'completed', response_status = $5, response_headers = $6, response_body = $7, locked_until = NULL
+		WHERE tenant_id = $1 AND scope = $2 AND idempotency_key = $3 AND fingerprint = $4 AND status = 'in_progress'
+	`, tenantID, record.Scope, record.Key, record.Fingerprint, response.Status, response.Headers, response.Body)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrIdempotencyKeyNotReserved
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey drops the reservation so the request can be retried with the same key.
+func (s *IdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, record IdempotencyRecord) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.pool.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE tenant_id = $1 AND scope = $2 AND idempotency_key = $3 AND fingerprint = $4 AND status = 'in_progress'
+	`, tenantID, record.Scope, record.Key, record.Fingerprint); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys removes the tenant's expired records.
+func (s *IdempotencyStore) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE tenant_id = $1 AND expires_at <= NOW()
+	`, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanIdempotencyRecord(row rowScanner) (IdempotencyRecord, error) {
+	var (
+		record          IdempotencyRecord
+		responseStatus  *int32
+		responseHeaders map[string]string
+		responseBody    []byte
+	)
+
+	if err := row.Scan(
+		&record.Scope,
+		&record.Key,
+		&record.Fingerprint,
+		&record.Status,
+		&responseStatus,
+		&responseHeaders,
+		&responseBody,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	); err != nil {
+		return IdempotencyRecord{}, err
+	}
+
+	if record.Status == IdempotencyStatusCompleted && responseStatus != nil {
+		record.Response = &IdempotentResponse{
+			Status:  int(*responseStatus),
+			Headers: responseHeaders,
+			Body:    responseBody,
+		}
+	}
+
+	return record, nil
+}
@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+func TestIdempotencyStoreIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping persistence integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := NewPool(ctx, PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ClosePool(pool)
+	})
+
+	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
+
+	store, err := NewIdempotencyStore(ctx, pool)
+	require.NoError(t, err)
+
+	ctx = tenant.WithID(ctx, "tenant-a")
+	params := ReserveIdempotencyKeyParams{
+		Scope:       "user:a",
+		Key:         "key-1",
+		Fingerprint: "fp-1",
+		TTL:         time.Hour,
+		LockTimeout: time.Minute,
+	}
+
+	record, reserved, err := store.ReserveIdempotencyKey(ctx, params)
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.Equal(t, IdempotencyStatusInProgress, record.Status)
+	require.Nil(t, record.Response)
+
+	held, reserved, err := store.ReserveIdempotencyKey(ctx, params)
+	require.NoError(t, err)
+	require.False(t, reserved, "an in-progress key is not reserved twice")
+	require.Equal(t, "fp-1", held.Fingerprint)
+	require.Nil(t, held.Response)
+
+	response := IdempotentResponse{
+		Status:  201,
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    []byte(`{"id":"1"}`),
+	}
+	require.NoError(t, store.CompleteIdempotencyKey(ctx, record, response))
+	require.ErrorIs(t, store.CompleteIdempotencyKey(ctx, record, response), ErrIdempotencyKeyNotReserved)
+
+	completed, reserved, err := store.ReserveIdempotencyKey(ctx, params)
+	require.NoError(t, err)
+	require.False(t, reserved)
+	require.Equal(t, IdempotencyStatusCompleted, completed.Status)
+	require.Equal(t, &response, completed.Response)
+
+	otherTenant, reserved, err := store.ReserveIdempotencyKey(tenant.WithID(ctx, "tenant-b"), params)
+	require.NoError(t, err)
+	require.True(t, reserved, "keys are scoped to the tenant")
+	require.Equal(t, IdempotencyStatusInProgress, otherTenant.Status)
+
+	// A released key can be reserved again.
+	released := params
+	released.Key = "key-2"
+	record, reserved, err = store.ReserveIdempotencyKey(ctx, released)
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.NoError(t, store.ReleaseIdempotencyKey(ctx, record))
+	_, reserved, err = store.ReserveIdempotencyKey(ctx, released)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	// A stale reservation is taken over by a retry of the same request, but not by a different one.
+	stale := params
+	stale.Key = "key-3"
+	stale.LockTimeout = 0
+	_, reserved, err = store.ReserveIdempotencyKey(ctx, stale)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	different := stale
+	different.Fingerprint = "fp-2"
+	_, reserved, err = store.ReserveIdempotencyKey(ctx, different)
+	require.NoError(t, err)
+	require.False(t, reserved)
+
+	_, reserved, err = store.ReserveIdempotencyKey(ctx, stale)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	// Expired records are swept and their keys become reusable.
+	expired := params
+	expired.Key = "key-4"
+	expired.TTL = 0
+	_, reserved, err = store.ReserveIdempotencyKey(ctx, expired)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	deleted, err := store.DeleteExpiredIdempotencyKeys(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deleted)
+}
@@ -0,0 +1,451 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+// JobStatus is the lifecycle state of a background job.
+type JobStatus string
+
+// Job statuses. Succeeded, failed and cancelled are final.
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Final reports whether the status ends the job.
+func (s JobStatus) Final() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
+
+var (
+	// ErrJobNotFound indicates the job does not exist for the tenant.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobFinished indicates the job already reached a final status.
+	ErrJobFinished = errors.New("job already finished")
+	// ErrJobNotClaimed indicates the worker no longer holds the job, e.g. because its heartbeat lapsed and
+	// the job was requeued.
+	ErrJobNotClaimed = errors.New("job not claimed by worker")
+)
+
+// Job is a row of jobs.
+type Job struct {
+	JobID           uuid.UUID
+	TenantID        string
+	Kind            string
+	Payload         json.RawMessage
+	Status          JobStatus
+	Attempts        int
+	MaxAttempts     int
+	RunAt           time.Time // when a queued job becomes eligible to run
+	WorkerID        *string
+	HeartbeatAt     *time.Time
+	Progress        float64 // percentage, 0-100
+	ProgressMessage *string
+	Result          json.RawMessage // set by a successful run
+	Error           *string         // why the last attempt failed
+	CancelRequested bool
+	CreatedBy       *string
+	CreatedAt       time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	UpdatedAt       time.Time
+}
+
+// EnqueueJobParams describes a new job.
+type EnqueueJobParams struct {
+	JobID       uuid.UUID
+	Kind        string
+	Payload     json.RawMessage
+	MaxAttempts int        // defaults to 1
+	RunAt       *time.Time // defaults to now
+	CreatedBy   *string
+}
+
+// ListJobsParams filters and paginates jobs, newest first.
+type ListJobsParams struct {
+	Page      int
+	PageSize  int
+	Kind      *string
+	Status    *JobStatus
+	CreatedBy *string
+}
+
+// ListJobsResult holds a page of jobs and the total matching the filters.
+type ListJobsResult struct {
+	Jobs       []Job
+	TotalItems int
+}
+
+// JobHeartbeat extends a worker's claim on a running job and optionally reports progress.
+type JobHeartbeat struct {
+	JobID           uuid.UUID
+	WorkerID        string
+	Progress        *float64
+	ProgressMessage *string
+}
+
+// FinishJobParams records the final status of a running job.
+type FinishJobParams struct {
+	JobID    uuid.UUID
+	WorkerID string
+	Status   JobStatus // succeeded, failed or cancelled
+	Result   json.RawMessage
+	Error    *string
+}
+
+const jobColumns = `job_id, tenant_id, kind, payload, status, attempts, max_attempts, run_at, worker_id, heartbeat_at, progress, progress_message, result, error, cancel_requested, created_by, created_at, started_at, finished_at, updated_at`
+
+// JobStore persists background jobs. Enqueueing, listing and cancelling are scoped to the tenant of the
+// context; claiming and the other worker operations span every tenant.
+type JobStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewJobStore returns a store backed by the shared pool.
+func NewJobStore(ctx context.Context, pool *pgxpool.Pool) (*JobStore, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+
+	return &JobStore{pool: pool}, nil
+}
+
+func (s *JobStore) EnqueueJob(ctx context.Context, params EnqueueJobParams) (Job, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return Job{}, err
+	}
+	if params.JobID == uuid.Nil {
+		return Job{}, errors.New("job id is required")
+	}
+	if strings.TrimSpace(params.Kind) == "" {
+		return Job{}, errors.New("job kind is required")
+	}
+	payload := params.Payload
+	if len(payload) == 0 {
+		payload = json.RawMessage(`{}`)
+	}
+	maxAttempts := params.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		INSERT INTO jobs (job_id, tenant_id, kind, payload, status, max_attempts, run_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 'queued', $5, COALESCE($6, NOW()), $7, NOW(), NOW())
+		RETURNING `+jobColumns,
+		params.JobID, tenantID, params.Kind, []byte(payload), maxAttempts, params.RunAt, params.CreatedBy)
+
+	job, err := scanJob(row)
+	if err != nil {
+		return Job{}, fmt.Errorf("insert job: %w", err)
+	}
+	return job, nil
+}
+
+func (s *JobStore) GetJob(ctx context.Context, jobID uuid.UUID) (Job, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return Job{}, err
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE tenant_id = $1 AND job_id = $2
+	`, tenantID, jobID)
+
+	job, err := scanJob(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Job{}, ErrJobNotFound
+		}
+		return Job{}, fmt.Errorf("get job: %w", err)
+	}
+	return job, nil
+}
+
+func (s *JobStore) ListJobs(ctx context.Context, params ListJobsParams) (ListJobsResult, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return ListJobsResult{}, err
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	whereParts := []string{"tenant_id = $1"}
+	args := []any{tenantID}
+	if params.Kind != nil {
+		args = append(args, *params.Kind)
+		whereParts = append(whereParts, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	if params.Status != nil {
+		args = append(args, string(*params.Status))
+		whereParts = append(whereParts, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if params.CreatedBy != nil {
+		args = append(args, *params.CreatedBy)
+		whereParts = append(whereParts, fmt.Sprintf("created_by = $%d", len(args)))
+	}
+	whereSQL := strings.Join(whereParts, " AND ")
+
+	var total int
+	if err := s.pool.QueryRow(ctx, "SELECT COUNT(*) FROM jobs WHERE "+whereSQL, args...).Scan(&total); err != nil {
+		return ListJobsResult{}, fmt.Errorf("count jobs: %w", err)
+	}
+
+	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM jobs
+		WHERE %s
+		ORDER BY created_at DESC, job_id
+		LIMIT $%d OFFSET $%d
+	`, jobColumns, whereSQL, len(args)-1, len(args)), args...)
+	if err != nil {
+		return ListJobsResult{}, fmt.Errorf("list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := make([]Job, 0, params.PageSize)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return ListJobsResult{}, fmt.Errorf("scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return ListJobsResult{}, fmt.Errorf("list jobs: %w", err)
+	}
+	return ListJobsResult{Jobs: jobs, TotalItems: total}, nil
+}
+
+// CancelJob cancels a queued job right away and asks the worker of a running job to stop it; the worker
+// marks it cancelled once the handler returns. Finished jobs return ErrJobFinished.
+func (s *JobStore) CancelJob(ctx context.Context, jobID uuid.UUID) (Job, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return Job{}, err
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		UPDATE jobs
+		SET status = CASE WHEN status = 'queued' THEN 'cancelled' ELSE status END,
+			finished_at = CASE WHEN status = 'queued' THEN NOW() ELSE finished_at END,
+			cancel_requested = TRUE,
+			updated_at = NOW()
+		WHERE tenant_id = $1 AND job_id = $2 AND status IN ('queued', 'running')
+		RETURNING `+jobColumns,
+		tenantID, jobID)
+
+	job, err := scanJob(row)
+	if err == nil {
+		return job, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return Job{}, fmt.Errorf("cancel job: %w", err)
+	}
+	if _, err := s.GetJob(ctx, jobID); err != nil {
+		return Job{}, err
+	}
+	return Job{}, ErrJobFinished
+}
+
+// ClaimJob hands the next due queued job of one of kinds to the worker and marks it running. ok is false
+// when no job is due. Concurrent workers never claim the same job.
+func (s *JobStore) ClaimJob(ctx context.Context, workerID string, kinds []string) (job Job, ok bool, err error) {
+	if workerID == "" {
+		return Job{}, false, errors.New("worker id is required")
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		UPDATE jobs
+		SET status = 'running',
+			attempts = attempts + 1,
+			worker_id = $1,
+			heartbeat_at = NOW(),
+			started_at = COALESCE(started_at, NOW()),
+			updated_at = NOW()
+		WHERE job_id = (
+			SELECT job_id
+			FROM jobs
+			WHERE status = 'queued' AND run_at <= NOW() AND kind = ANY($2)
+			ORDER BY run_at, created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns,
+		workerID, kinds)
+
+	job, err = scanJob(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Job{}, false, nil
+		}
+		return Job{}, false, fmt.Errorf("claim job: %w", err)
+	}
+	return job, true, nil
+}
+
+// HeartbeatJob extends the worker's claim and stores the reported progress. It returns whether
+// cancellation was requested, or ErrJobNotClaimed when the worker lost the job.
+func (s *JobStore) HeartbeatJob(ctx context.Context, heartbeat JobHeartbeat) (cancelRequested bool, err error) {
+	err = s.pool.QueryRow(ctx, `
+		UPDATE jobs
+		SET heartbeat_at = NOW(),
+			progress = COALESCE($3, progress),
+			progress_message = COALESCE($4, progress_message),
+			updated_at = NOW()
+		WHERE job_id = $1 AND worker_id = $2 AND status = 'running'
+		RETURNING cancel_requested
+	`, heartbeat.JobID, heartbeat.WorkerID, heartbeat.Progress, heartbeat.ProgressMessage).Scan(&cancelRequested)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrJobNotClaimed
+		}
+		return false, fmt.Errorf("heartbeat job: %w", err)
+	}
+	return cancelRequested, nil
+}
+
+// FinishJob records the final status of a job the worker holds.
+func (s *JobStore) FinishJob(ctx context.Context, params FinishJobParams) error {
+	if !params.Status.Final() {
+		return fmt.Errorf("unsupported final job status %q", params.Status)
+	}
+	var result []byte
+	if len(params.Result) > 0 {
+		result = params.Result
+	}
+
+	return s.updateClaimed(ctx, params.JobID, params.WorkerID, `
+		status = $3,
+		result = $4,
+		error = $5,
+		progress = CASE WHEN $3 = 'succeeded' THEN 100 ELSE progress END,
+		worker_id = NULL,
+		heartbeat_at = NULL,
+		finished_at = NOW()
+	`, string(params.Status), result, params.Error)
+}
+
+// RetryJob requeues a job the worker holds after a failed attempt; it runs again after delay.
+func (s *JobStore) RetryJob(ctx context.Context, jobID uuid.UUID, workerID string, delay time.Duration, cause string) error {
+	return s.updateClaimed(ctx, jobID, workerID, `
+		status = 'queued',
+		run_at = NOW() + make_interval(secs => $3),
+		error = $4,
+		worker_id = NULL,
+		heartbeat_at = NULL
+	`, delay.Seconds(), cause)
+}
+
+// ReleaseJob gives a job back to the queue without counting the attempt, e.g. when its worker shuts down.
+func (s *JobStore) ReleaseJob(ctx context.Context, jobID uuid.UUID, workerID string) error {
+	return s.updateClaimed(ctx, jobID, workerID, `
+		status = 'queued',
+		attempts = GREATEST(attempts - 1, 0),
+		run_at = NOW(),
+		worker_id = NULL,
+		heartbeat_at = NULL
+	`)
+}
+
+func (s *JobStore) updateClaimed(ctx context.Context, jobID uuid.UUID, workerID string, assignments string, args ...any) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE jobs
+		SET `+assignments+`, updated_at = NOW()
+		WHERE job_id = $1 AND worker_id = $2 AND status = 'running'
+	`, append([]any{jobID, workerID}, args...)...)
+	if err != nil {
+		return fmt.Errorf("update job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotClaimed
+	}
+	return nil
+}
+
+// RequeueStaleJobs recovers running jobs whose last heartbeat is older than staleAfter, because their
+// worker died: they are queued again, failed when out of attempts, or cancelled when that was requested.
+func (s *JobStore) RequeueStaleJobs(ctx context.Context, staleAfter time.Duration) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = CASE
+				WHEN cancel_requested THEN 'cancelled'
+				WHEN attempts >= max_attempts THEN 'failed'
+				ELSE 'queued'
+			END,
+			error = CASE WHEN cancel_requested THEN error ELSE 'worker stopped sending heartbeats' END,
+			finished_at = CASE WHEN cancel_requested OR attempts >= max_attempts THEN NOW() END,
+			run_at = NOW(),
+			worker_id = NULL,
+			heartbeat_at = NULL,
+			updated_at = NOW()
+		WHERE status = 'running' AND heartbeat_at < NOW() - make_interval(secs => $1)
+	`, staleAfter.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("requeue stale jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanJob(scanner rowScanner) (Job, error) {
+	var (
+		job     Job
+		payload []byte
+		result  []byte
+		status  string
+	)
+	if err := scanner.Scan(
+		&job.JobID,
+		&job.TenantID,
+		&job.Kind,
+		&payload,
+		&status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.RunAt,
+		&job.WorkerID,
+		&job.HeartbeatAt,
+		&job.Progress,
+		&job.ProgressMessage,
+		&result,
+		&job.Error,
+		&job.CancelRequested,
+		&job.CreatedBy,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.UpdatedAt,
+	); err != nil {
+		return Job{}, err
+	}
+	job.Payload = payload
+	job.Result = result
+	job.Status = JobStatus(status)
+	return job, nil
+}
@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+func TestJobStoreIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping persistence integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := NewPool(ctx, PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ClosePool(pool)
+	})
+
+	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
+
+	store, err := NewJobStore(ctx, pool)
+	require.NoError(t, err)
+
+	tenantA := tenant.WithID(ctx, "tenant-a")
+	tenantB := tenant.WithID(ctx, "tenant-b")
+	creator := "user-1"
+
+	job, err := store.EnqueueJob(tenantA, EnqueueJobParams{
+		JobID:       uuid.New(),
+		Kind:        "test.echo",
+		Payload:     json.RawMessage(`{"n":1}`),
+		MaxAttempts: 2,
+		CreatedBy:   &creator,
+	})
+	require.NoError(t, err)
+	require.Equal(t, JobQueued, job.Status)
+	require.Equal(t, "tenant-a", job.TenantID)
+
+	_, err = store.GetJob(tenantB, job.JobID)
+	require.ErrorIs(t, err, ErrJobNotFound, "jobs are scoped to their tenant")
+
+	claimed, ok, err := store.ClaimJob(ctx, "worker-1", []string{"test.other"})
+	require.NoError(t, err)
+	require.False(t, ok, "only registered kinds are claimed")
+
+	claimed, ok, err = store.ClaimJob(ctx, "worker-1", []string{"test.echo"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, job.JobID, claimed.JobID)
+	require.Equal(t, JobRunning, claimed.Status)
+	require.Equal(t, 1, claimed.Attempts)
+
+	_, ok, err = store.ClaimJob(ctx, "worker-2", []string{"test.echo"})
+	require.NoError(t, err)
+	require.False(t, ok, "a running job is not claimed twice")
+
+	progress, message := 40.0, "halfway"
+	cancelRequested, err := store.HeartbeatJob(ctx, JobHeartbeat{JobID: job.JobID, WorkerID: "worker-1", Progress: &progress, ProgressMessage: &message})
+	require.NoError(t, err)
+	require.False(t, cancelRequested)
+
+	_, err = store.HeartbeatJob(ctx, JobHeartbeat{JobID: job.JobID, WorkerID: "worker-2"})
+	require.ErrorIs(t, err, ErrJobNotClaimed)
+
+	require.NoError(t, store.RetryJob(ctx, job.JobID, "worker-1", 0, "boom"))
+	retried, err := store.GetJob(tenantA, job.JobID)
+	require.NoError(t, err)
+	require.Equal(t, JobQueued, retried.Status)
+	require.Equal(t, "boom", *retried.Error)
+	require.Equal(t, 40.0, retried.Progress)
+
+	_, ok, err = store.ClaimJob(ctx, "worker-1", []string{"test.echo"})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	cancelled, err := store.CancelJob(tenantA, job.JobID)
+	require.NoError(t, err)
+	require.Equal(t, JobRunning, cancelled.Status, "running jobs are cancelled by their worker")
+	cancelRequested, err = store.HeartbeatJob(ctx, JobHeartbeat{JobID: job.JobID, WorkerID: "worker-1"})
+	require.NoError(t, err)
+	require.True(t, cancelRequested)
+
+	require.NoError(t, store.FinishJob(ctx, FinishJobParams{JobID: job.JobID, WorkerID: "worker-1", Status: JobCancelled}))
+	_, err = store.CancelJob(tenantA, job.JobID)
+	require.ErrorIs(t, err, ErrJobFinished)
+	require.ErrorIs(t, store.FinishJob(ctx, FinishJobParams{JobID: job.JobID, WorkerID: "worker-1", Status: JobSucceeded}), ErrJobNotClaimed)
+
+	queued, err := store.EnqueueJob(tenantB, EnqueueJobParams{JobID: uuid.New(), Kind: "test.echo"})
+	require.NoError(t, err)
+	cancelled, err = store.CancelJob(tenantB, queued.JobID)
+	require.NoError(t, err)
+	require.Equal(t, JobCancelled, cancelled.Status, "queued jobs are cancelled right away")
+
+	stale, err := store.EnqueueJob(tenantB, EnqueueJobParams{JobID: uuid.New(), Kind: "test.echo"})
+	require.NoError(t, err)
+	_, ok, err = store.ClaimJob(ctx, "worker-3", []string{"test.echo"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, err = pool.Exec(ctx, `UPDATE jobs SET heartbeat_at = NOW() - INTERVAL '1 hour' WHERE job_id = $1`, stale.JobID)
+	require.NoError(t, err)
+	requeued, err := store.RequeueStaleJobs(ctx, time.Minute)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, requeued)
+	stale, err = store.GetJob(tenantB, stale.JobID)
+	require.NoError(t, err)
+	require.Equal(t, JobFailed, stale.Status, "a stale job out of attempts fails")
+
+	list, err := store.ListJobs(tenantA, ListJobsParams{CreatedBy: &creator})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.TotalItems)
+	require.Equal(t, JobCancelled, list.Jobs[0].Status)
+
+	status := JobCancelled
+	list, err = store.ListJobs(tenantB, ListJobsParams{Status: &status})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.TotalItems)
+	require.Equal(t, queued.JobID, list.Jobs[0].JobID)
+}
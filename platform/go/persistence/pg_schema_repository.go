@@ -10,11 +10,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
 )
 
 // ErrSchemaNotFound indicates the requested schema/version could not be located.
 var ErrSchemaNotFound = errors.New("schema not found")
 
+// ErrSchemaConflict indicates the schema identifier is already owned by another tenant.
+var ErrSchemaConflict = errors.New("schema conflict")
+
 // SchemaRepositoryStore provides PostgreSQL-backed access to the schema_repository table.
 type SchemaRepositoryStore struct {
 	pool *pgxpool.Pool
@@ -40,8 +45,21 @@ func NewSchemaRepositoryStore(ctx context.Context, pool *pgxpool.Pool) (*SchemaR
 	return &SchemaRepositoryStore{pool: pool}, nil
 }
 
+// CheckReadable runs a trivial query against schema_repository; used by readiness checks.
+func (s *SchemaRepositoryStore) CheckReadable(ctx context.Context) error {
+	if _, err := s.pool.Exec(ctx, `SELECT 1 FROM schema_repository LIMIT 1`); err != nil {
+		return fmt.Errorf("read schema repository: %w", err)
+	}
+	return nil
+}
+
 // CreateOrUpdateSchema persists the provided schema definition and optionally activates it.
 func (s *SchemaRepositoryStore) CreateOrUpdateSchema(ctx context.Context, params CreateSchemaParams) (SchemaRecord, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaRecord{}, err
+	}
+
 	if params.SchemaID == uuid.Nil {
 		return SchemaRecord{}, errors.New("schema id is required")
 	}
@@ -62,12 +80,12 @@ func (s *SchemaRepositoryStore) CreateOrUpdateSchema(ctx context.Context, params
 		_ = tx.Rollback(ctx)
 	}()
 
-	tableName, err := s.resolveSchemaTableName(ctx, tx, params.SchemaID, params.TableName)
+	tableName, err := s.resolveSchemaTableName(ctx, tx, tenantID, params.SchemaID, params.TableName)
 	if err != nil {
 		return SchemaRecord{}, err
 	}
 
-	slug, err := s.resolveSchemaSlug(ctx, tx, params.SchemaID, params.Slug)
+	slug, err := s.resolveSchemaSlug(ctx, tx, tenantID, params.SchemaID, params.Slug)
 	if err != nil {
 		return SchemaRecord{}, err
 	}
@@ -76,17 +94,19 @@ func (s *SchemaRepositoryStore) CreateOrUpdateSchema(ctx context.Context, params
 		if _, err = tx.Exec(ctx, `
 			UPDATE schema_repository
 			SET is_active = FALSE
-			WHERE schema_id = $1 AND is_soft_deleted = FALSE
-		`, params.SchemaID); err != nil {
+			WHERE tenant_id = $1 AND schema_id = $2 AND is_soft_deleted = FALSE
+		`, tenantID, params.SchemaID); err != nil {
 			return SchemaRecord{}, fmt.Errorf("deactivate previous schema versions: %w", err)
 		}
 	}
 
-	if _, err = tx.Exec(ctx, `
+	// The conflict target is global, so the update is guarded to the caller's tenant;
+	// a schema id owned by another tenant results in zero affected rows.
+	result, err := tx.Exec(ctx, `
         INSERT INTO schema_repository (
-            schema_id, schema_version, schema_definition, table_name, slug, category_id, is_active, is_soft_deleted, created_at
+            schema_id, schema_version, tenant_id, schema_definition, table_name, slug, category_id, is_active, is_soft_deleted, created_at
         ) VALUES (
-            $1, $2, $3, $4, $5, $6, $7, FALSE, NOW()
+            $1, $2, $3, $4, $5, $6, $7, $8, FALSE, NOW()
         )
         ON CONFLICT (schema_id, schema_version)
         DO UPDATE
@@ -96,15 +116,20 @@ func (s *SchemaRepositoryStore) CreateOrUpdateSchema(ctx context.Context, params
             table_name = EXCLUDED.table_name,
             slug = EXCLUDED.slug,
             category_id = EXCLUDED.category_id
-    `, params.SchemaID, params.Version.String(), []byte(params.Definition), tableName, slug, params.CategoryID, params.Activate); err != nil {
+        WHERE schema_repository.tenant_id = EXCLUDED.tenant_id
+    `, params.SchemaID, params.Version.String(), tenantID, []byte(params.Definition), tableName, slug, params.CategoryID, params.Activate)
+	if err != nil {
 		return SchemaRecord{}, fmt.Errorf("upsert schema: %w", err)
 	}
+	if result.RowsAffected() == 0 {
+		return SchemaRecord{}, ErrSchemaConflict
+	}
 
 	row := tx.QueryRow(ctx, `
-        SELECT schema_id, schema_version, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
+        SELECT schema_id, schema_version, tenant_id, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
         FROM schema_repository
-        WHERE schema_id = $1 AND schema_version = $2
-    `, params.SchemaID, params.Version.String())
+        WHERE tenant_id = $1 AND schema_id = $2 AND schema_version = $3
+    `, tenantID, params.SchemaID, params.Version.String())
 
 	record, err := scanSchemaRecord(row)
 	if err != nil {
@@ -120,11 +145,16 @@ func (s *SchemaRepositoryStore) CreateOrUpdateSchema(ctx context.Context, params
 
 // GetSchemaByVersion retrieves a specific schema version.
 func (s *SchemaRepositoryStore) GetSchemaByVersion(ctx context.Context, schemaID uuid.UUID, version SemanticVersion) (SchemaRecord, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaRecord{}, err
+	}
+
 	row := s.pool.QueryRow(ctx, `
-        SELECT schema_id, schema_version, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
+        SELECT schema_id, schema_version, tenant_id, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
         FROM schema_repository
-        WHERE schema_id = $1 AND schema_version = $2 AND is_soft_deleted = FALSE
-    `, schemaID, version.String())
+        WHERE tenant_id = $1 AND schema_id = $2 AND schema_version = $3 AND is_soft_deleted = FALSE
+    `, tenantID, schemaID, version.String())
 
 	record, err := scanSchemaRecord(row)
 	if err != nil {
@@ -139,11 +169,16 @@ func (s *SchemaRepositoryStore) GetSchemaByVersion(ctx context.Context, schemaID
 
 // GetActiveSchema fetches the currently active schema for the provided identifier.
 func (s *SchemaRepositoryStore) GetActiveSchema(ctx context.Context, schemaID uuid.UUID) (SchemaRecord, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaRecord{}, err
+	}
+
 	row := s.pool.QueryRow(ctx, `
-        SELECT schema_id, schema_version, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
+        SELECT schema_id, schema_version, tenant_id, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
         FROM schema_repository
-        WHERE schema_id = $1 AND is_active = TRUE AND is_soft_deleted = FALSE
-    `, schemaID)
+        WHERE tenant_id = $1 AND schema_id = $2 AND is_active = TRUE AND is_soft_deleted = FALSE
+    `, tenantID, schemaID)
 
 	record, err := scanSchemaRecord(row)
 	if err != nil {
@@ -158,12 +193,17 @@ func (s *SchemaRepositoryStore) GetActiveSchema(ctx context.Context, schemaID uu
 
 // ListSchemas returns every non-deleted schema version for the identifier ordered by version chronology.
 func (s *SchemaRepositoryStore) ListSchemas(ctx context.Context, schemaID uuid.UUID) ([]SchemaRecord, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := s.pool.Query(ctx, `
-        SELECT schema_id, schema_version, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
+        SELECT schema_id, schema_version, tenant_id, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
         FROM schema_repository
-        WHERE schema_id = $1
+        WHERE tenant_id = $1 AND schema_id = $2
         ORDER BY created_at DESC
-    `, schemaID)
+    `, tenantID, schemaID)
 	if err != nil {
 		return nil, fmt.Errorf("list schemas: %w", err)
 	}
@@ -185,16 +225,21 @@ func (s *SchemaRepositoryStore) ListSchemas(ctx context.Context, schemaID uuid.U
 	return records, nil
 }
 
-// ListAllSchemaVersions returns every schema version across all schema identifiers.
+// ListAllSchemaVersions returns every schema version across all schema identifiers owned by the tenant.
 func (s *SchemaRepositoryStore) ListAllSchemaVersions(ctx context.Context, includeInactive bool) ([]SchemaRecord, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-        SELECT schema_id, schema_version, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
+        SELECT schema_id, schema_version, tenant_id, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
         FROM schema_repository
-        WHERE $1::bool = TRUE OR is_active = TRUE
+        WHERE tenant_id = $1 AND ($2::bool = TRUE OR is_active = TRUE)
         ORDER BY created_at DESC
     `
 
-	rows, err := s.pool.Query(ctx, query, includeInactive)
+	rows, err := s.pool.Query(ctx, query, tenantID, includeInactive)
 	if err != nil {
 		return nil, fmt.Errorf("list all schema versions: %w", err)
 	}
@@ -221,17 +266,22 @@ func (s *SchemaRepositoryStore) ListAllSchemaVersions(ctx context.Context, inclu
 
 // GetActiveSchemaByTableName fetches the active schema associated with the provided table name.
 func (s *SchemaRepositoryStore) GetActiveSchemaByTableName(ctx context.Context, tableName string) (SchemaRecord, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaRecord{}, err
+	}
+
 	normalized, err := normalizeTableName(tableName)
 	if err != nil {
 		return SchemaRecord{}, err
 	}
 
 	row := s.pool.QueryRow(ctx, `
-		SELECT schema_id, schema_version, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
+		SELECT schema_id, schema_version, tenant_id, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
 		FROM schema_repository
-		WHERE table_name = $1 AND is_active = TRUE AND is_soft_deleted = FALSE
+		WHERE tenant_id = $1 AND table_name = $2 AND is_active = TRUE AND is_soft_deleted = FALSE
 		LIMIT 1
-	`, normalized)
+	`, tenantID, normalized)
 
 	record, err := scanSchemaRecord(row)
 	if err != nil {
@@ -246,13 +296,18 @@ func (s *SchemaRepositoryStore) GetActiveSchemaByTableName(ctx context.Context,
 
 // GetLatestSchemaBySlug returns the most recent schema record that matches the provided slug.
 func (s *SchemaRepositoryStore) GetLatestSchemaBySlug(ctx context.Context, slug string) (SchemaRecord, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaRecord{}, err
+	}
+
 	row := s.pool.QueryRow(ctx, `
-        SELECT schema_id, schema_version, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
+        SELECT schema_id, schema_version, tenant_id, category_id, table_name, slug, schema_definition, created_at, is_soft_deleted, is_active
         FROM schema_repository
-        WHERE slug = $1
+        WHERE tenant_id = $1 AND slug = $2
         ORDER BY created_at DESC
         LIMIT 1
-    `, slug)
+    `, tenantID, slug)
 
 	record, err := scanSchemaRecord(row)
 	if err != nil {
@@ -267,6 +322,11 @@ func (s *SchemaRepositoryStore) GetLatestSchemaBySlug(ctx context.Context, slug
 
 // ActivateSchemaVersion toggles the target version as the active one (soft-deleting remains intact).
 func (s *SchemaRepositoryStore) ActivateSchemaVersion(ctx context.Context, schemaID uuid.UUID, version SemanticVersion) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("begin activate schema tx: %w", err)
@@ -278,16 +338,16 @@ func (s *SchemaRepositoryStore) ActivateSchemaVersion(ctx context.Context, schem
 	if _, err = tx.Exec(ctx, `
 		UPDATE schema_repository
 		SET is_active = FALSE
-		WHERE schema_id = $1 AND is_soft_deleted = FALSE
-	`, schemaID); err != nil {
+		WHERE tenant_id = $1 AND schema_id = $2 AND is_soft_deleted = FALSE
+	`, tenantID, schemaID); err != nil {
 		return fmt.Errorf("deactivate schemas: %w", err)
 	}
 
 	result, err := tx.Exec(ctx, `
 		UPDATE schema_repository
 		SET is_active = TRUE
-		WHERE schema_id = $1 AND schema_version = $2 AND is_soft_deleted = FALSE
-	`, schemaID, version.String())
+		WHERE tenant_id = $1 AND schema_id = $2 AND schema_version = $3 AND is_soft_deleted = FALSE
+	`, tenantID, schemaID, version.String())
 	if err != nil {
 		return fmt.Errorf("activate schema: %w", err)
 	}
@@ -303,12 +363,17 @@ func (s *SchemaRepositoryStore) ActivateSchemaVersion(ctx context.Context, schem
 // SoftDeleteSchema marks the provided schema version as deleted and deactivates it when needed.
 // deletedAt is ignored because schema versions are immutable and only track creation timestamps.
 func (s *SchemaRepositoryStore) SoftDeleteSchema(ctx context.Context, schemaID uuid.UUID, version SemanticVersion, _ time.Time) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
 	result, err := s.pool.Exec(ctx, `
 		UPDATE schema_repository
 		SET is_soft_deleted = TRUE,
 		    is_active = FALSE
-		WHERE schema_id = $1 AND schema_version = $2 AND is_soft_deleted = FALSE
-	`, schemaID, version.String())
+		WHERE tenant_id = $1 AND schema_id = $2 AND schema_version = $3 AND is_soft_deleted = FALSE
+	`, tenantID, schemaID, version.String())
 	if err != nil {
 		return fmt.Errorf("soft delete schema: %w", err)
 	}
@@ -329,6 +394,7 @@ func scanSchemaRecord(scanner rowScanner) (SchemaRecord, error) {
 	var (
 		schemaID      uuid.UUID
 		versionText   string
+		tenantID      string
 		categoryID    uuid.UUID
 		tableName     string
 		slug          string
@@ -338,7 +404,7 @@ func scanSchemaRecord(scanner rowScanner) (SchemaRecord, error) {
 		isActive      bool
 	)
 
-	if err := scanner.Scan(&schemaID, &versionText, &categoryID, &tableName, &slug, &rawDef, &createdAt, &isSoftDeleted, &isActive); err != nil {
+	if err := scanner.Scan(&schemaID, &versionText, &tenantID, &categoryID, &tableName, &slug, &rawDef, &createdAt, &isSoftDeleted, &isActive); err != nil {
 		return SchemaRecord{}, err
 	}
 
@@ -350,6 +416,7 @@ func scanSchemaRecord(scanner rowScanner) (SchemaRecord, error) {
 	return SchemaRecord{
 		SchemaID:         schemaID,
 		SchemaVersion:    version,
+		TenantID:         tenantID,
 		SchemaDefinition: SchemaDefinition(rawDef),
 		TableName:        tableName,
 		Slug:             slug,
@@ -360,7 +427,7 @@ func scanSchemaRecord(scanner rowScanner) (SchemaRecord, error) {
 	}, nil
 }
 
-func (s *SchemaRepositoryStore) resolveSchemaTableName(ctx context.Context, tx pgx.Tx, schemaID uuid.UUID, candidate string) (string, error) {
+func (s *SchemaRepositoryStore) resolveSchemaTableName(ctx context.Context, tx pgx.Tx, tenantID string, schemaID uuid.UUID, candidate string) (string, error) {
 	candidate = strings.TrimSpace(candidate)
 	if candidate != "" {
 		normalized, err := normalizeTableName(candidate)
@@ -373,9 +440,9 @@ func (s *SchemaRepositoryStore) resolveSchemaTableName(ctx context.Context, tx p
 	row := tx.QueryRow(ctx, `
 		SELECT table_name
 		FROM schema_repository
-		WHERE schema_id = $1
+		WHERE tenant_id = $1 AND schema_id = $2
 		LIMIT 1
-	`, schemaID)
+	`, tenantID, schemaID)
 
 	var existing string
 	err := row.Scan(&existing)
@@ -398,7 +465,7 @@ func (s *SchemaRepositoryStore) resolveSchemaTableName(ctx context.Context, tx p
 	}
 }
 
-func (s *SchemaRepositoryStore) resolveSchemaSlug(ctx context.Context, tx pgx.Tx, schemaID uuid.UUID, candidate string) (string, error) {
+func (s *SchemaRepositoryStore) resolveSchemaSlug(ctx context.Context, tx pgx.Tx, tenantID string, schemaID uuid.UUID, candidate string) (string, error) {
 	candidate = strings.TrimSpace(candidate)
 	if candidate != "" {
 		normalized, err := NormalizeSlug(candidate)
@@ -411,9 +478,9 @@ func (s *SchemaRepositoryStore) resolveSchemaSlug(ctx context.Context, tx pgx.Tx
 	row := tx.QueryRow(ctx, `
 		SELECT slug
 		FROM schema_repository
-		WHERE schema_id = $1
+		WHERE tenant_id = $1 AND schema_id = $2
 		LIMIT 1
-	`, schemaID)
+	`, tenantID, schemaID)
 
 	var existing string
 	err := row.Scan(&existing)
@@ -10,6 +10,8 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
 )
 
 func TestSchemaRepositoryStoreIntegration(t *testing.T) {
@@ -45,8 +47,11 @@ func TestSchemaRepositoryStoreIntegration(t *testing.T) {
 
 	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
 
+	ctx = tenant.WithID(ctx, "tenant-a")
+
 	store, err := NewSchemaRepositoryStore(ctx, pool)
 	require.NoError(t, err)
+	require.NoError(t, store.CheckReadable(ctx))
 
 	categoryStore, err := NewSchemaCategoryStore(ctx, pool)
 	require.NoError(t, err)
@@ -177,6 +182,31 @@ func TestSchemaRepositoryStoreIntegration(t *testing.T) {
 	})
 	require.Error(t, err)
 
+	otherTenantCtx := tenant.WithID(ctx, "tenant-b")
+
+	_, err = store.GetSchemaByVersion(otherTenantCtx, schemaID, versionV2)
+	require.ErrorIs(t, err, ErrSchemaNotFound)
+
+	_, err = store.GetActiveSchemaByTableName(otherTenantCtx, "cards_entities")
+	require.ErrorIs(t, err, ErrSchemaNotFound)
+
+	otherCategories, err := categoryStore.ListSchemaCategories(otherTenantCtx, true)
+	require.NoError(t, err)
+	require.Empty(t, otherCategories)
+
+	_, err = store.CreateOrUpdateSchema(otherTenantCtx, CreateSchemaParams{
+		SchemaID:   schemaID,
+		Version:    versionV2,
+		Definition: SchemaDefinition(`{"title":"hijack"}`),
+		TableName:  "cards_entities",
+		Slug:       "cards-schema",
+		CategoryID: childCategoryID,
+	})
+	require.Error(t, err)
+
+	_, err = store.ListSchemas(context.Background(), schemaID)
+	require.ErrorIs(t, err, tenant.ErrTenantRequired)
+
 	require.NoError(t, categoryStore.SoftDeleteSchemaCategory(ctx, rootCategoryID, time.Now().UTC()))
 
 	_, err = categoryStore.GetSchemaCategory(ctx, rootCategoryID)
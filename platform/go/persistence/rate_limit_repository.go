@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rateLimitRefillExpr is the token level of bucket b after refilling it ($2 capacity, $3 tokens per second).
+const rateLimitRefillExpr = `LEAST($2::DOUBLE PRECISION,
+	b.tokens + EXTRACT(EPOCH FROM NOW() - b.refilled_at)::DOUBLE PRECISION * $3::DOUBLE PRECISION)`
+
+// RateLimitStore keeps token buckets in rate_limit_buckets so every API replica shares the same limits.
+// Buckets are keyed by caller identity rather than tenant, so the table is not tenant-scoped.
+type RateLimitStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewRateLimitStore returns a store backed by the shared pool.
+func NewRateLimitStore(ctx context.Context, pool *pgxpool.Pool) (*RateLimitStore, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+
+	return &RateLimitStore{pool: pool}, nil
+}
+
+// TakeToken refills the bucket by refillPerSecond for the time elapsed since its last use, capped at
+// capacity, and removes one token if available. It returns the tokens left and whether a token was taken.
+// New buckets start full.
+func (s *RateLimitStore) TakeToken(ctx context.Context, key string, capacity, refillPerSecond float64) (float64, bool, error) {
+	var tokens float64
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO rate_limit_buckets AS b (bucket_key, tokens, refilled_at)
+		VALUES ($1, $2::DOUBLE PRECISION - 1, NOW())
+		ON CONFLICT (bucket_key) DO UPDATE
+		SET tokens = `+rateLimitRefillExpr+` - 1,
+		    refilled_at = NOW()
+		WHERE `+rateLimitRefillExpr+` >= 1
+		RETURNING tokens
+	`, key, capacity, refillPerSecond).Scan(&tokens)
+	if err == nil {
+		return tokens, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, fmt.Errorf("take rate limit token: %w", err)
+	}
+
+	// The bucket is empty; report its current level without touching it.
+	if err := s.pool.QueryRow(ctx, `
+		SELECT `+rateLimitRefillExpr+`
+		FROM rate_limit_buckets AS b
+		WHERE bucket_key = $1
+	`, key, capacity, refillPerSecond).Scan(&tokens); err != nil {
+		return 0, false, fmt.Errorf("read rate limit bucket: %w", err)
+	}
+
+	return tokens, false, nil
+}
+
+// DeleteIdleBuckets removes buckets untouched for longer than idle; a missing bucket starts full, so
+// this only needs idle to exceed the longest refill time.
+func (s *RateLimitStore) DeleteIdleBuckets(ctx context.Context, idle time.Duration) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM rate_limit_buckets
+		WHERE refilled_at < NOW() - make_interval(secs => $1)
+	`, idle.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("delete idle rate limit buckets: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestRateLimitStoreIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping persistence integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := NewPool(ctx, PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ClosePool(pool)
+	})
+
+	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
+
+	store, err := NewRateLimitStore(ctx, pool)
+	require.NoError(t, err)
+
+	// A slow refill keeps the test deterministic: one token per hour.
+	const refill = 1.0 / 3600
+
+	tokens, allowed, err := store.TakeToken(ctx, "read:user:a", 2, refill)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.InDelta(t, 1, tokens, 0.01)
+
+	tokens, allowed, err = store.TakeToken(ctx, "read:user:a", 2, refill)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.InDelta(t, 0, tokens, 0.01)
+
+	tokens, allowed, err = store.TakeToken(ctx, "read:user:a", 2, refill)
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.InDelta(t, 0, tokens, 0.01)
+
+	_, allowed, err = store.TakeToken(ctx, "read:user:b", 2, refill)
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	_, err = pool.Exec(ctx, `UPDATE rate_limit_buckets SET refilled_at = NOW() - INTERVAL '2 days' WHERE bucket_key = 'read:user:a'`)
+	require.NoError(t, err)
+
+	tokens, allowed, err = store.TakeToken(ctx, "read:user:a", 2, refill)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.InDelta(t, 1, tokens, 0.01, "refills are capped at capacity")
+
+	_, err = pool.Exec(ctx, `UPDATE rate_limit_buckets SET refilled_at = NOW() - INTERVAL '2 days' WHERE bucket_key = 'read:user:b'`)
+	require.NoError(t, err)
+
+	deleted, err := store.DeleteIdleBuckets(ctx, 24*time.Hour)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deleted)
+}
@@ -11,12 +11,15 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
 )
 
 const SchemaCategoryTable = "schema_categories"
 
 type SchemaCategory struct {
 	CategoryID       uuid.UUID  `db:"category_id" json:"categoryId"`
+	TenantID         string     `db:"tenant_id" json:"tenantId"`
 	ParentCategoryID *uuid.UUID `db:"parent_category_id" json:"parentCategoryId,omitempty"`
 	Name             string     `db:"name" json:"name"`
 	Slug             string     `db:"slug" json:"slug"`
@@ -52,6 +55,10 @@ var (
 )
 
 func (s *SchemaCategoryStore) CreateSchemaCategory(ctx context.Context, params CreateSchemaCategoryParams) (SchemaCategory, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaCategory{}, err
+	}
 	if params.CategoryID == uuid.Nil {
 		return SchemaCategory{}, errors.New("category id is required")
 	}
@@ -79,8 +86,8 @@ func (s *SchemaCategoryStore) CreateSchemaCategory(ctx context.Context, params C
 	switch scanErr := tx.QueryRow(ctx, `
 		SELECT slug
 		FROM schema_categories
-		WHERE category_id = $1
-	`, params.CategoryID).Scan(&existingSlug); {
+		WHERE tenant_id = $1 AND category_id = $2
+	`, tenantID, params.CategoryID).Scan(&existingSlug); {
 	case scanErr == nil:
 		if existingSlug != slug {
 			return SchemaCategory{}, fmt.Errorf("slug for category %s cannot be modified", params.CategoryID)
@@ -93,11 +100,11 @@ func (s *SchemaCategoryStore) CreateSchemaCategory(ctx context.Context, params C
 
 	if _, err = tx.Exec(ctx, `
 		INSERT INTO schema_categories (
-			category_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
+			category_id, tenant_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
 		) VALUES (
-			$1, $2, $3, $4, $5, NOW(), NOW(), NULL
+			$1, $2, $3, $4, $5, $6, NOW(), NOW(), NULL
 		)
-	`, params.CategoryID, params.ParentCategoryID, params.Name, slug, params.Description); err != nil {
+	`, params.CategoryID, tenantID, params.ParentCategoryID, params.Name, slug, params.Description); err != nil {
 		if isUniqueViolation(err) {
 			return SchemaCategory{}, ErrSchemaCategoryConflict
 		}
@@ -105,10 +112,10 @@ func (s *SchemaCategoryStore) CreateSchemaCategory(ctx context.Context, params C
 	}
 
 	row := tx.QueryRow(ctx, `
-		SELECT category_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
+		SELECT category_id, tenant_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
 		FROM schema_categories
-		WHERE category_id = $1
-	`, params.CategoryID)
+		WHERE tenant_id = $1 AND category_id = $2
+	`, tenantID, params.CategoryID)
 
 	category, err := scanSchemaCategory(row)
 	if err != nil {
@@ -123,11 +130,16 @@ func (s *SchemaCategoryStore) CreateSchemaCategory(ctx context.Context, params C
 }
 
 func (s *SchemaCategoryStore) GetSchemaCategory(ctx context.Context, categoryID uuid.UUID) (SchemaCategory, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaCategory{}, err
+	}
+
 	row := s.pool.QueryRow(ctx, `
-		SELECT category_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
+		SELECT category_id, tenant_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
 		FROM schema_categories
-		WHERE category_id = $1 AND deleted_at IS NULL
-	`, categoryID)
+		WHERE tenant_id = $1 AND category_id = $2 AND deleted_at IS NULL
+	`, tenantID, categoryID)
 
 	category, err := scanSchemaCategory(row)
 	if err != nil {
@@ -141,12 +153,17 @@ func (s *SchemaCategoryStore) GetSchemaCategory(ctx context.Context, categoryID
 }
 
 func (s *SchemaCategoryStore) ListSchemaCategories(ctx context.Context, includeDeleted bool) ([]SchemaCategory, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := s.pool.Query(ctx, `
-		SELECT category_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
+		SELECT category_id, tenant_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
 		FROM schema_categories
-		WHERE ($1::bool = TRUE OR deleted_at IS NULL)
+		WHERE tenant_id = $1 AND ($2::bool = TRUE OR deleted_at IS NULL)
 		ORDER BY created_at ASC
-	`, includeDeleted)
+	`, tenantID, includeDeleted)
 	if err != nil {
 		return nil, fmt.Errorf("list schema categories: %w", err)
 	}
@@ -169,16 +186,20 @@ func (s *SchemaCategoryStore) ListSchemaCategories(ctx context.Context, includeD
 }
 
 func (s *SchemaCategoryStore) SoftDeleteSchemaCategory(ctx context.Context, categoryID uuid.UUID, deletedAt time.Time) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
 	if deletedAt.IsZero() {
 		deletedAt = time.Now().UTC()
 	}
 
 	result, err := s.pool.Exec(ctx, `
 		UPDATE schema_categories
-		SET deleted_at = $2,
+		SET deleted_at = $3,
 		    updated_at = NOW()
-		WHERE category_id = $1 AND deleted_at IS NULL
-	`, categoryID, deletedAt)
+		WHERE tenant_id = $1 AND category_id = $2 AND deleted_at IS NULL
+	`, tenantID, categoryID, deletedAt)
 	if err != nil {
 		return fmt.Errorf("soft delete schema category: %w", err)
 	}
@@ -198,6 +219,10 @@ type UpdateSchemaCategoryParams struct {
 }
 
 func (s *SchemaCategoryStore) UpdateSchemaCategory(ctx context.Context, categoryID uuid.UUID, params UpdateSchemaCategoryParams) (SchemaCategory, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return SchemaCategory{}, err
+	}
 	if categoryID == uuid.Nil {
 		return SchemaCategory{}, errors.New("category id is required")
 	}
@@ -211,11 +236,11 @@ func (s *SchemaCategoryStore) UpdateSchemaCategory(ctx context.Context, category
 	}()
 
 	row := tx.QueryRow(ctx, `
-		SELECT category_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
+		SELECT category_id, tenant_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
 		FROM schema_categories
-		WHERE category_id = $1 AND deleted_at IS NULL
+		WHERE tenant_id = $1 AND category_id = $2 AND deleted_at IS NULL
 		FOR UPDATE
-	`, categoryID)
+	`, tenantID, categoryID)
 
 	current, err := scanSchemaCategory(row)
 	if err != nil {
@@ -258,13 +283,13 @@ func (s *SchemaCategoryStore) UpdateSchemaCategory(ctx context.Context, category
 
 	if _, err = tx.Exec(ctx, `
 		UPDATE schema_categories
-		SET parent_category_id = $2,
-		    name = $3,
-		    description = $4,
-		    slug = $5,
+		SET parent_category_id = $3,
+		    name = $4,
+		    description = $5,
+		    slug = $6,
 		    updated_at = NOW()
-		WHERE category_id = $1
-	`, categoryID, parentID, name, description, slug); err != nil {
+		WHERE tenant_id = $1 AND category_id = $2
+	`, tenantID, categoryID, parentID, name, description, slug); err != nil {
 		if isUniqueViolation(err) {
 			return SchemaCategory{}, ErrSchemaCategoryConflict
 		}
@@ -272,10 +297,10 @@ func (s *SchemaCategoryStore) UpdateSchemaCategory(ctx context.Context, category
 	}
 
 	row = tx.QueryRow(ctx, `
-		SELECT category_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
+		SELECT category_id, tenant_id, parent_category_id, name, slug, description, created_at, updated_at, deleted_at
 		FROM schema_categories
-		WHERE category_id = $1
-	`, categoryID)
+		WHERE tenant_id = $1 AND category_id = $2
+	`, tenantID, categoryID)
 
 	category, err := scanSchemaCategory(row)
 	if err != nil {
@@ -292,6 +317,7 @@ func (s *SchemaCategoryStore) UpdateSchemaCategory(ctx context.Context, category
 func scanSchemaCategory(scanner rowScanner) (SchemaCategory, error) {
 	var (
 		categoryID       uuid.UUID
+		tenantID         string
 		parentCategoryID pgtype.UUID
 		name             string
 		slug             string
@@ -301,7 +327,7 @@ func scanSchemaCategory(scanner rowScanner) (SchemaCategory, error) {
 		deletedAt        pgtype.Timestamptz
 	)
 
-	if err := scanner.Scan(&categoryID, &parentCategoryID, &name, &slug, &description, &createdAt, &updatedAt, &deletedAt); err != nil {
+	if err := scanner.Scan(&categoryID, &tenantID, &parentCategoryID, &name, &slug, &description, &createdAt, &updatedAt, &deletedAt); err != nil {
 		return SchemaCategory{}, err
 	}
 
@@ -328,6 +354,7 @@ func scanSchemaCategory(scanner rowScanner) (SchemaCategory, error) {
 
 	return SchemaCategory{
 		CategoryID:       categoryID,
+		TenantID:         tenantID,
 		ParentCategoryID: parentPtr,
 		Name:             name,
 		Slug:             slug,
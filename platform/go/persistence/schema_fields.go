@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// maxSchemaFieldDepth bounds schema flattening, which also stops recursive $refs.
+const maxSchemaFieldDepth = 8
+
+// SchemaField is a leaf of a schema's object properties.
+type SchemaField struct {
+	Path string // dotted path, e.g. "set.code"
+	Type string // declared JSON type ("null" aside); empty when the schema does not declare one
+}
+
+// SchemaFields flattens the object properties of a JSON Schema into dotted paths, sorted per level. Objects
+// declaring properties are descended into; arrays and other objects are single fields. Local $refs are
+// followed. Definitions that do not decode yield no fields.
+func SchemaFields(definition SchemaDefinition) []SchemaField {
+	var root map[string]any
+	if err := json.Unmarshal(definition, &root); err != nil {
+		return nil
+	}
+
+	var fields []SchemaField
+	var walk func(node map[string]any, prefix string, depth int)
+	walk = func(node map[string]any, prefix string, depth int) {
+		properties, _ := resolveSchemaRef(root, node)["properties"].(map[string]any)
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			child, _ := properties[name].(map[string]any)
+			child = resolveSchemaRef(root, child)
+			if _, nested := child["properties"].(map[string]any); nested && depth < maxSchemaFieldDepth {
+				walk(child, prefix+name+".", depth+1)
+				continue
+			}
+			fields = append(fields, SchemaField{Path: prefix + name, Type: schemaType(child)})
+		}
+	}
+	walk(root, "", 0)
+	return fields
+}
+
+func schemaType(node map[string]any) string {
+	switch declared := node["type"].(type) {
+	case string:
+		return declared
+	case []any:
+		for _, candidate := range declared {
+			if name, ok := candidate.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// resolveSchemaRef follows a local "#/..." $ref of node; other nodes are returned unchanged.
+func resolveSchemaRef(root, node map[string]any) map[string]any {
+	ref, _ := node["$ref"].(string)
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return node
+	}
+	var current any = root
+	for _, token := range strings.Split(pointer, "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := current.(map[string]any)
+		if !ok {
+			return node
+		}
+		current = object[token]
+	}
+	resolved, ok := current.(map[string]any)
+	if !ok {
+		return node
+	}
+	return resolved
+}
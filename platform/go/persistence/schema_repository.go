@@ -93,6 +93,7 @@ type SchemaDefinition = json.RawMessage
 type SchemaRecord struct {
 	SchemaID         uuid.UUID        `db:"schema_id" json:"schemaId"`
 	SchemaVersion    SemanticVersion  `db:"schema_version" json:"schemaVersion"`
+	TenantID         string           `db:"tenant_id" json:"tenantId"`
 	SchemaDefinition SchemaDefinition `db:"schema_definition" json:"schemaDefinition"`
 	TableName        string           `db:"table_name" json:"tableName"`
 	Slug             string           `db:"slug" json:"slug"`
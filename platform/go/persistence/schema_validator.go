@@ -6,30 +6,62 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tracing"
 )
 
+// SchemaValidatorObserver is told about schema cache lookups, compilations and validation outcomes.
+type SchemaValidatorObserver interface {
+	ObserveSchemaCache(hit bool)
+	ObserveSchemaCompile(duration time.Duration, err error)
+	ObserveSchemaValidation(err error)
+}
+
+// SchemaValidatorOption customizes a SchemaValidator.
+type SchemaValidatorOption func(*SchemaValidator)
+
+// WithSchemaValidatorObserver reports cache, compile and validation events to observer.
+func WithSchemaValidatorObserver(observer SchemaValidatorObserver) SchemaValidatorOption {
+	return func(v *SchemaValidator) {
+		v.observer = observer
+	}
+}
+
 // SchemaValidator validates payloads against JSON Schemas compiled via santhosh-tekuri/jsonschema.
 type SchemaValidator struct {
-	mu    sync.RWMutex
-	cache map[string]*jsonschema.Schema
+	mu       sync.RWMutex
+	cache    map[string]*jsonschema.Schema
+	observer SchemaValidatorObserver
 }
 
 // NewSchemaValidator returns a validator with an empty schema cache.
-func NewSchemaValidator() *SchemaValidator {
-	return &SchemaValidator{
+func NewSchemaValidator(opts ...SchemaValidatorOption) *SchemaValidator {
+	v := &SchemaValidator{
 		cache: make(map[string]*jsonschema.Schema),
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // Validate ensures the payload matches the provided schema definition.
-func (v *SchemaValidator) Validate(ctx context.Context, schema SchemaRecord, payload []byte) error {
+func (v *SchemaValidator) Validate(ctx context.Context, schema SchemaRecord, payload []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "schema.validate",
+		attribute.String("palmyra.schema.id", schema.SchemaID.String()),
+		attribute.String("palmyra.schema.version", schema.VersionString()),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	if len(payload) == 0 {
 		return fmt.Errorf("payload is required for validation")
 	}
 
-	compiled, err := v.getOrCompile(schema)
+	compiled, err := v.getOrCompile(ctx, schema)
 	if err != nil {
 		return err
 	}
@@ -39,20 +71,25 @@ func (v *SchemaValidator) Validate(ctx context.Context, schema SchemaRecord, pay
 		return fmt.Errorf("decode payload: %w", err)
 	}
 
-	if err := compiled.Validate(document); err != nil {
+	err = compiled.Validate(document)
+	if v.observer != nil {
+		v.observer.ObserveSchemaValidation(err)
+	}
+	if err != nil {
 		return fmt.Errorf("schema validation: %w", err)
 	}
 
 	return nil
 }
 
-func (v *SchemaValidator) getOrCompile(schema SchemaRecord) (*jsonschema.Schema, error) {
+func (v *SchemaValidator) getOrCompile(ctx context.Context, schema SchemaRecord) (*jsonschema.Schema, error) {
 	key := v.cacheKey(schema)
 
 	v.mu.RLock()
 	compiled, ok := v.cache[key]
 	v.mu.RUnlock()
 	if ok {
+		v.observeCache(true)
 		return compiled, nil
 	}
 
@@ -61,21 +98,43 @@ func (v *SchemaValidator) getOrCompile(schema SchemaRecord) (*jsonschema.Schema,
 
 	// another goroutine may have populated the cache while we were waiting
 	if compiled, ok = v.cache[key]; ok {
+		v.observeCache(true)
 		return compiled, nil
 	}
+	v.observeCache(false)
+
+	_, span := tracing.Start(ctx, "schema.compile")
+	started := time.Now()
+	newCompiled, err := compileSchema(key, schema.SchemaDefinition)
+	tracing.End(span, err)
+	if v.observer != nil {
+		v.observer.ObserveSchemaCompile(time.Since(started), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v.cache[key] = newCompiled
+	return newCompiled, nil
+}
 
+func (v *SchemaValidator) observeCache(hit bool) {
+	if v.observer != nil {
+		v.observer.ObserveSchemaCache(hit)
+	}
+}
+
+func compileSchema(key string, definition []byte) (*jsonschema.Schema, error) {
 	compiler := jsonschema.NewCompiler()
-	if err := compiler.AddResource(key, bytes.NewReader(schema.SchemaDefinition)); err != nil {
+	if err := compiler.AddResource(key, bytes.NewReader(definition)); err != nil {
 		return nil, fmt.Errorf("register schema %s: %w", key, err)
 	}
 
-	newCompiled, err := compiler.Compile(key)
+	compiled, err := compiler.Compile(key)
 	if err != nil {
 		return nil, fmt.Errorf("compile schema %s: %w", key, err)
 	}
-
-	v.cache[key] = newCompiled
-	return newCompiled, nil
+	return compiled, nil
 }
 
 func (v *SchemaValidator) cacheKey(schema SchemaRecord) string {
@@ -0,0 +1,503 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+// ServiceAccount is a non-human principal authenticating with API keys.
+type ServiceAccount struct {
+	ServiceAccountID uuid.UUID `db:"service_account_id" json:"serviceAccountId"`
+	TenantID         string    `db:"tenant_id" json:"tenantId"`
+	Name             string    `db:"name" json:"name"`
+	Description      *string   `db:"description" json:"description,omitempty"`
+	Roles            []string  `db:"roles" json:"roles"`
+	// TableScope lists the entity tables the account may access; nil means unrestricted.
+	TableScope []string  `db:"table_scope" json:"tableScope,omitempty"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// APIKey is a credential of a service account. Only the hash of its secret is stored.
+type APIKey struct {
+	KeyID            uuid.UUID  `db:"key_id" json:"keyId"`
+	TenantID         string     `db:"tenant_id" json:"tenantId"`
+	ServiceAccountID uuid.UUID  `db:"service_account_id" json:"serviceAccountId"`
+	Name             string     `db:"name" json:"name"`
+	Prefix           string     `db:"key_prefix" json:"prefix"`
+	SecretHash       string     `db:"secret_hash" json:"-"`
+	CreatedAt        time.Time  `db:"created_at" json:"createdAt"`
+	ExpiresAt        *time.Time `db:"expires_at" json:"expiresAt,omitempty"`
+	LastUsedAt       *time.Time `db:"last_used_at" json:"lastUsedAt,omitempty"`
+	RevokedAt        *time.Time `db:"revoked_at" json:"revokedAt,omitempty"`
+}
+
+var (
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	// ErrServiceAccountConflict indicates the service account name is already taken.
+	ErrServiceAccountConflict = errors.New("service account conflict")
+	ErrAPIKeyNotFound         = errors.New("api key not found")
+	// ErrAPIKeyRevoked indicates the operation requires a key that has not been revoked.
+	ErrAPIKeyRevoked = errors.New("api key revoked")
+)
+
+// apiKeyTouchInterval throttles last_used_at writes so busy keys do not update their row on every request.
+const apiKeyTouchInterval = time.Minute
+
+const (
+	serviceAccountColumns = "service_account_id, tenant_id, name, description, roles, table_scope, created_at, updated_at"
+	apiKeyColumns         = "key_id, tenant_id, service_account_id, name, key_prefix, secret_hash, created_at, expires_at, last_used_at, revoked_at"
+)
+
+// ServiceAccountStore persists service accounts and their API keys.
+type ServiceAccountStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewServiceAccountStore(ctx context.Context, pool *pgxpool.Pool) (*ServiceAccountStore, error) {
+	if pool == nil {
+		return nil, errors.New("pool is required")
+	}
+
+	return &ServiceAccountStore{pool: pool}, nil
+}
+
+type CreateServiceAccountParams struct {
+	ServiceAccountID uuid.UUID
+	Name             string
+	Description      *string
+	Roles            []string
+	TableScope       []string // nil leaves the account unrestricted
+}
+
+func (s *ServiceAccountStore) CreateServiceAccount(ctx context.Context, params CreateServiceAccountParams) (ServiceAccount, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return ServiceAccount{}, err
+	}
+	if params.ServiceAccountID == uuid.Nil {
+		return ServiceAccount{}, errors.New("service account id is required")
+	}
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return ServiceAccount{}, errors.New("service account name is required")
+	}
+	if len(params.Roles) == 0 {
+		return ServiceAccount{}, errors.New("service account roles are required")
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		INSERT INTO service_accounts (service_account_id, tenant_id, name, description, roles, table_scope, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING `+serviceAccountColumns,
+		params.ServiceAccountID, tenantID, name, params.Description, params.Roles, params.TableScope)
+
+	account, err := scanServiceAccount(row)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ServiceAccount{}, ErrServiceAccountConflict
+		}
+		return ServiceAccount{}, fmt.Errorf("insert service account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (s *ServiceAccountStore) GetServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) (ServiceAccount, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return ServiceAccount{}, err
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		SELECT `+serviceAccountColumns+`
+		FROM service_accounts
+		WHERE tenant_id = $1 AND service_account_id = $2
+	`, tenantID, serviceAccountID)
+
+	account, err := scanServiceAccount(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ServiceAccount{}, ErrServiceAccountNotFound
+		}
+		return ServiceAccount{}, fmt.Errorf("get service account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (s *ServiceAccountStore) ListServiceAccounts(ctx context.Context) ([]ServiceAccount, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+serviceAccountColumns+`
+		FROM service_accounts
+		WHERE tenant_id = $1
+		ORDER BY LOWER(name) ASC
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]ServiceAccount, 0)
+	for rows.Next() {
+		account, scanErr := scanServiceAccount(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// UpdateServiceAccountParams lists the editable fields; nil fields are left untouched.
+type UpdateServiceAccountParams struct {
+	Name        *string
+	Description *string // an empty string clears the description
+	Roles       []string
+	TableScope  *[]string // an empty slice removes the restriction
+}
+
+func (s *ServiceAccountStore) UpdateServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, params UpdateServiceAccountParams) (ServiceAccount, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return ServiceAccount{}, err
+	}
+
+	setParts := []string{}
+	var args []any
+
+	if params.Name != nil {
+		args = append(args, strings.TrimSpace(*params.Name))
+		setParts = append(setParts, fmt.Sprintf("name = $%d", len(args)))
+	}
+
+	if params.Description != nil {
+		var description *string
+		if *params.Description != "" {
+			description = params.Description
+		}
+		args = append(args, description)
+		setParts = append(setParts, fmt.Sprintf("description = $%d", len(args)))
+	}
+
+	if params.Roles != nil {
+		args = append(args, params.Roles)
+		setParts = append(setParts, fmt.Sprintf("roles = $%d", len(args)))
+	}
+
+	if params.TableScope != nil {
+		var scope []string
+		if len(*params.TableScope) > 0 {
+			scope = *params.TableScope
+		}
+		args = append(args, scope)
+		setParts = append(setParts, fmt.Sprintf("table_scope = $%d", len(args)))
+	}
+
+	if len(setParts) == 0 {
+		return ServiceAccount{}, errors.New("no fields to update")
+	}
+
+	args = append(args, tenantID, serviceAccountID)
+
+	row := s.pool.QueryRow(ctx, fmt.Sprintf(`
+		UPDATE service_accounts
+		SET %s, updated_at = NOW()
+		WHERE tenant_id = $%d AND service_account_id = $%d
+		RETURNING %s
+	`, strings.Join(setParts, ", "), len(args)-1, len(args), serviceAccountColumns), args...)
+
+	account, err := scanServiceAccount(row)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return ServiceAccount{}, ErrServiceAccountNotFound
+		case isUniqueViolation(err):
+			return ServiceAccount{}, ErrServiceAccountConflict
+		}
+		return ServiceAccount{}, fmt.Errorf("update service account: %w", err)
+	}
+
+	return account, nil
+}
+
+// DeleteServiceAccount removes the account; its API keys cascade.
+func (s *ServiceAccountStore) DeleteServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.pool.Exec(ctx, `
+		DELETE FROM service_accounts
+		WHERE tenant_id = $1 AND service_account_id = $2
+	`, tenantID, serviceAccountID)
+	if err != nil {
+		return fmt.Errorf("delete service account: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrServiceAccountNotFound
+	}
+
+	return nil
+}
+
+type CreateAPIKeyParams struct {
+	KeyID            uuid.UUID
+	ServiceAccountID uuid.UUID
+	Name             string
+	Prefix           string
+	SecretHash       string
+	ExpiresAt        *time.Time
+}
+
+func (s *ServiceAccountStore) CreateAPIKey(ctx context.Context, params CreateAPIKeyParams) (APIKey, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return APIKey{}, err
+	}
+	if params.KeyID == uuid.Nil {
+		return APIKey{}, errors.New("key id is required")
+	}
+	if params.Prefix == "" || params.SecretHash == "" {
+		return APIKey{}, errors.New("key prefix and secret hash are required")
+	}
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return APIKey{}, errors.New("key name is required")
+	}
+
+	row := s.pool.QueryRow(ctx, `
+		INSERT INTO api_keys (key_id, tenant_id, service_account_id, name, key_prefix, secret_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), $7)
+		RETURNING `+apiKeyColumns,
+		params.KeyID, tenantID, params.ServiceAccountID, name, params.Prefix, params.SecretHash, params.ExpiresAt)
+
+	key, err := scanAPIKey(row)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return APIKey{}, ErrServiceAccountNotFound
+		}
+		return APIKey{}, fmt.Errorf("insert api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (s *ServiceAccountStore) ListAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]APIKey, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE tenant_id = $1 AND service_account_id = $2
+		ORDER BY created_at ASC, key_id ASC
+	`, tenantID, serviceAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]APIKey, 0)
+	for rows.Next() {
+		key, scanErr := scanAPIKey(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks the key revoked; revoking a revoked key keeps its original revocation time.
+func (s *ServiceAccountStore) RevokeAPIKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.pool.Exec(ctx, `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE tenant_id = $1 AND service_account_id = $2 AND key_id = $3
+	`, tenantID, serviceAccountID, keyID)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// RotateAPIKey revokes the key and inserts replacement in one transaction. The replacement keeps the
+// name of the rotated key and, when it expires, the same lifetime counted from now.
+func (s *ServiceAccountStore) RotateAPIKey(ctx context.Context, serviceAccountID, keyID uuid.UUID, replacement CreateAPIKeyParams) (APIKey, error) {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return APIKey{}, err
+	}
+	if replacement.KeyID == uuid.Nil {
+		return APIKey{}, errors.New("key id is required")
+	}
+	if replacement.Prefix == "" || replacement.SecretHash == "" {
+		return APIKey{}, errors.New("key prefix and secret hash are required")
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return APIKey{}, fmt.Errorf("begin rotate tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	current, err := scanAPIKey(tx.QueryRow(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE tenant_id = $1 AND service_account_id = $2 AND key_id = $3
+		FOR UPDATE
+	`, tenantID, serviceAccountID, keyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return APIKey{}, ErrAPIKeyNotFound
+		}
+		return APIKey{}, fmt.Errorf("get api key: %w", err)
+	}
+	if current.RevokedAt != nil {
+		return APIKey{}, ErrAPIKeyRevoked
+	}
+
+	if _, err = tx.Exec(ctx, `
+		UPDATE api_keys SET revoked_at = NOW() WHERE key_id = $1
+	`, keyID); err != nil {
+		return APIKey{}, fmt.Errorf("revoke rotated api key: %w", err)
+	}
+
+	key, err := scanAPIKey(tx.QueryRow(ctx, `
+		INSERT INTO api_keys (key_id, tenant_id, service_account_id, name, key_prefix, secret_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), CASE WHEN $7::timestamptz IS NULL THEN NULL ELSE NOW() + ($7::timestamptz - $8::timestamptz) END)
+		RETURNING `+apiKeyColumns,
+		replacement.KeyID, tenantID, serviceAccountID, current.Name, replacement.Prefix, replacement.SecretHash, current.ExpiresAt, current.CreatedAt))
+	if err != nil {
+		return APIKey{}, fmt.Errorf("insert rotated api key: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return APIKey{}, fmt.Errorf("commit rotate tx: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeyByPrefix resolves a key from its public prefix across tenants; it is used to authenticate
+// requests before their tenant is known. Callers must verify the secret hash, expiry and revocation.
+func (s *ServiceAccountStore) GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE key_prefix = $1
+	`, prefix)
+
+	key, err := scanAPIKey(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return APIKey{}, ErrAPIKeyNotFound
+		}
+		return APIKey{}, fmt.Errorf("get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// TouchAPIKey records that the key was used, at most once per apiKeyTouchInterval.
+func (s *ServiceAccountStore) TouchAPIKey(ctx context.Context, keyID uuid.UUID) error {
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE key_id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - make_interval(secs => $2))
+	`, keyID, apiKeyTouchInterval.Seconds()); err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+
+	return nil
+}
+
+func scanServiceAccount(scanner rowScanner) (ServiceAccount, error) {
+	var (
+		account     ServiceAccount
+		description pgtype.Text
+	)
+
+	if err := scanner.Scan(
+		&account.ServiceAccountID,
+		&account.TenantID,
+		&account.Name,
+		&description,
+		&account.Roles,
+		&account.TableScope,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	); err != nil {
+		return ServiceAccount{}, err
+	}
+
+	if description.Valid {
+		desc := description.String
+		account.Description = &desc
+	}
+
+	return account, nil
+}
+
+func scanAPIKey(scanner rowScanner) (APIKey, error) {
+	var key APIKey
+
+	if err := scanner.Scan(
+		&key.KeyID,
+		&key.TenantID,
+		&key.ServiceAccountID,
+		&key.Name,
+		&key.Prefix,
+		&key.SecretHash,
+		&key.CreatedAt,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	); err != nil {
+		return APIKey{}, err
+	}
+
+	return key, nil
+}
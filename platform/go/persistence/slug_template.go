@@ -0,0 +1,212 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugTemplateExtension is the schema keyword declaring how entity slugs are derived from payloads.
+// It holds either a template string or an object:
+//
+//	"x-slug-template": "{name}-{number}"
+//	"x-slug-template": {"template": "{name}-{number}", "onUpdate": true}
+//
+// Placeholders name payload fields, with dots for nested objects ({images.small}). Arrays are joined
+// with "-"; missing, null and object values render as nothing. With onUpdate the slug is re-derived
+// whenever a new version changes the rendered value.
+const SlugTemplateExtension = "x-slug-template"
+
+// ErrEmptySlug indicates a slug source contains no usable characters.
+var ErrEmptySlug = errors.New("empty slug after normalization")
+
+var (
+	slugPlaceholderPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+	slugSuffixPattern      = regexp.MustCompile(`^-[0-9]+$`)
+)
+
+// transliterations covers letters that Unicode decomposition does not reduce to ASCII.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i", 'ħ': "h",
+}
+
+// SlugTemplate renders slugs from entity payloads.
+type SlugTemplate struct {
+	source   string
+	literals []string // len(literals) == len(fields)+1; literals[i] precedes fields[i]
+	fields   [][]string
+	// OnUpdate re-derives the slug when an entity is updated without an explicit slug.
+	OnUpdate bool
+}
+
+// ParseSlugTemplate compiles a template such as "{name}-{number}".
+func ParseSlugTemplate(source string) (SlugTemplate, error) {
+	t := SlugTemplate{source: source}
+	last := 0
+	for _, match := range slugPlaceholderPattern.FindAllStringSubmatchIndex(source, -1) {
+		field := strings.TrimSpace(source[match[2]:match[3]])
+		if !IsPayloadPath(field) {
+			return SlugTemplate{}, fmt.Errorf("slug template %q: invalid field %q", source, field)
+		}
+		t.literals = append(t.literals, source[last:match[0]])
+		t.fields = append(t.fields, strings.Split(field, "."))
+		last = match[1]
+	}
+	t.literals = append(t.literals, source[last:])
+	if len(t.fields) == 0 {
+		return SlugTemplate{}, fmt.Errorf("slug template %q: no {field} placeholders", source)
+	}
+	for _, literal := range t.literals {
+		if strings.ContainsAny(literal, "{}") {
+			return SlugTemplate{}, fmt.Errorf("slug template %q: unbalanced braces", source)
+		}
+	}
+	return t, nil
+}
+
+// SchemaSlugTemplate reads the SlugTemplateExtension of a schema definition; ok is false when the schema
+// does not declare one.
+func SchemaSlugTemplate(definition SchemaDefinition) (template SlugTemplate, ok bool, err error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(definition, &doc); err != nil {
+		return SlugTemplate{}, false, fmt.Errorf("decode schema definition: %w", err)
+	}
+	raw, found := doc[SlugTemplateExtension]
+	if !found {
+		return SlugTemplate{}, false, nil
+	}
+
+	var source string
+	var onUpdate bool
+	if err := json.Unmarshal(raw, &source); err != nil {
+		var spec struct {
+			Template string `json:"template"`
+			OnUpdate bool   `json:"onUpdate"`
+		}
+		if err := json.Unmarshal(raw, &spec); err != nil {
+			return SlugTemplate{}, false, fmt.Errorf("%s must be a string or {\"template\", \"onUpdate\"}", SlugTemplateExtension)
+		}
+		source, onUpdate = spec.Template, spec.OnUpdate
+	}
+
+	template, err = ParseSlugTemplate(source)
+	if err != nil {
+		return SlugTemplate{}, false, fmt.Errorf("%s: %w", SlugTemplateExtension, err)
+	}
+	template.OnUpdate = onUpdate
+	return template, true, nil
+}
+
+// String returns the template source.
+func (t SlugTemplate) String() string {
+	return t.source
+}
+
+// Render fills the template from payload and returns the normalized slug.
+func (t SlugTemplate) Render(payload map[string]any) (string, error) {
+	var b strings.Builder
+	for i, path := range t.fields {
+		b.WriteString(t.literals[i])
+		b.WriteString(slugValue(lookupField(payload, path)))
+	}
+	b.WriteString(t.literals[len(t.literals)-1])
+
+	slug, err := Slugify(b.String())
+	if err != nil {
+		return "", fmt.Errorf("slug template %q: %w", t.source, err)
+	}
+	return slug, nil
+}
+
+// RenderJSON is Render for a raw JSON payload.
+func (t SlugTemplate) RenderJSON(payload []byte) (string, error) {
+	var document map[string]any
+	if err := json.Unmarshal(payload, &document); err != nil {
+		return "", fmt.Errorf("decode payload: %w", err)
+	}
+	return t.Render(document)
+}
+
+// Slugify transliterates input to ASCII, lowercases it and joins the remaining letters and digits with
+// single hyphens, e.g. "Pokémon: Team Rocket's Mewtwo" becomes "pokemon-team-rocket-s-mewtwo".
+func Slugify(input string) (string, error) {
+	var b strings.Builder
+	dash := false
+	write := func(r rune) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			return
+		}
+		dash = true
+	}
+
+	for _, r := range norm.NFKD.String(input) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		if replacement, ok := transliterations[r]; ok {
+			for _, c := range replacement {
+				write(c)
+			}
+			continue
+		}
+		write(r)
+	}
+
+	if b.Len() == 0 {
+		return "", ErrEmptySlug
+	}
+	return NormalizeSlug(b.String())
+}
+
+// hasSlugBase reports whether slug is base or base with a numeric collision suffix ("base-2").
+func hasSlugBase(slug, base string) bool {
+	rest, ok := strings.CutPrefix(slug, base)
+	return ok && (rest == "" || slugSuffixPattern.MatchString(rest))
+}
+
+func lookupField(payload map[string]any, path []string) any {
+	var current any = payload
+	for _, key := range path {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = object[key]
+	}
+	return current
+}
+
+func slugValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if part := slugValue(item); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, "-")
+	default:
+		return ""
+	}
+}
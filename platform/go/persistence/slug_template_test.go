@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{input: "Black Lotus", expect: "black-lotus"},
+		{input: "Pokémon: Team Rocket's Mewtwo", expect: "pokemon-team-rocket-s-mewtwo"},
+		{input: "Æther Vial", expect: "aether-vial"},
+		{input: "Straße -- Ørsted Łódź", expect: "strasse-orsted-lodz"},
+		{input: "  ½ Fullwidth Ａ1 ", expect: "1-2-fullwidth-a1"},
+	}
+
+	for _, tt := range tests {
+		slug, err := Slugify(tt.input)
+		require.NoError(t, err, tt.input)
+		require.Equal(t, tt.expect, slug, tt.input)
+	}
+
+	_, err := Slugify(" !? ")
+	require.ErrorIs(t, err, ErrEmptySlug)
+}
+
+func TestSlugTemplateRender(t *testing.T) {
+	t.Parallel()
+
+	template, err := ParseSlugTemplate("{tcgLandPublicId}-{ set.code }/{number}-{name}-{tcgPlayerIds}")
+	require.NoError(t, err)
+
+	slug, err := template.RenderJSON([]byte(`{
+		"tcgLandPublicId": "PKM-1",
+		"set": {"code": "base1"},
+		"number": 4,
+		"name": "Charizard",
+		"tcgPlayerIds": [42, "43", null]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, "pkm-1-base1-4-charizard-42-43", slug)
+
+	// Missing fields render as nothing.
+	slug, err = template.Render(map[string]any{"name": "Charizard"})
+	require.NoError(t, err)
+	require.Equal(t, "charizard", slug)
+
+	_, err = template.Render(map[string]any{})
+	require.ErrorIs(t, err, ErrEmptySlug)
+}
+
+func TestParseSlugTemplateErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []string{"", "name", "{name", "{name}}", "{}", "{na me}"} {
+		_, err := ParseSlugTemplate(source)
+		require.Error(t, err, source)
+	}
+}
+
+func TestSchemaSlugTemplate(t *testing.T) {
+	t.Parallel()
+
+	_, ok, err := SchemaSlugTemplate(SchemaDefinition(`{"type":"object"}`))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	template, ok, err := SchemaSlugTemplate(SchemaDefinition(`{"x-slug-template":"{name}"}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.False(t, template.OnUpdate)
+	require.Equal(t, "{name}", template.String())
+
+	template, ok, err = SchemaSlugTemplate(SchemaDefinition(`{"x-slug-template":{"template":"{name}-{number}","onUpdate":true}}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.True(t, template.OnUpdate)
+
+	_, _, err = SchemaSlugTemplate(SchemaDefinition(`{"x-slug-template":42}`))
+	require.Error(t, err)
+}
+
+func TestHasSlugBase(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, hasSlugBase("black-lotus", "black-lotus"))
+	require.True(t, hasSlugBase("black-lotus-3", "black-lotus"))
+	require.False(t, hasSlugBase("black-lotus-alpha", "black-lotus"))
+	require.False(t, hasSlugBase("black", "black-lotus"))
+}
@@ -0,0 +1,170 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const UserIdentitiesTable = "user_identities"
+
+// UserIdentity links an external identity (token issuer and subject) to a user record.
+type UserIdentity struct {
+	Provider  string    `db:"provider" json:"provider"`
+	Subject   string    `db:"subject" json:"subject"`
+	UserID    uuid.UUID `db:"user_id" json:"userId"`
+	Email     *string   `db:"email" json:"email,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// ErrUserIdentityConflict indicates the external identity is already linked to a user.
+var ErrUserIdentityConflict = errors.New("user identity conflict")
+
+// UserIdentityParams describes an external identity to link.
+type UserIdentityParams struct {
+	Provider string
+	Subject  string
+	Email    string // email carried by the token when the identity was linked; optional
+}
+
+// GetUserByIdentity returns the user linked to the external identity.
+func (s *UserStore) GetUserByIdentity(ctx context.Context, provider, subject string) (User, error) {
+	row := s.pool.QueryRow(ctx, fmt.Sprintf(`
+        SELECT %s
+        FROM %s
+        WHERE user_id = (SELECT user_id FROM %s WHERE provider = $1 AND subject = $2)
+    `, userColumns, UsersTable, UserIdentitiesTable), provider, subject)
+
+	user, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, ErrUserNotFound
+		}
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+// LinkUserIdentity links the external identity to an existing user. It returns ErrUserIdentityConflict
+// when the identity is already linked and ErrUserNotFound when the user does not exist.
+func (s *UserStore) LinkUserIdentity(ctx context.Context, userID uuid.UUID, params UserIdentityParams) (UserIdentity, error) {
+	return insertUserIdentity(ctx, s.pool, userID, params)
+}
+
+// CreateUserWithIdentity inserts the user and links the external identity to it in one transaction.
+func (s *UserStore) CreateUserWithIdentity(ctx context.Context, params CreateUserParams, identity UserIdentityParams) (User, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return User{}, fmt.Errorf("begin user identity tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	user, err := insertUser(ctx, tx, params)
+	if err != nil {
+		return User{}, err
+	}
+
+	if _, err = insertUserIdentity(ctx, tx, user.UserID, identity); err != nil {
+		return User{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return User{}, fmt.Errorf("commit user identity tx: %w", err)
+	}
+
+	return user, nil
+}
+
+// HasPasswordCredential reports whether the user signs in with a local password (AUTH_PROVIDER=local).
+func (s *UserStore) HasPasswordCredential(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var exists bool
+	if err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auth_credentials WHERE user_id = $1)`, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check password credential: %w", err)
+	}
+	return exists, nil
+}
+
+// ListUserIdentities returns the external identities linked to the user, oldest first.
+func (s *UserStore) ListUserIdentities(ctx context.Context, userID uuid.UUID) ([]UserIdentity, error) {
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
+        SELECT provider, subject, user_id, email, created_at
+        FROM %s
+        WHERE user_id = $1
+        ORDER BY created_at, provider, subject
+    `, UserIdentitiesTable), userID)
+	if err != nil {
+		return nil, fmt.Errorf("list user identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := make([]UserIdentity, 0)
+	for rows.Next() {
+		identity, scanErr := scanUserIdentity(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("scan user identity: %w", scanErr)
+		}
+		identities = append(identities, identity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+func insertUserIdentity(ctx context.Context, q rowQuerier, userID uuid.UUID, params UserIdentityParams) (UserIdentity, error) {
+	provider := strings.TrimSpace(params.Provider)
+	subject := strings.TrimSpace(params.Subject)
+	if provider == "" || subject == "" {
+		return UserIdentity{}, errors.New("identity provider and subject are required")
+	}
+
+	var email *string
+	if trimmed := strings.TrimSpace(params.Email); trimmed != "" {
+		email = &trimmed
+	}
+
+	row := q.QueryRow(ctx, fmt.Sprintf(`
+        INSERT INTO %s (provider, subject, user_id, email)
+        VALUES ($1, $2, $3, $4)
+        RETURNING provider, subject, user_id, email, created_at
+    `, UserIdentitiesTable), provider, subject, userID, email)
+
+	identity, err := scanUserIdentity(row)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return UserIdentity{}, ErrUserIdentityConflict
+		case isForeignKeyViolation(err):
+			return UserIdentity{}, ErrUserNotFound
+		}
+		return UserIdentity{}, err
+	}
+
+	return identity, nil
+}
+
+func scanUserIdentity(row pgx.Row) (UserIdentity, error) {
+	var identity UserIdentity
+
+	if err := row.Scan(
+		&identity.Provider,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.Email,
+		&identity.CreatedAt,
+	); err != nil {
+		return UserIdentity{}, err
+	}
+
+	return identity, nil
+}
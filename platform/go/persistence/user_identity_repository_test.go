@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestUserIdentityIntegration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping persistence integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("palmyra"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	connString, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pool, err := NewPool(ctx, PoolConfig{ConnString: connString})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ClosePool(pool)
+	})
+
+	require.NoError(t, applyCoreSchemaDDL(ctx, pool))
+
+	store, err := NewUserStore(ctx, pool)
+	require.NoError(t, err)
+
+	const issuer = "https://securetoken.google.com/palmyra"
+
+	user, err := store.CreateUserWithIdentity(ctx,
+		CreateUserParams{UserID: uuid.New(), Email: "jit@example.com", FullName: "JIT"},
+		UserIdentityParams{Provider: issuer, Subject: "firebase-uid-1", Email: "jit@example.com"},
+	)
+	require.NoError(t, err)
+
+	found, err := store.GetUserByIdentity(ctx, issuer, "firebase-uid-1")
+	require.NoError(t, err)
+	require.Equal(t, user.UserID, found.UserID)
+
+	_, err = store.GetUserByIdentity(ctx, "https://other-issuer", "firebase-uid-1")
+	require.ErrorIs(t, err, ErrUserNotFound)
+
+	// A failed identity insert rolls the user back as well.
+	_, err = store.CreateUserWithIdentity(ctx,
+		CreateUserParams{UserID: uuid.New(), Email: "dup@example.com", FullName: "Dup"},
+		UserIdentityParams{Provider: issuer, Subject: "firebase-uid-1"},
+	)
+	require.ErrorIs(t, err, ErrUserIdentityConflict)
+	_, err = store.GetUserByEmail(ctx, "dup@example.com")
+	require.ErrorIs(t, err, ErrUserNotFound)
+
+	identity, err := store.LinkUserIdentity(ctx, user.UserID, UserIdentityParams{Provider: "palmyra-api", Subject: user.UserID.String()})
+	require.NoError(t, err)
+	require.Nil(t, identity.Email)
+
+	_, err = store.LinkUserIdentity(ctx, uuid.New(), UserIdentityParams{Provider: "palmyra-api", Subject: "other"})
+	require.ErrorIs(t, err, ErrUserNotFound)
+
+	identities, err := store.ListUserIdentities(ctx, user.UserID)
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+
+	hasPassword, err := store.HasPasswordCredential(ctx, user.UserID)
+	require.NoError(t, err)
+	require.False(t, hasPassword, "users created through an identity have no local password")
+
+	require.NoError(t, store.DeleteUser(ctx, user.UserID))
+	_, err = store.GetUserByIdentity(ctx, issuer, "firebase-uid-1")
+	require.ErrorIs(t, err, ErrUserNotFound)
+}
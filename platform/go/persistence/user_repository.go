@@ -16,18 +16,31 @@ const UsersTable = "users"
 
 // User represents a row in the users table.
 type User struct {
-	UserID    uuid.UUID `db:"user_id" json:"userId"`
-	Email     string    `db:"email" json:"email"`
-	FullName  string    `db:"full_name" json:"fullName"`
-	CreatedAt time.Time `db:"created_at" json:"createdAt"`
-	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+	UserID       uuid.UUID `db:"user_id" json:"userId"`
+	Email        string    `db:"email" json:"email"`
+	FullName     string    `db:"full_name" json:"fullName"`
+	Roles        []string  `db:"roles" json:"roles"`
+	Status       string    `db:"status" json:"status"`
+	StatusReason *string   `db:"status_reason" json:"statusReason,omitempty"`
+	CreatedAt    time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updatedAt"`
 }
 
+// DefaultUserRoles is assigned when a user is created without explicit roles.
+var DefaultUserRoles = []string{"user"}
+
+// DefaultUserStatus is assigned when a user is created without an explicit status.
+const DefaultUserStatus = "pending"
+
+const userColumns = "user_id, email, full_name, roles, status, status_reason, created_at, updated_at"
+
 var (
 	// ErrUserNotFound indicates a missing user record.
 	ErrUserNotFound = errors.New("user not found")
 	// ErrUserConflict indicates a uniqueness violation (e.g., duplicated email).
 	ErrUserConflict = errors.New("user conflict")
+	// ErrUserStatusConflict indicates the user status changed before a transition could be applied.
+	ErrUserStatusConflict = errors.New("user status conflict")
 )
 
 // UserStore exposes persistence helpers for the users table.
@@ -50,6 +63,8 @@ type ListUsersParams struct {
 	PageSize int
 	Sort     *string
 	Email    *string
+	Status   *string
+	Role     *string
 }
 
 // ListUsersResult includes the rows and the total count for pagination metadata.
@@ -63,22 +78,45 @@ type CreateUserParams struct {
 	UserID   uuid.UUID
 	Email    string
 	FullName string
+	Roles    []string // defaults to DefaultUserRoles when empty
+	Status   string   // defaults to DefaultUserStatus when empty
 }
 
 // CreateUser inserts a new user and returns the persisted record.
 func (s *UserStore) CreateUser(ctx context.Context, params CreateUserParams) (User, error) {
+	return insertUser(ctx, s.pool, params)
+}
+
+// rowQuerier is satisfied by both the pool and transactions.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func insertUser(ctx context.Context, q rowQuerier, params CreateUserParams) (User, error) {
 	if params.UserID == uuid.Nil {
 		return User{}, errors.New("user id is required")
 	}
 
-	row := s.pool.QueryRow(ctx, fmt.Sprintf(`
-        INSERT INTO %s (user_id, email, full_name)
-        VALUES ($1, $2, $3)
-        RETURNING user_id, email, full_name, created_at, updated_at
-    `, UsersTable),
+	roles := params.Roles
+	if len(roles) == 0 {
+		roles = DefaultUserRoles
+	}
+
+	status := params.Status
+	if status == "" {
+		status = DefaultUserStatus
+	}
+
+	row := q.QueryRow(ctx, fmt.Sprintf(`
+        INSERT INTO %s (user_id, email, full_name, roles, status)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING %s
+    `, UsersTable, userColumns),
 		params.UserID,
 		strings.TrimSpace(params.Email),
 		strings.TrimSpace(params.FullName),
+		roles,
+		status,
 	)
 
 	user, err := scanUser(row)
@@ -113,6 +151,16 @@ func (s *UserStore) ListUsers(ctx context.Context, params ListUsersParams) (List
 		whereParts = append(whereParts, fmt.Sprintf("LOWER(email) LIKE $%d", len(args)))
 	}
 
+	if params.Status != nil && strings.TrimSpace(*params.Status) != "" {
+		args = append(args, strings.TrimSpace(*params.Status))
+		whereParts = append(whereParts, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if params.Role != nil && strings.TrimSpace(*params.Role) != "" {
+		args = append(args, strings.TrimSpace(*params.Role))
+		whereParts = append(whereParts, fmt.Sprintf("$%d = ANY(roles)", len(args)))
+	}
+
 	whereSQL := strings.Join(whereParts, " AND ")
 
 	orderSQL, err := buildUserOrderBy(params.Sort)
@@ -138,12 +186,12 @@ func (s *UserStore) ListUsers(ctx context.Context, params ListUsersParams) (List
 	dataArgs = append(dataArgs, limit, offset)
 
 	query := fmt.Sprintf(`
-        SELECT user_id, email, full_name, created_at, updated_at
+        SELECT %s
         FROM %s
         WHERE %s
         %s
         LIMIT $%d OFFSET $%d
-    `, UsersTable, whereSQL, orderSQL, len(dataArgs)-1, len(dataArgs))
+    `, userColumns, UsersTable, whereSQL, orderSQL, len(dataArgs)-1, len(dataArgs))
 
 	rows, err := s.pool.Query(ctx, query, dataArgs...)
 	if err != nil {
@@ -179,6 +227,7 @@ func buildUserOrderBy(sort *string) (string, error) {
 	mapping := map[string]string{
 		"email":     "email",
 		"fullName":  "full_name",
+		"status":    "status",
 		"createdAt": "created_at",
 		"updatedAt": "updated_at",
 	}
@@ -213,9 +262,27 @@ func buildUserOrderBy(sort *string) (string, error) {
 // GetUser returns a single user by identifier.
 func (s *UserStore) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 	row := s.pool.QueryRow(ctx, fmt.Sprintf(`
-        SELECT user_id, email, full_name, created_at, updated_at
+        SELECT %s
         FROM %s WHERE user_id = $1
-    `, UsersTable), id)
+    `, userColumns, UsersTable), id)
+
+	user, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, ErrUserNotFound
+		}
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+// GetUserByEmail returns a single user by (case-insensitive) email.
+func (s *UserStore) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := s.pool.QueryRow(ctx, fmt.Sprintf(`
+        SELECT %s
+        FROM %s WHERE LOWER(email) = LOWER($1)
+    `, userColumns, UsersTable), strings.TrimSpace(email))
 
 	user, err := scanUser(row)
 	if err != nil {
@@ -231,6 +298,7 @@ func (s *UserStore) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 // UpdateUserParams represents admin-editable fields.
 type UpdateUserParams struct {
 	FullName *string
+	Roles    []string // nil leaves roles untouched
 }
 
 // UpdateUser applies the provided fields and returns the updated record.
@@ -243,6 +311,11 @@ func (s *UserStore) UpdateUser(ctx context.Context, id uuid.UUID, params UpdateU
 		setParts = append(setParts, fmt.Sprintf("full_name = $%d", len(args)))
 	}
 
+	if params.Roles != nil {
+		args = append(args, params.Roles)
+		setParts = append(setParts, fmt.Sprintf("roles = $%d", len(args)))
+	}
+
 	if len(setParts) == 0 {
 		return User{}, errors.New("no fields to update")
 	}
@@ -253,8 +326,8 @@ func (s *UserStore) UpdateUser(ctx context.Context, id uuid.UUID, params UpdateU
         UPDATE %s
         SET %s, updated_at = NOW()
         WHERE user_id = $%d
-        RETURNING user_id, email, full_name, created_at, updated_at
-    `, UsersTable, strings.Join(setParts, ", "), len(args))
+        RETURNING %s
+    `, UsersTable, strings.Join(setParts, ", "), len(args), userColumns)
 
 	row := s.pool.QueryRow(ctx, query, args...)
 
@@ -278,8 +351,8 @@ func (s *UserStore) UpdateUserFullName(ctx context.Context, id uuid.UUID, fullNa
         UPDATE %s
         SET full_name = $1, updated_at = NOW()
         WHERE user_id = $2
-        RETURNING user_id, email, full_name, created_at, updated_at
-    `, UsersTable), strings.TrimSpace(fullName), id)
+        RETURNING %s
+    `, UsersTable, userColumns), strings.TrimSpace(fullName), id)
 
 	user, err := scanUser(row)
 	if err != nil {
@@ -295,6 +368,37 @@ func (s *UserStore) UpdateUserFullName(ctx context.Context, id uuid.UUID, fullNa
 	return user, nil
 }
 
+// TransitionUserStatusParams describes a status change guarded by the expected current status.
+type TransitionUserStatusParams struct {
+	From   string
+	To     string
+	Reason *string // stored as status_reason; nil clears it
+}
+
+// TransitionUserStatus moves the user from params.From to params.To. It returns ErrUserStatusConflict
+// when the stored status no longer matches params.From, so concurrent transitions cannot both win.
+func (s *UserStore) TransitionUserStatus(ctx context.Context, id uuid.UUID, params TransitionUserStatusParams) (User, error) {
+	row := s.pool.QueryRow(ctx, fmt.Sprintf(`
+        UPDATE %s
+        SET status = $1, status_reason = $2, updated_at = NOW()
+        WHERE user_id = $3 AND status = $4
+        RETURNING %s
+    `, UsersTable, userColumns), params.To, params.Reason, id, params.From)
+
+	user, err := scanUser(row)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return User{}, err
+	}
+
+	if _, getErr := s.GetUser(ctx, id); getErr != nil {
+		return User{}, getErr
+	}
+	return User{}, ErrUserStatusConflict
+}
+
 // DeleteUser removes a user by identifier.
 func (s *UserStore) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	if id == uuid.Nil {
@@ -316,7 +420,16 @@ func (s *UserStore) DeleteUser(ctx context.Context, id uuid.UUID) error {
 func scanUser(row pgx.Row) (User, error) {
 	var user User
 
-	if err := row.Scan(&user.UserID, &user.Email, &user.FullName, &user.CreatedAt, &user.UpdatedAt); err != nil {
+	if err := row.Scan(
+		&user.UserID,
+		&user.Email,
+		&user.FullName,
+		&user.Roles,
+		&user.Status,
+		&user.StatusReason,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	); err != nil {
 		return User{}, err
 	}
 
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
+	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
+)
+
+// Class groups routes that share a limit.
+type Class string
+
+// Supported route classes.
+const (
+	ClassRead  Class = "read"
+	ClassWrite Class = "write"
+	ClassAdmin Class = "admin"
+)
+
+// Policies configures the limit of each route class; a disabled policy leaves the class unlimited.
+type Policies struct {
+	Read  Policy
+	Write Policy
+	Admin Policy
+}
+
+func (p Policies) forClass(class Class) Policy {
+	switch class {
+	case ClassAdmin:
+		return p.Admin
+	case ClassWrite:
+		return p.Write
+	default:
+		return p.Read
+	}
+}
+
+// Limiter applies per-identity token buckets to API requests.
+type Limiter struct {
+	backend       Backend
+	policies      Policies
+	adminPrefixes []string
+	logger        *zap.Logger
+}
+
+// New builds a Limiter. Requests under adminPrefixes use the admin policy; other safe methods (GET,
+// HEAD) use the read policy and everything else the write policy.
+func New(backend Backend, policies Policies, logger *zap.Logger, adminPrefixes ...string) *Limiter {
+	if backend == nil {
+		panic("rate limit backend is required")
+	}
+	if logger == nil {
+		panic("logger is required")
+	}
+	return &Limiter{backend: backend, policies: policies, adminPrefixes: adminPrefixes, logger: logger}
+}
+
+// Classify returns the route class of the request.
+func (l *Limiter) Classify(r *http.Request) Class {
+	for _, prefix := range l.adminPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return ClassAdmin
+		}
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return ClassRead
+	}
+	return ClassWrite
+}
+
+// Middleware limits requests per caller and route class. It must run after authentication so callers
+// are keyed by identity; anonymous requests are keyed by client IP. Every limited response carries the
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers; rejected requests
+// get 429 with Retry-After. Backend failures are logged and the request is let through.
+func (l *Limiter) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			class := l.Classify(r)
+			policy := l.policies.forClass(class)
+			if !policy.Enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decision, err := l.backend.Take(r.Context(), string(class)+":"+identityKey(r), policy)
+			if err != nil {
+				platformlogging.FromRequest(r, l.logger).Warn("rate limit backend failed; request allowed",
+					zap.String("class", string(class)), zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := w.Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(int(policy.Capacity())))
+			header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			header.Set("RateLimit-Reset", ceilSeconds(decision.Reset))
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", policy.Requests, ceilSeconds(policy.Per)))
+
+			if !decision.Allowed {
+				header.Set("Retry-After", ceilSeconds(decision.RetryAfter))
+				platformhttp.WriteProblem(w, r, http.StatusTooManyRequests, platformhttp.ProblemTypeRateLimited,
+					"Too Many Requests", fmt.Sprintf("%s rate limit of %s exceeded", class, policy))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// identityKey identifies the caller by their principal (see UserCredentials.PrincipalKey), or by client
+// IP for anonymous requests.
+func identityKey(r *http.Request) string {
+	if creds, ok := platformauth.UserFromContext(r.Context()); ok {
+		if key := creds.PrincipalKey(); key != "" {
+			return key
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// ceilSeconds renders d as whole seconds, rounded up so clients never retry early.
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
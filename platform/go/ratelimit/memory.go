@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Backend stores token buckets.
+type Backend interface {
+	// Take removes one token from the bucket identified by key, refilling it according to policy first.
+	Take(ctx context.Context, key string, policy Policy) (Decision, error)
+}
+
+// memorySweepInterval bounds how often idle buckets are dropped from a MemoryBackend.
+const memorySweepInterval = time.Minute
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	fullAt  time.Time
+}
+
+// MemoryBackend keeps buckets in process memory. Limits are per replica; use the Postgres backend to
+// share them between replicas.
+type MemoryBackend struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryBackend returns an empty in-memory backend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{buckets: map[string]*memoryBucket{}, now: time.Now}
+}
+
+// Take implements Backend.
+func (b *MemoryBackend) Take(_ context.Context, key string, policy Policy) (Decision, error) {
+	now := b.now()
+	capacity := policy.Capacity()
+	rate := policy.RefillRate()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sweep(now)
+
+	bucket, ok := b.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: capacity, updated: now}
+		b.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.updated).Seconds()
+	tokens := math.Min(capacity, bucket.tokens+math.Max(0, elapsed)*rate)
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	bucket.tokens = tokens
+	bucket.updated = now
+	bucket.fullAt = now.Add(secondsToDuration((capacity - tokens) / rate))
+
+	return policy.decide(allowed, tokens), nil
+}
+
+// sweep drops buckets that have refilled completely; they behave exactly like missing ones.
+func (b *MemoryBackend) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < memorySweepInterval {
+		return
+	}
+	b.lastSweep = now
+
+	for key, bucket := range b.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(b.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy describes a token bucket: Requests tokens are refilled every Per and the bucket holds up to
+// Burst tokens (Requests when zero). The zero Policy disables limiting.
+type Policy struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// ParsePolicy reads "<requests>/<duration>[:<burst>]", e.g. "600/1m" or "120/1m:20".
+// An empty value or "off" returns the zero Policy.
+func ParsePolicy(raw string) (Policy, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.EqualFold(raw, "off") {
+		return Policy{}, nil
+	}
+
+	rate, burstRaw, hasBurst := strings.Cut(raw, ":")
+	requestsRaw, perRaw, ok := strings.Cut(rate, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<duration>[:<burst>]", raw)
+	}
+
+	requests, err := strconv.Atoi(strings.TrimSpace(requestsRaw))
+	if err != nil || requests <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", raw)
+	}
+
+	per, err := time.ParseDuration(strings.TrimSpace(perRaw))
+	if err != nil || per <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit %q: duration must be positive (e.g. 1m)", raw)
+	}
+
+	policy := Policy{Requests: requests, Per: per}
+	if hasBurst {
+		burst, err := strconv.Atoi(strings.TrimSpace(burstRaw))
+		if err != nil || burst <= 0 {
+			return Policy{}, fmt.Errorf("invalid rate limit %q: burst must be a positive integer", raw)
+		}
+		policy.Burst = burst
+	}
+
+	return policy, nil
+}
+
+// UnmarshalText lets policies be loaded straight from environment variables.
+func (p *Policy) UnmarshalText(text []byte) error {
+	parsed, err := ParsePolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// String renders the policy in the ParsePolicy format.
+func (p Policy) String() string {
+	if !p.Enabled() {
+		return "off"
+	}
+	if p.Burst > 0 && p.Burst != p.Requests {
+		return fmt.Sprintf("%d/%s:%d", p.Requests, p.Per, p.Burst)
+	}
+	return fmt.Sprintf("%d/%s", p.Requests, p.Per)
+}
+
+// Enabled reports whether the policy limits anything.
+func (p Policy) Enabled() bool {
+	return p.Requests > 0 && p.Per > 0
+}
+
+// Capacity is the number of tokens a full bucket holds.
+func (p Policy) Capacity() float64 {
+	if p.Burst > 0 {
+		return float64(p.Burst)
+	}
+	return float64(p.Requests)
+}
+
+// RefillRate is the number of tokens added per second.
+func (p Policy) RefillRate() float64 {
+	return float64(p.Requests) / p.Per.Seconds()
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left after this request.
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available; zero when the request was allowed.
+	RetryAfter time.Duration
+}
+
+// decide builds the Decision for a bucket left with tokens after the request.
+func (p Policy) decide(allowed bool, tokens float64) Decision {
+	rate := p.RefillRate()
+	tokens = math.Max(0, tokens)
+
+	decision := Decision{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     secondsToDuration((p.Capacity() - tokens) / rate),
+	}
+	if !allowed {
+		decision.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	return decision
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
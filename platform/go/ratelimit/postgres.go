@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+const (
+	// postgresSweepInterval bounds how often idle buckets are deleted from Postgres.
+	postgresSweepInterval = 5 * time.Minute
+	// postgresIdleBucket is how long a bucket may stay untouched before it is deleted. It must exceed the
+	// longest time a bucket needs to refill, or deleting it would hand out tokens early.
+	postgresIdleBucket = 24 * time.Hour
+)
+
+// PostgresBackend shares buckets between API replicas through persistence.RateLimitStore.
+type PostgresBackend struct {
+	store *persistence.RateLimitStore
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewPostgresBackend wraps the store.
+func NewPostgresBackend(store *persistence.RateLimitStore) *PostgresBackend {
+	if store == nil {
+		panic("rate limit store is required")
+	}
+	return &PostgresBackend{store: store, lastSweep: time.Now()}
+}
+
+// Take implements Backend.
+func (b *PostgresBackend) Take(ctx context.Context, key string, policy Policy) (Decision, error) {
+	b.sweep(ctx)
+
+	tokens, allowed, err := b.store.TakeToken(ctx, key, policy.Capacity(), policy.RefillRate())
+	if err != nil {
+		return Decision{}, err
+	}
+	return policy.decide(allowed, tokens), nil
+}
+
+// sweep deletes idle buckets at most once per postgresSweepInterval per replica. Failures are ignored;
+// the next sweep retries.
+func (b *PostgresBackend) sweep(ctx context.Context) {
+	b.mu.Lock()
+	if time.Since(b.lastSweep) < postgresSweepInterval {
+		b.mu.Unlock()
+		return
+	}
+	b.lastSweep = time.Now()
+	b.mu.Unlock()
+
+	_, _ = b.store.DeleteIdleBuckets(ctx, postgresIdleBucket)
+}
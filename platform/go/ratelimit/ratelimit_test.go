@@ -0,0 +1,195 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+	platformhttp "github.com/zenGate-Global/palmyra-pro-saas/platform/go/http"
+)
+
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		raw     string
+		want    Policy
+		wantErr bool
+	}{
+		{raw: "", want: Policy{}},
+		{raw: "off", want: Policy{}},
+		{raw: "600/1m", want: Policy{Requests: 600, Per: time.Minute}},
+		{raw: " 120/1m:20 ", want: Policy{Requests: 120, Per: time.Minute, Burst: 20}},
+		{raw: "600", wantErr: true},
+		{raw: "0/1m", wantErr: true},
+		{raw: "10/soon", wantErr: true},
+		{raw: "10/1m:0", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParsePolicy(tc.raw)
+		if tc.wantErr {
+			require.Error(t, err, tc.raw)
+			continue
+		}
+		require.NoError(t, err, tc.raw)
+		require.Equal(t, tc.want, got, tc.raw)
+	}
+
+	require.False(t, Policy{}.Enabled())
+	require.Equal(t, 20.0, Policy{Requests: 120, Per: time.Minute, Burst: 20}.Capacity())
+	require.Equal(t, 2.0, Policy{Requests: 120, Per: time.Minute}.RefillRate())
+}
+
+func TestMemoryBackendRefills(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	backend := NewMemoryBackend()
+	backend.now = func() time.Time { return now }
+
+	policy := Policy{Requests: 2, Per: time.Second, Burst: 3}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		decision, err := backend.Take(ctx, "k", policy)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+		require.Equal(t, i, decision.Remaining)
+	}
+
+	decision, err := backend.Take(ctx, "k", policy)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+	require.Equal(t, 1500*time.Millisecond, decision.Reset)
+
+	other, err := backend.Take(ctx, "other", policy)
+	require.NoError(t, err)
+	require.True(t, other.Allowed, "buckets are independent")
+
+	now = now.Add(500 * time.Millisecond)
+	decision, err = backend.Take(ctx, "k", policy)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Equal(t, 0, decision.Remaining)
+
+	now = now.Add(time.Hour)
+	decision, err = backend.Take(ctx, "k", policy)
+	require.NoError(t, err)
+	require.Equal(t, 2, decision.Remaining, "refills are capped at the burst")
+	require.Len(t, backend.buckets, 1, "full buckets are swept")
+}
+
+func TestLimiterMiddleware(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(NewMemoryBackend(), Policies{
+		Read:  Policy{Requests: 2, Per: time.Minute},
+		Write: Policy{Requests: 1, Per: time.Minute},
+	}, zaptest.NewLogger(t), "/api/v1/admin/")
+
+	handler := limiter.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serve := func(method, path string, creds *platformauth.UserCredentials) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "203.0.113.7:4711"
+		if creds != nil {
+			req = req.WithContext(platformauth.WithUserCredentials(req.Context(), creds))
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	alice := &platformauth.UserCredentials{Id: "uid-a", UserID: "user-a"}
+	bob := &platformauth.UserCredentials{Id: "uid-b", UserID: "user-b"}
+
+	first := serve(http.MethodGet, "/api/v1/entities/cards_entities/documents", alice)
+	require.Equal(t, http.StatusNoContent, first.Code)
+	require.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "30", first.Header().Get("RateLimit-Reset"))
+	require.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+
+	require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/entities/cards_entities/documents", alice).Code)
+
+	limited := serve(http.MethodGet, "/api/v1/entities/cards_entities/documents", alice)
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	require.Equal(t, "30", limited.Header().Get("Retry-After"))
+	require.Equal(t, "application/problem+json", limited.Header().Get("Content-Type"))
+
+	var problem map[string]any
+	require.NoError(t, json.NewDecoder(limited.Body).Decode(&problem))
+	require.Equal(t, platformhttp.ProblemTypeRateLimited, problem["type"])
+	require.EqualValues(t, http.StatusTooManyRequests, problem["status"])
+
+	require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/entities/cards_entities/documents", bob).Code, "callers are limited separately")
+	require.Equal(t, http.StatusNoContent, serve(http.MethodPost, "/api/v1/entities/cards_entities/documents", alice).Code, "writes have their own bucket")
+	require.Equal(t, http.StatusTooManyRequests, serve(http.MethodPatch, "/api/v1/entities/cards_entities/documents/x", alice).Code)
+
+	admin := serve(http.MethodGet, "/api/v1/admin/users", alice)
+	require.Equal(t, http.StatusNoContent, admin.Code, "the admin class is unlimited when its policy is off")
+	require.Empty(t, admin.Header().Get("RateLimit-Limit"))
+
+	require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/users/me", nil).Code)
+	require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/users/me", nil).Code)
+	require.Equal(t, http.StatusTooManyRequests, serve(http.MethodGet, "/api/v1/users/me", nil).Code, "anonymous callers share their IP bucket")
+}
+
+func TestLimiterClassify(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(NewMemoryBackend(), Policies{}, zaptest.NewLogger(t), "/api/v1/admin/")
+
+	require.Equal(t, ClassRead, limiter.Classify(httptest.NewRequest(http.MethodHead, "/api/v1/entities", nil)))
+	require.Equal(t, ClassWrite, limiter.Classify(httptest.NewRequest(http.MethodDelete, "/api/v1/entities/x", nil)))
+	require.Equal(t, ClassAdmin, limiter.Classify(httptest.NewRequest(http.MethodGet, "/api/v1/admin/service-accounts", nil)))
+}
+
+func TestIdentityKey(t *testing.T) {
+	t.Parallel()
+
+	withCreds := func(creds *platformauth.UserCredentials) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "198.51.100.1:1234"
+		if creds != nil {
+			req = req.WithContext(platformauth.WithUserCredentials(req.Context(), creds))
+		}
+		return req
+	}
+
+	require.Equal(t, "sa:sa-1", identityKey(withCreds(&platformauth.UserCredentials{Id: "sa-1", ServiceAccountID: "sa-1"})))
+	require.Equal(t, "user:u-1", identityKey(withCreds(&platformauth.UserCredentials{Id: "uid", UserID: "u-1"})))
+	require.Equal(t, "sub:https://issuer|uid", identityKey(withCreds(&platformauth.UserCredentials{Id: "uid", Issuer: "https://issuer"})))
+	require.Equal(t, "ip:198.51.100.1", identityKey(withCreds(nil)))
+}
+
+func TestLimiterFailsOpen(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(failingBackend{}, Policies{Read: Policy{Requests: 1, Per: time.Minute}}, zaptest.NewLogger(t))
+	handler := limiter.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/entities", nil))
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+type failingBackend struct{}
+
+func (failingBackend) Take(context.Context, string, Policy) (Decision, error) {
+	return Decision{}, errors.New("backend down")
+}
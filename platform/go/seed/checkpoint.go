@@ -0,0 +1,180 @@
+package seed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// checkpointInterval is how often Run saves its progress while records are being written.
+const checkpointInterval = 5 * time.Second
+
+// position locates the end of an input record.
+type position struct {
+	Line   int   `json:"line"`   // last line of the record
+	Offset int64 `json:"offset"` // byte offset just past the record
+}
+
+// checkpoint is the progress of a seed run, persisted to Options.CheckpointFile. Everything up to Line
+// has been written (or rejected, when Options.OnResult kept the run going); later records may have been
+// written too, which is harmless since writes are idempotent upserts.
+type checkpoint struct {
+	Input       string    `json:"input"`
+	InputSize   int64     `json:"inputSize"`
+	InputSHA256 string    `json:"inputSha256"`
+	Format      Format    `json:"format"`
+	TenantID    string    `json:"tenantId"`
+	TableName   string    `json:"tableName"`
+	Completed   bool      `json:"completed"` // the whole input was written
+	UpdatedAt   time.Time `json:"updatedAt"`
+	position
+}
+
+// hashInput returns the size and SHA-256 of file and rewinds it.
+func hashInput(file *os.File) (int64, string, error) {
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", fmt.Errorf("hash input: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, "", fmt.Errorf("rewind input: %w", err)
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// loadCheckpoint reads the checkpoint at path; ok is false when there is none.
+func loadCheckpoint(path string) (cp checkpoint, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint{}, false, nil
+	}
+	if err != nil {
+		return checkpoint{}, false, fmt.Errorf("read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return checkpoint{}, false, fmt.Errorf("decode checkpoint %s: %w", path, err)
+	}
+	return cp, true, nil
+}
+
+// resumable reports why a run described by current cannot continue from cp, if it cannot.
+func (cp checkpoint) resumable(current checkpoint) error {
+	switch {
+	case cp.InputSize != current.InputSize || cp.InputSHA256 != current.InputSHA256:
+		return errors.New("input file changed since the checkpoint was written; rerun without -resume")
+	case cp.Format != current.Format || cp.TenantID != current.TenantID || cp.TableName != current.TableName:
+		return fmt.Errorf("checkpoint is for %s input into %s/%s; rerun without -resume", cp.Format, cp.TenantID, cp.TableName)
+	default:
+		return nil
+	}
+}
+
+// save writes cp to path atomically, so a crash mid-write leaves the previous checkpoint in place.
+func (cp checkpoint) save(path string) error {
+	cp.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // nolint:errcheck
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// progress tracks the completed prefix of the input while workers finish records out of order. Records
+// are numbered in input order by the readers (job.seq). A nil progress tracks nothing.
+type progress struct {
+	mu      sync.Mutex
+	next    int64              // seq of the oldest record not completed yet
+	pending map[int64]position // completed records after next
+	done    position           // end of the completed prefix
+}
+
+func newProgress(from position) *progress {
+	return &progress{pending: make(map[int64]position), done: from}
+}
+
+func (p *progress) complete(seq int64, end position) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending[seq] = end
+	for {
+		pos, ok := p.pending[p.next]
+		if !ok {
+			return
+		}
+		delete(p.pending, p.next)
+		p.done = pos
+		p.next++
+	}
+}
+
+// completed returns the end of the completed prefix.
+func (p *progress) completed() position {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done
+}
+
+// keepCheckpoint saves cp with the progress of p to path every checkpointInterval. The returned function
+// stops the saving and writes the final checkpoint.
+func keepCheckpoint(path string, cp checkpoint, p *progress, logger *zap.Logger) (finish func(completed bool) error) {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(checkpointInterval)
+		defer ticker.Stop()
+		last := cp.position
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if cp.position = p.completed(); cp.position == last {
+				continue
+			}
+			if err := cp.save(path); err != nil {
+				logger.Warn("save seed checkpoint", zap.Error(err))
+				continue
+			}
+			last = cp.position
+		}
+	}()
+
+	return func(completed bool) error {
+		close(stop)
+		<-stopped
+		cp.position = p.completed()
+		cp.Completed = completed
+		return cp.save(path)
+	}
+}
@@ -0,0 +1,88 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracksCompletedPrefix(t *testing.T) {
+	p := newProgress(position{Line: 10, Offset: 100})
+
+	p.complete(1, position{Line: 12, Offset: 120})
+	require.Equal(t, position{Line: 10, Offset: 100}, p.completed(), "record 0 is still running")
+
+	p.complete(0, position{Line: 11, Offset: 110})
+	require.Equal(t, position{Line: 12, Offset: 120}, p.completed())
+
+	p.complete(3, position{Line: 14, Offset: 140})
+	require.Equal(t, position{Line: 12, Offset: 120}, p.completed())
+}
+
+func TestCheckpointRoundTripAndResumable(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "cards.jsonl")
+	require.NoError(t, os.WriteFile(input, []byte("{\"name\":\"a\"}\n{\"name\":\"b\"}\n"), 0o600))
+
+	file, err := os.Open(input)
+	require.NoError(t, err)
+	defer file.Close()
+	size, sum, err := hashInput(file)
+	require.NoError(t, err)
+	require.EqualValues(t, 26, size)
+
+	path := filepath.Join(dir, "cards.jsonl.checkpoint.json")
+	_, ok, err := loadCheckpoint(path)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	current := checkpoint{Input: input, InputSize: size, InputSHA256: sum, Format: FormatNDJSON, TenantID: "default", TableName: "cards"}
+	saved := current
+	saved.position = position{Line: 1, Offset: 13}
+	require.NoError(t, saved.save(path))
+
+	loaded, ok, err := loadCheckpoint(path)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, position{Line: 1, Offset: 13}, loaded.position)
+	require.NoError(t, loaded.resumable(current))
+
+	changed := current
+	changed.InputSHA256 = "other"
+	require.ErrorContains(t, loaded.resumable(changed), "input file changed")
+
+	otherTable := current
+	otherTable.TableName = "sets"
+	require.ErrorContains(t, loaded.resumable(otherTable), "checkpoint is for")
+}
+
+func TestReadersResumeAfterPosition(t *testing.T) {
+	ndjson := "{\"name\":\"a\"}\n\n{\"name\":\"b\"}\r\n{\"name\":\"c\"}\n"
+
+	all := readAll(t, func(ctx context.Context, out chan<- job) error {
+		return readNDJSON(ctx, strings.NewReader(ndjson), position{}, out)
+	})
+	require.Len(t, all, 3)
+	require.Equal(t, position{Line: 3, Offset: 28}, all[1].end)
+
+	resumed := readAll(t, func(ctx context.Context, out chan<- job) error {
+		return readNDJSON(ctx, strings.NewReader(ndjson[all[1].end.Offset:]), all[1].end, out)
+	})
+	require.Len(t, resumed, 1)
+	require.EqualValues(t, 0, resumed[0].seq)
+	require.Equal(t, 4, resumed[0].line)
+	require.Equal(t, all[2].end, resumed[0].end)
+	require.JSONEq(t, `{"name":"c"}`, string(resumed[0].raw))
+
+	csvInput := "name\na\nb\nc\n"
+	rows := readAll(t, func(ctx context.Context, out chan<- job) error {
+		return readCSV(ctx, strings.NewReader(csvInput), csvTestSchema, 3, out)
+	})
+	require.Len(t, rows, 1)
+	require.Equal(t, 4, rows[0].line)
+	require.EqualValues(t, len(csvInput), rows[0].end.Offset)
+}
@@ -0,0 +1,28 @@
+package seed
+
+import (
+	platformconfig "github.com/zenGate-Global/palmyra-pro-saas/platform/go/config"
+)
+
+// Config holds the settings the seed commands share with the API: the database pool and logging. It
+// reads the same YAML file (other sections are ignored) and environment variables.
+type Config struct {
+	Database platformconfig.Database `yaml:"database"`
+	Log      platformconfig.Log      `yaml:"log"`
+}
+
+// LoadConfig loads Config from the optional YAML file at path and the environment; a non-empty
+// databaseURL (the -database-url flag) overrides both.
+func LoadConfig(path, databaseURL string) (Config, error) {
+	var cfg Config
+	if err := platformconfig.Decode(&cfg, path, platformconfig.IgnoreUnknownKeys()); err != nil {
+		return Config{}, err
+	}
+	if databaseURL != "" {
+		cfg.Database.URL = databaseURL
+	}
+	if err := platformconfig.Validate(&cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
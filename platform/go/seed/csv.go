@@ -0,0 +1,176 @@
+package seed
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// csvPayloadColumn holds a whole JSON payload, as written by entity exports of schemas without properties.
+// Other columns are merged into it.
+const csvPayloadColumn = "payload"
+
+// csvMetadataColumns are written by entity exports and are not part of the payload.
+var csvMetadataColumns = map[string]bool{
+	"entityId": true, "entityVersion": true, "schemaId": true, "schemaVersion": true, "slug": true, "createdAt": true,
+}
+
+type csvColumn struct {
+	index   int
+	path    []string
+	kind    string // declared JSON Schema type of the field
+	payload bool
+}
+
+// readCSV turns the rows of a CSV file into JSON records. Header cells name payload fields by dotted path;
+// cells are converted to the type the schema declares for their field (arrays and objects are parsed as
+// JSON), and empty cells leave their field out. Rows ending on or before line skip are not read into records.
+func readCSV(ctx context.Context, reader io.Reader, schema persistence.SchemaDefinition, skip int, jobs chan<- job) error {
+	r := csv.NewReader(reader)
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read csv header: %w", err)
+	}
+	columns, err := csvColumns(header, schema)
+	if err != nil {
+		return err
+	}
+
+	var seq int64
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		j := job{seq: seq}
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			j.line, j.end.Line = parseErr.StartLine, parseErr.Line
+			j.err = err
+		case err != nil:
+			return fmt.Errorf("read csv: %w", err)
+		default:
+			j.line, _ = r.FieldPos(0)
+			j.end.Line, _ = r.FieldPos(len(row) - 1)
+		}
+		if j.end.Line <= skip {
+			continue
+		}
+		if j.err == nil {
+			j.raw, j.err = csvRecord(columns, row)
+		}
+		j.end.Offset = r.InputOffset()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case jobs <- j:
+		}
+		seq++
+	}
+}
+
+func csvColumns(header []string, schema persistence.SchemaDefinition) ([]csvColumn, error) {
+	kinds := make(map[string]string)
+	for _, field := range persistence.SchemaFields(schema) {
+		kinds[field.Path] = field.Type
+	}
+
+	seen := make(map[string]bool, len(header))
+	columns := make([]csvColumn, 0, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // byte order mark
+		}
+		switch {
+		case csvMetadataColumns[name]:
+			continue
+		case seen[name]:
+			return nil, fmt.Errorf("csv header: duplicate column %q", name)
+		case name == csvPayloadColumn:
+			columns = append(columns, csvColumn{index: i, payload: true})
+		case persistence.IsPayloadPath(name):
+			columns = append(columns, csvColumn{index: i, path: strings.Split(name, "."), kind: kinds[name]})
+		default:
+			return nil, fmt.Errorf("csv header: invalid column %q", name)
+		}
+		seen[name] = true
+	}
+	return columns, nil
+}
+
+func csvRecord(columns []csvColumn, row []string) ([]byte, error) {
+	document := make(map[string]any)
+	for _, column := range columns {
+		if cell := row[column.index]; column.payload && cell != "" {
+			if err := json.Unmarshal([]byte(cell), &document); err != nil {
+				return nil, fmt.Errorf("column %s: %w", csvPayloadColumn, err)
+			}
+		}
+	}
+
+	for _, column := range columns {
+		cell := row[column.index]
+		if column.payload || cell == "" {
+			continue
+		}
+		value, err := csvValue(cell, column.kind)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", strings.Join(column.path, "."), err)
+		}
+
+		target := document
+		for _, key := range column.path[:len(column.path)-1] {
+			next, ok := target[key].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				target[key] = next
+			}
+			target = next
+		}
+		target[column.path[len(column.path)-1]] = value
+	}
+	return json.Marshal(document)
+}
+
+func csvValue(cell, kind string) (any, error) {
+	switch kind {
+	case "integer":
+		if _, err := strconv.ParseInt(cell, 10, 64); err != nil {
+			return nil, fmt.Errorf("%q is not an integer", cell)
+		}
+		return json.Number(cell), nil
+	case "number":
+		if _, err := strconv.ParseFloat(cell, 64); err != nil || !json.Valid([]byte(cell)) {
+			return nil, fmt.Errorf("%q is not a number", cell)
+		}
+		return json.Number(cell), nil
+	case "boolean":
+		value, err := strconv.ParseBool(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", cell)
+		}
+		return value, nil
+	case "array", "object":
+		var value any
+		if err := json.Unmarshal([]byte(cell), &value); err != nil {
+			return nil, fmt.Errorf("%q is not JSON: %w", cell, err)
+		}
+		return value, nil
+	default:
+		return cell, nil
+	}
+}
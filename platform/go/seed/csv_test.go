@@ -0,0 +1,112 @@
+package seed
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+var csvTestSchema = persistence.SchemaDefinition([]byte(`{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"number": {"type": "integer"},
+		"price": {"type": "number"},
+		"foil": {"type": "boolean"},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"set": {
+			"type": "object",
+			"properties": {"code": {"type": "string"}, "year": {"type": "integer"}}
+		}
+	}
+}`))
+
+func readAll(t *testing.T, read func(context.Context, chan<- job) error) []job {
+	t.Helper()
+
+	jobs := make(chan job, 16)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		errCh <- read(context.Background(), jobs)
+	}()
+
+	var out []job
+	for j := range jobs {
+		out = append(out, j)
+	}
+	require.NoError(t, <-errCh)
+	return out
+}
+
+func TestReadCSV(t *testing.T) {
+	input := "\ufeffentityId,name,number,price,foil,tags,set.code,set.year\n" +
+		"x,Sol Ring,1,2.50,true,\"[\"\"artifact\"\"]\",LEA,1993\n" +
+		"\n" +
+		"y,Mox Pearl,,,,,LEA,\n" +
+		"z,Broken,one,,,,,\n"
+
+	jobs := readAll(t, func(ctx context.Context, out chan<- job) error {
+		return readCSV(ctx, strings.NewReader(input), csvTestSchema, 0, out)
+	})
+	require.Len(t, jobs, 3)
+
+	require.NoError(t, jobs[0].err)
+	require.Equal(t, 2, jobs[0].line)
+	require.JSONEq(t, `{"name":"Sol Ring","number":1,"price":2.50,"foil":true,"tags":["artifact"],"set":{"code":"LEA","year":1993}}`, string(jobs[0].raw))
+
+	require.NoError(t, jobs[1].err)
+	require.Equal(t, 4, jobs[1].line)
+	require.JSONEq(t, `{"name":"Mox Pearl","set":{"code":"LEA"}}`, string(jobs[1].raw), "empty cells are left out")
+
+	require.Equal(t, 5, jobs[2].line)
+	require.ErrorContains(t, jobs[2].err, `column number: "one" is not an integer`)
+}
+
+func TestReadCSVPayloadColumn(t *testing.T) {
+	input := "payload,name\n" +
+		"\"{\"\"name\"\":\"\"old\"\",\"\"number\"\":7}\",new\n"
+
+	jobs := readAll(t, func(ctx context.Context, out chan<- job) error {
+		return readCSV(ctx, strings.NewReader(input), csvTestSchema, 0, out)
+	})
+	require.Len(t, jobs, 1)
+	require.NoError(t, jobs[0].err)
+	require.JSONEq(t, `{"name":"new","number":7}`, string(jobs[0].raw), "columns override the payload column")
+}
+
+func TestReadCSVMalformedRow(t *testing.T) {
+	input := "name,number\nSol Ring,1\nMox Pearl\n"
+
+	jobs := readAll(t, func(ctx context.Context, out chan<- job) error {
+		return readCSV(ctx, strings.NewReader(input), csvTestSchema, 0, out)
+	})
+	require.Len(t, jobs, 2)
+	require.NoError(t, jobs[0].err)
+	require.Error(t, jobs[1].err, "rows with the wrong number of fields are rejected, not fatal")
+	require.Equal(t, 3, jobs[1].line)
+}
+
+func TestCSVColumnsRejectsBadHeaders(t *testing.T) {
+	_, err := csvColumns([]string{"name", "name"}, csvTestSchema)
+	require.ErrorContains(t, err, "duplicate column")
+
+	_, err = csvColumns([]string{"name", "set..code"}, csvTestSchema)
+	require.ErrorContains(t, err, "invalid column")
+}
+
+func TestReadNDJSONSkipsBlankLines(t *testing.T) {
+	input := "{\"name\":\"a\"}\n\n  \n{\"name\":\"b\"}\n"
+
+	jobs := readAll(t, func(ctx context.Context, out chan<- job) error {
+		return readNDJSON(ctx, strings.NewReader(input), position{}, out)
+	})
+	require.Len(t, jobs, 2)
+	require.Equal(t, 1, jobs[0].line)
+	require.Equal(t, 4, jobs[1].line)
+	require.JSONEq(t, `{"name":"b"}`, string(jobs[1].raw))
+}
@@ -0,0 +1,98 @@
+package seed
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/metrics"
+)
+
+// pushJob is the Pushgateway job name seed runs are grouped under, together with the table label.
+const pushJob = "palmyra_seed"
+
+// runMetrics exposes a seed run's throughput, served on Options.MetricsAddr while the run lasts and/or
+// pushed to Options.PushGatewayURL when it ends.
+type runMetrics struct {
+	registry *prometheus.Registry
+	records  *prometheus.CounterVec
+	duration prometheus.Gauge
+	started  time.Time
+	table    string
+
+	validator *metrics.SchemaValidator
+	writes    *metrics.EntityWrites
+}
+
+func newRunMetrics(table string, pool *pgxpool.Pool) *runMetrics {
+	registry := metrics.NewRegistry()
+	m := &runMetrics{
+		registry: registry,
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   "seed",
+			Name:        "records_total",
+			Help:        "Seed input records by result (accepted, updated, unchanged, rejected or valid).",
+			ConstLabels: prometheus.Labels{"table": table},
+		}, []string{"result"}),
+		duration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   "seed",
+			Name:        "duration_seconds",
+			Help:        "Wall-clock time the seed run has taken so far.",
+			ConstLabels: prometheus.Labels{"table": table},
+		}),
+		started:   time.Now(),
+		table:     table,
+		validator: metrics.NewSchemaValidator(registry),
+		writes:    metrics.NewEntityWrites(registry),
+	}
+	registry.MustRegister(m.records, m.duration, metrics.NewPoolCollector(pool, "seed"))
+	for _, outcome := range []Outcome{OutcomeAccepted, OutcomeUpdated, OutcomeUnchanged, OutcomeRejected, OutcomeValid} {
+		m.records.WithLabelValues(string(outcome))
+	}
+	return m
+}
+
+// observe counts one record; runs without metrics (Apply) pass a nil runMetrics.
+func (m *runMetrics) observe(result string) {
+	if m == nil {
+		return
+	}
+	m.records.WithLabelValues(result).Inc()
+	m.duration.Set(time.Since(m.started).Seconds())
+}
+
+// serve exposes /metrics on addr until the returned function is called. Stopping closes the listener
+// before returning, so the next run of a seed plan can serve on the same address.
+func (m *runMetrics) serve(addr string, logger *zap.Logger) (stop func(), err error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler(m.registry))
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			logger.Warn("metrics server stopped", zap.Error(err))
+		}
+	}()
+
+	logger.Info("serving seed metrics", zap.String("addr", listener.Addr().String()))
+	return func() { _ = server.Close() }, nil
+}
+
+// push sends the final values to a Prometheus Pushgateway, replacing the previous run of the same table.
+func (m *runMetrics) push(url string) error {
+	m.duration.Set(time.Since(m.started).Seconds())
+	return push.New(url, pushJob).Grouping("table", m.table).Gatherer(m.registry).Push()
+}
@@ -0,0 +1,182 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+// DefaultMaxRemoveRatio is the share of a table's active entities a reconciling run may remove unless
+// Options.MaxRemoveRatio says otherwise.
+const DefaultMaxRemoveRatio = 0.1
+
+// Diff reports what a run changed in its table, written to Options.DiffFile.
+type Diff struct {
+	TenantID   string     `json:"tenantId"`
+	TableName  string     `json:"tableName"`
+	Input      string     `json:"input"`
+	DryRun     bool       `json:"dryRun"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt time.Time  `json:"finishedAt"`
+	Counts     DiffCounts `json:"counts"`
+	Added      []string   `json:"added"`
+	Updated    []string   `json:"updated"`
+	Unchanged  []string   `json:"unchanged"`
+	Valid      []string   `json:"valid,omitempty"` // dry runs only
+	Rejected   []string   `json:"rejected"`        // entities whose records were rejected; they are never removed
+	// Removed lists the active entities missing from the input when the run reconciled; Applied tells
+	// whether they were soft-deleted, which dry runs and runs over Options.MaxRemoveRatio don't do.
+	Removed []string `json:"removed"`
+	Applied bool     `json:"applied"`
+}
+
+// DiffCounts are the sizes of the lists of a Diff.
+type DiffCounts struct {
+	Added     int `json:"added"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Valid     int `json:"valid"`
+	Rejected  int `json:"rejected"`
+	Removed   int `json:"removed"`
+}
+
+// outcomeRank decides the outcome an entity mentioned several times in the input is reported with: a
+// record that created it wins over later updates, and any written record over a rejected one.
+var outcomeRank = map[Outcome]int{
+	OutcomeRejected:  0,
+	OutcomeUnchanged: 1,
+	OutcomeValid:     1,
+	OutcomeUpdated:   2,
+	OutcomeAccepted:  3,
+}
+
+// diffTracker records the entity IDs a run's input mentioned. A nil diffTracker records nothing.
+type diffTracker struct {
+	mu           sync.Mutex
+	ids          map[string]Outcome
+	unidentified int64 // rejected records whose entity ID could not be derived
+}
+
+func newDiffTracker() *diffTracker {
+	return &diffTracker{ids: make(map[string]Outcome)}
+}
+
+func (d *diffTracker) add(result LineResult) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if result.EntityID == "" {
+		d.unidentified++
+		return
+	}
+	if previous, ok := d.ids[result.EntityID]; !ok || outcomeRank[result.Outcome] > outcomeRank[previous] {
+		d.ids[result.EntityID] = result.Outcome
+	}
+}
+
+// diff lists the IDs seen by outcome, sorted.
+func (d *diffTracker) diff() Diff {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var diff Diff
+	lists := map[Outcome]*[]string{
+		OutcomeAccepted:  &diff.Added,
+		OutcomeUpdated:   &diff.Updated,
+		OutcomeUnchanged: &diff.Unchanged,
+		OutcomeValid:     &diff.Valid,
+		OutcomeRejected:  &diff.Rejected,
+	}
+	for id, outcome := range d.ids {
+		*lists[outcome] = append(*lists[outcome], id)
+	}
+	for _, list := range lists {
+		sort.Strings(*list)
+	}
+	return diff
+}
+
+// missing returns the IDs of active that the input did not mention.
+func (d *diffTracker) missing(active []string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var missing []string
+	for _, id := range active {
+		if _, ok := d.ids[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// reconcile fills diff.Removed with the active entities, written before the run started, that the input
+// did not mention, and soft-deletes them unless the run is dry or they exceed opts.MaxRemoveRatio.
+func reconcile(ctx context.Context, repo *persistence.EntityRepository, seen *diffTracker, started time.Time, diff *Diff, opts Options) error {
+	if seen.unidentified > 0 {
+		return fmt.Errorf("reconcile: %d rejected records have no entity ID, so the entities they stand for are unknown; nothing was removed", seen.unidentified)
+	}
+
+	active, err := repo.ActiveEntityIDs(ctx, started)
+	if err != nil {
+		return fmt.Errorf("reconcile: %w", err)
+	}
+	diff.Removed = seen.missing(active)
+	if len(diff.Removed) == 0 {
+		return nil
+	}
+
+	ratio := float64(len(diff.Removed)) / float64(len(active))
+	if ratio > opts.MaxRemoveRatio {
+		return fmt.Errorf("reconcile: %d of %d active entities (%.1f%%) are missing from the input, more than the %.1f%% allowed; nothing was removed",
+			len(diff.Removed), len(active), ratio*100, opts.MaxRemoveRatio*100)
+	}
+	if opts.DryRun {
+		opts.Logger.Info("reconcile dry run; missing entities were not removed", zap.Int("missing", len(diff.Removed)))
+		return nil
+	}
+
+	removed, err := repo.SoftDeleteEntities(ctx, diff.Removed, started)
+	if err != nil {
+		return fmt.Errorf("reconcile: %w", err)
+	}
+	diff.Applied = true
+	opts.Logger.Info("reconcile removed missing entities", zap.Int64("removed", removed))
+	return nil
+}
+
+// writeDiff saves diff to path as indented JSON, with empty lists rather than nulls.
+func writeDiff(path string, diff Diff) error {
+	for _, list := range []*[]string{&diff.Added, &diff.Updated, &diff.Unchanged, &diff.Rejected, &diff.Removed} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+	diff.Counts = DiffCounts{
+		Added:     len(diff.Added),
+		Updated:   len(diff.Updated),
+		Unchanged: len(diff.Unchanged),
+		Valid:     len(diff.Valid),
+		Rejected:  len(diff.Rejected),
+		Removed:   len(diff.Removed),
+	}
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write diff report: %w", err)
+	}
+	return nil
+}
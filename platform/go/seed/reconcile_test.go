@@ -0,0 +1,59 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTrackerReportsEachEntityOnce(t *testing.T) {
+	seen := newDiffTracker()
+	seen.add(LineResult{EntityID: "b", Outcome: OutcomeUnchanged})
+	seen.add(LineResult{EntityID: "a", Outcome: OutcomeAccepted})
+	seen.add(LineResult{EntityID: "a", Outcome: OutcomeUpdated})
+	seen.add(LineResult{EntityID: "c", Outcome: OutcomeRejected})
+	seen.add(LineResult{EntityID: "d", Outcome: OutcomeRejected})
+	seen.add(LineResult{EntityID: "d", Outcome: OutcomeUpdated})
+
+	diff := seen.diff()
+	require.Equal(t, []string{"a"}, diff.Added, "the record that created an entity wins over later updates")
+	require.Equal(t, []string{"d"}, diff.Updated)
+	require.Equal(t, []string{"b"}, diff.Unchanged)
+	require.Equal(t, []string{"c"}, diff.Rejected)
+
+	require.Equal(t, []string{"e", "f"}, seen.missing([]string{"a", "b", "c", "e", "f"}), "rejected entities are never missing")
+
+	var nilTracker *diffTracker
+	nilTracker.add(LineResult{EntityID: "a"})
+}
+
+func TestReconcileRefusesUnidentifiedRejections(t *testing.T) {
+	seen := newDiffTracker()
+	seen.add(LineResult{EntityID: "a", Outcome: OutcomeAccepted})
+	seen.add(LineResult{Outcome: OutcomeRejected})
+
+	var diff Diff
+	err := reconcile(context.Background(), nil, seen, diff.StartedAt, &diff, withDefaults(Options{Reconcile: true}))
+	require.ErrorContains(t, err, "1 rejected records have no entity ID")
+	require.Empty(t, diff.Removed)
+	require.False(t, diff.Applied)
+}
+
+func TestWriteDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cards.diff.json")
+	require.NoError(t, writeDiff(path, Diff{TableName: "cards", Added: []string{"a", "b"}, Removed: []string{"z"}, Applied: true}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, map[string]any{"added": 2.0, "updated": 0.0, "unchanged": 0.0, "valid": 0.0, "rejected": 0.0, "removed": 1.0}, decoded["counts"])
+	require.Equal(t, []any{}, decoded["updated"], "empty lists are written as []")
+	require.NotContains(t, decoded, "valid")
+	require.Equal(t, true, decoded["applied"])
+}
@@ -0,0 +1,140 @@
+package seed
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// RecordError explains why a record was rejected.
+type RecordError struct {
+	Stage string // what failed, e.g. "decode payload" or "write entity"
+	Err   error
+}
+
+func (e *RecordError) Error() string {
+	return e.Stage + ": " + e.Err.Error()
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Violation is one schema violation of a rejected record.
+type Violation struct {
+	Pointer string `json:"pointer"` // JSON pointer into the record, e.g. "/prices/usd"
+	Keyword string `json:"keyword"` // JSON pointer to the failing schema keyword, e.g. "/properties/prices/properties/usd/type"
+	Message string `json:"message"`
+}
+
+// Violations returns the individual schema violations err reports, or nil when err is not a schema
+// validation error.
+func Violations(err error) []Violation {
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	var violations []Violation
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			violations = append(violations, Violation{Pointer: e.InstanceLocation, Keyword: e.KeywordLocation, Message: e.Message})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(validationErr)
+	return violations
+}
+
+// ErrorTypes classifies why a record was rejected, for Summary.Errors: one "schema <keyword>" type per
+// schema violation, otherwise the stage that failed.
+func ErrorTypes(err error) []string {
+	if violations := Violations(err); len(violations) > 0 {
+		types := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			types = append(types, "schema "+violation.Keyword)
+		}
+		return types
+	}
+	var recordErr *RecordError
+	if errors.As(err, &recordErr) {
+		return []string{recordErr.Stage}
+	}
+	return []string{"other"}
+}
+
+// rejectedRecord is a line of the reject file.
+type rejectedRecord struct {
+	Line       int             `json:"line"`
+	EntityID   string          `json:"entityId,omitempty"`
+	Error      string          `json:"error"`
+	Violations []Violation     `json:"violations,omitempty"`
+	Record     json.RawMessage `json:"record,omitempty"` // the record as read, when it is valid JSON
+}
+
+// rejectWriter writes rejected records to an NDJSON file. A nil rejectWriter writes nothing.
+type rejectWriter struct {
+	mu   sync.Mutex
+	file *os.File
+	buf  *bufio.Writer
+}
+
+// openRejects opens the reject file at path, appending to it when resuming a run.
+func openRejects(path string, appendTo bool) (*rejectWriter, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendTo {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open reject file: %w", err)
+	}
+	return &rejectWriter{file: file, buf: bufio.NewWriter(file)}, nil
+}
+
+func (w *rejectWriter) write(j job, result LineResult) error {
+	if w == nil {
+		return nil
+	}
+	record := rejectedRecord{
+		Line:       result.Line,
+		EntityID:   result.EntityID,
+		Error:      result.Err.Error(),
+		Violations: Violations(result.Err),
+	}
+	if json.Valid(j.raw) {
+		record.Record = j.raw
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode rejected record: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.buf.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write reject file: %w", err)
+	}
+	return nil
+}
+
+func (w *rejectWriter) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.buf.Flush(); err != nil {
+		w.file.Close()
+		return fmt.Errorf("write reject file: %w", err)
+	}
+	return w.file.Close()
+}
@@ -0,0 +1,103 @@
+package seed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func schemaError(t *testing.T, schema, document string) error {
+	t.Helper()
+
+	compiler := jsonschema.NewCompiler()
+	require.NoError(t, compiler.AddResource("memory://test", strings.NewReader(schema)))
+	compiled, err := compiler.Compile("memory://test")
+	require.NoError(t, err)
+
+	var value any
+	require.NoError(t, json.Unmarshal([]byte(document), &value))
+	err = compiled.Validate(value)
+	require.Error(t, err)
+	return &RecordError{Stage: "write entity", Err: fmt.Errorf("schema validation: %w", err)}
+}
+
+func TestViolationsAndErrorTypes(t *testing.T) {
+	err := schemaError(t,
+		`{"type": "object", "properties": {"name": {"type": "string"}, "hp": {"type": "integer"}}, "required": ["name"]}`,
+		`{"hp": "120"}`,
+	)
+
+	violations := Violations(err)
+	require.Len(t, violations, 2)
+	require.ElementsMatch(t, []string{"schema /required", "schema /properties/hp/type"}, ErrorTypes(err))
+	for _, violation := range violations {
+		if violation.Keyword == "/properties/hp/type" {
+			require.Equal(t, "/hp", violation.Pointer)
+		}
+	}
+
+	require.Nil(t, Violations(errors.New("boom")))
+	require.Equal(t, []string{"decode payload"}, ErrorTypes(&RecordError{Stage: "decode payload", Err: errors.New("bad json")}))
+	require.Equal(t, []string{"other"}, ErrorTypes(errors.New("boom")))
+}
+
+func TestRejectWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rejects.jsonl")
+	err := schemaError(t, `{"properties": {"hp": {"type": "integer"}}}`, `{"hp": "120"}`)
+
+	w, openErr := openRejects(path, false)
+	require.NoError(t, openErr)
+	require.NoError(t, w.write(job{raw: []byte(`{"id":"a","hp":"120"}`)}, LineResult{Line: 3, EntityID: "a", Err: err}))
+	require.NoError(t, w.write(job{raw: []byte(`not json`)}, LineResult{Line: 4, Err: &RecordError{Stage: "decode payload", Err: errors.New("bad json")}}))
+	require.NoError(t, w.Close())
+
+	var nilWriter *rejectWriter
+	require.NoError(t, nilWriter.write(job{}, LineResult{Err: err}))
+	require.NoError(t, nilWriter.Close())
+
+	data, readErr := os.ReadFile(path)
+	require.NoError(t, readErr)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var first rejectedRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.Equal(t, 3, first.Line)
+	require.Equal(t, "a", first.EntityID)
+	require.Len(t, first.Violations, 1)
+	require.Equal(t, "/hp", first.Violations[0].Pointer)
+	require.JSONEq(t, `{"id":"a","hp":"120"}`, string(first.Record))
+
+	var second rejectedRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	require.Equal(t, "decode payload: bad json", second.Error)
+	require.Empty(t, second.Record)
+}
+
+func TestReportStopsAfterMaxErrors(t *testing.T) {
+	rejected := LineResult{Outcome: OutcomeRejected, Err: &RecordError{Stage: "decode payload", Err: errors.New("bad json")}}
+
+	stats := &statsTracker{}
+	opts := withDefaults(Options{MaxErrors: 2})
+	require.NoError(t, report(job{}, LineResult{Outcome: OutcomeAccepted}, stats, opts))
+	require.NoError(t, report(job{}, rejected, stats, opts))
+	require.NoError(t, report(job{}, rejected, stats, opts))
+	require.ErrorContains(t, report(job{}, rejected, stats, opts), "more than 2 records rejected")
+
+	summary := stats.summary()
+	require.EqualValues(t, 3, summary.Rejected)
+	require.EqualValues(t, 3, summary.Errors["decode payload"])
+
+	stats = &statsTracker{}
+	require.Error(t, report(job{}, rejected, stats, withDefaults(Options{})), "the first rejection stops a run by default")
+
+	stats = &statsTracker{}
+	require.NoError(t, report(job{}, rejected, stats, withDefaults(Options{DryRun: true})), "dry runs go on")
+}
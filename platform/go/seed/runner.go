@@ -0,0 +1,716 @@
+package seed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/tenant"
+)
+
+// KeyFunc returns a stable identifier string extracted from the raw JSON payload.
+type KeyFunc func(map[string]any) (string, error)
+
+// Format is the encoding of seed input.
+type Format string
+
+// Supported input formats.
+const (
+	FormatNDJSON Format = "ndjson" // one JSON object per line; blank lines are skipped
+	FormatCSV    Format = "csv"    // a header row of dotted payload paths, then one record per row
+)
+
+// Outcome is what a run did with one input record.
+type Outcome string
+
+// Record outcomes.
+const (
+	OutcomeAccepted  Outcome = "accepted"  // created a new entity
+	OutcomeUpdated   Outcome = "updated"   // wrote a new version of an existing entity
+	OutcomeUnchanged Outcome = "unchanged" // matched the active version; nothing was written
+	OutcomeRejected  Outcome = "rejected"  // invalid record, see LineResult.Err
+	OutcomeValid     Outcome = "valid"     // passed validation in a dry run; nothing was written
+)
+
+// LineResult reports the outcome of one input record.
+type LineResult struct {
+	Line     int    // 1-based line of the record in the input
+	EntityID string // empty when the record was rejected before its ID was derived
+	Outcome  Outcome
+	Err      error // why the record was rejected, usually a *RecordError
+}
+
+// Summary counts the outcomes of a run.
+type Summary struct {
+	Accepted      int64
+	Updated       int64
+	Unchanged     int64
+	Rejected      int64
+	Valid         int64
+	Removed       int64            // entities soft-deleted by Options.Reconcile
+	IgnoredFields map[string]int64 // fields removed by Mutate, by name
+	Errors        map[string]int64 // rejections by error type, see ErrorTypes
+}
+
+// Options control how a seed job runs.
+type Options struct {
+	InputPath    string
+	Format       Format // defaults to FormatNDJSON
+	TableName    string
+	Pool         persistence.PoolConfig
+	TenantID     string // owner of the target table; defaults to tenant.DefaultID
+	Concurrency  int
+	BatchSize    int // records written per transaction; defaults to DefaultBatchSize
+	KeyFunc      KeyFunc
+	SlugTemplate string // derives slugs from payloads, e.g. "{name}-{number}"; defaults to the schema's x-slug-template
+	Mutate       func(map[string]any) ([]string, error)
+	EntityIDFunc func(map[string]any, string) (string, error)
+	Namespace    uuid.UUID
+	Logger       *zap.Logger
+	// OnResult receives the outcome of every record, from the worker goroutines. Without it the run stops
+	// after MaxErrors rejected records; with it the run goes on unless OnResult returns an error.
+	OnResult func(LineResult) error
+	// MaxErrors is the number of rejected records tolerated before the run stops: 0 stops at the first,
+	// a negative number never stops. Ignored when OnResult is set.
+	MaxErrors int
+	// DryRun validates records against the active schema and writes nothing; records come out valid or
+	// rejected. Rejections do not stop a dry run unless MaxErrors is positive.
+	DryRun bool
+	// RejectFile receives the rejected records as NDJSON, with their errors and schema violations.
+	RejectFile string
+
+	// CheckpointFile is where Run saves its progress; defaults to InputPath with ".checkpoint.json" appended.
+	CheckpointFile string
+	// Resume continues a run from its checkpoint, skipping the input it completed. Runs without a
+	// checkpoint start over; runs whose input changed since are refused.
+	Resume bool
+
+	// Reconcile makes the input the full source of the table: after a successful pass, active entities the
+	// input did not mention are soft-deleted. Entities written while the run lasted are kept. Cannot be
+	// combined with Resume, since the entities of the skipped input would look missing.
+	Reconcile bool
+	// MaxRemoveRatio is the share of the table's active entities a reconcile may remove; a run that would
+	// remove more removes nothing and fails. Defaults to DefaultMaxRemoveRatio; 1 lifts the limit.
+	MaxRemoveRatio float64
+	// DiffFile receives a JSON report of the entities the run added, updated, left unchanged and removed.
+	DiffFile string
+
+	MetricsAddr    string // serves Prometheus metrics on this address while the run lasts, e.g. ":9102"
+	PushGatewayURL string // pushes the final metrics to this Prometheus Pushgateway
+}
+
+// DefaultBatchSize is the number of records a worker writes per transaction unless Options.BatchSize says otherwise.
+const DefaultBatchSize = 500
+
+// Run streams the input file and writes each record into the requested table.
+func Run(ctx context.Context, opts Options) error {
+	if opts.InputPath == "" {
+		return errors.New("input path is required")
+	}
+	if opts.TableName == "" {
+		return errors.New("table name is required")
+	}
+	if opts.Pool.ConnString == "" {
+		return errors.New("database url is required")
+	}
+	if opts.KeyFunc == nil {
+		return errors.New("key extractor is required")
+	}
+	if opts.TenantID == "" {
+		opts.TenantID = tenant.DefaultID
+	}
+	if opts.CheckpointFile == "" {
+		opts.CheckpointFile = opts.InputPath + ".checkpoint.json"
+	}
+	if opts.DryRun && opts.Resume {
+		return errors.New("a dry run cannot resume")
+	}
+	if opts.Reconcile && opts.Resume {
+		return errors.New("a reconciling run cannot resume; it needs a full pass over the input")
+	}
+	opts = withDefaults(opts)
+
+	tenantID, err := tenant.Normalize(opts.TenantID)
+	if err != nil {
+		return err
+	}
+	ctx = tenant.WithID(ctx, tenantID)
+
+	file, err := os.Open(opts.InputPath)
+	if err != nil {
+		return fmt.Errorf("open input: %w", err)
+	}
+	defer file.Close()
+
+	state, err := startCheckpoint(file, tenantID, opts)
+	if err != nil {
+		return err
+	}
+	if state.Completed {
+		opts.Logger.Info("seed already completed; nothing to resume", zap.String("checkpoint", opts.CheckpointFile))
+		return nil
+	}
+
+	pool, err := persistence.NewPool(ctx, opts.Pool)
+	if err != nil {
+		return fmt.Errorf("init pool: %w", err)
+	}
+	defer persistence.ClosePool(pool)
+
+	schemaStore, err := persistence.NewSchemaRepositoryStore(ctx, pool)
+	if err != nil {
+		return fmt.Errorf("init schema store: %w", err)
+	}
+
+	schemaRecord, err := schemaStore.GetActiveSchemaByTableName(ctx, opts.TableName)
+	if err != nil {
+		return fmt.Errorf("resolve schema for %s: %w", opts.TableName, err)
+	}
+
+	seedMetrics := newRunMetrics(opts.TableName, pool)
+	if opts.MetricsAddr != "" {
+		stopMetrics, err := seedMetrics.serve(opts.MetricsAddr, opts.Logger)
+		if err != nil {
+			return fmt.Errorf("serve metrics: %w", err)
+		}
+		defer stopMetrics()
+	}
+	if opts.PushGatewayURL != "" {
+		defer func() {
+			if err := seedMetrics.push(opts.PushGatewayURL); err != nil {
+				opts.Logger.Warn("push seed metrics", zap.Error(err))
+			}
+		}()
+	}
+
+	validator := persistence.NewSchemaValidator(persistence.WithSchemaValidatorObserver(seedMetrics.validator))
+	entityRepo, err := persistence.NewEntityRepository(ctx, pool, schemaStore, validator, persistence.EntityRepositoryConfig{
+		SchemaID:      schemaRecord.SchemaID,
+		WriteObserver: seedMetrics.writes,
+		SlugTemplate:  opts.SlugTemplate,
+	})
+	if err != nil {
+		return fmt.Errorf("init entity repo: %w", err)
+	}
+
+	opts.Logger.Info("starting seed", zap.String("tenant", tenantID), zap.String("table", opts.TableName), zap.String("file", opts.InputPath), zap.Int("concurrency", opts.Concurrency), zap.Int("batchSize", opts.BatchSize))
+	if state.Line > 0 {
+		opts.Logger.Info("resuming seed", zap.Int("afterLine", state.Line), zap.String("checkpoint", opts.CheckpointFile))
+		// NDJSON records start on their own line, so the reader can jump past the completed ones; CSV
+		// rows are skipped by line instead because the header has to be read first.
+		if opts.Format == FormatNDJSON {
+			if _, err := file.Seek(state.Offset, io.SeekStart); err != nil {
+				return fmt.Errorf("seek input: %w", err)
+			}
+		}
+	}
+
+	// Reconciling compares against created_at, so the cutoff comes from the database clock.
+	started := time.Now()
+	if opts.Reconcile {
+		if err := pool.QueryRow(ctx, "SELECT now()").Scan(&started); err != nil {
+			return fmt.Errorf("read database time: %w", err)
+		}
+	}
+
+	stats := &statsTracker{metrics: seedMetrics}
+	if opts.Reconcile || opts.DiffFile != "" {
+		stats.diff = newDiffTracker()
+	}
+	if opts.RejectFile != "" {
+		// A resumed run skips the lines rejected before, so their entries are kept.
+		if stats.rejects, err = openRejects(opts.RejectFile, state.Line > 0); err != nil {
+			return err
+		}
+	}
+	finishCheckpoint := func(bool) error { return nil }
+	if !opts.DryRun {
+		stats.progress = newProgress(state.position)
+		finishCheckpoint = keepCheckpoint(opts.CheckpointFile, state, stats.progress, opts.Logger)
+	}
+
+	summary, err := apply(ctx, entityRepo, file, opts, stats, state.position)
+	if saveErr := finishCheckpoint(err == nil); saveErr != nil {
+		opts.Logger.Warn("save seed checkpoint", zap.Error(saveErr))
+	}
+	if closeErr := stats.rejects.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	logRejections(opts.Logger, summary)
+	if err != nil {
+		return err
+	}
+
+	if stats.diff != nil {
+		diff := stats.diff.diff()
+		diff.TenantID = tenantID
+		diff.TableName = opts.TableName
+		diff.Input = opts.InputPath
+		diff.DryRun = opts.DryRun
+		diff.StartedAt = started.UTC()
+		if opts.Reconcile {
+			err = reconcile(ctx, entityRepo, stats.diff, started, &diff, opts)
+			if diff.Applied {
+				summary.Removed = int64(len(diff.Removed))
+			}
+		}
+		diff.FinishedAt = time.Now().UTC()
+		if opts.DiffFile != "" {
+			if writeErr := writeDiff(opts.DiffFile, diff); writeErr != nil && err == nil {
+				err = writeErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	message := "seed completed"
+	if opts.DryRun {
+		message = "seed dry run completed; nothing was written"
+	}
+	opts.Logger.Info(message,
+		zap.String("table", opts.TableName),
+		zap.Int64("accepted", summary.Accepted),
+		zap.Int64("updated", summary.Updated),
+		zap.Int64("unchanged", summary.Unchanged),
+		zap.Int64("valid", summary.Valid),
+		zap.Int64("rejected", summary.Rejected),
+		zap.Int64("removed", summary.Removed),
+		zap.Any("ignoredFields", summary.IgnoredFields),
+	)
+
+	return nil
+}
+
+// logRejections logs how many records were rejected for each error type, most frequent first.
+func logRejections(logger *zap.Logger, summary Summary) {
+	types := make([]string, 0, len(summary.Errors))
+	for errorType := range summary.Errors {
+		types = append(types, errorType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if summary.Errors[types[i]] != summary.Errors[types[j]] {
+			return summary.Errors[types[i]] > summary.Errors[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	for _, errorType := range types {
+		logger.Warn("rejected records", zap.String("type", errorType), zap.Int64("count", summary.Errors[errorType]))
+	}
+}
+
+// Apply writes the records read from input into repo, which must be bound to the tenant of ctx. Only the
+// record options (Format, Concurrency, BatchSize, KeyFunc, Mutate, EntityIDFunc, Namespace, OnResult, MaxErrors,
+// DryRun and Logger) are used; the caller owns the pool, repository and input.
+func Apply(ctx context.Context, repo *persistence.EntityRepository, input io.Reader, opts Options) (Summary, error) {
+	if opts.KeyFunc == nil {
+		return Summary{}, errors.New("key extractor is required")
+	}
+	return apply(ctx, repo, input, withDefaults(opts), &statsTracker{}, position{})
+}
+
+// startCheckpoint describes the run about to start, or the run it resumes when opts.Resume is set.
+func startCheckpoint(file *os.File, tenantID string, opts Options) (checkpoint, error) {
+	size, sum, err := hashInput(file)
+	if err != nil {
+		return checkpoint{}, err
+	}
+	current := checkpoint{
+		Input:       opts.InputPath,
+		InputSize:   size,
+		InputSHA256: sum,
+		Format:      opts.Format,
+		TenantID:    tenantID,
+		TableName:   opts.TableName,
+	}
+	if !opts.Resume {
+		return current, nil
+	}
+
+	saved, ok, err := loadCheckpoint(opts.CheckpointFile)
+	if err != nil {
+		return checkpoint{}, err
+	}
+	if !ok {
+		opts.Logger.Info("no seed checkpoint to resume; starting over", zap.String("checkpoint", opts.CheckpointFile))
+		return current, nil
+	}
+	if err := saved.resumable(current); err != nil {
+		return checkpoint{}, fmt.Errorf("resume from %s: %w", opts.CheckpointFile, err)
+	}
+	current.position = saved.position
+	current.Completed = saved.Completed
+	return current, nil
+}
+
+func withDefaults(opts Options) Options {
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = runtime.NumCPU()
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.Format == "" {
+		opts.Format = FormatNDJSON
+	}
+	if opts.DryRun && opts.MaxErrors == 0 {
+		opts.MaxErrors = -1
+	}
+	if opts.MaxRemoveRatio <= 0 {
+		opts.MaxRemoveRatio = DefaultMaxRemoveRatio
+	}
+	return opts
+}
+
+type statsTracker struct {
+	outcomes sync.Map // map[Outcome]*atomic.Int64
+	ignored  sync.Map // map[string]*atomic.Int64
+	errors   sync.Map // map[string]*atomic.Int64
+	done     atomic.Int64
+	metrics  *runMetrics
+	progress *progress     // nil when the run keeps no checkpoint
+	rejects  *rejectWriter // nil without a reject file
+	diff     *diffTracker  // nil unless the run reconciles or reports a diff
+}
+
+type job struct {
+	seq  int64    // position of the record among the records read, from 0
+	line int      // first line of the record
+	end  position // where the record ends
+	raw  []byte
+	err  error // the record could not be read; it is rejected
+}
+
+func (s *statsTracker) addIgnored(fields []string) {
+	for _, field := range fields {
+		if field == "" {
+			continue
+		}
+		counterAny, _ := s.ignored.LoadOrStore(field, &atomic.Int64{})
+		counterAny.(*atomic.Int64).Add(1)
+	}
+}
+
+func (s *statsTracker) addErrors(err error) {
+	for _, errorType := range ErrorTypes(err) {
+		counterAny, _ := s.errors.LoadOrStore(errorType, &atomic.Int64{})
+		counterAny.(*atomic.Int64).Add(1)
+	}
+}
+
+func (s *statsTracker) count(outcome Outcome) int64 {
+	if counterAny, ok := s.outcomes.Load(outcome); ok {
+		return counterAny.(*atomic.Int64).Load()
+	}
+	return 0
+}
+
+func (s *statsTracker) record(outcome Outcome) int64 {
+	s.metrics.observe(string(outcome))
+	counterAny, _ := s.outcomes.LoadOrStore(outcome, &atomic.Int64{})
+	counterAny.(*atomic.Int64).Add(1)
+	return s.done.Add(1)
+}
+
+func (s *statsTracker) summary() Summary {
+	summary := Summary{
+		Accepted:      s.count(OutcomeAccepted),
+		Updated:       s.count(OutcomeUpdated),
+		Unchanged:     s.count(OutcomeUnchanged),
+		Rejected:      s.count(OutcomeRejected),
+		Valid:         s.count(OutcomeValid),
+		IgnoredFields: make(map[string]int64),
+		Errors:        make(map[string]int64),
+	}
+	s.ignored.Range(func(key, value any) bool {
+		summary.IgnoredFields[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	s.errors.Range(func(key, value any) bool {
+		summary.Errors[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return summary
+}
+
+// apply writes the records of reader after from, which is where reader is positioned for NDJSON input.
+func apply(ctx context.Context, repo *persistence.EntityRepository, reader io.Reader, opts Options, stats *statsTracker, from position) (Summary, error) {
+	var read func(ctx context.Context, jobs chan<- job) error
+	switch opts.Format {
+	case FormatNDJSON:
+		read = func(ctx context.Context, jobs chan<- job) error {
+			return readNDJSON(ctx, reader, from, jobs)
+		}
+	case FormatCSV:
+		schema, err := repo.ActiveSchema(ctx)
+		if err != nil {
+			return Summary{}, fmt.Errorf("resolve schema: %w", err)
+		}
+		read = func(ctx context.Context, jobs chan<- job) error {
+			return readCSV(ctx, reader, schema.SchemaDefinition, from.Line, jobs)
+		}
+	default:
+		return Summary{}, fmt.Errorf("unsupported input format %q", opts.Format)
+	}
+
+	// With a slug template the repository derives the slug (and resolves collisions); otherwise the key is used.
+	_, templated := repo.SlugTemplate()
+	slugFromKey := !templated
+
+	jobs := make(chan job, opts.Concurrency*2)
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		defer close(jobs)
+		return read(ctx, jobs)
+	})
+
+	for i := 0; i < opts.Concurrency; i++ {
+		g.Go(func() error {
+			batch := make([]pendingRecord, 0, opts.BatchSize)
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case j, ok := <-jobs:
+					if !ok {
+						return writeBatch(ctx, repo, batch, stats, opts)
+					}
+					result, entity := prepareJob(j, slugFromKey, stats, opts)
+					if entity == nil {
+						if err := report(j, result, stats, opts); err != nil {
+							return err
+						}
+						continue
+					}
+					batch = append(batch, pendingRecord{job: j, result: result, entity: *entity})
+					if len(batch) < opts.BatchSize {
+						continue
+					}
+					if err := writeBatch(ctx, repo, batch, stats, opts); err != nil {
+						return err
+					}
+					batch = batch[:0]
+				}
+			}
+		})
+	}
+
+	// The summary of a failed run shows how far it got.
+	err := g.Wait()
+	return stats.summary(), err
+}
+
+// report counts the result of j and hands it to opts.OnResult; without one, rejections beyond
+// opts.MaxErrors end the run. Records whose result was taken complete the checkpoint progress.
+func report(j job, result LineResult, stats *statsTracker, opts Options) error {
+	if v := stats.record(result.Outcome); v%1000 == 0 {
+		opts.Logger.Info("progress", zap.Int64("records", v))
+	}
+	stats.diff.add(result)
+	if result.Outcome == OutcomeRejected {
+		stats.addErrors(result.Err)
+		if err := stats.rejects.write(j, result); err != nil {
+			return err
+		}
+	}
+	switch {
+	case opts.OnResult != nil:
+		if err := opts.OnResult(result); err != nil {
+			return err
+		}
+	case result.Outcome != OutcomeRejected || opts.MaxErrors < 0:
+	case opts.MaxErrors == 0:
+		return fmt.Errorf("line %d: %w", result.Line, result.Err)
+	case stats.count(OutcomeRejected) > int64(opts.MaxErrors):
+		return fmt.Errorf("more than %d records rejected; line %d: %w", opts.MaxErrors, result.Line, result.Err)
+	}
+	stats.progress.complete(j.seq, j.end)
+	return nil
+}
+
+// readNDJSON reads the records of reader, which is positioned at the end of from.
+func readNDJSON(ctx context.Context, reader io.Reader, from position, jobs chan<- job) error {
+	scanner := bufio.NewScanner(reader)
+	buf := make([]byte, 0, 1024*1024)
+	scanner.Buffer(buf, 16*1024*1024)
+	offset := from.Offset
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		offset += int64(advance)
+		return advance, token, err
+	})
+	line := from.Line
+	var seq int64
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		b := append([]byte(nil), scanner.Bytes()...)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case jobs <- job{seq: seq, line: line, end: position{Line: line, Offset: offset}, raw: b}:
+		}
+		seq++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan input: %w", err)
+	}
+	return nil
+}
+
+// pendingRecord is a prepared record waiting for its batch to be written.
+type pendingRecord struct {
+	job    job
+	result LineResult
+	entity persistence.BulkEntity
+}
+
+// prepareJob turns one record into the entity to write. Problems with the record come back as a rejected
+// LineResult and a nil entity.
+func prepareJob(j job, slugFromKey bool, stats *statsTracker, opts Options) (LineResult, *persistence.BulkEntity) {
+	result := LineResult{Line: j.line}
+	reject := func(stage string, err error) (LineResult, *persistence.BulkEntity) {
+		result.Outcome = OutcomeRejected
+		result.Err = &RecordError{Stage: stage, Err: err}
+		return result, nil
+	}
+
+	if j.err != nil {
+		return reject("read record", j.err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(j.raw, &payload); err != nil {
+		return reject("decode payload", err)
+	}
+
+	rawBytes := j.raw
+	if opts.Mutate != nil {
+		ignored, err := opts.Mutate(payload)
+		if err != nil {
+			return reject("mutate payload", err)
+		}
+		stats.addIgnored(ignored)
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return reject("encode payload", err)
+		}
+		rawBytes = encoded
+	}
+
+	key, err := opts.KeyFunc(payload)
+	if err != nil {
+		return reject("derive key", err)
+	}
+
+	var slug *string
+	if slugFromKey {
+		keySlug, err := persistence.Slugify(key)
+		if err != nil {
+			return reject("slugify key", err)
+		}
+		slug = &keySlug
+	}
+
+	var entityID string
+	if opts.EntityIDFunc != nil {
+		entityID, err = opts.EntityIDFunc(payload, key)
+		if err != nil {
+			return reject("derive entity id", err)
+		}
+	} else if opts.Namespace != uuid.Nil {
+		entityID = uuid.NewSHA1(opts.Namespace, []byte(key)).String()
+	} else {
+		entityID = key
+	}
+	entityID, err = persistence.NormalizeEntityIdentifier(entityID)
+	if err != nil {
+		return reject("sanitize entity id", err)
+	}
+	result.EntityID = entityID
+
+	return result, &persistence.BulkEntity{
+		EntityID: entityID,
+		Slug:     slug,
+		Payload:  persistence.SchemaDefinition(rawBytes),
+	}
+}
+
+// writeBatch writes the batch in one transaction and reports each record. Records the repository refuses
+// are rejected; the error is reserved for failures that should stop the run, such as a lost database
+// connection.
+func writeBatch(ctx context.Context, repo *persistence.EntityRepository, batch []pendingRecord, stats *statsTracker, opts Options) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	entities := make([]persistence.BulkEntity, len(batch))
+	for i, pending := range batch {
+		entities[i] = pending.entity
+	}
+	write := repo.BulkUpsertEntities
+	if opts.DryRun {
+		write = repo.ValidateEntities
+	}
+	written, err := write(ctx, entities)
+	if err != nil {
+		return fmt.Errorf("write batch from line %d: %w", batch[0].result.Line, err)
+	}
+
+	for i, pending := range batch {
+		result := pending.result
+		switch entity := written[i]; {
+		case entity.Err == nil && opts.DryRun:
+			result.Outcome = OutcomeValid
+		case entity.Err == nil:
+			result.Outcome = upsertOutcomes[entity.Outcome]
+		case isRecordError(entity.Err):
+			result.Outcome = OutcomeRejected
+			result.Err = &RecordError{Stage: "write entity", Err: entity.Err}
+		default:
+			return fmt.Errorf("line %d: write entity: %w", result.Line, entity.Err)
+		}
+		if err := report(pending.job, result, stats, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var upsertOutcomes = map[persistence.UpsertOutcome]Outcome{
+	persistence.UpsertCreated:   OutcomeAccepted,
+	persistence.UpsertUpdated:   OutcomeUpdated,
+	persistence.UpsertUnchanged: OutcomeUnchanged,
+}
+
+// isRecordError reports whether a write failed because of the record rather than the database.
+func isRecordError(err error) bool {
+	var validationErr *jsonschema.ValidationError
+	var idErr *persistence.InvalidEntityIdentifierError
+	return errors.As(err, &validationErr) || errors.As(err, &idErr) ||
+		errors.Is(err, persistence.ErrSlugTaken) || errors.Is(err, persistence.ErrEmptySlug)
+}
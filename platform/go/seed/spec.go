@@ -0,0 +1,446 @@
+package seed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is a declarative seed plan: the datasets to load, in which order, and how their records become
+// entities. Specs are YAML (or JSON) files read by LoadSpec and run by the seed command.
+type Spec struct {
+	Steps []Step `yaml:"steps"`
+}
+
+// Step seeds one input file into one entity table.
+type Step struct {
+	Name string `yaml:"name"`
+	// Input is the path of the input file. $VAR and ${VAR} are expanded from the environment; relative
+	// paths are resolved against the directory of the spec file.
+	Input     string   `yaml:"input"`
+	Format    Format   `yaml:"format"` // defaults to FormatNDJSON
+	Table     string   `yaml:"table"`
+	DependsOn []string `yaml:"dependsOn"` // steps that must run first, e.g. the sets the cards refer to
+	// Key is the dotted path of the field identifying a record, e.g. "id" or "set.code", read after the
+	// field rules were applied. String and number values are accepted.
+	Key          string           `yaml:"key"`
+	EntityID     EntityIDStrategy `yaml:"entityId"`     // defaults to EntityIDKey
+	Namespace    string           `yaml:"namespace"`    // UUID namespace of EntityIDUUID
+	SlugTemplate string           `yaml:"slugTemplate"` // defaults to the schema's x-slug-template, then the key
+	Fields       FieldRules       `yaml:"fields"`
+
+	dir string // directory of the spec file, for relative inputs
+}
+
+// EntityIDStrategy is how a step or an API import derives entity IDs from record keys.
+type EntityIDStrategy string
+
+// Entity ID strategies.
+const (
+	EntityIDKey  EntityIDStrategy = "key"  // the key is the entity ID
+	EntityIDUUID EntityIDStrategy = "uuid" // a UUIDv5 of the key in a namespace (Options.Namespace)
+)
+
+// Validate reports an unknown strategy; the empty strategy means EntityIDKey.
+func (s EntityIDStrategy) Validate() error {
+	switch s {
+	case "", EntityIDKey, EntityIDUUID:
+		return nil
+	default:
+		return fmt.Errorf("unknown entityId strategy %q", s)
+	}
+}
+
+// FieldRules reshape every record before it is keyed and validated, in field order: renames, then
+// coercions, then the allow list, then the deny list. Fields removed by Allow and Deny are counted in
+// Summary.IgnoredFields. Use Apply as Options.Mutate.
+type FieldRules struct {
+	Rename map[string]string   `yaml:"rename"` // top-level field to its new name
+	Coerce map[string]Coercion `yaml:"coerce"` // dotted path to the type its value is converted to
+	Allow  []string            `yaml:"allow"`  // top-level fields to keep; all when empty
+	Deny   []string            `yaml:"deny"`   // top-level fields to drop
+}
+
+// Coercion is the JSON type a field is converted to. Values that already have the type are kept, empty
+// strings are dropped, and values that cannot be converted reject the record.
+type Coercion string
+
+// Supported coercions.
+const (
+	CoerceString  Coercion = "string"  // numbers and booleans are formatted
+	CoerceInteger Coercion = "integer" // numeric strings and whole numbers are parsed
+	CoerceNumber  Coercion = "number"  // numeric strings are parsed
+	CoerceBoolean Coercion = "boolean" // strconv.ParseBool strings are parsed
+)
+
+// LoadSpec reads and validates the spec at path. Unknown keys are refused so typos don't go unnoticed.
+func LoadSpec(path string) (Spec, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Spec{}, fmt.Errorf("open seed spec: %w", err)
+	}
+	defer file.Close()
+
+	var spec Spec
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return Spec{}, fmt.Errorf("decode seed spec %s: %w", path, err)
+	}
+	dir := filepath.Dir(path)
+	for i := range spec.Steps {
+		spec.Steps[i].dir = dir
+	}
+	if err := spec.Validate(); err != nil {
+		return Spec{}, fmt.Errorf("seed spec %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Validate reports the first problem with the spec: missing settings, unknown values, duplicate step
+// names and dependencies that are unknown or cyclic.
+func (s Spec) Validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("no steps")
+	}
+	names := make(map[string]struct{}, len(s.Steps))
+	for _, step := range s.Steps {
+		if step.Name == "" {
+			return errors.New("every step needs a name")
+		}
+		if _, ok := names[step.Name]; ok {
+			return fmt.Errorf("step %s is defined twice", step.Name)
+		}
+		names[step.Name] = struct{}{}
+		if err := step.validate(); err != nil {
+			return fmt.Errorf("step %s: %w", step.Name, err)
+		}
+	}
+	for _, step := range s.Steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := names[dep]; !ok {
+				return fmt.Errorf("step %s depends on unknown step %s", step.Name, dep)
+			}
+		}
+	}
+	_, err := s.Plan()
+	return err
+}
+
+func (s Step) validate() error {
+	switch {
+	case s.Input == "":
+		return errors.New("input is required")
+	case s.Table == "":
+		return errors.New("table is required")
+	case s.Key == "":
+		return errors.New("key is required")
+	}
+	switch s.Format {
+	case "", FormatNDJSON, FormatCSV:
+	default:
+		return fmt.Errorf("unknown format %q", s.Format)
+	}
+	if err := s.EntityID.Validate(); err != nil {
+		return err
+	}
+	if s.EntityID == EntityIDUUID {
+		if _, err := uuid.Parse(s.Namespace); err != nil {
+			return fmt.Errorf("entityId uuid needs a UUID namespace: %w", err)
+		}
+	}
+	for path, coercion := range s.Fields.Coerce {
+		switch coercion {
+		case CoerceString, CoerceInteger, CoerceNumber, CoerceBoolean:
+		default:
+			return fmt.Errorf("field %s: unknown coercion %q", path, coercion)
+		}
+	}
+	return nil
+}
+
+// Plan returns the steps to run in dependency order, keeping the spec's order otherwise. With names, only
+// those steps and the steps they depend on are planned.
+func (s Spec) Plan(names ...string) ([]Step, error) {
+	byName := make(map[string]Step, len(s.Steps))
+	for _, step := range s.Steps {
+		byName[step.Name] = step
+	}
+
+	wanted := make(map[string]bool, len(s.Steps))
+	var want func(name string) error
+	want = func(name string) error {
+		step, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown step %s", name)
+		}
+		if wanted[name] {
+			return nil
+		}
+		wanted[name] = true
+		for _, dep := range step.DependsOn {
+			if err := want(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if len(names) == 0 {
+		for _, step := range s.Steps {
+			wanted[step.Name] = true
+		}
+	}
+	for _, name := range names {
+		if err := want(name); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := make([]Step, 0, len(wanted))
+	planned := make(map[string]bool, len(wanted))
+	for len(plan) < len(wanted) {
+		progressed := false
+		for _, step := range s.Steps {
+			if !wanted[step.Name] || planned[step.Name] || !dependenciesPlanned(step, planned) {
+				continue
+			}
+			plan = append(plan, step)
+			planned[step.Name] = true
+			progressed = true
+		}
+		if !progressed {
+			var cyclic []string
+			for name := range wanted {
+				if !planned[name] {
+					cyclic = append(cyclic, name)
+				}
+			}
+			sort.Strings(cyclic)
+			return nil, fmt.Errorf("steps %s depend on each other", strings.Join(cyclic, ", "))
+		}
+	}
+	return plan, nil
+}
+
+func dependenciesPlanned(step Step, planned map[string]bool) bool {
+	for _, dep := range step.DependsOn {
+		if !planned[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// InputPath resolves Input: environment variables are expanded and relative paths are taken from the
+// directory of the spec file. Unset variables are an error rather than an empty path segment.
+func (s Step) InputPath() (string, error) {
+	var unset []string
+	path := os.Expand(s.Input, func(name string) string {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			unset = append(unset, name)
+		}
+		return value
+	})
+	if len(unset) > 0 {
+		return "", fmt.Errorf("input of step %s uses unset variables %s", s.Name, strings.Join(unset, ", "))
+	}
+	if !filepath.IsAbs(path) && s.dir != "" {
+		path = filepath.Join(s.dir, path)
+	}
+	return path, nil
+}
+
+// Options returns base with the dataset settings of the step: input, format, table, key, entity IDs,
+// slug template and field rules. The run settings (pool, tenant, concurrency, ...) come from base.
+func (s Step) Options(base Options) (Options, error) {
+	input, err := s.InputPath()
+	if err != nil {
+		return Options{}, err
+	}
+
+	opts := base
+	opts.InputPath = input
+	opts.Format = s.Format
+	opts.TableName = s.Table
+	opts.SlugTemplate = s.SlugTemplate
+	opts.KeyFunc = KeyAt(s.Key)
+	opts.EntityIDFunc = nil
+	opts.Namespace = uuid.Nil
+	opts.Mutate = nil
+	if s.EntityID == EntityIDUUID {
+		if opts.Namespace, err = uuid.Parse(s.Namespace); err != nil {
+			return Options{}, fmt.Errorf("step %s: namespace: %w", s.Name, err)
+		}
+	}
+	if !s.Fields.IsZero() {
+		opts.Mutate = s.Fields.Apply
+	}
+	return opts, nil
+}
+
+// KeyAt returns a KeyFunc reading the string or number at the dotted path, e.g. "id" or "set.code".
+func KeyAt(path string) KeyFunc {
+	return func(data map[string]any) (string, error) {
+		parent, field, ok := lookupParent(data, path)
+		if !ok {
+			return "", fmt.Errorf("field %s is missing", path)
+		}
+		switch value := parent[field].(type) {
+		case string:
+			if value == "" {
+				return "", fmt.Errorf("field %s is empty", path)
+			}
+			return value, nil
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64), nil
+		case json.Number:
+			return value.String(), nil
+		case nil:
+			return "", fmt.Errorf("field %s is missing", path)
+		default:
+			return "", fmt.Errorf("field %s is not a string or number", path)
+		}
+	}
+}
+
+// lookupParent finds the object holding the last segment of the dotted path.
+func lookupParent(data map[string]any, path string) (map[string]any, string, bool) {
+	segments := strings.Split(path, ".")
+	parent := data
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := parent[segment].(map[string]any)
+		if !ok {
+			return nil, "", false
+		}
+		parent = child
+	}
+	field := segments[len(segments)-1]
+	_, ok := parent[field]
+	return parent, field, ok
+}
+
+// IsZero reports whether the rules leave records as they are.
+func (r FieldRules) IsZero() bool {
+	return len(r.Rename) == 0 && len(r.Coerce) == 0 && len(r.Allow) == 0 && len(r.Deny) == 0
+}
+
+// Apply reshapes data in place and returns the top-level fields it removed.
+func (r FieldRules) Apply(data map[string]any) ([]string, error) {
+	for _, from := range sortedKeys(r.Rename) {
+		if value, ok := data[from]; ok {
+			delete(data, from)
+			data[r.Rename[from]] = value
+		}
+	}
+
+	for _, path := range sortedKeys(r.Coerce) {
+		parent, field, ok := lookupParent(data, path)
+		if !ok || parent[field] == nil {
+			continue
+		}
+		value, keep, err := coerce(parent[field], r.Coerce[path])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", path, err)
+		}
+		if keep {
+			parent[field] = value
+		} else {
+			delete(parent, field)
+		}
+	}
+
+	var removed []string
+	if len(r.Allow) > 0 {
+		allowed := make(map[string]struct{}, len(r.Allow))
+		for _, field := range r.Allow {
+			allowed[field] = struct{}{}
+		}
+		for field := range data {
+			if _, ok := allowed[field]; !ok {
+				delete(data, field)
+				removed = append(removed, field)
+			}
+		}
+	}
+	for _, field := range r.Deny {
+		if _, ok := data[field]; ok {
+			delete(data, field)
+			removed = append(removed, field)
+		}
+	}
+	return removed, nil
+}
+
+// coerce converts a decoded JSON value; keep is false for empty strings, which are dropped.
+func coerce(value any, to Coercion) (result any, keep bool, err error) {
+	if s, ok := value.(string); ok && to != CoerceString {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil, false, nil
+		}
+		switch to {
+		case CoerceInteger:
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, false, fmt.Errorf("%q is not an integer", s)
+			}
+			return n, true, nil
+		case CoerceNumber:
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+				return nil, false, fmt.Errorf("%q is not a number", s)
+			}
+			return n, true, nil
+		case CoerceBoolean:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, false, fmt.Errorf("%q is not a boolean", s)
+			}
+			return b, true, nil
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, true, nil
+	case float64:
+		switch to {
+		case CoerceString:
+			return strconv.FormatFloat(v, 'f', -1, 64), true, nil
+		case CoerceInteger:
+			if v != math.Trunc(v) {
+				return nil, false, fmt.Errorf("%v is not an integer", v)
+			}
+			return v, true, nil
+		case CoerceNumber:
+			return v, true, nil
+		}
+	case bool:
+		switch to {
+		case CoerceString:
+			return strconv.FormatBool(v), true, nil
+		case CoerceBoolean:
+			return v, true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("cannot convert %T to %s", value, to)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,155 @@
+package seed
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSpecShippedSpecs(t *testing.T) {
+	t.Setenv("PKMTCGIO_DIR", "/dumps/pkmtcgio")
+	t.Setenv("SCRYFALL_DIR", "/dumps/scryfall")
+
+	for _, name := range []string{"pkm.yaml", "mtg.yaml"} {
+		spec, err := LoadSpec(filepath.Join("..", "..", "..", "tools", "seeders", "specs", name))
+		require.NoError(t, err, name)
+
+		plan, err := spec.Plan()
+		require.NoError(t, err)
+		require.Len(t, plan, 2)
+		require.Empty(t, plan[0].DependsOn, "sets run before cards")
+
+		opts, err := plan[1].Options(Options{Concurrency: 3})
+		require.NoError(t, err)
+		require.Regexp(t, `^/dumps/\w+/cards\.jsonl$`, opts.InputPath)
+		require.Equal(t, 3, opts.Concurrency)
+		require.NotNil(t, opts.KeyFunc)
+	}
+}
+
+func TestLoadSpecRejectsBadSpecs(t *testing.T) {
+	cases := map[string]string{
+		"unknown key":        "steps:\n  - name: a\n    input: a.jsonl\n    table: a\n    key: id\n    keys: id\n",
+		"missing key":        "steps:\n  - name: a\n    input: a.jsonl\n    table: a\n",
+		"unknown dependency": "steps:\n  - name: a\n    input: a.jsonl\n    table: a\n    key: id\n    dependsOn: [b]\n",
+		"bad coercion":       "steps:\n  - name: a\n    input: a.jsonl\n    table: a\n    key: id\n    fields:\n      coerce: {hp: int}\n",
+		"uuid namespace":     "steps:\n  - name: a\n    input: a.jsonl\n    table: a\n    key: id\n    entityId: uuid\n",
+		"cycle":              "steps:\n  - {name: a, input: a.jsonl, table: a, key: id, dependsOn: [b]}\n  - {name: b, input: b.jsonl, table: b, key: id, dependsOn: [a]}\n",
+	}
+	for name, content := range cases {
+		path := filepath.Join(t.TempDir(), "spec.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := LoadSpec(path)
+		require.Error(t, err, name)
+	}
+}
+
+func TestSpecPlanOrdersByDependencies(t *testing.T) {
+	spec := Spec{Steps: []Step{
+		{Name: "cards", DependsOn: []string{"sets", "rarities"}},
+		{Name: "sets"},
+		{Name: "prices", DependsOn: []string{"cards"}},
+		{Name: "rarities"},
+	}}
+
+	plan, err := spec.Plan()
+	require.NoError(t, err)
+	require.Equal(t, []string{"sets", "rarities", "cards", "prices"}, stepNames(plan))
+
+	plan, err = spec.Plan("cards")
+	require.NoError(t, err)
+	require.Equal(t, []string{"sets", "rarities", "cards"}, stepNames(plan), "dependencies of the chosen steps run too")
+
+	_, err = spec.Plan("nope")
+	require.ErrorContains(t, err, "unknown step nope")
+}
+
+func TestStepOptionsAndInputPath(t *testing.T) {
+	t.Setenv("DUMP_DIR", "dumps")
+	step := Step{Name: "cards", Input: "${DUMP_DIR}/cards.jsonl", Table: "cards", Key: "id", EntityID: EntityIDUUID, Namespace: "6ba7b811-9dad-11d1-80b4-00c04fd430c8", dir: "/specs"}
+
+	opts, err := step.Options(Options{Mutate: func(map[string]any) ([]string, error) { return nil, nil }})
+	require.NoError(t, err)
+	require.Equal(t, "/specs/dumps/cards.jsonl", opts.InputPath)
+	require.Equal(t, "6ba7b811-9dad-11d1-80b4-00c04fd430c8", opts.Namespace.String())
+	require.Nil(t, opts.Mutate, "a step without field rules keeps payloads as read")
+
+	step.Input = "${UNSET_SEED_DIR}/cards.jsonl"
+	_, err = step.Options(Options{})
+	require.ErrorContains(t, err, "UNSET_SEED_DIR")
+}
+
+func TestKeyAt(t *testing.T) {
+	data := map[string]any{"id": "base1-4", "number": float64(4), "set": map[string]any{"code": "lea"}, "empty": ""}
+
+	key, err := KeyAt("id")(data)
+	require.NoError(t, err)
+	require.Equal(t, "base1-4", key)
+
+	key, err = KeyAt("set.code")(data)
+	require.NoError(t, err)
+	require.Equal(t, "lea", key)
+
+	key, err = KeyAt("number")(data)
+	require.NoError(t, err)
+	require.Equal(t, "4", key)
+
+	key, err = KeyAt("decoded")(map[string]any{"decoded": json.Number("0042")})
+	require.NoError(t, err)
+	require.Equal(t, "0042", key)
+
+	_, err = KeyAt("empty")(data)
+	require.ErrorContains(t, err, "is empty")
+	_, err = KeyAt("set.name")(data)
+	require.ErrorContains(t, err, "is missing")
+	_, err = KeyAt("set")(data)
+	require.ErrorContains(t, err, "not a string or number")
+}
+
+func TestFieldRulesApply(t *testing.T) {
+	rules := FieldRules{
+		Rename: map[string]string{"setCode": "sId"},
+		Coerce: map[string]Coercion{"hp": CoerceInteger, "price.usd": CoerceNumber, "foil": CoerceBoolean, "number": CoerceString, "level": CoerceInteger},
+		Allow:  []string{"name", "sId", "hp", "price", "foil", "number", "level", "debug"},
+		Deny:   []string{"debug"},
+	}
+	data := map[string]any{
+		"name":    "Alakazam",
+		"setCode": "base1",
+		"hp":      "80",
+		"price":   map[string]any{"usd": "12.5"},
+		"foil":    "true",
+		"number":  float64(1),
+		"level":   " ",
+		"debug":   true,
+		"extra":   "x",
+	}
+
+	removed, err := rules.Apply(data)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"extra", "debug"}, removed)
+	require.Equal(t, map[string]any{
+		"name":   "Alakazam",
+		"sId":    "base1",
+		"hp":     int64(80),
+		"price":  map[string]any{"usd": 12.5},
+		"foil":   true,
+		"number": "1",
+	}, data)
+
+	_, err = FieldRules{Coerce: map[string]Coercion{"hp": CoerceInteger}}.Apply(map[string]any{"hp": "eighty"})
+	require.ErrorContains(t, err, "field hp")
+	_, err = FieldRules{Coerce: map[string]Coercion{"hp": CoerceInteger}}.Apply(map[string]any{"hp": 1.5})
+	require.ErrorContains(t, err, "not an integer")
+}
+
+func stepNames(steps []Step) []string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.Name
+	}
+	return names
+}
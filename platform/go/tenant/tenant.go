@@ -0,0 +1,62 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type ctxKey string
+
+const (
+	ctxTenantID ctxKey = "PALMYRA_TENANT_ID"
+)
+
+// DefaultID is the tenant assigned to rows created before tenancy existed and to
+// single-tenant deployments whose tokens carry no tenant claim.
+const DefaultID = "default"
+
+// ErrTenantRequired indicates a tenant-scoped operation ran without a tenant on the context.
+var ErrTenantRequired = errors.New("tenant is required")
+
+// idPattern accepts Firebase/Identity Platform tenant identifiers (e.g. "acme-x1y2z") and simple slugs.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$`)
+
+// Normalize trims the identifier and ensures it is safe to store and compare.
+func Normalize(id string) (string, error) {
+	trimmed := strings.TrimSpace(id)
+	if trimmed == "" {
+		return "", ErrTenantRequired
+	}
+
+	if !idPattern.MatchString(trimmed) {
+		return "", fmt.Errorf("invalid tenant id %q: must match %s", trimmed, idPattern.String())
+	}
+
+	return trimmed, nil
+}
+
+// WithID stores the tenant identifier on the context.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxTenantID, id)
+}
+
+// FromContext retrieves the tenant identifier from the context, if present.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ctxTenantID).(string)
+	if !ok || id == "" {
+		return "", false
+	}
+	return id, true
+}
+
+// Require returns the tenant identifier stored on the context or ErrTenantRequired.
+func Require(ctx context.Context) (string, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return "", ErrTenantRequired
+	}
+	return id, nil
+}
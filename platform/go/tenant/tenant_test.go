@@ -0,0 +1,43 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "firebase tenant", input: "acme-x1y2z", want: "acme-x1y2z"},
+		{name: "trims whitespace", input: "  default ", want: "default"},
+		{name: "empty", input: "   ", wantErr: true},
+		{name: "invalid characters", input: "acme'; DROP", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Normalize(tc.input)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+	_, err := Require(context.Background())
+	require.ErrorIs(t, err, ErrTenantRequired)
+
+	id, err := Require(WithID(context.Background(), "tenant-a"))
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a", id)
+}
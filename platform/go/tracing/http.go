@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware opens a server span per request, continuing the caller's W3C trace context. Spans are
+// renamed to "<METHOD> <route template>" once chi has matched the route, so it must wrap the chi
+// router. Requests to skipPaths (health checks, metrics) are not traced.
+func Middleware(skipPaths ...string) func(http.Handler) http.Handler {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+
+			if pattern := routePattern(r); pattern != "" {
+				span := trace.SpanFromContext(r.Context())
+				span.SetName(spanName("", r))
+				span.SetAttributes(semconv.HTTPRoute(pattern))
+			}
+		})
+
+		return otelhttp.NewHandler(named, "http.request",
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				_, skipped := skip[r.URL.Path]
+				return !skipped
+			}),
+			otelhttp.WithSpanNameFormatter(spanName),
+		)
+	}
+}
+
+// spanName formats server spans as "<METHOD> <route template>", or just the method before routing.
+func spanName(_ string, r *http.Request) string {
+	if pattern := routePattern(r); pattern != "" {
+		return r.Method + " " + pattern
+	}
+	return r.Method
+}
+
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return ""
+}
+
+// StrictMiddleware opens a span around each strict handler operation of the domain, named
+// "<domain>.<operationID>". Pass it to the generated NewStrictHandler.
+func StrictMiddleware(domain string) nethttp.StrictHTTPMiddlewareFunc {
+	return func(f nethttp.StrictHTTPHandlerFunc, operationID string) nethttp.StrictHTTPHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+			ctx, span := Start(ctx, domain+"."+operationID,
+				attribute.String("palmyra.domain", domain),
+				attribute.String("palmyra.operation", operationID),
+			)
+			response, err := f(ctx, w, r, request)
+			End(span, err)
+			return response, err
+		}
+	}
+}
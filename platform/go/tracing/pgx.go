@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer opens a client span per pgx query, batch and COPY; set it as persistence.PoolConfig.Tracer.
+// Statements are recorded as-is; arguments are never recorded.
+type QueryTracer struct{}
+
+var (
+	_ pgx.QueryTracer    = QueryTracer{}
+	_ pgx.BatchTracer    = QueryTracer{}
+	_ pgx.CopyFromTracer = QueryTracer{}
+)
+
+// NewQueryTracer returns a tracer using the global tracer provider.
+func NewQueryTracer() QueryTracer {
+	return QueryTracer{}
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+	ctx, _ = Start(ctx, "db."+strings.ToLower(operation), dbAttributes(operation, data.SQL)...)
+	return ctx
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	End(span, data.Err)
+}
+
+// TraceBatchStart implements pgx.BatchTracer.
+func (QueryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, _ = Start(ctx, "db.batch",
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName("BATCH"),
+		attribute.Int("db.operation.batch.size", data.Batch.Len()),
+	)
+	return ctx
+}
+
+// TraceBatchQuery implements pgx.BatchTracer.
+func (QueryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if data.Err != nil {
+		trace.SpanFromContext(ctx).RecordError(data.Err)
+	}
+}
+
+// TraceBatchEnd implements pgx.BatchTracer.
+func (QueryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	End(trace.SpanFromContext(ctx), data.Err)
+}
+
+// TraceCopyFromStart implements pgx.CopyFromTracer.
+func (QueryTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	ctx, _ = Start(ctx, "db.copy",
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName("COPY"),
+		semconv.DBCollectionName(data.TableName.Sanitize()),
+	)
+	return ctx
+}
+
+// TraceCopyFromEnd implements pgx.CopyFromTracer.
+func (QueryTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	End(span, data.Err)
+}
+
+func dbAttributes(operation, sql string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(strings.TrimSpace(sql)),
+	}
+}
+
+// queryOperation returns the statement's leading keyword (SELECT, INSERT, ...), skipping comments.
+func queryOperation(sql string) string {
+	for _, line := range strings.Split(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			return strings.ToUpper(strings.Trim(fields[0], "("))
+		}
+	}
+	return "QUERY"
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// These tests swap the global tracer provider, so they do not run in parallel.
+
+func installRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestMiddlewareNamesSpansByRouteAndContinuesTraces(t *testing.T) {
+	recorder := installRecorder(t)
+
+	api := chi.NewRouter()
+	api.Get("/entities/{tableName}/documents", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	root := chi.NewRouter()
+	root.Use(Middleware("/healthz"))
+	root.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+	root.Mount("/api/v1", api)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/entities/cards/documents", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	root.ServeHTTP(httptest.NewRecorder(), req)
+	root.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1, "skipped paths are not traced")
+	require.Equal(t, "GET /api/v1/entities/{tableName}/documents", spans[0].Name())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func TestStrictMiddlewareRecordsErrors(t *testing.T) {
+	recorder := installRecorder(t)
+
+	handler := StrictMiddleware("users")(func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return nil, errors.New("boom")
+	}, "usersCreate")
+
+	_, err := handler(context.Background(), httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil), nil)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "users.usersCreate", spans[0].Name())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestQueryOperation(t *testing.T) {
+	require.Equal(t, "SELECT", queryOperation("\n\t\tSELECT id FROM users"))
+	require.Equal(t, "INSERT", queryOperation("-- seed\ninsert into t values (1)"))
+	require.Equal(t, "WITH", queryOperation("(WITH x AS (SELECT 1) SELECT * FROM x)"))
+	require.Equal(t, "QUERY", queryOperation("  "))
+}
@@ -0,0 +1,208 @@
+// Command mint-dev-token signs JWTs with a local private key so AUTH_PROVIDER=jwks can be exercised
+// without an external identity provider.
+//
+//	go run ./tools/auth/go/cmd/mint-dev-token -generate ES256 -key dev-signing.pem -jwks dev-jwks.json
+//	go run ./tools/auth/go/cmd/mint-dev-token -key dev-signing.pem -email admin@example.com -roles admin
+//
+// Point the API at the written JWKS with AUTH_JWKS_FILE=dev-jwks.json and AUTH_JWKS_ISSUER matching -iss.
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+
+	platformauth "github.com/zenGate-Global/palmyra-pro-saas/platform/go/auth"
+)
+
+func main() {
+	keyPath := flag.String("key", "", "Path to the PEM private key (PKCS#8, PKCS#1 or SEC 1)")
+	generate := flag.String("generate", "", "Create -key with a new RS256, ES256 or EdDSA key when it does not exist")
+	jwksPath := flag.String("jwks", "", "Write the public JWKS of -key to this path")
+	kid := flag.String("kid", "", "Key id (defaults to the RFC 7638 thumbprint)")
+	issuer := flag.String("iss", "palmyra-dev", "Issuer claim")
+	audience := flag.String("aud", "", "Audience claim (optional)")
+	subject := flag.String("sub", "", "Subject / uid claim (defaults to a random UUID)")
+	email := flag.String("email", "", "Email claim")
+	name := flag.String("name", "", "Name claim")
+	roles := flag.String("roles", "user", "Comma-separated roles claim")
+	tenant := flag.String("tenant", "", "Tenant (firebase.tenant claim)")
+	ttl := flag.Duration("ttl", time.Hour, "Token lifetime")
+	extra := flag.String("claims", "", "Extra claims as a JSON object, merged last")
+	flag.Parse()
+
+	if *keyPath == "" {
+		log.Fatal("key is required")
+	}
+
+	if *generate != "" {
+		if err := generateKey(*keyPath, *generate); err != nil {
+			log.Fatalf("generate key: %v", err)
+		}
+	}
+
+	signer, err := loadPrivateKey(*keyPath)
+	if err != nil {
+		log.Fatalf("load key: %v", err)
+	}
+
+	jwk, err := platformauth.PublicJWK(*kid, signer.Public())
+	if err != nil {
+		log.Fatalf("encode public key: %v", err)
+	}
+
+	if *jwksPath != "" {
+		doc, err := json.MarshalIndent(platformauth.JWKSet{Keys: []platformauth.JWK{jwk}}, "", "  ")
+		if err != nil {
+			log.Fatalf("encode jwks: %v", err)
+		}
+		if err := os.WriteFile(*jwksPath, append(doc, '\n'), 0o644); err != nil {
+			log.Fatalf("write jwks: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "wrote %s (kid %s)\n", *jwksPath, jwk.Kid)
+	}
+
+	sub := *subject
+	if sub == "" {
+		sub = uuid.NewString()
+	}
+
+	now := time.Now().UTC()
+	claims := jwt.MapClaims{
+		"iss":   *issuer,
+		"sub":   sub,
+		"uid":   sub,
+		"iat":   now.Unix(),
+		"nbf":   now.Unix(),
+		"exp":   now.Add(*ttl).Unix(),
+		"roles": splitList(*roles),
+	}
+	if *audience != "" {
+		claims["aud"] = *audience
+	}
+	if *email != "" {
+		claims["email"] = *email
+		claims["email_verified"] = true
+	}
+	if *name != "" {
+		claims["name"] = *name
+	}
+	if *tenant != "" {
+		claims["firebase"] = map[string]interface{}{"tenant": *tenant}
+	}
+	if *extra != "" {
+		var more map[string]interface{}
+		if err := json.Unmarshal([]byte(*extra), &more); err != nil {
+			log.Fatalf("parse claims: %v", err)
+		}
+		for k, v := range more {
+			claims[k] = v
+		}
+	}
+
+	token := jwt.NewWithClaims(signingMethod(jwk.Alg), claims)
+	token.Header["kid"] = jwk.Kid
+
+	signed, err := token.SignedString(signer)
+	if err != nil {
+		log.Fatalf("sign token: %v", err)
+	}
+	fmt.Println(signed)
+}
+
+func generateKey(path, alg string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	var (
+		key crypto.Signer
+		err error
+	)
+	switch alg {
+	case platformauth.AlgRS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case platformauth.AlgES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case platformauth.AlgEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+}
+
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+func signingMethod(alg string) jwt.SigningMethod {
+	switch alg {
+	case platformauth.AlgRS256:
+		return jwt.SigningMethodRS256
+	case platformauth.AlgES256:
+		return jwt.SigningMethodES256
+	default:
+		return jwt.SigningMethodEdDSA
+	}
+}
+
+func splitList(raw string) []string {
+	var values []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -21,5 +21,8 @@ package main
 //go:generate go tool oapi-codegen -config ./configs/schema-categories.yaml ../../../../contracts/schema-categories.yaml
 //go:generate go tool oapi-codegen -config ./configs/schema-repository.yaml ../../../../contracts/schema-repository.yaml
 //go:generate go tool oapi-codegen -config ./configs/entities.yaml           ../../../../contracts/entities.yaml
+//go:generate go tool oapi-codegen -config ./configs/access-control.yaml    ../../../../contracts/access-control.yaml
+//go:generate go tool oapi-codegen -config ./configs/service-accounts.yaml  ../../../../contracts/service-accounts.yaml
+//go:generate go tool oapi-codegen -config ./configs/jobs.yaml              ../../../../contracts/jobs.yaml
 
 func main() {}
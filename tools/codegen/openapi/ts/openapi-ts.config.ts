@@ -49,4 +49,22 @@ export default [
     services: true,
     schemas: true,
   },
+  {
+    input: './contracts/access-control.yaml',
+    output: './packages/api-sdk/src/generated/access-control',
+    client: 'fetch',
+    base: '/api/v1',
+    types: true,
+    services: true,
+    schemas: true,
+  },
+  {
+    input: './contracts/service-accounts.yaml',
+    output: './packages/api-sdk/src/generated/service-accounts',
+    client: 'fetch',
+    base: '/api/v1',
+    types: true,
+    services: true,
+    schemas: true,
+  },
 ];
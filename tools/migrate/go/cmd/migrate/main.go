@@ -0,0 +1,138 @@
+// Command migrate applies the SQL migrations embedded from database/migrations.
+//
+//	go run ./tools/migrate/go/cmd/migrate status
+//	go run ./tools/migrate/go/cmd/migrate up
+//	go run ./tools/migrate/go/cmd/migrate -steps 2 down
+//	go run ./tools/migrate/go/cmd/migrate verify
+//	go run ./tools/migrate/go/cmd/migrate -version 20261018T170000 baseline
+//	go run ./tools/migrate/go/cmd/migrate -version 0 baseline
+//
+// up provisions an empty database from database/schema; databases created before migrations were
+// tracked must be baselined at the last migration their schema contains, or at version 0 when it
+// contains none (the snapshot before the first migration), after which up applies them all.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/database"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/migrate"
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/persistence"
+)
+
+func main() {
+	databaseURL := flag.String("database-url", os.Getenv("DATABASE_URL"), "PostgreSQL connection string")
+	steps := flag.Int("steps", 1, "Number of migrations rolled back by down")
+	version := flag.String("version", "", "Last migration already contained in the schema, for baseline; 0 when it contains none")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: migrate [flags] up|down|status|verify|baseline\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *databaseURL == "" {
+		log.Fatal("database-url is required (flag or DATABASE_URL env)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	migrations, err := migrate.Load(database.Migrations())
+	if err != nil {
+		log.Fatalf("load migrations: %v", err)
+	}
+
+	pool, err := persistence.NewPool(ctx, persistence.PoolConfig{ConnString: *databaseURL, MaxConns: 2})
+	if err != nil {
+		log.Fatalf("connect: %v", err)
+	}
+	defer persistence.ClosePool(pool)
+
+	migrator, err := migrate.New(pool, migrate.Config{Migrations: migrations, Schema: database.Schema()})
+	if err != nil {
+		log.Fatalf("init migrator: %v", err)
+	}
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		done, err := migrator.Up(ctx)
+		report("applied", done)
+		if err != nil {
+			log.Fatalf("up: %v", err)
+		}
+	case "down":
+		done, err := migrator.Down(ctx, *steps)
+		report("reverted", done)
+		if err != nil {
+			log.Fatalf("down: %v", err)
+		}
+	case "baseline":
+		if *version == "" {
+			log.Fatal("version is required for baseline")
+		}
+		done, err := migrator.Baseline(ctx, *version)
+		report("recorded", done)
+		if err != nil {
+			log.Fatalf("baseline: %v", err)
+		}
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("status: %v", err)
+		}
+		printStatus(status)
+	case "verify":
+		if err := migrator.Verify(ctx); err != nil {
+			log.Fatalf("verify: %v", err)
+		}
+		fmt.Println("database is up to date")
+	default:
+		log.Fatalf("unknown command %q", command)
+	}
+}
+
+func report(verb string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Printf("nothing %s\n", verb)
+		return
+	}
+	for _, migration := range migrations {
+		fmt.Printf("%s %s\n", verb, migration)
+	}
+}
+
+func printStatus(status migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT\tDOWN")
+	for _, state := range status.Migrations {
+		label, appliedAt := "pending", ""
+		if state.Applied != nil {
+			label, appliedAt = "applied", state.Applied.AppliedAt.Format("2006-01-02 15:04:05Z07:00")
+			if state.Applied.Baseline {
+				label = "baseline"
+			}
+			if state.Modified {
+				label = "modified"
+			}
+		}
+		down := "no"
+		if state.Reversible() {
+			down = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", state.Version, state.Name, label, appliedAt, down)
+	}
+	for _, applied := range status.Unknown {
+		fmt.Fprintf(w, "%s\t%s\tunknown\t%s\t-\n", applied.Version, applied.Name, applied.AppliedAt.Format("2006-01-02 15:04:05Z07:00"))
+	}
+	_ = w.Flush()
+}
@@ -1,51 +0,0 @@
-package main
-
-import (
-	"context"
-	"flag"
-	"log"
-	"os"
-
-	"go.uber.org/zap"
-
-	platformlogging "github.com/zenGate-Global/palmyra-pro-saas/platform/go/logging"
-	"github.com/zenGate-Global/palmyra-pro-saas/tools/seeders/go/internal/seed"
-)
-
-func main() {
-	input := flag.String("input", "", "Path to the Scryfall cards JSONL file")
-	table := flag.String("table", "mtg_cards", "Target entity table name")
-	concurrency := flag.Int("concurrency", 8, "Number of parallel workers")
-	databaseURL := flag.String("database-url", os.Getenv("DATABASE_URL"), "PostgreSQL connection string")
-	flag.Parse()
-
-	if *input == "" {
-		log.Fatal("input is required")
-	}
-	if *databaseURL == "" {
-		log.Fatal("database-url is required (flag or DATABASE_URL env)")
-	}
-
-	logger, err := platformlogging.NewLogger(platformlogging.Config{Component: "seed-mtg-cards", Level: "info"})
-	if err != nil {
-		log.Fatalf("init logger: %v", err)
-	}
-	defer func() {
-		_ = logger.Sync()
-	}()
-
-	ctx := context.Background()
-	if err := seed.Run(ctx, seed.Options{
-		InputPath:   *input,
-		TableName:   *table,
-		DatabaseURL: *databaseURL,
-		Concurrency: *concurrency,
-		KeyFunc:     seed.MTGCardKey,
-		EntityIDFunc: func(_ map[string]any, key string) (string, error) {
-			return key, nil
-		},
-		Logger: logger,
-	}); err != nil {
-		logger.Fatal("seed failed", zap.Error(err))
-	}
-}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/zenGate-Global/palmyra-pro-saas/platform/go/seed"
+)
+
+func main() {
+	specFile := flag.String("spec", "", "Seed spec (YAML or JSON) describing the datasets to load")
+	steps := flag.String("steps", "", "Comma-separated steps to run, with the steps they depend on (defaults to every step)")
+	inputs := map[string]string{}
+	flag.Func("input", "Override the input of a step, as step=path (repeatable)", func(value string) error {
+		name, path, ok := strings.Cut(value, "=")
+		if !ok || name == "" || path == "" {
+			return fmt.Errorf("expected step=path, got %q", value)
+		}
+		inputs[name] = path
+		return nil
+	})
+	concurrency := flag.Int("concurrency", 8, "Number of parallel workers")
+	batchSize := flag.Int("batch-size", seed.DefaultBatchSize, "Records written per transaction")
+	resume := flag.Bool("resume", false, "Continue every step from the checkpoint of an interrupted run, skipping the lines it completed")
+	dryRun := flag.Bool("dry-run", false, "Validate every record against the active schema without writing anything")
+	maxErrors := flag.Int("max-errors", 0, "Rejected records tolerated per step before the run stops (0 stops at the first, -1 never stops)")
+	reconcile := flag.Bool("reconcile", false, "Soft-delete active entities missing from the input after a successful pass (full sync)")
+	maxRemove := flag.Float64("max-remove", seed.DefaultMaxRemoveRatio, "Largest share of a table's active entities a reconcile may remove (1 lifts the limit)")
+	diffDir := flag.String("diff-dir", "", "Write a report of the entities each step added, updated, left unchanged and removed to <step>.diff.json in this directory")
+	rejectDir := flag.String("reject-dir", "", "Write the rejected records of each step to <step>.rejects.jsonl in this directory")
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file (database and log sections)")
+	databaseURL := flag.String("database-url", "", "PostgreSQL connection string (overrides DATABASE_URL and the config file)")
+	tenantID := flag.String("tenant", os.Getenv("TENANT_ID"), "Tenant that owns the target tables (defaults to \"default\")")
+	metricsAddr := flag.String("metrics-addr", os.Getenv("SEED_METRICS_ADDR"), "Serve Prometheus metrics on this address while seeding (e.g. :9102)")
+	pushGateway := flag.String("pushgateway", os.Getenv("PUSHGATEWAY_URL"), "Push final metrics to this Prometheus Pushgateway URL")
+	flag.Parse()
+
+	if *specFile == "" {
+		log.Fatal("spec is required")
+	}
+	spec, err := seed.LoadSpec(*specFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var names []string
+	if *steps != "" {
+		names = strings.Split(*steps, ",")
+	}
+	plan, err := spec.Plan(names...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name := range inputs {
+		if !planned(plan, name) {
+			log.Fatalf("input given for step %s, which is not planned", name)
+		}
+	}
+
+	cfg, err := seed.LoadConfig(*configFile, *databaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := cfg.Log.Logger("seed")
+	if err != nil {
+		log.Fatalf("init logger: %v", err)
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	base := seed.Options{
+		Pool:           cfg.Database.PoolConfig(),
+		TenantID:       *tenantID,
+		Concurrency:    *concurrency,
+		BatchSize:      *batchSize,
+		Resume:         *resume,
+		DryRun:         *dryRun,
+		MaxErrors:      *maxErrors,
+		Reconcile:      *reconcile,
+		MaxRemoveRatio: *maxRemove,
+		MetricsAddr:    *metricsAddr,
+		PushGatewayURL: *pushGateway,
+		Logger:         logger,
+	}
+
+	ctx := context.Background()
+	for _, step := range plan {
+		if path, ok := inputs[step.Name]; ok {
+			if step.Input, err = filepath.Abs(path); err != nil {
+				logger.Fatal("resolve input", zap.String("step", step.Name), zap.Error(err))
+			}
+		}
+		opts, err := step.Options(base)
+		if err != nil {
+			logger.Fatal("seed failed", zap.Error(err))
+		}
+		if *rejectDir != "" {
+			opts.RejectFile = filepath.Join(*rejectDir, step.Name+".rejects.jsonl")
+		}
+		if *diffDir != "" {
+			opts.DiffFile = filepath.Join(*diffDir, step.Name+".diff.json")
+		}
+
+		logger.Info("seed step", zap.String("step", step.Name), zap.String("table", opts.TableName))
+		if err := seed.Run(ctx, opts); err != nil {
+			logger.Fatal("seed failed", zap.String("step", step.Name), zap.Error(err))
+		}
+	}
+}
+
+func planned(plan []seed.Step, name string) bool {
+	for _, step := range plan {
+		if step.Name == name {
+			return true
+		}
+	}
+	return false
+}